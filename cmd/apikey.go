@@ -39,6 +39,12 @@ Examples:
   # Create an API key (--name flag, backward compatible)
   agentbay apikey create --name "my-api-key"
 
+  # Create a key and save its plaintext value to a file (mode 0600)
+  agentbay apikey create "ci-key" --write-to ./agentbay.key
+
+  # Create a key and put it straight into a Kubernetes Secret manifest
+  agentbay apikey create "prod-key" --k8s-secret secret.yaml --k8s-namespace prod
+
   # Create with verbose output
   agentbay apikey create "production-key" -v`,
 	Args: cobra.MaximumNArgs(1),
@@ -49,6 +55,7 @@ var apikeyCreateName string
 
 func init() {
 	apikeyCreateCmd.Flags().StringVar(&apikeyCreateName, "name", "", "API key name (can also be provided as positional argument)")
+	addSecretDeliveryFlags(apikeyCreateCmd)
 
 	ApiKeyCmd.AddCommand(apikeyCreateCmd)
 	ApiKeyCmd.AddCommand(ApiKeyConcurrencyCmd)
@@ -64,6 +71,14 @@ func runApikeyCreate(cmd *cobra.Command, args []string) error {
 	if name == "" {
 		return fmt.Errorf("[ERROR] API key name is required. Usage: agentbay apikey create <name>  or  --name <name>")
 	}
+	delivery, err := secretDeliveryFromFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	totalSteps := 1
+	if delivery.wantsSecret() {
+		totalSteps = 2
+	}
 	
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -72,7 +87,7 @@ func runApikeyCreate(cmd *cobra.Command, args []string) error {
 	apiClient := agentbay.NewClientFromConfig(cfg)
 	ctx := context.Background()
 
-	fmt.Printf("[STEP 1/1] Creating API key...\n")
+	fmt.Printf("[STEP 1/%d] Creating API key...\n", totalSteps)
 	
 	req := &client.CreateApiKeyRequest{Name: &name}
 	resp, err := apiClient.CreateApiKey(ctx, req)
@@ -106,6 +121,19 @@ func runApikeyCreate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("[SUCCESS] ✅ API key created successfully!\n")
 	fmt.Printf("%-*s %s\n", apikeyDetailLabelW, "ApiKeyId:", keyId)
 	fmt.Printf("%-*s %s\n", apikeyDetailLabelW, "Name:", name)
-	
+
+	if !delivery.wantsSecret() {
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("[STEP 2/2] Fetching API key content...\n")
+	secret, err := describeApiKeyContent(ctx, apiClient, keyId)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w (the key was created; retry with 'agentbay apikey describe-key-content --api-key-id %s')", err, keyId)
+	}
+	fmt.Printf("%-*s %s\n", apikeyDetailLabelW, "ApiKey:", delivery.display(secret))
+	if failed := delivery.deliver(apikeySecret{KeyId: keyId, Name: name, Secret: secret}); failed > 0 {
+		return fmt.Errorf("[ERROR] API key could not be written to %d destination(s); retry with 'agentbay apikey describe-key-content --api-key-id %s'", failed, keyId)
+	}
 	return nil
 }
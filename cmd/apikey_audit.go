@@ -0,0 +1,226 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var apikeyAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Flag API keys that are expired, too old or unused",
+	Long: `Check every API key against simple hygiene rules and exit non-zero when any fails,
+so the command can gate a CI pipeline:
+
+  expired     the local expiry date ("apikey expire --at/--in") has passed
+  over-age    the key is older than its maximum age ("apikey expire --max-age", else --max-age)
+  unused      the key has not been used for --unused-for
+  never-used  the key has never been used and is older than --unused-for
+  expiring    the expiry date is within --expiring-within (warning; fails only with --strict)
+
+Disabled keys are skipped unless --include-disabled is given. Set a threshold to 0 to turn
+its check off.
+
+Examples:
+  agentbay apikey audit
+  agentbay apikey audit --max-age 180d --unused-for 60d
+  agentbay apikey audit --label env=prod --strict -o json`,
+	Args: cobra.NoArgs,
+	RunE: runApikeyAudit,
+}
+
+func init() {
+	apikeyAuditCmd.Flags().String("max-age", "90d", "Default maximum key age (0 disables the check)")
+	apikeyAuditCmd.Flags().String("unused-for", "30d", "Flag keys not used for this long (0 disables the check)")
+	apikeyAuditCmd.Flags().String("expiring-within", "7d", "Warn about keys expiring within this period")
+	apikeyAuditCmd.Flags().StringArray("label", nil, "Only audit keys with this local label key=value (repeatable)")
+	apikeyAuditCmd.Flags().Bool("include-disabled", false, "Also audit disabled keys")
+	apikeyAuditCmd.Flags().Bool("strict", false, "Exit non-zero on warnings too")
+	apikeyAuditCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+
+	ApiKeyCmd.AddCommand(apikeyAuditCmd)
+}
+
+// apikeyAuditPolicy holds the thresholds of one audit run; zero durations disable a check.
+type apikeyAuditPolicy struct {
+	MaxAge          time.Duration
+	UnusedFor       time.Duration
+	ExpiringWithin  time.Duration
+	IncludeDisabled bool
+	Labels          map[string]string
+}
+
+type apikeyAuditFinding struct {
+	KeyId    string `json:"keyId"`
+	Name     string `json:"name"`
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Detail   string `json:"detail"`
+}
+
+// auditApiKeys applies policy to keys and returns the findings ordered by key and check, plus the
+// number of keys audited.
+func auditApiKeys(keys []*client.DescribeApiKeysResponseBodyDataApiKey, meta *apikeyMetadataStore, policy apikeyAuditPolicy, now time.Time) ([]apikeyAuditFinding, int) {
+	var findings []apikeyAuditFinding
+	audited := 0
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+		m := meta.get(key.GetKeyId())
+		if !labelsMatch(m, policy.Labels) {
+			continue
+		}
+		if !policy.IncludeDisabled && strings.EqualFold(key.GetStatus(), "DISABLED") {
+			continue
+		}
+		audited++
+		add := func(check, severity, format string, a ...interface{}) {
+			findings = append(findings, apikeyAuditFinding{
+				KeyId: key.GetKeyId(), Name: key.GetName(), Check: check, Severity: severity,
+				Detail: fmt.Sprintf(format, a...),
+			})
+		}
+
+		if t, ok := m.expiry(); ok {
+			if !t.After(now) {
+				add("expired", "error", "expired on %s", t.Local().Format("2006-01-02"))
+			} else if policy.ExpiringWithin > 0 && t.Sub(now) <= policy.ExpiringWithin {
+				add("expiring", "warning", "expires on %s (%s)", t.Local().Format("2006-01-02"), relativeDays(t, now))
+			}
+		}
+
+		created, hasCreated := parseApiKeyTime(key.GetGmtCreate())
+		maxAge, maxAgeSource := policy.MaxAge, "default"
+		if m != nil && m.MaxAge != "" {
+			if d, err := parseDayDuration(m.MaxAge); err == nil && d > 0 {
+				maxAge, maxAgeSource = d, "key policy"
+			}
+		}
+		if hasCreated && maxAge > 0 && now.Sub(created) > maxAge {
+			add("over-age", "error", "created %s, %s max age is %s", relativeDays(created, now), maxAgeSource, formatDayDuration(maxAge))
+		}
+
+		if policy.UnusedFor > 0 {
+			if lastUsed, ok := parseApiKeyTime(key.GetLastUseDate()); ok {
+				if now.Sub(lastUsed) > policy.UnusedFor {
+					add("unused", "error", "last used %s", relativeDays(lastUsed, now))
+				}
+			} else if hasCreated && now.Sub(created) > policy.UnusedFor {
+				add("never-used", "error", "never used since creation %s", relativeDays(created, now))
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].KeyId < findings[j].KeyId })
+	return findings, audited
+}
+
+// listAllApiKeys fetches every page of DescribeApiKeys.
+func listAllApiKeys(ctx context.Context, apiClient agentbay.Client) ([]*client.DescribeApiKeysResponseBodyDataApiKey, error) {
+	var all []*client.DescribeApiKeysResponseBodyDataApiKey
+	fetch := apiKeyPages(apiClient, 100, "", io.Discard)
+	_, err := walkPages(ctx, listPaging{All: true}, pageCursor{}, fetch, func(key *client.DescribeApiKeysResponseBodyDataApiKey) error {
+		all = append(all, key)
+		return nil
+	})
+	return all, err
+}
+
+func runApikeyAudit(cmd *cobra.Command, args []string) error {
+	policy := apikeyAuditPolicy{}
+	for _, f := range []struct {
+		name string
+		dst  *time.Duration
+	}{{"max-age", &policy.MaxAge}, {"unused-for", &policy.UnusedFor}, {"expiring-within", &policy.ExpiringWithin}} {
+		v, _ := cmd.Flags().GetString(f.name)
+		if v == "0" || v == "" {
+			continue
+		}
+		d, err := parseDayDuration(v)
+		if err != nil || d < 0 {
+			return fmt.Errorf("[ERROR] invalid --%s %q (use e.g. 90d)", f.name, v)
+		}
+		*f.dst = d
+	}
+	labelArgs, _ := cmd.Flags().GetStringArray("label")
+	policy.Labels = map[string]string{}
+	for _, l := range labelArgs {
+		k, v, ok := strings.Cut(l, "=")
+		if !ok || !labelKeyValid(k) {
+			return fmt.Errorf("[ERROR] invalid --label %q (use key=value)", l)
+		}
+		policy.Labels[k] = v
+	}
+	policy.IncludeDisabled, _ = cmd.Flags().GetBool("include-disabled")
+	strict, _ := cmd.Flags().GetBool("strict")
+	outputFmt, _ := cmd.Flags().GetString("output")
+	jsonOutput := strings.EqualFold(outputFmt, "json")
+
+	meta, err := loadApikeyMetadata()
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+
+	keys, err := listAllApiKeys(context.Background(), apiClient)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	findings, audited := auditApiKeys(keys, meta, policy, time.Now())
+
+	errorsFound, warnings := 0, 0
+	for _, f := range findings {
+		if f.Severity == "error" {
+			errorsFound++
+		} else {
+			warnings++
+		}
+	}
+
+	if jsonOutput {
+		out := struct {
+			Audited  int                  `json:"audited"`
+			Errors   int                  `json:"errors"`
+			Warnings int                  `json:"warnings"`
+			Findings []apikeyAuditFinding `json:"findings"`
+		}{audited, errorsFound, warnings, findings}
+		if out.Findings == nil {
+			out.Findings = []apikeyAuditFinding{}
+		}
+		b, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		fmt.Println(string(b))
+	} else if len(findings) == 0 {
+		fmt.Printf("[OK] %d API key(s) audited, no findings.\n", audited)
+	} else {
+		fmt.Printf("%s %s %s %s %s\n", padString("KEY ID", 25), padString("NAME", 20), padString("CHECK", 11), padString("SEVERITY", 9), "DETAIL")
+		for _, f := range findings {
+			fmt.Printf("%s %s %s %s %s\n", padString(truncateString(f.KeyId, 25), 25), padString(truncateString(f.Name, 20), 20), padString(f.Check, 11), padString(f.Severity, 9), f.Detail)
+		}
+		fmt.Printf("\n[INFO] %d API key(s) audited: %d error(s), %d warning(s).\n", audited, errorsFound, warnings)
+	}
+
+	if errorsFound > 0 || (strict && warnings > 0) {
+		return fmt.Errorf("[ERROR] API key audit failed: %d error(s), %d warning(s)", errorsFound, warnings)
+	}
+	return nil
+}
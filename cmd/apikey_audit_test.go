@@ -0,0 +1,170 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestParseLabelArgs(t *testing.T) {
+	set, remove, err := parseLabelArgs([]string{"team=search", "env=", "owner-"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "search", "env": ""}, set)
+	assert.Equal(t, []string{"owner"}, remove)
+
+	for _, bad := range []string{"team", "=x", "a b=c", "-"} {
+		_, _, err := parseLabelArgs([]string{bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseDayDuration(t *testing.T) {
+	d, err := parseDayDuration("90d")
+	require.NoError(t, err)
+	assert.Equal(t, 90*24*time.Hour, d)
+	d, err = parseDayDuration("12h")
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, d)
+	_, err = parseDayDuration("xd")
+	assert.Error(t, err)
+	assert.Equal(t, "30d", formatDayDuration(30*24*time.Hour))
+	assert.Equal(t, "12h0m0s", formatDayDuration(12*time.Hour))
+}
+
+func TestApikeyMetadataStore(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	_, err := updateApikeyMetadata("ak-1", now, func(m *apikeyMetadata) {
+		m.Labels = map[string]string{"team": "search", "env": "prod"}
+		m.MaxAge = "30d"
+	})
+	require.NoError(t, err)
+
+	loaded, err := loadApikeyMetadata()
+	require.NoError(t, err)
+	m := loaded.get("ak-1")
+	require.NotNil(t, m)
+	assert.Equal(t, "env=prod,team=search", m.labelString())
+	assert.True(t, labelsMatch(m, map[string]string{"env": "prod"}))
+	assert.False(t, labelsMatch(m, map[string]string{"env": "dev"}))
+	assert.False(t, labelsMatch(nil, map[string]string{"env": "prod"}))
+	assert.True(t, labelsMatch(nil, nil))
+
+	// Clearing everything drops the entry.
+	loaded.update("ak-1", now, func(m *apikeyMetadata) { m.Labels, m.MaxAge = nil, "" })
+	assert.Nil(t, loaded.get("ak-1"))
+}
+
+func TestAuditApiKeys(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	ts := func(daysAgo int) *string { return dara.String(now.AddDate(0, 0, -daysAgo).Format(time.RFC3339)) }
+	key := func(id, status string, created, lastUsed *string) *client.DescribeApiKeysResponseBodyDataApiKey {
+		return &client.DescribeApiKeysResponseBodyDataApiKey{KeyId: dara.String(id), Name: dara.String(id), Status: dara.String(status), GmtCreate: created, LastUseDate: lastUsed}
+	}
+	keys := []*client.DescribeApiKeysResponseBodyDataApiKey{
+		key("ak-fresh", "ENABLED", ts(10), ts(1)),
+		key("ak-old", "ENABLED", ts(200), ts(1)),
+		key("ak-idle", "ENABLED", ts(50), ts(40)),
+		key("ak-never", "ENABLED", ts(45), nil),
+		key("ak-strict", "ENABLED", ts(40), ts(1)),
+		key("ak-expired", "ENABLED", ts(5), ts(1)),
+		key("ak-expiring", "ENABLED", ts(5), ts(1)),
+		key("ak-disabled", "DISABLED", ts(400), nil),
+	}
+	meta := &apikeyMetadataStore{Keys: map[string]*apikeyMetadata{
+		"ak-strict":   {MaxAge: "30d", Labels: map[string]string{"env": "prod"}},
+		"ak-expired":  {ExpiresAt: now.AddDate(0, 0, -1).Format(time.RFC3339)},
+		"ak-expiring": {ExpiresAt: now.AddDate(0, 0, 3).Format(time.RFC3339), Labels: map[string]string{"env": "prod"}},
+	}}
+	policy := apikeyAuditPolicy{MaxAge: 90 * 24 * time.Hour, UnusedFor: 30 * 24 * time.Hour, ExpiringWithin: 7 * 24 * time.Hour}
+
+	findings, audited := auditApiKeys(keys, meta, policy, now)
+	assert.Equal(t, 7, audited, "disabled keys are skipped")
+	got := map[string]string{}
+	for _, f := range findings {
+		got[f.KeyId] = f.Check
+	}
+	assert.Equal(t, map[string]string{
+		"ak-old":      "over-age",
+		"ak-idle":     "unused",
+		"ak-never":    "never-used",
+		"ak-strict":   "over-age",
+		"ak-expired":  "expired",
+		"ak-expiring": "expiring",
+	}, got)
+	for _, f := range findings {
+		if f.KeyId == "ak-strict" {
+			assert.Contains(t, f.Detail, "key policy max age is 30d")
+		}
+		if f.KeyId == "ak-expiring" {
+			assert.Equal(t, "warning", f.Severity)
+		}
+	}
+
+	policy.Labels = map[string]string{"env": "prod"}
+	findings, audited = auditApiKeys(keys, meta, policy, now)
+	assert.Equal(t, 2, audited)
+	assert.Len(t, findings, 2)
+
+	policy = apikeyAuditPolicy{IncludeDisabled: true}
+	findings, audited = auditApiKeys(keys, meta, policy, now)
+	assert.Equal(t, 8, audited)
+	require.Len(t, findings, 2, "with every threshold off only local expiry dates and per-key max ages apply")
+	assert.Equal(t, "ak-expired", findings[0].KeyId)
+	assert.Equal(t, "ak-strict", findings[1].KeyId)
+}
+
+type mockListKeysClient struct {
+	agentbay.Client
+	pages [][]*client.DescribeApiKeysResponseBodyDataApiKey
+	calls int
+}
+
+func (m *mockListKeysClient) DescribeApiKeys(ctx context.Context, req *client.DescribeApiKeysRequest) (*client.DescribeApiKeysResponse, error) {
+	page := m.pages[m.calls]
+	m.calls++
+	data := &client.DescribeApiKeysResponseBodyData{ApiKeys: page}
+	if m.calls < len(m.pages) {
+		data.NextToken = dara.String("next")
+	}
+	return &client.DescribeApiKeysResponse{Body: &client.DescribeApiKeysResponseBody{Code: dara.String("200"), Data: data}}, nil
+}
+
+func TestListAllApiKeys(t *testing.T) {
+	k := func(id string) *client.DescribeApiKeysResponseBodyDataApiKey {
+		return &client.DescribeApiKeysResponseBodyDataApiKey{KeyId: dara.String(id)}
+	}
+	mock := &mockListKeysClient{pages: [][]*client.DescribeApiKeysResponseBodyDataApiKey{{k("ak-1"), k("ak-2")}, {k("ak-3")}}}
+	keys, err := listAllApiKeys(context.Background(), mock)
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+	assert.Equal(t, 2, mock.calls)
+}
+
+func TestNewApikeyDetails(t *testing.T) {
+	key := &client.DescribeApiKeysResponseBodyDataApiKey{
+		KeyId:       dara.String("ak-new"),
+		ApiKey:      dara.String("akm-0123456789abcdef"),
+		BoundPolicy: &client.DescribeApiKeysResponseBodyDataApiKeyBoundPolicy{Name: dara.String("default"), PolicyId: dara.String("p-1")},
+	}
+	meta := &apikeyMetadataStore{Keys: map[string]*apikeyMetadata{"ak-new": {Labels: map[string]string{"a": "b"}}}}
+	rotations := &apikeyRotationState{Rotations: map[string]*apikeyRotation{"ak-old": {OldKeyId: "ak-old", NewKeyId: "ak-new"}}}
+
+	d := newApikeyDetails(key, meta, rotations)
+	assert.Equal(t, "akm-****cdef", d.ApiKey)
+	assert.Equal(t, "default (p-1)", d.BoundPolicy)
+	assert.Equal(t, map[string]string{"a": "b"}, d.Labels)
+	assert.Equal(t, "ak-old", d.RotatedFrom)
+	assert.Empty(t, d.RotatedTo)
+}
@@ -54,6 +54,8 @@ func init() {
 	apikeyDeleteCmd.Flags().StringVar(&apikeyDeleteApiKey, "api-key", "", "User-visible API Key (akm-xxx format, recommended)")
 	apikeyDeleteCmd.Flags().StringVar(&apikeyDeleteApiKeyId, "api-key-id", "", "Internal API Key ID (ak-xxx). Prefer --api-key for normal usage")
 	apikeyDeleteCmd.Flags().BoolP("yes", "y", false, "Skip all confirmation prompts (for non-interactive use)")
+	registerApiKeyFlagCompletion(apikeyDeleteCmd)
+
 	ApiKeyCmd.AddCommand(apikeyDeleteCmd)
 }
 
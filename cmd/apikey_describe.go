@@ -0,0 +1,228 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var apikeyDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show details of an API key",
+	Long: `Show everything known about one API key: status, concurrency limit, creation and
+last-used time and bound policy from AgentBay, plus the local labels, expiry and maximum
+age ("apikey label", "apikey expire") and any rotation in progress ("apikey rotate").
+
+The AgentBay API does not report how a key was created; keys replaced by "apikey rotate"
+show the rotation they belong to.
+
+Examples:
+  agentbay apikey describe --api-key akm-xxxxxxxxxxxxxxxx
+  agentbay apikey describe --api-key-id ak-xxxxxxxxxxxxxxxx -o json`,
+	Args: cobra.NoArgs,
+	RunE: runApikeyDescribe,
+}
+
+func init() {
+	apikeyDescribeCmd.Flags().String("api-key", "", "User-visible API Key (akm-xxx format, recommended)")
+	apikeyDescribeCmd.Flags().String("api-key-id", "", "Internal API Key ID (ak-xxx). Prefer --api-key for normal usage")
+	apikeyDescribeCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+	addCacheFlags(apikeyDescribeCmd)
+
+	registerApiKeyFlagCompletion(apikeyDescribeCmd)
+
+	ApiKeyCmd.AddCommand(apikeyDescribeCmd)
+}
+
+// apikeyDetails is one key with its local metadata, as printed by describe and list -o json.
+type apikeyDetails struct {
+	KeyId       string            `json:"keyId"`
+	Name        string            `json:"name"`
+	ApiKey      string            `json:"apiKey,omitempty"`
+	Status      string            `json:"status"`
+	Concurrency *int32            `json:"concurrency"`
+	GmtCreate   string            `json:"gmtCreate"`
+	LastUseDate string            `json:"lastUseDate"`
+	BoundPolicy string            `json:"boundPolicy,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	ExpiresAt   string            `json:"expiresAt,omitempty"`
+	MaxAge      string            `json:"maxAge,omitempty"`
+	RotatedTo   string            `json:"rotatedTo,omitempty"`
+	RotatedFrom string            `json:"rotatedFrom,omitempty"`
+}
+
+func newApikeyDetails(key *client.DescribeApiKeysResponseBodyDataApiKey, meta *apikeyMetadataStore, rotations *apikeyRotationState) apikeyDetails {
+	d := apikeyDetails{
+		KeyId:       key.GetKeyId(),
+		Name:        key.GetName(),
+		ApiKey:      key.GetApiKey(),
+		Status:      key.GetStatus(),
+		Concurrency: key.Concurrency,
+		GmtCreate:   key.GetGmtCreate(),
+		LastUseDate: key.GetLastUseDate(),
+	}
+	if d.ApiKey != "" && !strings.Contains(d.ApiKey, "*") {
+		d.ApiKey = maskSecret(d.ApiKey)
+	}
+	if p := key.BoundPolicy; p != nil && (p.Name != nil || p.PolicyId != nil) {
+		d.BoundPolicy = strings.TrimSpace(fmt.Sprintf("%s (%s)", strPtrValue(p.Name), strPtrValue(p.PolicyId)))
+		d.BoundPolicy = strings.TrimSuffix(d.BoundPolicy, " ()")
+	}
+	if m := meta.get(d.KeyId); m != nil {
+		d.Labels = m.Labels
+		d.ExpiresAt = m.ExpiresAt
+		d.MaxAge = m.MaxAge
+	}
+	if rotations != nil {
+		if r, ok := rotations.Rotations[d.KeyId]; ok {
+			d.RotatedTo = r.NewKeyId
+		}
+		for _, r := range rotations.Rotations {
+			if r.NewKeyId == d.KeyId {
+				d.RotatedFrom = r.OldKeyId
+			}
+		}
+	}
+	return d
+}
+
+func strPtrValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// relativeDays renders t relative to now ("12 days ago", "in 3 days", "today").
+func relativeDays(t, now time.Time) string {
+	days := int(now.Sub(t).Hours() / 24)
+	switch {
+	case days == 0:
+		if t.After(now) {
+			return "within a day"
+		}
+		return "today"
+	case days > 0:
+		return fmt.Sprintf("%d days ago", days)
+	default:
+		return fmt.Sprintf("in %d days", -days)
+	}
+}
+
+func runApikeyDescribe(cmd *cobra.Command, args []string) error {
+	apiKey, _ := cmd.Flags().GetString("api-key")
+	apiKeyId, _ := cmd.Flags().GetString("api-key-id")
+	outputFmt, _ := cmd.Flags().GetString("output")
+	if apiKey != "" && apiKeyId != "" {
+		return fmt.Errorf("[ERROR] --api-key and --api-key-id are mutually exclusive; please specify only one")
+	}
+	if apiKey == "" && apiKeyId == "" {
+		return fmt.Errorf("[ERROR] Either --api-key or --api-key-id must be specified. Using --api-key is recommended")
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient, err := newCachedClient(cmd, cfg)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	jsonOutput := strings.EqualFold(outputFmt, "json")
+	if err := describeApiKey(context.Background(), apiClient, apiKey, apiKeyId, jsonOutput, time.Now()); err != nil {
+		return err
+	}
+	printCacheAge(cacheNoteWriter(listOutputFormat(outputFmt)), apiClient, time.Now())
+	return nil
+}
+
+func describeApiKey(ctx context.Context, apiClient agentbay.Client, apiKey, apiKeyId string, jsonOutput bool, now time.Time) error {
+	key, err := lookupApiKey(ctx, apiClient, apiKey, apiKeyId)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	meta, err := loadApikeyMetadata()
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	rotations, err := loadApikeyRotationState()
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	d := newApikeyDetails(key, meta, rotations)
+
+	if jsonOutput {
+		b, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	const w = 14
+	fmt.Println()
+	fmt.Printf("%-*s %s\n", w, "Name:", valueOrDash(d.Name))
+	fmt.Printf("%-*s %s\n", w, "ApiKeyId:", d.KeyId)
+	if d.ApiKey != "" {
+		fmt.Printf("%-*s %s\n", w, "ApiKey:", d.ApiKey)
+	}
+	fmt.Printf("%-*s %s\n", w, "Status:", valueOrDash(d.Status))
+	if d.Concurrency != nil {
+		fmt.Printf("%-*s %d\n", w, "Concurrency:", *d.Concurrency)
+	} else {
+		fmt.Printf("%-*s %s\n", w, "Concurrency:", "- (no limit set)")
+	}
+	created := valueOrDash(truncateDateOffset(d.GmtCreate))
+	if t, ok := parseApiKeyTime(d.GmtCreate); ok {
+		created += " (" + relativeDays(t, now) + ")"
+	}
+	fmt.Printf("%-*s %s\n", w, "Created:", created)
+	lastUsed := "never"
+	if t, ok := parseApiKeyTime(d.LastUseDate); ok {
+		lastUsed = truncateDateOffset(d.LastUseDate) + " (" + relativeDays(t, now) + ")"
+	}
+	fmt.Printf("%-*s %s\n", w, "Last used:", lastUsed)
+	if d.BoundPolicy != "" {
+		fmt.Printf("%-*s %s\n", w, "Policy:", d.BoundPolicy)
+	}
+
+	m := meta.get(d.KeyId)
+	fmt.Printf("%-*s %s\n", w, "Labels:", valueOrDash(m.labelString()))
+	expires := "-"
+	if t, ok := m.expiry(); ok {
+		expires = t.Local().Format("2006-01-02 15:04:05") + " (" + relativeDays(t, now) + ")"
+		if !t.After(now) {
+			expires += " EXPIRED"
+		}
+	}
+	fmt.Printf("%-*s %s\n", w, "Expires:", expires)
+	if d.MaxAge != "" {
+		fmt.Printf("%-*s %s\n", w, "Max age:", d.MaxAge)
+	}
+	if d.RotatedTo != "" {
+		r := rotations.Rotations[d.KeyId]
+		state := "disabled"
+		if r.Incomplete {
+			state = "incomplete, old key enabled"
+		} else if r.DisabledAt == "" {
+			state = "enabled until " + r.graceEnds().Local().Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-*s being replaced by %s (%s)\n", w, "Rotation:", r.NewKeyId, state)
+	}
+	if d.RotatedFrom != "" {
+		fmt.Printf("%-*s replaces %s\n", w, "Rotation:", d.RotatedFrom)
+	}
+	return nil
+}
@@ -25,9 +25,19 @@ var apikeyDescribeKeyContentCmd = &cobra.Command{
 This command calls the DescribeKeyContent API and returns the user-visible API key
 associated with the specified internal API key ID.
 
+The key is masked in the output unless --show-secret is given. Use --write-to, --env-file,
+--k8s-secret or --vault-path to deliver it without printing it.
+
 Examples:
   # Retrieve the plaintext API key for a given API key ID
-  agentbay apikey describe-key-content --api-key-id ak-xxxxxxxxxxxxxxxx`,
+  agentbay apikey describe-key-content --api-key-id ak-xxxxxxxxxxxxxxxx --show-secret
+
+  # Save it to a file readable only by you
+  agentbay apikey describe-key-content --api-key-id ak-xxxxxxxxxxxxxxxx --write-to ./agentbay.key
+
+  # Add it to a dotenv file, or write it to Vault
+  agentbay apikey describe-key-content --api-key-id ak-xxxxxxxxxxxxxxxx --env-file .env
+  agentbay apikey describe-key-content --api-key-id ak-xxxxxxxxxxxxxxxx --vault-path secret/agentbay/prod`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runApikeyDescribeKeyContent(cmd)
 	},
@@ -36,11 +46,19 @@ Examples:
 func init() {
 	apikeyDescribeKeyContentCmd.Flags().StringVar(&apikeyDescribeKeyContentApiKeyId, "api-key-id", "", "Internal API key ID (ak-xxx format)")
 	_ = apikeyDescribeKeyContentCmd.MarkFlagRequired("api-key-id")
+	addSecretDeliveryFlags(apikeyDescribeKeyContentCmd)
+
+	registerApiKeyFlagCompletion(apikeyDescribeKeyContentCmd)
 
 	ApiKeyCmd.AddCommand(apikeyDescribeKeyContentCmd)
 }
 
 func runApikeyDescribeKeyContent(cmd *cobra.Command) error {
+	delivery, err := secretDeliveryFromFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -90,8 +108,14 @@ func runApikeyDescribeKeyContent(cmd *cobra.Command) error {
 
 	fmt.Println()
 	fmt.Printf("[SUCCESS] API key content retrieved successfully!\n")
-	fmt.Printf("%-*s %s\n", apikeyDetailLabelW, "ApiKey:", apiKey)
+	fmt.Printf("%-*s %s\n", apikeyDetailLabelW, "ApiKey:", delivery.display(apiKey))
 	fmt.Printf("%-*s %s\n", apikeyDetailLabelW, "ApiKeyId:", apikeyDescribeKeyContentApiKeyId)
 
+	if failed := delivery.deliver(apikeySecret{KeyId: apikeyDescribeKeyContentApiKeyId, Secret: apiKey}); failed > 0 {
+		return fmt.Errorf("[ERROR] API key could not be written to %d destination(s)", failed)
+	}
+	if !delivery.ShowSecret && len(delivery.Sinks) == 0 {
+		fmt.Println("Note: The API key is masked. Use --show-secret to print it, or --write-to/--env-file to save it.")
+	}
 	return nil
 }
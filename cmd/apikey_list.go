@@ -7,7 +7,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -38,7 +41,13 @@ Examples:
   agentbay apikey list --api-key-id ak-xxxxxxxxxxxxxxxx
 
   # Fetch the next page of results
-  agentbay apikey list --next-token AAAAAV3MpHK1AP0pfERHZN5pu6mUZcGrgQ3JzaYuUyH0MyLn`,
+  agentbay apikey list --next-token AAAAAV3MpHK1AP0pfERHZN5pu6mUZcGrgQ3JzaYuUyH0MyLn
+
+  # Fetch every page, streaming one JSON object per line
+  agentbay apikey list --all -o ndjson
+
+  # First 25 keys across pages
+  agentbay apikey list --limit 25`,
 	RunE: runApikeyList,
 }
 
@@ -54,7 +63,12 @@ func init() {
 	apikeyListCmd.Flags().StringVar(&apikeyListApiKey, "api-key", "", "User-visible API key (akm-xxx format, recommended) to filter")
 	apikeyListCmd.Flags().StringVar(&apikeyListApiKeyId, "api-key-id", "", "Internal API Key ID (ak-xxx) to filter. Prefer --api-key for normal usage")
 	apikeyListCmd.Flags().StringVar(&apikeyListNextToken, "next-token", "", "Pagination token from previous query")
-	apikeyListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+	apikeyListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts), or "ndjson" for one object per line`)
+	apikeyListCmd.Flags().Bool("show-secret", false, "Include plaintext API keys in JSON output (masked by default)")
+	addListPagingFlags(apikeyListCmd)
+	addCacheFlags(apikeyListCmd)
+
+	registerApiKeyFlagCompletion(apikeyListCmd)
 
 	ApiKeyCmd.AddCommand(apikeyListCmd)
 }
@@ -69,12 +83,24 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 	apiKey := apikeyListApiKey
 	apiKeyIdFlag := apikeyListApiKeyId
 	nextToken := apikeyListNextToken
+	paging, err := listPagingFromFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	outputFmt, _ := cmd.Flags().GetString("output")
+	format := listOutputFormat(outputFmt)
+	paged := paging.walks() || format == "ndjson"
+	progress := listProgress(paged, format)
 
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
-	apiClient := agentbay.NewClientFromConfig(cfg)
+	apiClient, err := newCachedClient(cmd, cfg)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	defer func() { printCacheAge(cacheNoteWriter(format), apiClient, time.Now()) }()
 	ctx := context.Background()
 
 	var apiKeyId string
@@ -83,7 +109,7 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 	// If --api-key is provided, look up the internal KeyId first
 	if apiKey != "" {
 		totalSteps = 2
-		fmt.Printf("[STEP 1/%d] Looking up API key...\n", totalSteps)
+		fmt.Fprintf(progress, "[STEP 1/%d] Looking up API key...\n", totalSteps)
 
 		descResp, err := apiClient.DescribeMcpApiKey(ctx, &client.DescribeMcpApiKeyRequest{
 			ApiKey: &apiKey,
@@ -98,7 +124,7 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 		}
 
 		if reqID := descResp.Body.GetRequestId(); reqID != "" {
-			fmt.Printf("[INFO] DescribeMcpApiKey Request ID: %s\n", reqID)
+			fmt.Fprintf(progress, "[INFO] DescribeMcpApiKey Request ID: %s\n", reqID)
 		}
 
 		if !descResp.Body.GetSuccess() {
@@ -120,14 +146,20 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("[ERROR] Invalid response: missing ApiKeyId")
 		}
 
-		fmt.Printf("  ApiKeyId: %s\n", apiKeyId)
+		fmt.Fprintf(progress, "  ApiKeyId: %s\n", apiKeyId)
 	} else if apiKeyIdFlag != "" {
 		// --api-key-id path: use the ID directly, no lookup needed
 		apiKeyId = apiKeyIdFlag
 	}
 
 	// Call DescribeApiKeys
-	fmt.Printf("[STEP %d/%d] Listing API keys...\n", totalSteps, totalSteps)
+	fmt.Fprintf(progress, "[STEP %d/%d] Listing API keys...\n", totalSteps, totalSteps)
+
+	if paged {
+		showSecret, _ := cmd.Flags().GetBool("show-secret")
+		fetch := apiKeyPages(apiClient, maxResults, apiKeyId, progress)
+		return listApiKeysPaged(ctx, fetch, paging, pageCursor{Token: nextToken}, format, showSecret, progress)
+	}
 
 	req := &client.DescribeApiKeysRequest{
 		MaxResults: &maxResults,
@@ -172,23 +204,20 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 	apiKeys := data.GetApiKeys()
 	fmt.Printf("\n[OK] Found %d API key(s)\n\n", len(apiKeys))
 
+	// Local labels and expiry ("apikey label", "apikey expire"); a broken file only hides them.
+	meta, metaErr := loadApikeyMetadata()
+	if metaErr != nil {
+		fmt.Printf("[WARN] %v\n", metaErr)
+	}
+
 	// JSON output mode
-	outputFmt, _ := cmd.Flags().GetString("output")
-	if strings.EqualFold(outputFmt, "json") {
-		type apiKeyJSON struct {
-			KeyId       string `json:"keyId"`
-			Name        string `json:"name"`
-			ApiKey      string `json:"apiKey"`
-			Status      string `json:"status"`
-			Concurrency *int32 `json:"concurrency"`
-			GmtCreate   string `json:"gmtCreate"`
-			LastUseDate string `json:"lastUseDate"`
-		}
+	if format == "json" {
 		type apiKeysOutput struct {
-			TotalCount int          `json:"totalCount"`
-			NextToken  string       `json:"nextToken,omitempty"`
-			ApiKeys    []apiKeyJSON `json:"apiKeys"`
+			TotalCount int              `json:"totalCount"`
+			NextToken  string           `json:"nextToken,omitempty"`
+			ApiKeys    []apiKeyListItem `json:"apiKeys"`
 		}
+		showSecret, _ := cmd.Flags().GetBool("show-secret")
 		out := apiKeysOutput{TotalCount: len(apiKeys)}
 		if nt := data.GetNextToken(); nt != "" {
 			out.NextToken = nt
@@ -197,19 +226,10 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 			if key == nil {
 				continue
 			}
-			entry := apiKeyJSON{
-				KeyId:       key.GetKeyId(),
-				Name:        key.GetName(),
-				ApiKey:      key.GetApiKey(),
-				Status:      key.GetStatus(),
-				Concurrency: key.Concurrency,
-				GmtCreate:   key.GetGmtCreate(),
-				LastUseDate: key.GetLastUseDate(),
-			}
-			out.ApiKeys = append(out.ApiKeys, entry)
+			out.ApiKeys = append(out.ApiKeys, newApiKeyListItem(key, meta, showSecret))
 		}
 		if out.ApiKeys == nil {
-			out.ApiKeys = []apiKeyJSON{}
+			out.ApiKeys = []apiKeyListItem{}
 		}
 		b, jerr := json.MarshalIndent(out, "", "  ")
 		if jerr != nil {
@@ -219,7 +239,7 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	printApiKeyTable(apiKeys)
+	printApiKeyTable(apiKeys, meta)
 
 	// Print pagination hint
 	if nextTokenVal := data.GetNextToken(); nextTokenVal != "" {
@@ -229,22 +249,162 @@ func runApikeyList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func printApiKeyTable(apiKeys []*client.DescribeApiKeysResponseBodyDataApiKey) {
+// apiKeyListItem is one key in list -o json / ndjson output.
+type apiKeyListItem struct {
+	KeyId       string            `json:"keyId"`
+	Name        string            `json:"name"`
+	ApiKey      string            `json:"apiKey"`
+	Status      string            `json:"status"`
+	Concurrency *int32            `json:"concurrency"`
+	GmtCreate   string            `json:"gmtCreate"`
+	LastUseDate string            `json:"lastUseDate"`
+	BoundPolicy string            `json:"boundPolicy,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	ExpiresAt   string            `json:"expiresAt,omitempty"`
+	MaxAge      string            `json:"maxAge,omitempty"`
+}
+
+func newApiKeyListItem(key *client.DescribeApiKeysResponseBodyDataApiKey, meta *apikeyMetadataStore, showSecret bool) apiKeyListItem {
+	apiKey := key.GetApiKey()
+	if !showSecret && apiKey != "" {
+		apiKey = maskSecret(apiKey)
+	}
+	details := newApikeyDetails(key, meta, nil)
+	return apiKeyListItem{
+		KeyId:       key.GetKeyId(),
+		Name:        key.GetName(),
+		ApiKey:      apiKey,
+		Status:      key.GetStatus(),
+		Concurrency: key.Concurrency,
+		GmtCreate:   key.GetGmtCreate(),
+		LastUseDate: key.GetLastUseDate(),
+		BoundPolicy: details.BoundPolicy,
+		Labels:      details.Labels,
+		ExpiresAt:   details.ExpiresAt,
+		MaxAge:      details.MaxAge,
+	}
+}
+
+// apiKeyPages fetches DescribeApiKeys pages, optionally for a single key ID, reporting each
+// request ID to progress.
+func apiKeyPages(apiClient agentbay.Client, maxResults int32, keyId string, progress io.Writer) pageFetcher[*client.DescribeApiKeysResponseBodyDataApiKey] {
+	return func(ctx context.Context, at pageCursor) (*listPage[*client.DescribeApiKeysResponseBodyDataApiKey], error) {
+		req := &client.DescribeApiKeysRequest{MaxResults: &maxResults}
+		if at.Token != "" {
+			token := at.Token
+			req.NextToken = &token
+		}
+		if keyId != "" {
+			req.KeyIds = []string{keyId}
+		}
+		resp, err := apiClient.DescribeApiKeys(ctx, req)
+		if err != nil {
+			if reqID := extractRequestIDFromErr(err); reqID != "" {
+				fmt.Fprintf(progress, "[INFO] DescribeApiKeys Request ID: %s\n", reqID)
+			}
+			return nil, fmt.Errorf("failed to list API keys: %w", err)
+		}
+		if resp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing body")
+		}
+		if reqID := resp.Body.GetRequestId(); reqID != "" {
+			fmt.Fprintf(progress, "[INFO] DescribeApiKeys Request ID: %s\n", reqID)
+		}
+		code := resp.Body.GetCode()
+		successPtr := resp.Body.Success
+		if (successPtr != nil && !*successPtr) || (code != "" && !isSuccessCode(code)) {
+			return nil, fmt.Errorf("failed to list API keys: Code=%s, Message=%s", code, resp.Body.GetMessage())
+		}
+		page := &listPage[*client.DescribeApiKeysResponseBodyDataApiKey]{}
+		if data := resp.Body.GetData(); data != nil {
+			page.Items = data.GetApiKeys()
+			if len(page.Items) > 0 {
+				page.Next = nextPageToken(data.GetNextToken())
+			}
+		}
+		return page, nil
+	}
+}
+
+// listApiKeysPaged is apikey list with --all, --limit or -o ndjson: JSON and NDJSON are written
+// as pages arrive, the table once every page is in.
+func listApiKeysPaged(ctx context.Context, fetch pageFetcher[*client.DescribeApiKeysResponseBodyDataApiKey], paging listPaging, start pageCursor, format string, showSecret bool, progress io.Writer) error {
+	meta, metaErr := loadApikeyMetadata()
+	if metaErr != nil {
+		fmt.Fprintf(progress, "[WARN] %v\n", metaErr)
+	}
+
+	if format != "" {
+		stream := newListStream(os.Stdout, format, "apiKeys")
+		walk, err := walkPages(ctx, paging, start, fetch, func(key *client.DescribeApiKeysResponseBodyDataApiKey) error {
+			if key == nil {
+				return nil
+			}
+			return stream.write(newApiKeyListItem(key, meta, showSecret))
+		})
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", stream.fail(err))
+		}
+		fields := []listStreamField{{"totalCount", walk.totalCount()}}
+		if walk.Next != nil && walk.Next.Token != "" {
+			fields = append(fields, listStreamField{"nextToken", walk.Next.Token})
+		}
+		return stream.close(fields...)
+	}
+
+	var apiKeys []*client.DescribeApiKeysResponseBodyDataApiKey
+	walk, err := walkPages(ctx, paging, start, fetch, func(key *client.DescribeApiKeysResponseBodyDataApiKey) error {
+		if key != nil {
+			apiKeys = append(apiKeys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if len(apiKeys) == 0 {
+		fmt.Printf("\n[EMPTY] No API keys found.\n")
+		return nil
+	}
+	fmt.Printf("\n[OK] Found %d API key(s) in %d page(s)\n\n", len(apiKeys), walk.Pages)
+	printApiKeyTable(apiKeys, meta)
+	printMorePagesHint(os.Stdout, walk, "--next-token")
+	return nil
+}
+
+// printApiKeyTable prints keys as a table. EXPIRES and LABELS columns are added when any listed
+// key has local metadata.
+func printApiKeyTable(apiKeys []*client.DescribeApiKeysResponseBodyDataApiKey, meta *apikeyMetadataStore) {
+	withMeta := false
+	for _, key := range apiKeys {
+		if key != nil && meta.get(key.GetKeyId()) != nil {
+			withMeta = true
+			break
+		}
+	}
+	metaHeader, metaRule := "", ""
+	lastUsedHeader, lastUsedRule := "LAST USED", "----------"
+	if withMeta {
+		lastUsedHeader, lastUsedRule = padString(lastUsedHeader, 22), padString(lastUsedRule, 22)
+		metaHeader = " " + padString("EXPIRES", 12) + " LABELS"
+		metaRule = " " + padString("-------", 12) + " ------"
+	}
+
 	// Print header
-	fmt.Printf("%s %s %s %s %s %s\n",
+	fmt.Printf("%s %s %s %s %s %s%s\n",
 		padString("NAME", 20),
 		padString("STATUS", 12),
 		padString("CONCURRENCY", 14),
 		padString("KEY ID", 25),
 		padString("CREATED", 22),
-		"LAST USED")
-	fmt.Printf("%s %s %s %s %s %s\n",
+		lastUsedHeader, metaHeader)
+	fmt.Printf("%s %s %s %s %s %s%s\n",
 		padString("----", 20),
 		padString("------", 12),
 		padString("------------", 14),
 		padString("------", 25),
 		padString("-------", 22),
-		"----------")
+		lastUsedRule, metaRule)
 
 	// Print each API key
 	for _, key := range apiKeys {
@@ -265,13 +425,24 @@ func printApiKeyTable(apiKeys []*client.DescribeApiKeysResponseBodyDataApiKey) {
 			concurrency = "-"
 		}
 
-		fmt.Printf("%s %s %s %s %s %s\n",
+		lastUsedCol, metaCols := truncateString(lastUsed, 22), ""
+		if withMeta {
+			lastUsedCol = padString(lastUsedCol, 22)
+			m := meta.get(keyId)
+			expires := "-"
+			if t, ok := m.expiry(); ok {
+				expires = t.Local().Format("2006-01-02")
+			}
+			metaCols = " " + padString(expires, 12) + " " + valueOrDash(m.labelString())
+		}
+
+		fmt.Printf("%s %s %s %s %s %s%s\n",
 			padString(truncateString(name, 20), 20),
 			padString(status, 12),
 			padString(concurrency, 14),
 			padString(truncateString(keyId, 25), 25),
 			padString(truncateString(created, 22), 22),
-			truncateString(lastUsed, 22))
+			lastUsedCol, metaCols)
 	}
 }
 
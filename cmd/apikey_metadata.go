@@ -0,0 +1,390 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// apikey_metadata.go keeps local metadata for API keys that the service does not store: labels,
+// an expiry date and a maximum-age policy. It backs "apikey label", "apikey expire",
+// "apikey describe", "apikey audit" and the extra columns of "apikey list". The metadata lives in
+// apikey_metadata.json in the CLI config directory, keyed by API key ID (ak-xxx).
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// apikeyMetadataStore is the on-disk structure of apikey_metadata.json.
+type apikeyMetadataStore struct {
+	Keys map[string]*apikeyMetadata `json:"keys"`
+}
+
+type apikeyMetadata struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	// ExpiresAt is the date the key should be retired by (RFC 3339); audit flags it afterwards.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// MaxAge overrides "apikey audit --max-age" for this key (e.g. "30d").
+	MaxAge    string `json:"max_age,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func (m *apikeyMetadata) empty() bool {
+	return len(m.Labels) == 0 && m.ExpiresAt == "" && m.MaxAge == ""
+}
+
+func (m *apikeyMetadata) expiry() (time.Time, bool) {
+	if m == nil || m.ExpiresAt == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, m.ExpiresAt)
+	return t, err == nil
+}
+
+// labelString renders labels as sorted k=v pairs.
+func (m *apikeyMetadata) labelString() string {
+	if m == nil || len(m.Labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + m.Labels[k]
+	}
+	return strings.Join(pairs, ",")
+}
+
+func apikeyMetadataFile() (*config.StateFile, error) {
+	return config.NewStateFile("apikey_metadata.json")
+}
+
+func loadApikeyMetadata() (*apikeyMetadataStore, error) {
+	f, err := apikeyMetadataFile()
+	if err != nil {
+		return nil, err
+	}
+	store := &apikeyMetadataStore{}
+	if err := f.Load(store); err != nil {
+		return nil, err
+	}
+	if store.Keys == nil {
+		store.Keys = map[string]*apikeyMetadata{}
+	}
+	return store, nil
+}
+
+// updateApikeyMetadata applies fn to the metadata of keyId on disk while holding the lock of the
+// metadata file, and returns the saved store.
+func updateApikeyMetadata(keyId string, now time.Time, fn func(m *apikeyMetadata)) (*apikeyMetadataStore, error) {
+	f, err := apikeyMetadataFile()
+	if err != nil {
+		return nil, err
+	}
+	store := &apikeyMetadataStore{}
+	err = f.Update(store, func() error {
+		if store.Keys == nil {
+			store.Keys = map[string]*apikeyMetadata{}
+		}
+		store.update(keyId, now, fn)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// get returns the metadata of keyId, or nil.
+func (s *apikeyMetadataStore) get(keyId string) *apikeyMetadata {
+	if s == nil {
+		return nil
+	}
+	return s.Keys[keyId]
+}
+
+// update applies fn to the (possibly new) metadata of keyId and drops it when it ends up empty.
+func (s *apikeyMetadataStore) update(keyId string, now time.Time, fn func(m *apikeyMetadata)) {
+	m := s.Keys[keyId]
+	if m == nil {
+		m = &apikeyMetadata{}
+	}
+	fn(m)
+	if m.empty() {
+		delete(s.Keys, keyId)
+		return
+	}
+	m.UpdatedAt = now.Format(time.RFC3339)
+	s.Keys[keyId] = m
+}
+
+// parseDayDuration parses "90d" as days, anything else as a Go duration ("12h").
+func parseDayDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q (use e.g. 90d or 12h)", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 90d or 12h)", s)
+	}
+	return d, nil
+}
+
+// formatDayDuration renders d in whole days when it is at least one day.
+func formatDayDuration(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return d.String()
+}
+
+// parseApiKeyTime parses GmtCreate / LastUseDate values ("2026-04-10T10:10:57+08:00").
+func parseApiKeyTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseLabelArgs splits "key=value" (set) and "key-" (remove) arguments.
+func parseLabelArgs(args []string) (map[string]string, []string, error) {
+	set := map[string]string{}
+	var remove []string
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			if !labelKeyValid(key) {
+				return nil, nil, fmt.Errorf("invalid label key %q", key)
+			}
+			set[key] = value
+			continue
+		}
+		if key, ok := strings.CutSuffix(arg, "-"); ok && labelKeyValid(key) {
+			remove = append(remove, key)
+			continue
+		}
+		return nil, nil, fmt.Errorf("invalid label %q (use key=value to set, key- to remove)", arg)
+	}
+	return set, remove, nil
+}
+
+func labelKeyValid(key string) bool {
+	if key == "" || len(key) > 63 {
+		return false
+	}
+	for _, r := range key {
+		if !(r == '-' || r == '_' || r == '.' || r == '/' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// labelsMatch reports whether m has every label in selector.
+func labelsMatch(m *apikeyMetadata, selector map[string]string) bool {
+	for k, v := range selector {
+		if m == nil || m.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveApiKeyId returns the API key ID for --api-key / --api-key-id without a full lookup when
+// the ID was given directly.
+func resolveApiKeyId(ctx context.Context, apiClient agentbay.Client, apiKey, apiKeyId string) (string, error) {
+	if apiKey != "" && apiKeyId != "" {
+		return "", fmt.Errorf("--api-key and --api-key-id are mutually exclusive; please specify only one")
+	}
+	if apiKeyId != "" {
+		return apiKeyId, nil
+	}
+	if apiKey == "" {
+		return "", fmt.Errorf("either --api-key or --api-key-id must be specified. Using --api-key is recommended")
+	}
+	key, err := lookupApiKey(ctx, apiClient, apiKey, "")
+	if err != nil {
+		return "", err
+	}
+	return key.GetKeyId(), nil
+}
+
+// ---------------------------------------------------------------------------
+// apikey label / apikey expire
+// ---------------------------------------------------------------------------
+
+var apikeyLabelCmd = &cobra.Command{
+	Use:   "label key=value... | key-...",
+	Short: "Set or remove local labels on an API key",
+	Long: `Attach labels to an API key. Labels are stored locally (apikey_metadata.json in the
+CLI config directory), not in AgentBay; they are shown by "apikey list" and "apikey describe"
+and can select keys in "apikey audit --label".
+
+Use key=value to set a label and key- to remove it.
+
+Examples:
+  agentbay apikey label --api-key akm-xxxxxxxxxxxxxxxx team=search env=prod
+  agentbay apikey label --api-key-id ak-xxxxxxxxxxxxxxxx env-`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runApikeyLabel,
+}
+
+var apikeyExpireCmd = &cobra.Command{
+	Use:   "expire",
+	Short: "Set a local expiry date or maximum age for an API key",
+	Long: `Record when an API key should be retired. AgentBay keys do not expire on their own;
+the expiry date and maximum age are stored locally (apikey_metadata.json in the CLI config
+directory) and enforced by "apikey audit", which fails once a key is past them.
+
+Examples:
+  # Expire on a date, or a period from now
+  agentbay apikey expire --api-key akm-xxxxxxxxxxxxxxxx --at 2026-12-31
+  agentbay apikey expire --api-key-id ak-xxxxxxxxxxxxxxxx --in 90d
+
+  # Require this key to be rotated every 30 days (overrides "apikey audit --max-age")
+  agentbay apikey expire --api-key-id ak-xxxxxxxxxxxxxxxx --max-age 30d
+
+  # Remove the expiry date and maximum age
+  agentbay apikey expire --api-key-id ak-xxxxxxxxxxxxxxxx --clear`,
+	Args: cobra.NoArgs,
+	RunE: runApikeyExpire,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{apikeyLabelCmd, apikeyExpireCmd} {
+		c.Flags().String("api-key", "", "User-visible API Key (akm-xxx format, recommended)")
+		c.Flags().String("api-key-id", "", "Internal API Key ID (ak-xxx). Prefer --api-key for normal usage")
+		registerApiKeyFlagCompletion(c)
+	}
+	apikeyExpireCmd.Flags().String("at", "", "Expiry date (YYYY-MM-DD or RFC 3339)")
+	apikeyExpireCmd.Flags().String("in", "", "Expiry as a period from now (e.g. 90d or 720h)")
+	apikeyExpireCmd.Flags().String("max-age", "", "Maximum key age for this key (e.g. 30d)")
+	apikeyExpireCmd.Flags().Bool("clear", false, "Remove the expiry date and maximum age")
+
+	ApiKeyCmd.AddCommand(apikeyLabelCmd)
+	ApiKeyCmd.AddCommand(apikeyExpireCmd)
+}
+
+func runApikeyLabel(cmd *cobra.Command, args []string) error {
+	set, remove, err := parseLabelArgs(args)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	apiKey, _ := cmd.Flags().GetString("api-key")
+	apiKeyIdFlag, _ := cmd.Flags().GetString("api-key-id")
+
+	keyId, err := resolveApiKeyIdFromConfig(apiKey, apiKeyIdFlag)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	store, err := updateApikeyMetadata(keyId, time.Now(), func(m *apikeyMetadata) {
+		if m.Labels == nil {
+			m.Labels = map[string]string{}
+		}
+		for k, v := range set {
+			m.Labels[k] = v
+		}
+		for _, k := range remove {
+			delete(m.Labels, k)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to save API key metadata: %w", err)
+	}
+	fmt.Printf("[SUCCESS] Labels of %s: %s\n", keyId, valueOrDash(store.get(keyId).labelString()))
+	return nil
+}
+
+func runApikeyExpire(cmd *cobra.Command, args []string) error {
+	at, _ := cmd.Flags().GetString("at")
+	in, _ := cmd.Flags().GetString("in")
+	maxAge, _ := cmd.Flags().GetString("max-age")
+	clear, _ := cmd.Flags().GetBool("clear")
+	if clear && (at != "" || in != "" || maxAge != "") {
+		return fmt.Errorf("[ERROR] --clear cannot be combined with --at, --in or --max-age")
+	}
+	if !clear && at == "" && in == "" && maxAge == "" {
+		return fmt.Errorf("[ERROR] one of --at, --in, --max-age or --clear must be specified")
+	}
+	now := time.Now()
+	var expiry time.Time
+	if at != "" || in != "" {
+		var err error
+		if expiry, err = parseShareExpiry(in, at, now); err != nil {
+			return fmt.Errorf("[ERROR] %s", strings.NewReplacer("--expires-in", "--in", "--expires-at", "--at").Replace(err.Error()))
+		}
+	}
+	if maxAge != "" {
+		d, err := parseDayDuration(maxAge)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("[ERROR] invalid --max-age %q (use e.g. 30d)", maxAge)
+		}
+	}
+
+	apiKey, _ := cmd.Flags().GetString("api-key")
+	apiKeyIdFlag, _ := cmd.Flags().GetString("api-key-id")
+	keyId, err := resolveApiKeyIdFromConfig(apiKey, apiKeyIdFlag)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	store, err := updateApikeyMetadata(keyId, now, func(m *apikeyMetadata) {
+		if clear {
+			m.ExpiresAt, m.MaxAge = "", ""
+			return
+		}
+		if !expiry.IsZero() {
+			m.ExpiresAt = expiry.Format(time.RFC3339)
+		}
+		if maxAge != "" {
+			m.MaxAge = maxAge
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to save API key metadata: %w", err)
+	}
+
+	m := store.get(keyId)
+	if m == nil || (m.ExpiresAt == "" && m.MaxAge == "") {
+		fmt.Printf("[SUCCESS] Expiry of %s cleared.\n", keyId)
+		return nil
+	}
+	fmt.Printf("[SUCCESS] Expiry of %s updated.\n", keyId)
+	if t, ok := m.expiry(); ok {
+		fmt.Printf("%-*s %s\n", 14, "Expires:", t.Local().Format("2006-01-02 15:04:05"))
+	}
+	if m.MaxAge != "" {
+		fmt.Printf("%-*s %s\n", 14, "Max age:", m.MaxAge)
+	}
+	return nil
+}
+
+// resolveApiKeyIdFromConfig is resolveApiKeyId with a client built from the CLI config; no
+// client is needed (and no login required) when the ID is given directly.
+func resolveApiKeyIdFromConfig(apiKey, apiKeyId string) (string, error) {
+	if apiKey == "" {
+		return resolveApiKeyId(context.Background(), nil, apiKey, apiKeyId)
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return "", fmt.Errorf("load config: %w", err)
+	}
+	return resolveApiKeyId(context.Background(), agentbay.NewClientFromConfig(cfg), apiKey, apiKeyId)
+}
@@ -0,0 +1,727 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// apikey_rotate.go implements "agentbay apikey rotate": create a replacement key with the same
+// concurrency, deliver its secret, and retire the old key (disable after an optional grace
+// period, delete with --finalize). Rotations in progress are tracked in apikey_rotations.json in
+// the CLI config directory; no secret is stored there.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var (
+	apikeyRotateApiKey   string
+	apikeyRotateApiKeyId string
+	apikeyRotateName     string
+	apikeyRotateGrace    time.Duration
+	apikeyRotateFinalize bool
+	apikeyRotatePending  bool
+	apikeyRotateAbandon  bool
+	apikeyRotateForce    bool
+)
+
+var apikeyRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotate an API key",
+	Long: `Replace an API key with a new one and retire the old key.
+
+A rotation:
+  1. looks up the old key (--api-key akm-xxx or --api-key-id ak-xxx),
+  2. creates a new key named <old name>-rot<timestamp> (or --name),
+  3. copies the old key's concurrency limit to it,
+  4. delivers the new plaintext key (--write-to, --env-file, --k8s-secret, --vault-path;
+     printed masked unless --show-secret),
+  5. disables the old key, immediately or once --grace has passed.
+
+The old key is only disabled once steps 3 and 4 have succeeded for every destination. If
+one of them fails, the rotation is left incomplete and the old key stays enabled: run the same
+command again to resume it, or "apikey rotate --abandon" to delete the new key instead.
+
+The rotation is recorded locally (apikey_rotations.json in the CLI config directory).
+Old keys whose grace period has ended are disabled by any later "apikey rotate" run;
+"apikey rotate --pending" lists rotations and does just that, so it can run from cron.
+"apikey rotate --finalize" deletes the old keys of finished rotations.
+
+Examples:
+  # Rotate now: the old key is disabled right away
+  agentbay apikey rotate --api-key akm-xxxxxxxxxxxxxxxx
+
+  # Keep the old key working for another day while clients switch over
+  agentbay apikey rotate --api-key akm-xxxxxxxxxxxxxxxx --grace 24h
+
+  # Write the new key straight into the env file your service reads
+  agentbay apikey rotate --api-key akm-xxxxxxxxxxxxxxxx --grace 24h --env-file /etc/myapp/agentbay.env
+
+  # Show rotations in progress (and disable old keys whose grace has ended)
+  agentbay apikey rotate --pending
+
+  # Delete the old keys once clients have switched
+  agentbay apikey rotate --finalize
+  agentbay apikey rotate --finalize --api-key-id ak-xxxxxxxxxxxxxxxx --yes
+
+  # Give up an incomplete rotation: delete the new key, keep the old one
+  agentbay apikey rotate --abandon --api-key-id ak-xxxxxxxxxxxxxxxx`,
+	Args: cobra.NoArgs,
+	RunE: runApikeyRotate,
+}
+
+func init() {
+	apikeyRotateCmd.Flags().StringVar(&apikeyRotateApiKey, "api-key", "", "User-visible API Key to rotate (akm-xxx format, recommended)")
+	apikeyRotateCmd.Flags().StringVar(&apikeyRotateApiKeyId, "api-key-id", "", "Internal API Key ID (ak-xxx) to rotate. Prefer --api-key for normal usage")
+	apikeyRotateCmd.Flags().StringVar(&apikeyRotateName, "name", "", "Name of the new key (default: <old name>-rot<timestamp>)")
+	apikeyRotateCmd.Flags().DurationVar(&apikeyRotateGrace, "grace", 0, "Keep the old key enabled for this long (e.g. 24h); 0 disables it immediately")
+	apikeyRotateCmd.Flags().BoolVar(&apikeyRotateFinalize, "finalize", false, "Delete the old keys of rotations whose grace period has ended (all, or the one given by --api-key/--api-key-id)")
+	apikeyRotateCmd.Flags().BoolVar(&apikeyRotatePending, "pending", false, "List rotations in progress and disable old keys whose grace period has ended")
+	apikeyRotateCmd.Flags().BoolVar(&apikeyRotateAbandon, "abandon", false, "Delete the new key of an incomplete rotation (given by --api-key/--api-key-id) and keep the old key")
+	apikeyRotateCmd.Flags().BoolVar(&apikeyRotateForce, "force", false, "With --finalize, also finalize rotations still in their grace period")
+	apikeyRotateCmd.Flags().BoolP("yes", "y", false, "Skip all confirmation prompts (for non-interactive use)")
+	addSecretDeliveryFlags(apikeyRotateCmd)
+
+	registerApiKeyFlagCompletion(apikeyRotateCmd)
+
+	ApiKeyCmd.AddCommand(apikeyRotateCmd)
+}
+
+// ---------------------------------------------------------------------------
+// Rotation state
+// ---------------------------------------------------------------------------
+
+// apikeyRotationState is the on-disk structure of apikey_rotations.json, keyed by old key ID.
+type apikeyRotationState struct {
+	Rotations map[string]*apikeyRotation `json:"rotations"`
+}
+
+type apikeyRotation struct {
+	OldKeyId    string `json:"old_key_id"`
+	OldName     string `json:"old_name,omitempty"`
+	NewKeyId    string `json:"new_key_id"`
+	NewName     string `json:"new_name"`
+	Concurrency *int32 `json:"concurrency,omitempty"`
+	StartedAt   string `json:"started_at"`
+	// Incomplete is set until the new key has its concurrency limit and has been delivered.
+	// The old key is not disabled while it is set.
+	Incomplete bool `json:"incomplete,omitempty"`
+	// DisableAfter is when the grace period ends (RFC 3339).
+	DisableAfter string `json:"disable_after"`
+	// DisabledAt is set once the old key has been disabled.
+	DisabledAt string `json:"disabled_at,omitempty"`
+}
+
+func (r *apikeyRotation) graceEnds() time.Time {
+	t, _ := time.Parse(time.RFC3339, r.DisableAfter)
+	return t
+}
+
+func apikeyRotationFile() (*config.StateFile, error) {
+	return config.NewStateFile("apikey_rotations.json")
+}
+
+func loadApikeyRotationState() (*apikeyRotationState, error) {
+	f, err := apikeyRotationFile()
+	if err != nil {
+		return nil, err
+	}
+	state := &apikeyRotationState{}
+	if err := f.Load(state); err != nil {
+		return nil, err
+	}
+	if state.Rotations == nil {
+		state.Rotations = map[string]*apikeyRotation{}
+	}
+	return state, nil
+}
+
+// saveApikeyRotation records r in the state file, merged with the rotations other agentbay
+// processes recorded since the file was loaded.
+func saveApikeyRotation(r *apikeyRotation) error {
+	return updateApikeyRotations(func(rotations map[string]*apikeyRotation) { rotations[r.OldKeyId] = r })
+}
+
+// forgetApikeyRotation removes the rotation of oldKeyId from the state file.
+func forgetApikeyRotation(oldKeyId string) error {
+	return updateApikeyRotations(func(rotations map[string]*apikeyRotation) { delete(rotations, oldKeyId) })
+}
+
+func updateApikeyRotations(fn func(rotations map[string]*apikeyRotation)) error {
+	f, err := apikeyRotationFile()
+	if err != nil {
+		return err
+	}
+	state := &apikeyRotationState{}
+	return f.Update(state, func() error {
+		if state.Rotations == nil {
+			state.Rotations = map[string]*apikeyRotation{}
+		}
+		fn(state.Rotations)
+		return nil
+	})
+}
+
+// sorted returns the rotations ordered by start time.
+func (s *apikeyRotationState) sorted() []*apikeyRotation {
+	out := make([]*apikeyRotation, 0, len(s.Rotations))
+	for _, r := range s.Rotations {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].StartedAt != out[j].StartedAt {
+			return out[i].StartedAt < out[j].StartedAt
+		}
+		return out[i].OldKeyId < out[j].OldKeyId
+	})
+	return out
+}
+
+// rotationSuffix matches the suffix added by earlier rotations so names do not grow.
+var rotationSuffix = regexp.MustCompile(`-rot\d{14}$`)
+
+// rotatedApiKeyName returns the default name of the replacement for a key called name.
+func rotatedApiKeyName(name string, now time.Time) string {
+	base := rotationSuffix.ReplaceAllString(name, "")
+	if base == "" {
+		base = "apikey"
+	}
+	return base + "-rot" + now.Format("20060102150405")
+}
+
+// ---------------------------------------------------------------------------
+// API helpers
+// ---------------------------------------------------------------------------
+
+// lookupApiKey resolves --api-key (akm-xxx) or --api-key-id (ak-xxx) to the key's full record.
+func lookupApiKey(ctx context.Context, apiClient agentbay.Client, apiKey, apiKeyId string) (*client.DescribeApiKeysResponseBodyDataApiKey, error) {
+	if apiKey != "" {
+		descResp, err := apiClient.DescribeMcpApiKey(ctx, &client.DescribeMcpApiKeyRequest{ApiKey: &apiKey})
+		if err != nil {
+			printReqIDFromErr(err)
+			return nil, fmt.Errorf("failed to look up API key: %w", err)
+		}
+		if descResp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing body")
+		}
+		if reqID := descResp.Body.GetRequestId(); reqID != "" {
+			fmt.Printf("[INFO] DescribeMcpApiKey Request ID: %s\n", reqID)
+		}
+		if !descResp.Body.GetSuccess() {
+			msg := ""
+			if descResp.Body.Message != nil {
+				msg = *descResp.Body.Message
+			}
+			return nil, fmt.Errorf("failed to look up API key: Code=%s, Message=%s", descResp.Body.GetCode(), msg)
+		}
+		data := descResp.Body.GetData()
+		if data == nil || data.GetApiKeyId() == "" {
+			return nil, fmt.Errorf("invalid response: missing ApiKeyId")
+		}
+		apiKeyId = data.GetApiKeyId()
+	}
+
+	listResp, err := apiClient.DescribeApiKeys(ctx, &client.DescribeApiKeysRequest{KeyIds: []string{apiKeyId}})
+	if err != nil {
+		printReqIDFromErr(err)
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+	if listResp.Body == nil {
+		return nil, fmt.Errorf("invalid response: missing body")
+	}
+	if reqID := listResp.Body.GetRequestId(); reqID != "" {
+		fmt.Printf("[INFO] DescribeApiKeys Request ID: %s\n", reqID)
+	}
+	code := listResp.Body.GetCode()
+	successPtr := listResp.Body.Success
+	if (successPtr != nil && !*successPtr) || (code != "" && !isSuccessCode(code)) {
+		return nil, fmt.Errorf("failed to look up API key: Code=%s, Message=%s", code, listResp.Body.GetMessage())
+	}
+	listData := listResp.Body.GetData()
+	if listData == nil || len(listData.GetApiKeys()) == 0 || listData.GetApiKeys()[0] == nil {
+		return nil, fmt.Errorf("API key not found for the given API Key ID: %s", apiKeyId)
+	}
+	return listData.GetApiKeys()[0], nil
+}
+
+func createApiKeyNamed(ctx context.Context, apiClient agentbay.Client, name string) (string, error) {
+	resp, err := apiClient.CreateApiKey(ctx, &client.CreateApiKeyRequest{Name: &name})
+	if err != nil {
+		printReqIDFromErr(err)
+		return "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	if resp.Body == nil {
+		return "", fmt.Errorf("invalid response: missing body")
+	}
+	if resp.Body.RequestId != nil && *resp.Body.RequestId != "" {
+		fmt.Printf("[INFO] CreateApiKey Request ID: %s\n", *resp.Body.RequestId)
+	}
+	keyId := resp.Body.GetData()
+	if keyId == "" {
+		return "", fmt.Errorf("invalid response: missing ApiKeyId")
+	}
+	return keyId, nil
+}
+
+func setApiKeyConcurrency(ctx context.Context, apiClient agentbay.Client, keyId string, concurrency int32) error {
+	resp, err := apiClient.ModifyMcpApiKeyConfig(ctx, &client.ModifyMcpApiKeyConfigRequest{ApiKeyId: &keyId, Concurrency: &concurrency})
+	if err != nil {
+		printReqIDFromErr(err)
+		return fmt.Errorf("failed to set concurrency: %w", err)
+	}
+	if resp.Body == nil {
+		return fmt.Errorf("invalid response: missing body")
+	}
+	if resp.Body.RequestId != nil && *resp.Body.RequestId != "" {
+		fmt.Printf("[INFO] ModifyMcpApiKeyConfig Request ID: %s\n", *resp.Body.RequestId)
+	}
+	if !resp.Body.GetSuccess() {
+		msg := ""
+		if resp.Body.Message != nil {
+			msg = *resp.Body.Message
+		}
+		return fmt.Errorf("failed to set concurrency: Code=%s, Message=%s", resp.Body.GetCode(), msg)
+	}
+	return nil
+}
+
+func describeApiKeyContent(ctx context.Context, apiClient agentbay.Client, keyId string) (string, error) {
+	resp, err := apiClient.DescribeKeyContent(ctx, &client.DescribeKeyContentRequest{KeyId: &keyId})
+	if err != nil {
+		printReqIDFromErr(err)
+		return "", fmt.Errorf("failed to describe key content: %w", err)
+	}
+	if resp.Body == nil {
+		return "", fmt.Errorf("invalid response: missing body")
+	}
+	if reqID := resp.Body.GetRequestId(); reqID != "" {
+		fmt.Printf("[INFO] DescribeKeyContent Request ID: %s\n", reqID)
+	}
+	code := resp.Body.GetCode()
+	successPtr := resp.Body.Success
+	isOk := code == "" || strings.EqualFold(code, "ok") || code == "200"
+	if (successPtr != nil && !*successPtr) || !isOk {
+		return "", fmt.Errorf("failed to describe key content: Code=%s, Message=%s", code, resp.Body.GetMessage())
+	}
+	if resp.Body.Data == nil || resp.Body.Data.GetApiKey() == "" {
+		return "", fmt.Errorf("invalid response: missing ApiKey in data")
+	}
+	return resp.Body.Data.GetApiKey(), nil
+}
+
+func setApiKeyStatus(ctx context.Context, apiClient agentbay.Client, keyId, status string) error {
+	resp, err := apiClient.ModifyApiKeyStatus(ctx, &client.ModifyApiKeyStatusRequest{ApiKey: &keyId, Status: &status})
+	if err != nil {
+		printReqIDFromErr(err)
+		return fmt.Errorf("failed to modify API key status: %w", err)
+	}
+	if resp.Body == nil {
+		return fmt.Errorf("invalid response: missing body")
+	}
+	if reqID := resp.Body.GetRequestId(); reqID != "" {
+		fmt.Printf("[INFO] ModifyApiKeyStatus Request ID: %s\n", reqID)
+	}
+	if !resp.Body.GetSuccess() {
+		msg := ""
+		if resp.Body.Message != nil {
+			msg = *resp.Body.Message
+		}
+		return fmt.Errorf("failed to modify API key status: Code=%s, Message=%s", resp.Body.GetCode(), msg)
+	}
+	return nil
+}
+
+func deleteApiKeyById(ctx context.Context, apiClient agentbay.Client, keyId string) error {
+	keyIdListJSON, err := json.Marshal([]string{keyId})
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	keyIdListStr := string(keyIdListJSON)
+	resp, err := apiClient.DeleteApiKey(ctx, &client.DeleteApiKeyRequest{KeyIdListJson: &keyIdListStr})
+	if err != nil {
+		printReqIDFromErr(err)
+		return fmt.Errorf("failed to delete API key: %w", err)
+	}
+	if resp.Body == nil {
+		return fmt.Errorf("invalid response: missing body")
+	}
+	if reqID := resp.Body.GetRequestId(); reqID != "" {
+		fmt.Printf("[INFO] DeleteApiKey Request ID: %s\n", reqID)
+	}
+	code := resp.Body.GetCode()
+	successPtr := resp.Body.Success
+	if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
+		return fmt.Errorf("failed to delete API key: Code=%s, Message=%s", code, resp.Body.GetMessage())
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Rotation steps
+// ---------------------------------------------------------------------------
+
+// startApiKeyRotation creates the replacement key for old (step 2), records the rotation and
+// completes it (steps 3-5). The rotation is nil only when the new key could not be created.
+func startApiKeyRotation(ctx context.Context, apiClient agentbay.Client, state *apikeyRotationState, old *client.DescribeApiKeysResponseBodyDataApiKey, newName string, grace time.Duration, delivery *secretDeliveryOptions, now time.Time) (*apikeyRotation, error) {
+	r := &apikeyRotation{
+		OldKeyId:     old.GetKeyId(),
+		OldName:      old.GetName(),
+		NewName:      newName,
+		Concurrency:  old.Concurrency,
+		StartedAt:    now.Format(time.RFC3339),
+		DisableAfter: now.Add(grace).Format(time.RFC3339),
+		Incomplete:   true,
+	}
+
+	fmt.Printf("[STEP 2/5] Creating replacement key %q...\n", newName)
+	newKeyId, err := createApiKeyNamed(ctx, apiClient, newName)
+	if err != nil {
+		return nil, err
+	}
+	r.NewKeyId = newKeyId
+	fmt.Printf("  ApiKeyId: %s\n", newKeyId)
+
+	// Record the rotation as soon as the new key exists so a later failure can be resumed.
+	state.Rotations[r.OldKeyId] = r
+	if err := saveApikeyRotation(r); err != nil {
+		fmt.Printf("[WARN] Failed to save rotation state: %v\n", err)
+	}
+	return r, completeApiKeyRotation(ctx, apiClient, r, old.GetStatus(), grace, delivery, now)
+}
+
+// completeApiKeyRotation performs steps 3-5 for r: it copies the concurrency limit, delivers the
+// new key and retires the old one. Any failure before the old key is retired leaves r incomplete,
+// so it is neither disabled by advanceApiKeyRotations nor finalized until the rotation is resumed.
+func completeApiKeyRotation(ctx context.Context, apiClient agentbay.Client, r *apikeyRotation, oldStatus string, grace time.Duration, delivery *secretDeliveryOptions, now time.Time) error {
+	retry := fmt.Sprintf("the old key %s stays enabled; resume with 'agentbay apikey rotate --api-key-id %s' and the same delivery flags, or drop the new key with 'agentbay apikey rotate --abandon --api-key-id %s'", r.OldKeyId, r.OldKeyId, r.OldKeyId)
+
+	if r.Concurrency != nil && *r.Concurrency > 0 {
+		fmt.Printf("[STEP 3/5] Copying concurrency %d...\n", *r.Concurrency)
+		if err := setApiKeyConcurrency(ctx, apiClient, r.NewKeyId, *r.Concurrency); err != nil {
+			return fmt.Errorf("%w; %s", err, retry)
+		}
+	} else {
+		fmt.Printf("[STEP 3/5] Old key has no concurrency limit, skipping.\n")
+	}
+
+	fmt.Printf("[STEP 4/5] Fetching and delivering the new key...\n")
+	secret, err := describeApiKeyContent(ctx, apiClient, r.NewKeyId)
+	if err != nil {
+		return fmt.Errorf("%w; %s", err, retry)
+	}
+	fmt.Printf("  ApiKey:   %s\n", delivery.display(secret))
+	if failed := delivery.deliver(apikeySecret{KeyId: r.NewKeyId, Name: r.NewName, Secret: secret}); failed > 0 {
+		return fmt.Errorf("API key could not be written to %d destination(s); %s", failed, retry)
+	}
+
+	// The grace period starts once clients can actually get the new key.
+	r.Incomplete = false
+	r.DisableAfter = now.Add(grace).Format(time.RFC3339)
+	if grace > 0 {
+		fmt.Printf("[STEP 5/5] Old key stays enabled until %s.\n", r.graceEnds().Local().Format("2006-01-02 15:04:05"))
+	} else if oldStatus == "DISABLED" {
+		fmt.Printf("[STEP 5/5] Old key is already DISABLED.\n")
+		r.DisabledAt = now.Format(time.RFC3339)
+	} else {
+		fmt.Printf("[STEP 5/5] Disabling old key %s...\n", r.OldKeyId)
+		if err := setApiKeyStatus(ctx, apiClient, r.OldKeyId, "DISABLED"); err != nil {
+			fmt.Printf("[WARN] %v; it is retried by 'agentbay apikey rotate --pending'\n", err)
+		} else {
+			r.DisabledAt = now.Format(time.RFC3339)
+		}
+	}
+	if err := saveApikeyRotation(r); err != nil {
+		fmt.Printf("[WARN] Failed to save rotation state: %v\n", err)
+	}
+	return nil
+}
+
+// advanceApiKeyRotations disables the old keys whose grace period has ended and returns how many
+// failed. Each rotation is saved as its old key is disabled.
+func advanceApiKeyRotations(ctx context.Context, apiClient agentbay.Client, state *apikeyRotationState, now time.Time) int {
+	failed := 0
+	for _, r := range state.sorted() {
+		if r.Incomplete || r.DisabledAt != "" || now.Before(r.graceEnds()) {
+			continue
+		}
+		fmt.Printf("[INFO] Grace period of %s ended; disabling it...\n", r.OldKeyId)
+		if err := setApiKeyStatus(ctx, apiClient, r.OldKeyId, "DISABLED"); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+			failed++
+			continue
+		}
+		r.DisabledAt = now.Format(time.RFC3339)
+		if err := saveApikeyRotation(r); err != nil {
+			fmt.Printf("[WARN] Failed to save rotation state: %v\n", err)
+		}
+	}
+	return failed
+}
+
+// finalizeApiKeyRotation disables (if needed) and deletes the old key of r and forgets the rotation.
+func finalizeApiKeyRotation(ctx context.Context, apiClient agentbay.Client, state *apikeyRotationState, r *apikeyRotation, now time.Time) error {
+	if r.DisabledAt == "" {
+		if err := setApiKeyStatus(ctx, apiClient, r.OldKeyId, "DISABLED"); err != nil {
+			return err
+		}
+		r.DisabledAt = now.Format(time.RFC3339)
+	}
+	if err := deleteApiKeyById(ctx, apiClient, r.OldKeyId); err != nil {
+		_ = saveApikeyRotation(r)
+		return err
+	}
+	delete(state.Rotations, r.OldKeyId)
+	return forgetApikeyRotation(r.OldKeyId)
+}
+
+// ---------------------------------------------------------------------------
+// Command
+// ---------------------------------------------------------------------------
+
+func runApikeyRotate(cmd *cobra.Command, args []string) error {
+	if apikeyRotateApiKey != "" && apikeyRotateApiKeyId != "" {
+		return fmt.Errorf("[ERROR] --api-key and --api-key-id are mutually exclusive; please specify only one")
+	}
+	if (apikeyRotateFinalize && apikeyRotatePending) || (apikeyRotateAbandon && (apikeyRotateFinalize || apikeyRotatePending)) {
+		return fmt.Errorf("[ERROR] --finalize, --pending and --abandon are mutually exclusive")
+	}
+	if apikeyRotateAbandon && apikeyRotateApiKey == "" && apikeyRotateApiKeyId == "" {
+		return fmt.Errorf("[ERROR] --abandon requires --api-key or --api-key-id")
+	}
+	starting := !apikeyRotateFinalize && !apikeyRotatePending && !apikeyRotateAbandon
+	if starting && apikeyRotateApiKey == "" && apikeyRotateApiKeyId == "" {
+		return fmt.Errorf("[ERROR] Either --api-key or --api-key-id must be specified. Using --api-key is recommended")
+	}
+	if apikeyRotateGrace < 0 {
+		return fmt.Errorf("[ERROR] --grace must not be negative")
+	}
+	if !starting && (apikeyRotateName != "" || apikeyRotateGrace != 0) {
+		return fmt.Errorf("[ERROR] --name and --grace only apply when starting a rotation")
+	}
+	if apikeyRotateForce && !apikeyRotateFinalize {
+		return fmt.Errorf("[ERROR] --force requires --finalize")
+	}
+	autoYes, _ := cmd.Flags().GetBool("yes")
+	delivery, err := secretDeliveryFromFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if !starting && delivery.wantsSecret() {
+		return fmt.Errorf("[ERROR] secret delivery flags only apply when starting a rotation")
+	}
+
+	state, err := loadApikeyRotationState()
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx := context.Background()
+	now := time.Now()
+
+	switch {
+	case apikeyRotatePending:
+		failed := advanceApiKeyRotations(ctx, apiClient, state, now)
+		printApiKeyRotations(state, now)
+		if failed > 0 {
+			return fmt.Errorf("[ERROR] %d old key(s) could not be disabled", failed)
+		}
+		return nil
+	case apikeyRotateFinalize:
+		return runApikeyRotateFinalize(ctx, apiClient, state, now, autoYes)
+	case apikeyRotateAbandon:
+		return runApikeyRotateAbandon(ctx, apiClient, state, autoYes)
+	}
+
+	// Start a rotation.
+	advanceApiKeyRotations(ctx, apiClient, state, now)
+
+	fmt.Printf("[STEP 1/5] Looking up API key...\n")
+	old, err := lookupApiKey(ctx, apiClient, apikeyRotateApiKey, apikeyRotateApiKeyId)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	fmt.Printf("  ApiKeyId: %s\n", old.GetKeyId())
+	if old.GetName() != "" {
+		fmt.Printf("  Name:     %s\n", old.GetName())
+	}
+	fmt.Printf("  Status:   %s\n", old.GetStatus())
+	var r *apikeyRotation
+	if pending, ok := state.Rotations[old.GetKeyId()]; ok {
+		if !pending.Incomplete {
+			return fmt.Errorf("[ERROR] API key %s is already being rotated to %s (started %s). Run 'agentbay apikey rotate --finalize' first", old.GetKeyId(), pending.NewKeyId, pending.StartedAt)
+		}
+		if apikeyRotateName != "" {
+			return fmt.Errorf("[ERROR] --name does not apply when resuming the incomplete rotation to %s", pending.NewKeyId)
+		}
+		fmt.Printf("[INFO] Resuming incomplete rotation to %s (%s)\n", pending.NewKeyId, pending.NewName)
+		r = pending
+		err = completeApiKeyRotation(ctx, apiClient, r, old.GetStatus(), apikeyRotateGrace, delivery, now)
+	} else {
+		newName := apikeyRotateName
+		if newName == "" {
+			newName = rotatedApiKeyName(old.GetName(), now)
+		}
+		r, err = startApiKeyRotation(ctx, apiClient, state, old, newName, apikeyRotateGrace, delivery, now)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("[SUCCESS] API key rotated.\n")
+	fmt.Printf("%-*s %s (%s)\n", apikeyDetailLabelW, "Old:", r.OldKeyId, valueOrDash(r.OldName))
+	fmt.Printf("%-*s %s (%s)\n", apikeyDetailLabelW, "New:", r.NewKeyId, r.NewName)
+	if !delivery.wantsSecret() {
+		fmt.Printf("Note: The new API key is masked. Retrieve it with 'agentbay apikey describe-key-content --api-key-id %s --show-secret'.\n", r.NewKeyId)
+	}
+	if r.DisabledAt == "" {
+		fmt.Printf("Note: The old key is disabled after %s by the next 'agentbay apikey rotate --pending' (or any rotate) run.\n", r.graceEnds().Local().Format("2006-01-02 15:04:05"))
+	}
+	fmt.Println("Note: Run 'agentbay apikey rotate --finalize' to delete the old key once clients have switched.")
+	return nil
+}
+
+// rotationFromFlags returns the rotation of the key given by --api-key or --api-key-id.
+func rotationFromFlags(ctx context.Context, apiClient agentbay.Client, state *apikeyRotationState) (*apikeyRotation, error) {
+	keyId := apikeyRotateApiKeyId
+	if apikeyRotateApiKey != "" {
+		old, err := lookupApiKey(ctx, apiClient, apikeyRotateApiKey, "")
+		if err != nil {
+			return nil, err
+		}
+		keyId = old.GetKeyId()
+	}
+	r, ok := state.Rotations[keyId]
+	if !ok {
+		return nil, fmt.Errorf("No rotation in progress for API key %s", keyId)
+	}
+	return r, nil
+}
+
+// runApikeyRotateAbandon deletes the new key of an incomplete rotation and forgets the rotation;
+// the old key was never disabled.
+func runApikeyRotateAbandon(ctx context.Context, apiClient agentbay.Client, state *apikeyRotationState, autoYes bool) error {
+	r, err := rotationFromFlags(ctx, apiClient, state)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if !r.Incomplete {
+		return fmt.Errorf("[ERROR] The rotation of %s to %s is complete; run 'agentbay apikey rotate --finalize' instead", r.OldKeyId, r.NewKeyId)
+	}
+
+	fmt.Printf("The new API key %s (%s) will be permanently deleted; %s stays enabled.\n", r.NewKeyId, r.NewName, r.OldKeyId)
+	confirmed, err := ConfirmPrompt("Are you sure you want to abandon this rotation? [y/N]: ", autoYes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if !confirmed {
+		fmt.Printf("[INFO] Operation cancelled.\n")
+		return nil
+	}
+	if err := deleteApiKeyById(ctx, apiClient, r.NewKeyId); err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	delete(state.Rotations, r.OldKeyId)
+	if err := forgetApikeyRotation(r.OldKeyId); err != nil {
+		return fmt.Errorf("[ERROR] Failed to save rotation state: %w", err)
+	}
+	fmt.Printf("[OK] %s deleted; rotation of %s abandoned.\n", r.NewKeyId, r.OldKeyId)
+	return nil
+}
+
+func runApikeyRotateFinalize(ctx context.Context, apiClient agentbay.Client, state *apikeyRotationState, now time.Time, autoYes bool) error {
+	targets := state.sorted()
+	if apikeyRotateApiKey != "" || apikeyRotateApiKeyId != "" {
+		r, err := rotationFromFlags(ctx, apiClient, state)
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		targets = []*apikeyRotation{r}
+	}
+	if len(targets) == 0 {
+		fmt.Println("[EMPTY] No rotations in progress.")
+		return nil
+	}
+
+	var ready []*apikeyRotation
+	for _, r := range targets {
+		if r.Incomplete {
+			fmt.Printf("[SKIP] %s: rotation is incomplete (resume it with 'agentbay apikey rotate --api-key-id %s', or use --abandon)\n", r.OldKeyId, r.OldKeyId)
+			continue
+		}
+		if now.Before(r.graceEnds()) && !apikeyRotateForce {
+			fmt.Printf("[SKIP] %s: grace period ends %s (use --force to finalize now)\n", r.OldKeyId, r.graceEnds().Local().Format("2006-01-02 15:04:05"))
+			continue
+		}
+		ready = append(ready, r)
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+
+	fmt.Printf("The following old API key(s) will be disabled (if needed) and permanently deleted:\n")
+	for _, r := range ready {
+		fmt.Printf("  %s (%s) -> replaced by %s (%s)\n", r.OldKeyId, valueOrDash(r.OldName), r.NewKeyId, r.NewName)
+	}
+	fmt.Println()
+	confirmed, err := ConfirmPrompt("Are you sure you want to permanently delete these API keys? [y/N]: ", autoYes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if !confirmed {
+		fmt.Printf("[INFO] Operation cancelled.\n")
+		return nil
+	}
+
+	failed := 0
+	for i, r := range ready {
+		fmt.Printf("[STEP %d/%d] Deleting old API key %s...\n", i+1, len(ready), r.OldKeyId)
+		if err := finalizeApiKeyRotation(ctx, apiClient, state, r, now); err != nil {
+			fmt.Printf("[ERROR] %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("[OK] %s deleted; rotation to %s finalized.\n", r.OldKeyId, r.NewKeyId)
+	}
+	if failed > 0 {
+		return fmt.Errorf("[ERROR] %d of %d rotation(s) could not be finalized", failed, len(ready))
+	}
+	return nil
+}
+
+func printApiKeyRotations(state *apikeyRotationState, now time.Time) {
+	rotations := state.sorted()
+	if len(rotations) == 0 {
+		fmt.Println("[EMPTY] No rotations in progress.")
+		return
+	}
+	fmt.Printf("%s  %s  %s  %s\n", padString("OLD KEY ID", 24), padString("NEW KEY ID", 24), padString("NEW NAME", 32), "OLD KEY")
+	for _, r := range rotations {
+		status := "disabled, run --finalize"
+		if r.Incomplete {
+			status = "incomplete, resume or --abandon"
+		} else if r.DisabledAt == "" {
+			if now.Before(r.graceEnds()) {
+				status = "enabled until " + r.graceEnds().Local().Format("2006-01-02 15:04")
+			} else {
+				status = "enabled, disable pending"
+			}
+		}
+		fmt.Printf("%s  %s  %s  %s\n", padString(r.OldKeyId, 24), padString(r.NewKeyId, 24), padString(truncateString(r.NewName, 32), 32), status)
+	}
+}
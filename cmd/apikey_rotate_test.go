@@ -0,0 +1,187 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+type mockRotateClient struct {
+	agentbay.Client
+	concurrencyErr error
+	created        []string
+	concurrency    map[string]int32
+	statuses       map[string]string
+	deleted        []string
+}
+
+func newMockRotateClient() *mockRotateClient {
+	return &mockRotateClient{concurrency: map[string]int32{}, statuses: map[string]string{}}
+}
+
+func (m *mockRotateClient) CreateApiKey(ctx context.Context, req *client.CreateApiKeyRequest) (*client.CreateApiKeyResponse, error) {
+	m.created = append(m.created, dara.StringValue(req.Name))
+	return &client.CreateApiKeyResponse{Body: &client.CreateApiKeyResponseBody{Success: dara.Bool(true), Data: dara.String("ak-new")}}, nil
+}
+
+func (m *mockRotateClient) ModifyMcpApiKeyConfig(ctx context.Context, req *client.ModifyMcpApiKeyConfigRequest) (*client.ModifyMcpApiKeyConfigResponse, error) {
+	if m.concurrencyErr != nil {
+		return nil, m.concurrencyErr
+	}
+	m.concurrency[dara.StringValue(req.ApiKeyId)] = dara.Int32Value(req.Concurrency)
+	return &client.ModifyMcpApiKeyConfigResponse{Body: &client.ModifyMcpApiKeyConfigResponseBody{Success: dara.Bool(true)}}, nil
+}
+
+func (m *mockRotateClient) DescribeKeyContent(ctx context.Context, req *client.DescribeKeyContentRequest) (*client.DescribeKeyContentResponse, error) {
+	return &client.DescribeKeyContentResponse{Body: &client.DescribeKeyContentResponseBody{
+		Success: dara.Bool(true),
+		Data:    &client.DescribeKeyContentResponseBodyData{ApiKey: dara.String("akm-secret")},
+	}}, nil
+}
+
+func (m *mockRotateClient) ModifyApiKeyStatus(ctx context.Context, req *client.ModifyApiKeyStatusRequest) (*client.ModifyApiKeyStatusResponse, error) {
+	m.statuses[dara.StringValue(req.ApiKey)] = dara.StringValue(req.Status)
+	return &client.ModifyApiKeyStatusResponse{Body: &client.ModifyApiKeyStatusResponseBody{Success: dara.Bool(true)}}, nil
+}
+
+func (m *mockRotateClient) DeleteApiKey(ctx context.Context, req *client.DeleteApiKeyRequest) (*client.DeleteApiKeyResponse, error) {
+	m.deleted = append(m.deleted, dara.StringValue(req.KeyIdListJson))
+	return &client.DeleteApiKeyResponse{Body: &client.DeleteApiKeyResponseBody{Success: dara.Bool(true), Code: dara.String("ok")}}, nil
+}
+
+// recordingSink collects delivered secrets, or fails with err.
+type recordingSink struct {
+	err       error
+	delivered []string
+}
+
+func (s *recordingSink) Describe() string { return "test sink" }
+
+func (s *recordingSink) Deliver(secret apikeySecret) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.delivered = append(s.delivered, secret.Secret)
+	return nil
+}
+
+func TestRotatedApiKeyName(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 30, 5, 0, time.UTC)
+	assert.Equal(t, "prod-svc-rot20261019083005", rotatedApiKeyName("prod-svc", now))
+	assert.Equal(t, "prod-svc-rot20261019083005", rotatedApiKeyName("prod-svc-rot20250101000000", now))
+	assert.Equal(t, "apikey-rot20261019083005", rotatedApiKeyName("", now))
+}
+
+func TestStartApiKeyRotationWithGrace(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	mock := newMockRotateClient()
+	state := &apikeyRotationState{Rotations: map[string]*apikeyRotation{}}
+	old := &client.DescribeApiKeysResponseBodyDataApiKey{KeyId: dara.String("ak-old"), Name: dara.String("svc"), Status: dara.String("ENABLED"), Concurrency: dara.Int32(7)}
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	sink := &recordingSink{}
+
+	r, err := startApiKeyRotation(context.Background(), mock, state, old, "svc-new", 24*time.Hour, &secretDeliveryOptions{Sinks: []secretSink{sink}}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"akm-secret"}, sink.delivered)
+	assert.Equal(t, []string{"svc-new"}, mock.created)
+	assert.Equal(t, int32(7), mock.concurrency["ak-new"])
+	assert.Empty(t, mock.statuses, "old key stays enabled during the grace period")
+	assert.Equal(t, "ak-new", r.NewKeyId)
+	assert.Empty(t, r.DisabledAt)
+
+	loaded, err := loadApikeyRotationState()
+	require.NoError(t, err)
+	require.Contains(t, loaded.Rotations, "ak-old")
+	assert.Equal(t, "ak-new", loaded.Rotations["ak-old"].NewKeyId)
+
+	// Still in grace: nothing happens.
+	assert.Equal(t, 0, advanceApiKeyRotations(context.Background(), mock, loaded, now.Add(time.Hour)))
+	assert.Empty(t, mock.statuses)
+
+	// Grace over: the old key is disabled and that is persisted.
+	assert.Equal(t, 0, advanceApiKeyRotations(context.Background(), mock, loaded, now.Add(25*time.Hour)))
+	assert.Equal(t, "DISABLED", mock.statuses["ak-old"])
+	reloaded, err := loadApikeyRotationState()
+	require.NoError(t, err)
+	assert.NotEmpty(t, reloaded.Rotations["ak-old"].DisabledAt)
+
+	require.NoError(t, finalizeApiKeyRotation(context.Background(), mock, reloaded, reloaded.Rotations["ak-old"], now.Add(26*time.Hour)))
+	assert.Equal(t, []string{`["ak-old"]`}, mock.deleted)
+	final, err := loadApikeyRotationState()
+	require.NoError(t, err)
+	assert.Empty(t, final.Rotations)
+}
+
+func TestStartApiKeyRotationImmediate(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	mock := newMockRotateClient()
+	state := &apikeyRotationState{Rotations: map[string]*apikeyRotation{}}
+	old := &client.DescribeApiKeysResponseBodyDataApiKey{KeyId: dara.String("ak-old"), Status: dara.String("ENABLED")}
+
+	r, err := startApiKeyRotation(context.Background(), mock, state, old, "n", 0, &secretDeliveryOptions{}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, mock.concurrency, "no concurrency to copy")
+	assert.Equal(t, "DISABLED", mock.statuses["ak-old"])
+	assert.NotEmpty(t, r.DisabledAt)
+}
+
+func TestStartApiKeyRotationKeepsOldKeyWhenDeliveryFails(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	mock := newMockRotateClient()
+	state := &apikeyRotationState{Rotations: map[string]*apikeyRotation{}}
+	old := &client.DescribeApiKeysResponseBodyDataApiKey{KeyId: dara.String("ak-old"), Status: dara.String("ENABLED")}
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	failing := &secretDeliveryOptions{Sinks: []secretSink{&recordingSink{}, &recordingSink{err: errors.New("vault unreachable")}}}
+
+	r, err := startApiKeyRotation(context.Background(), mock, state, old, "n", 0, failing, now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stays enabled")
+	require.NotNil(t, r)
+	assert.True(t, r.Incomplete)
+	assert.Empty(t, r.DisabledAt)
+	assert.Empty(t, mock.statuses, "the old key is not disabled before the new key is delivered")
+
+	// Incomplete rotations are not advanced, even once the grace period has ended.
+	loaded, err := loadApikeyRotationState()
+	require.NoError(t, err)
+	assert.True(t, loaded.Rotations["ak-old"].Incomplete)
+	assert.Equal(t, 0, advanceApiKeyRotations(context.Background(), mock, loaded, now.Add(time.Hour)))
+	assert.Empty(t, mock.statuses)
+
+	// Resuming with working destinations completes the rotation.
+	sink := &recordingSink{}
+	require.NoError(t, completeApiKeyRotation(context.Background(), mock, loaded.Rotations["ak-old"], "ENABLED", 0, &secretDeliveryOptions{Sinks: []secretSink{sink}}, now.Add(time.Hour)))
+	assert.Equal(t, []string{"akm-secret"}, sink.delivered)
+	assert.Equal(t, "DISABLED", mock.statuses["ak-old"])
+	assert.Equal(t, []string{"n"}, mock.created, "resuming does not create another key")
+	reloaded, err := loadApikeyRotationState()
+	require.NoError(t, err)
+	assert.False(t, reloaded.Rotations["ak-old"].Incomplete)
+}
+
+func TestStartApiKeyRotationIncompleteWhenConcurrencyFails(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	mock := newMockRotateClient()
+	mock.concurrencyErr = errors.New("throttled")
+	state := &apikeyRotationState{Rotations: map[string]*apikeyRotation{}}
+	old := &client.DescribeApiKeysResponseBodyDataApiKey{KeyId: dara.String("ak-old"), Status: dara.String("ENABLED"), Concurrency: dara.Int32(3)}
+	sink := &recordingSink{}
+
+	r, err := startApiKeyRotation(context.Background(), mock, state, old, "n", time.Hour, &secretDeliveryOptions{Sinks: []secretSink{sink}}, time.Now())
+	require.Error(t, err)
+	assert.True(t, r.Incomplete)
+	assert.Empty(t, sink.delivered, "the secret is not delivered for a key with the wrong concurrency")
+	assert.Empty(t, mock.statuses)
+}
@@ -0,0 +1,401 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// apikey_secret.go delivers a plaintext API key to files and secret stores instead of the
+// terminal. Commands that obtain a secret (apikey create, describe-key-content, rotate) register
+// the delivery flags with addSecretDeliveryFlags and print the key masked unless --show-secret.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// defaultSecretEnvVar is the variable name used for dotenv output, env files, Kubernetes Secret
+// keys and Vault fields unless --env-var is given.
+const defaultSecretEnvVar = "AGENTBAY_API_KEY"
+
+// apikeySecret is a plaintext API key together with what identifies it.
+type apikeySecret struct {
+	KeyId  string
+	Name   string
+	Secret string
+}
+
+// secretSink is a destination the plaintext key is written to.
+type secretSink interface {
+	// Describe returns a short, secret-free description for progress output.
+	Describe() string
+	Deliver(s apikeySecret) error
+}
+
+// secretDeliveryOptions are the parsed delivery flags of one command invocation.
+type secretDeliveryOptions struct {
+	ShowSecret bool
+	Sinks      []secretSink
+}
+
+func addSecretDeliveryFlags(c *cobra.Command) {
+	c.Flags().Bool("show-secret", false, "Print the plaintext API key (masked by default)")
+	c.Flags().String("write-to", "", "Write the API key to this file (mode 0600)")
+	c.Flags().String("format", "raw", "Format of the --write-to file: raw, dotenv or json")
+	c.Flags().String("env-file", "", "Set the API key variable in this dotenv file, keeping its other lines (mode 0600)")
+	c.Flags().String("env-var", defaultSecretEnvVar, "Variable / key name used for dotenv, env files, Kubernetes Secrets and Vault")
+	c.Flags().String("k8s-secret", "", "Write a Kubernetes Secret manifest holding the API key to this file (mode 0600)")
+	c.Flags().String("k8s-secret-name", "agentbay-api-key", "metadata.name of the Kubernetes Secret")
+	c.Flags().String("k8s-namespace", "", "metadata.namespace of the Kubernetes Secret")
+	c.Flags().String("vault-path", "", "Write the API key to this HashiCorp Vault KV path (<mount>/<path>, e.g. secret/agentbay/prod)")
+	c.Flags().String("vault-addr", "", "Vault address (default: $VAULT_ADDR); the token is read from $VAULT_TOKEN")
+	c.Flags().Int("vault-kv-version", 2, "Vault KV secrets engine version (1 or 2)")
+}
+
+// secretDeliveryFromFlags validates the delivery flags of c. It is called before any API request
+// so that a bad flag does not leave a key created but undelivered.
+func secretDeliveryFromFlags(c *cobra.Command) (*secretDeliveryOptions, error) {
+	flags := c.Flags()
+	opts := &secretDeliveryOptions{}
+	opts.ShowSecret, _ = flags.GetBool("show-secret")
+	writeTo, _ := flags.GetString("write-to")
+	format, _ := flags.GetString("format")
+	envFile, _ := flags.GetString("env-file")
+	envVar, _ := flags.GetString("env-var")
+	k8sFile, _ := flags.GetString("k8s-secret")
+	k8sName, _ := flags.GetString("k8s-secret-name")
+	k8sNamespace, _ := flags.GetString("k8s-namespace")
+	vaultPath, _ := flags.GetString("vault-path")
+	vaultAddr, _ := flags.GetString("vault-addr")
+	kvVersion, _ := flags.GetInt("vault-kv-version")
+
+	if !envVarNameValid(envVar) {
+		return nil, fmt.Errorf("--env-var %q is not a valid variable name", envVar)
+	}
+	switch format {
+	case "raw", "dotenv", "json":
+	default:
+		return nil, fmt.Errorf("--format must be raw, dotenv or json, got %q", format)
+	}
+	if flags.Changed("format") && writeTo == "" {
+		return nil, fmt.Errorf("--format requires --write-to")
+	}
+
+	if writeTo != "" {
+		opts.Sinks = append(opts.Sinks, &fileSecretSink{Path: writeTo, Format: format, EnvVar: envVar})
+	}
+	if envFile != "" {
+		opts.Sinks = append(opts.Sinks, &envFileSecretSink{Path: envFile, EnvVar: envVar})
+	}
+	if k8sFile != "" {
+		if k8sName == "" {
+			return nil, fmt.Errorf("--k8s-secret-name must not be empty")
+		}
+		opts.Sinks = append(opts.Sinks, &k8sSecretSink{Path: k8sFile, Name: k8sName, Namespace: k8sNamespace, Key: envVar})
+	} else if flags.Changed("k8s-secret-name") || k8sNamespace != "" {
+		return nil, fmt.Errorf("--k8s-secret-name and --k8s-namespace require --k8s-secret")
+	}
+	if vaultPath != "" {
+		if vaultAddr == "" {
+			vaultAddr = os.Getenv("VAULT_ADDR")
+		}
+		sink, err := newVaultSecretSink(vaultAddr, os.Getenv("VAULT_TOKEN"), os.Getenv("VAULT_NAMESPACE"), vaultPath, kvVersion, envVar)
+		if err != nil {
+			return nil, err
+		}
+		opts.Sinks = append(opts.Sinks, sink)
+	} else if vaultAddr != "" || flags.Changed("vault-kv-version") {
+		return nil, fmt.Errorf("--vault-addr and --vault-kv-version require --vault-path")
+	}
+	return opts, nil
+}
+
+// wantsSecret reports whether the plaintext key is needed at all.
+func (o *secretDeliveryOptions) wantsSecret() bool {
+	return o.ShowSecret || len(o.Sinks) > 0
+}
+
+// deliver writes s to every sink and returns how many failed; failures are reported, not fatal,
+// so one unreachable store does not hide the others' results.
+func (o *secretDeliveryOptions) deliver(s apikeySecret) int {
+	failed := 0
+	for _, sink := range o.Sinks {
+		if err := sink.Deliver(s); err != nil {
+			fmt.Printf("[ERROR] Failed to write API key to %s: %v\n", sink.Describe(), err)
+			failed++
+			continue
+		}
+		fmt.Printf("[OK] API key written to %s\n", sink.Describe())
+	}
+	return failed
+}
+
+// display returns the key as it may be printed: masked unless --show-secret.
+func (o *secretDeliveryOptions) display(secret string) string {
+	if o.ShowSecret {
+		return secret
+	}
+	return maskSecret(secret)
+}
+
+// maskSecret keeps the akm- prefix and the last four characters.
+func maskSecret(secret string) string {
+	return agentbay.MaskSecret(secret)
+}
+
+func envVarNameValid(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeSecretFile replaces path with data with mode 0600, via a temporary file in the same
+// directory so an existing file's mode or a symlink at path does not leak the key.
+func writeSecretFile(path string, data []byte) error {
+	return config.WriteFileAtomic(path, data, 0600)
+}
+
+// ---------------------------------------------------------------------------
+// Sinks
+// ---------------------------------------------------------------------------
+
+// fileSecretSink writes the key to a file of its own (--write-to).
+type fileSecretSink struct {
+	Path   string
+	Format string
+	EnvVar string
+}
+
+func (f *fileSecretSink) Describe() string {
+	return fmt.Sprintf("%s (%s, mode 0600)", f.Path, f.Format)
+}
+
+func (f *fileSecretSink) Deliver(s apikeySecret) error {
+	var data []byte
+	switch f.Format {
+	case "dotenv":
+		content, err := godotenv.Marshal(map[string]string{f.EnvVar: s.Secret})
+		if err != nil {
+			return err
+		}
+		data = []byte(content + "\n")
+	case "json":
+		fields := map[string]string{"api_key": s.Secret, "api_key_id": s.KeyId}
+		if s.Name != "" {
+			fields["name"] = s.Name
+		}
+		out, err := json.MarshalIndent(fields, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(out, '\n')
+	default:
+		data = []byte(s.Secret + "\n")
+	}
+	return writeSecretFile(f.Path, data)
+}
+
+// envFileSecretSink sets one variable in a dotenv file, replacing an existing assignment and
+// keeping every other line (--env-file).
+type envFileSecretSink struct {
+	Path   string
+	EnvVar string
+}
+
+func (e *envFileSecretSink) Describe() string {
+	return fmt.Sprintf("%s as %s (mode 0600)", e.Path, e.EnvVar)
+}
+
+func (e *envFileSecretSink) Deliver(s apikeySecret) error {
+	existing, err := os.ReadFile(e.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	assignment, err := godotenv.Marshal(map[string]string{e.EnvVar: s.Secret})
+	if err != nil {
+		return err
+	}
+	return writeSecretFile(e.Path, []byte(upsertEnvAssignment(string(existing), e.EnvVar, assignment)))
+}
+
+// upsertEnvAssignment replaces the assignments of name in content with assignment, or appends it.
+func upsertEnvAssignment(content, name, assignment string) string {
+	var lines []string
+	if content != "" {
+		lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+	}
+	replaced := false
+	out := lines[:0]
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "export "))
+		if key, _, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(key) == name {
+			if !replaced {
+				out = append(out, assignment)
+				replaced = true
+			}
+			continue
+		}
+		out = append(out, line)
+	}
+	if !replaced {
+		out = append(out, assignment)
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// k8sSecretSink writes a v1 Secret manifest for "kubectl apply -f" (--k8s-secret).
+type k8sSecretSink struct {
+	Path      string
+	Name      string
+	Namespace string
+	Key       string
+}
+
+type k8sSecretManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sSecretMetadata `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	Data       map[string]string `yaml:"data"`
+}
+
+type k8sSecretMetadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+func (k *k8sSecretSink) Describe() string {
+	return fmt.Sprintf("Kubernetes Secret manifest %s (secret %s, mode 0600)", k.Path, k.Name)
+}
+
+func (k *k8sSecretSink) Deliver(s apikeySecret) error {
+	manifest := k8sSecretManifest{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: k8sSecretMetadata{
+			Name:      k.Name,
+			Namespace: k.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "agentbay-cli"},
+		},
+		Type: "Opaque",
+		Data: map[string]string{k.Key: base64.StdEncoding.EncodeToString([]byte(s.Secret))},
+	}
+	if s.KeyId != "" {
+		manifest.Metadata.Annotations = map[string]string{"agentbay/api-key-id": s.KeyId}
+	}
+	data, err := yaml.Marshal(&manifest)
+	if err != nil {
+		return err
+	}
+	return writeSecretFile(k.Path, data)
+}
+
+// vaultSecretSink writes the key to a HashiCorp Vault KV path through the HTTP API (--vault-path).
+type vaultSecretSink struct {
+	Addr      string
+	Token     string
+	Namespace string
+	Mount     string
+	Path      string
+	KVVersion int
+	Field     string
+	client    *http.Client
+}
+
+func newVaultSecretSink(addr, token, namespace, path string, kvVersion int, field string) (*vaultSecretSink, error) {
+	if addr == "" {
+		return nil, fmt.Errorf("--vault-path requires --vault-addr or $VAULT_ADDR")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("--vault-path requires $VAULT_TOKEN")
+	}
+	if kvVersion != 1 && kvVersion != 2 {
+		return nil, fmt.Errorf("--vault-kv-version must be 1 or 2, got %d", kvVersion)
+	}
+	mount, rest, ok := strings.Cut(strings.Trim(path, "/"), "/")
+	if !ok || mount == "" || rest == "" {
+		return nil, fmt.Errorf("--vault-path must be <mount>/<path>, got %q", path)
+	}
+	return &vaultSecretSink{
+		Addr:      strings.TrimRight(addr, "/"),
+		Token:     token,
+		Namespace: namespace,
+		Mount:     mount,
+		Path:      rest,
+		KVVersion: kvVersion,
+		Field:     field,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (v *vaultSecretSink) Describe() string {
+	return fmt.Sprintf("Vault %s/%s (KV v%d, field %s)", v.Mount, v.Path, v.KVVersion, v.Field)
+}
+
+// url returns the write endpoint; KV v2 puts "data/" between the mount and the path.
+func (v *vaultSecretSink) url() string {
+	if v.KVVersion == 2 {
+		return fmt.Sprintf("%s/v1/%s/data/%s", v.Addr, v.Mount, v.Path)
+	}
+	return fmt.Sprintf("%s/v1/%s/%s", v.Addr, v.Mount, v.Path)
+}
+
+func (v *vaultSecretSink) Deliver(s apikeySecret) error {
+	fields := map[string]string{v.Field: s.Secret}
+	if s.KeyId != "" {
+		fields["api_key_id"] = s.KeyId
+	}
+	var payload interface{} = fields
+	if v.KVVersion == 2 {
+		payload = map[string]interface{}{"data": fields}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, v.url(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	req.Header.Set("Content-Type", "application/json")
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(respBody, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return fmt.Errorf("vault returned HTTP %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, "; "))
+		}
+		return fmt.Errorf("vault returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var testSecret = apikeySecret{KeyId: "ak-123", Name: "ci", Secret: "akm-0123456789abcdef"}
+
+func TestMaskSecret(t *testing.T) {
+	assert.Equal(t, "akm-****cdef", maskSecret("akm-0123456789abcdef"))
+	assert.Equal(t, "****wxyz", maskSecret("abcdefghijklmnopqrstuvwxyz"))
+	assert.Equal(t, "*****", maskSecret("short"))
+	assert.Equal(t, "", maskSecret(""))
+}
+
+func TestUpsertEnvAssignment(t *testing.T) {
+	assert.Equal(t, "A=1\n", upsertEnvAssignment("", "A", "A=1"))
+	assert.Equal(t, "# comment\nB=2\nA=new\n",
+		upsertEnvAssignment("# comment\nB=2\nA=old\n", "A", "A=new"))
+	assert.Equal(t, "A=new\nB=2\n",
+		upsertEnvAssignment("export A=old\nB=2\nA=dup", "A", "A=new"))
+	assert.Equal(t, "AB=1\nA=2\n", upsertEnvAssignment("AB=1", "A", "A=2"))
+}
+
+func TestFileSecretSinkFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	raw := filepath.Join(dir, "sub", "key")
+	require.NoError(t, (&fileSecretSink{Path: raw, Format: "raw"}).Deliver(testSecret))
+	data, err := os.ReadFile(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "akm-0123456789abcdef\n", string(data))
+	info, err := os.Stat(raw)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	dotenv := filepath.Join(dir, "key.env")
+	require.NoError(t, (&fileSecretSink{Path: dotenv, Format: "dotenv", EnvVar: "MY_KEY"}).Deliver(testSecret))
+	data, err = os.ReadFile(dotenv)
+	require.NoError(t, err)
+	assert.Equal(t, "MY_KEY=\"akm-0123456789abcdef\"\n", string(data))
+
+	jsonPath := filepath.Join(dir, "key.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte("{}"), 0644))
+	require.NoError(t, (&fileSecretSink{Path: jsonPath, Format: "json"}).Deliver(testSecret))
+	var decoded map[string]string
+	data, err = os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, map[string]string{"api_key": "akm-0123456789abcdef", "api_key_id": "ak-123", "name": "ci"}, decoded)
+	info, err = os.Stat(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "an existing file is tightened to 0600")
+}
+
+func TestWriteSecretFileReplacesSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "shared")
+	require.NoError(t, os.WriteFile(target, []byte("other\n"), 0644))
+	link := filepath.Join(dir, "key")
+	require.NoError(t, os.Symlink(target, link))
+
+	require.NoError(t, writeSecretFile(link, []byte("secret\n")))
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "other\n", string(data), "the symlink target is not written through")
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary file is left behind")
+}
+
+func TestEnvFileSecretSinkKeepsOtherLines(t *testing.T) {
+	p := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(p, []byte("OTHER=x\nAGENTBAY_API_KEY=old\n"), 0644))
+	require.NoError(t, (&envFileSecretSink{Path: p, EnvVar: defaultSecretEnvVar}).Deliver(testSecret))
+	data, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, "OTHER=x\nAGENTBAY_API_KEY=\"akm-0123456789abcdef\"\n", string(data))
+}
+
+func TestK8sSecretSinkManifest(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "secret.yaml")
+	sink := &k8sSecretSink{Path: p, Name: "agentbay", Namespace: "prod", Key: defaultSecretEnvVar}
+	require.NoError(t, sink.Deliver(testSecret))
+
+	data, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), testSecret.Secret, "the manifest holds the key base64-encoded only")
+	var manifest k8sSecretManifest
+	require.NoError(t, yaml.Unmarshal(data, &manifest))
+	assert.Equal(t, "v1", manifest.APIVersion)
+	assert.Equal(t, "Secret", manifest.Kind)
+	assert.Equal(t, "agentbay", manifest.Metadata.Name)
+	assert.Equal(t, "prod", manifest.Metadata.Namespace)
+	assert.Equal(t, "ak-123", manifest.Metadata.Annotations["agentbay/api-key-id"])
+	decoded, err := base64.StdEncoding.DecodeString(manifest.Data[defaultSecretEnvVar])
+	require.NoError(t, err)
+	assert.Equal(t, testSecret.Secret, string(decoded))
+}
+
+// newVaultDevServer is a minimal stand-in for "vault server -dev" that records KV writes.
+func newVaultDevServer(t *testing.T, token string) (*httptest.Server, map[string]map[string]interface{}) {
+	writes := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		writes[r.URL.Path] = body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, writes
+}
+
+func TestVaultSecretSink(t *testing.T) {
+	srv, writes := newVaultDevServer(t, "root")
+
+	v2, err := newVaultSecretSink(srv.URL+"/", "root", "", "secret/agentbay/prod", 2, defaultSecretEnvVar)
+	require.NoError(t, err)
+	require.NoError(t, v2.Deliver(testSecret))
+	require.Contains(t, writes, "/v1/secret/data/agentbay/prod")
+	data := writes["/v1/secret/data/agentbay/prod"]["data"].(map[string]interface{})
+	assert.Equal(t, testSecret.Secret, data[defaultSecretEnvVar])
+	assert.Equal(t, "ak-123", data["api_key_id"])
+
+	v1, err := newVaultSecretSink(srv.URL, "root", "", "kv/agentbay", 1, "api_key")
+	require.NoError(t, err)
+	require.NoError(t, v1.Deliver(testSecret))
+	assert.Equal(t, testSecret.Secret, writes["/v1/kv/agentbay"]["api_key"])
+
+	denied, err := newVaultSecretSink(srv.URL, "wrong", "", "secret/x", 2, "k")
+	require.NoError(t, err)
+	err = denied.Deliver(testSecret)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+	assert.NotContains(t, err.Error(), testSecret.Secret)
+}
+
+func TestNewVaultSecretSinkValidation(t *testing.T) {
+	_, err := newVaultSecretSink("", "t", "", "secret/x", 2, "k")
+	assert.Error(t, err)
+	_, err = newVaultSecretSink("http://v", "", "", "secret/x", 2, "k")
+	assert.Error(t, err)
+	_, err = newVaultSecretSink("http://v", "t", "", "secret", 2, "k")
+	assert.Error(t, err)
+	_, err = newVaultSecretSink("http://v", "t", "", "secret/x", 3, "k")
+	assert.Error(t, err)
+}
+
+func TestSecretDeliveryFromFlags(t *testing.T) {
+	parse := func(args ...string) (*secretDeliveryOptions, error) {
+		c := &cobra.Command{Use: "x"}
+		addSecretDeliveryFlags(c)
+		require.NoError(t, c.ParseFlags(args))
+		return secretDeliveryFromFlags(c)
+	}
+
+	opts, err := parse()
+	require.NoError(t, err)
+	assert.False(t, opts.wantsSecret())
+	assert.Equal(t, "akm-****cdef", opts.display(testSecret.Secret))
+
+	opts, err = parse("--show-secret")
+	require.NoError(t, err)
+	assert.True(t, opts.wantsSecret())
+	assert.Equal(t, testSecret.Secret, opts.display(testSecret.Secret))
+
+	opts, err = parse("--write-to", "k.json", "--format", "json", "--env-file", ".env", "--k8s-secret", "s.yaml")
+	require.NoError(t, err)
+	assert.Len(t, opts.Sinks, 3)
+
+	for _, bad := range [][]string{
+		{"--format", "json"},
+		{"--write-to", "k", "--format", "yaml"},
+		{"--env-var", "1BAD"},
+		{"--k8s-namespace", "prod"},
+		{"--vault-addr", "http://v"},
+	} {
+		_, err := parse(bad...)
+		assert.Error(t, err, bad)
+	}
+
+	t.Setenv("VAULT_ADDR", "http://127.0.0.1:8200")
+	t.Setenv("VAULT_TOKEN", "root")
+	opts, err = parse("--vault-path", "secret/agentbay")
+	require.NoError(t, err)
+	require.Len(t, opts.Sinks, 1)
+	assert.Equal(t, "http://127.0.0.1:8200/v1/secret/data/agentbay", opts.Sinks[0].(*vaultSecretSink).url())
+}
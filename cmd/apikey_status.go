@@ -71,6 +71,9 @@ func init() {
 	apikeyDisableCmd.Flags().StringVar(&apikeyStatusApiKey, "api-key", "", "User-visible API Key (akm-xxx format, recommended)")
 	apikeyDisableCmd.Flags().StringVar(&apikeyStatusApiKeyId, "api-key-id", "", "Internal API Key ID (ak-xxx). Prefer --api-key for normal usage")
 
+	registerApiKeyFlagCompletion(apikeyEnableCmd)
+	registerApiKeyFlagCompletion(apikeyDisableCmd)
+
 	ApiKeyCmd.AddCommand(apikeyEnableCmd)
 	ApiKeyCmd.AddCommand(apikeyDisableCmd)
 }
@@ -0,0 +1,328 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// completion.go backs the dynamic shell completion of resource IDs: image IDs, skill IDs, API
+// key IDs and share UIDs. Plaintext API keys are never completed or cached. The list responses are kept in completion_cache.json in the CLI config
+// directory for completionCacheTTL, so repeated tab presses do not each wait for the API. When a
+// refresh fails the stale entry is used rather than completing nothing. A write through the API
+// client drops the entry of the kind it changed.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+const (
+	completionCacheTTL = 2 * time.Minute
+	// completionFetchTimeout bounds how long a tab press may wait for the API.
+	completionFetchTimeout = 5 * time.Second
+)
+
+// Completion cache kinds, named like the response cache kinds they are invalidated with.
+const (
+	completionImages  = agentbay.CacheImages
+	completionSkills  = agentbay.CacheSkills
+	completionApiKeys = agentbay.CacheApiKeys
+	completionShares  = agentbay.CacheShares
+)
+
+// completionCache is the on-disk structure of completion_cache.json, keyed by kind.
+type completionCache struct {
+	Entries map[string]*completionCacheEntry `json:"entries"`
+}
+
+type completionCacheEntry struct {
+	FetchedAt string           `json:"fetched_at"`
+	Items     []completionItem `json:"items"`
+}
+
+// completionItem is one candidate. Kind narrows it down within its cache entry: the image type
+// (User/System) for images.
+type completionItem struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+}
+
+type completionFetcher func(ctx context.Context, apiClient agentbay.Client) ([]completionItem, error)
+
+var completionFetchers = map[string]completionFetcher{
+	completionImages:  fetchImageCompletions,
+	completionSkills:  fetchSkillCompletions,
+	completionApiKeys: fetchApiKeyCompletions,
+	completionShares:  fetchShareCompletions,
+}
+
+func init() {
+	// A write through the API client also drops the candidates of the kind it changed.
+	agentbay.OnCacheInvalidate(dropCompletions)
+}
+
+func completionCachePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "completion_cache.json"), nil
+}
+
+func loadCompletionCache() *completionCache {
+	cache := &completionCache{}
+	p, err := completionCachePath()
+	if err != nil {
+		return &completionCache{Entries: map[string]*completionCacheEntry{}}
+	}
+	// A corrupt cache is simply rebuilt.
+	if (&config.StateFile{Path: p}).Load(cache) != nil || cache.Entries == nil {
+		cache.Entries = map[string]*completionCacheEntry{}
+	}
+	// Earlier versions stored user-visible API keys next to the IDs; drop that entry.
+	if entry := cache.Entries[completionApiKeys]; entry != nil {
+		for _, it := range entry.Items {
+			if it.Kind != "" {
+				delete(cache.Entries, completionApiKeys)
+				break
+			}
+		}
+	}
+	return cache
+}
+
+// updateCompletionCache applies fn to the cache under its lock and saves it when fn reports a
+// change. The file is written with 0600 permissions: it describes the account.
+func updateCompletionCache(fn func(cache *completionCache) bool) error {
+	p, err := completionCachePath()
+	if err != nil {
+		return err
+	}
+	f := &config.StateFile{Path: p}
+	unlock, err := f.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	cache := loadCompletionCache()
+	if !fn(cache) {
+		return nil
+	}
+	return f.Save(cache)
+}
+
+// dropCompletions removes the cached candidates of kind.
+func dropCompletions(kind string) {
+	if _, ok := loadCompletionCache().Entries[kind]; !ok {
+		return
+	}
+	_ = updateCompletionCache(func(cache *completionCache) bool {
+		if _, ok := cache.Entries[kind]; !ok {
+			return false
+		}
+		delete(cache.Entries, kind)
+		return true
+	})
+}
+
+// fresh reports whether the entry was fetched less than completionCacheTTL before now.
+func (e *completionCacheEntry) fresh(now time.Time) bool {
+	if e == nil {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, e.FetchedAt)
+	return err == nil && now.Sub(t) < completionCacheTTL && !t.After(now)
+}
+
+// cachedCompletions returns the items of kind from the cache, calling fetch when the entry is
+// missing or older than completionCacheTTL. A failed fetch falls back to the stale entry.
+func cachedCompletions(kind string, now time.Time, fetch func() ([]completionItem, error)) []completionItem {
+	cache := loadCompletionCache()
+	entry := cache.Entries[kind]
+	if entry.fresh(now) {
+		return entry.Items
+	}
+	items, err := fetch()
+	if err != nil {
+		if entry != nil {
+			return entry.Items
+		}
+		return nil
+	}
+	_ = updateCompletionCache(func(cache *completionCache) bool {
+		cache.Entries[kind] = &completionCacheEntry{FetchedAt: now.UTC().Format(time.RFC3339), Items: items}
+		return true
+	})
+	return items
+}
+
+// completionCandidates loads the items of kind through the cache with a client built from the
+// current configuration. Without credentials it completes nothing.
+func completionCandidates(kind string) []completionItem {
+	return cachedCompletions(kind, time.Now(), func() ([]completionItem, error) {
+		cfg, err := config.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		if !cfg.IsAuthenticated() {
+			return nil, config.ErrNotAuthenticated()
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), completionFetchTimeout)
+		defer cancel()
+		return completionFetchers[kind](ctx, agentbay.NewClientFromConfig(cfg))
+	})
+}
+
+// matchCompletions formats the items accepted by keep whose value starts with toComplete as
+// "value\tdescription" lines.
+func matchCompletions(items []completionItem, toComplete string, keep func(completionItem) bool) []string {
+	var out []string
+	for _, it := range items {
+		if !strings.HasPrefix(it.Value, toComplete) || (keep != nil && !keep(it)) {
+			continue
+		}
+		if it.Description != "" {
+			out = append(out, it.Value+"\t"+it.Description)
+		} else {
+			out = append(out, it.Value)
+		}
+	}
+	return out
+}
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeFlag completes a flag value from the cached items of kind accepted by keep.
+func completeFlag(kind string, keep func(completionItem) bool) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matchCompletions(completionCandidates(kind), toComplete, keep), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeFirstArg completes the first positional argument like completeFlag. Later arguments
+// fall back to the shell's file completion, e.g. the directory of "skills diff".
+func completeFirstArg(kind string, keep func(completionItem) bool) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return matchCompletions(completionCandidates(kind), toComplete, keep), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeOnlyArg is completeFirstArg for commands that take exactly one argument.
+func completeOnlyArg(kind string, keep func(completionItem) bool) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return matchCompletions(completionCandidates(kind), toComplete, keep), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// registerFlagCompletion registers fn for a flag of c. It panics on an unknown flag so that a
+// misspelt name fails every test run instead of silently completing nothing.
+func registerFlagCompletion(c *cobra.Command, flag string, fn completionFunc) {
+	if err := c.RegisterFlagCompletionFunc(flag, fn); err != nil {
+		panic(fmt.Sprintf("%s: %v", c.CommandPath(), err))
+	}
+}
+
+// registerApiKeyFlagCompletion completes --api-key-id with ak- IDs. --api-key takes a secret, so
+// it offers no candidates, not even file names.
+func registerApiKeyFlagCompletion(c *cobra.Command) {
+	if c.Flags().Lookup("api-key") != nil {
+		registerFlagCompletion(c, "api-key", cobra.FixedCompletions(nil, cobra.ShellCompDirectiveNoFileComp))
+	}
+	registerFlagCompletion(c, "api-key-id", completeFlag(completionApiKeys, nil))
+}
+
+// userImagesOnly keeps user images, the only ones that can be activated, deactivated or deleted.
+func userImagesOnly(it completionItem) bool {
+	return IsUserImage(it.Kind)
+}
+
+// ---------------------------------------------------------------------------
+// Fetchers
+// ---------------------------------------------------------------------------
+
+func fetchImageCompletions(ctx context.Context, apiClient agentbay.Client) ([]completionItem, error) {
+	var items []completionItem
+	for _, imageType := range []string{"User", "System"} {
+		_, err := walkPages(ctx, listPaging{All: true}, pageCursor{PageNo: 1}, imagePages(apiClient, imageType, "", 50, io.Discard),
+			func(img *client.ListMcpImagesResponseBodyData) error {
+				if img == nil {
+					return nil
+				}
+				item := newImageItemJSON(img)
+				items = append(items, completionItem{
+					Value:       item.ImageId,
+					Description: fmt.Sprintf("%s (%s)", item.ImageName, item.StatusDisplay),
+					Kind:        imageType,
+				})
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func fetchSkillCompletions(ctx context.Context, apiClient agentbay.Client) ([]completionItem, error) {
+	skills, err := listAllSkills(ctx, apiClient, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]completionItem, 0, len(skills))
+	for _, s := range skills {
+		items = append(items, completionItem{Value: strPtr(s.SkillId), Description: strPtr(s.SkillName)})
+	}
+	return items, nil
+}
+
+// fetchApiKeyCompletions returns the ak- ID of every key, described by its name and status. The
+// user-visible key is left out: it is a secret.
+func fetchApiKeyCompletions(ctx context.Context, apiClient agentbay.Client) ([]completionItem, error) {
+	var items []completionItem
+	_, err := walkPages(ctx, listPaging{All: true}, pageCursor{}, apiKeyPages(apiClient, 50, "", io.Discard),
+		func(key *client.DescribeApiKeysResponseBodyDataApiKey) error {
+			if key == nil {
+				return nil
+			}
+			desc := fmt.Sprintf("%s (%s)", key.GetName(), strings.ToLower(key.GetStatus()))
+			items = append(items, completionItem{Value: key.GetKeyId(), Description: desc})
+			return nil
+		})
+	return items, err
+}
+
+// fetchShareCompletions returns the UIDs the Docker repo is shared with (Outgoing shares).
+func fetchShareCompletions(ctx context.Context, apiClient agentbay.Client) ([]completionItem, error) {
+	expiries := shareGrantExpiries("Outgoing")
+	var items []completionItem
+	_, err := walkPages(ctx, listPaging{All: true}, pageCursor{PageNo: 1}, sharedRepoPages(apiClient, "Outgoing", 0, 50, io.Discard),
+		func(it *client.ListSharedDockerReposResponseBodyDataItem) error {
+			if it == nil {
+				return nil
+			}
+			s := newShareListItem(it, expiries)
+			desc := s.Status
+			if s.ExpiresAt != "" {
+				desc += ", expires " + s.ExpiresAt
+			}
+			items = append(items, completionItem{Value: strconv.FormatInt(s.PeerAliUid, 10), Description: desc})
+			return nil
+		})
+	return items, err
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestCachedCompletions(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	fetch := func(items ...completionItem) func() ([]completionItem, error) {
+		return func() ([]completionItem, error) {
+			calls++
+			return items, nil
+		}
+	}
+	failing := func() ([]completionItem, error) {
+		calls++
+		return nil, errors.New("boom")
+	}
+
+	assert.Nil(t, cachedCompletions(completionImages, now, failing), "nothing cached and the fetch failed")
+
+	first := cachedCompletions(completionImages, now, fetch(completionItem{Value: "imgc-1"}))
+	assert.Equal(t, []completionItem{{Value: "imgc-1"}}, first)
+
+	p, err := completionCachePath()
+	require.NoError(t, err)
+	info, err := os.Stat(p)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	calls = 0
+	assert.Equal(t, first, cachedCompletions(completionImages, now.Add(time.Minute), fetch(completionItem{Value: "imgc-2"})))
+	assert.Equal(t, 0, calls, "a fresh entry is served from the cache")
+
+	assert.Equal(t, first, cachedCompletions(completionImages, now.Add(completionCacheTTL), failing), "a failed refresh falls back to the stale entry")
+	assert.Equal(t, 1, calls)
+
+	refreshed := cachedCompletions(completionImages, now.Add(completionCacheTTL), fetch(completionItem{Value: "imgc-2"}))
+	assert.Equal(t, []completionItem{{Value: "imgc-2"}}, refreshed)
+
+	calls = 0
+	cachedCompletions(completionSkills, now.Add(completionCacheTTL), fetch(completionItem{Value: "skill-1"}))
+	assert.Equal(t, 1, calls, "entries are cached per kind")
+	assert.Equal(t, refreshed, cachedCompletions(completionImages, now.Add(completionCacheTTL), failing))
+	assert.Equal(t, 1, calls)
+
+	require.NoError(t, os.WriteFile(p, []byte("{not json"), 0600))
+	assert.Equal(t, first, cachedCompletions(completionImages, now, fetch(completionItem{Value: "imgc-1"})), "a corrupt cache is rebuilt")
+}
+
+func TestCompletionCacheEntryFresh(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(at time.Time) *completionCacheEntry {
+		return &completionCacheEntry{FetchedAt: at.Format(time.RFC3339)}
+	}
+	assert.True(t, entry(now.Add(-time.Minute)).fresh(now))
+	assert.False(t, entry(now.Add(-completionCacheTTL)).fresh(now))
+	assert.False(t, entry(now.Add(time.Hour)).fresh(now), "a clock change does not pin an entry")
+	assert.False(t, (&completionCacheEntry{FetchedAt: "yesterday"}).fresh(now))
+	assert.False(t, (*completionCacheEntry)(nil).fresh(now))
+}
+
+func TestMatchCompletions(t *testing.T) {
+	items := []completionItem{
+		{Value: "imgc-user", Description: "web (Available)", Kind: "User"},
+		{Value: "imgc-sys", Description: "browser (Available)", Kind: "System"},
+		{Value: "other", Kind: "User"},
+	}
+	assert.Equal(t, []string{"imgc-user\tweb (Available)", "imgc-sys\tbrowser (Available)"}, matchCompletions(items, "imgc", nil))
+	assert.Equal(t, []string{"imgc-user\tweb (Available)", "other"}, matchCompletions(items, "", userImagesOnly))
+	assert.Empty(t, matchCompletions(items, "nope", nil))
+}
+
+func TestCompleteArgDirectives(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	require.NoError(t, updateCompletionCache(func(cache *completionCache) bool {
+		cache.Entries[completionSkills] = &completionCacheEntry{FetchedAt: time.Now().UTC().Format(time.RFC3339), Items: []completionItem{{Value: "skill-1", Description: "demo"}}}
+		return true
+	}))
+	c := &cobra.Command{}
+
+	got, dir := completeOnlyArg(completionSkills, nil)(c, nil, "sk")
+	assert.Equal(t, []string{"skill-1\tdemo"}, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, dir)
+
+	got, dir = completeOnlyArg(completionSkills, nil)(c, []string{"skill-1"}, "")
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, dir)
+
+	got, dir = completeFirstArg(completionSkills, nil)(c, []string{"skill-1"}, "")
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveDefault, dir, "the directory argument of skills diff completes files")
+}
+
+func TestFetchApiKeyCompletions(t *testing.T) {
+	key := func(id, value string) *client.DescribeApiKeysResponseBodyDataApiKey {
+		return &client.DescribeApiKeysResponseBodyDataApiKey{
+			KeyId: dara.String(id), ApiKey: dara.String(value), Name: dara.String("ci"), Status: dara.String("ENABLED"),
+		}
+	}
+	mock := &mockListKeysClient{pages: [][]*client.DescribeApiKeysResponseBodyDataApiKey{
+		{key("ak-1", "akm-plain")}, {key("ak-2", "akm-****")},
+	}}
+	items, err := fetchApiKeyCompletions(context.Background(), mock)
+	require.NoError(t, err)
+	assert.Equal(t, []completionItem{
+		{Value: "ak-1", Description: "ci (enabled)"},
+		{Value: "ak-2", Description: "ci (enabled)"},
+	}, items, "only key IDs are offered, never the keys")
+}
+
+func TestApiKeyFlagNeverCompletesKeys(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	// A cache written by an earlier version that stored plaintext keys.
+	require.NoError(t, updateCompletionCache(func(cache *completionCache) bool {
+		cache.Entries[completionApiKeys] = &completionCacheEntry{FetchedAt: time.Now().UTC().Format(time.RFC3339), Items: []completionItem{
+			{Value: "ak-1", Kind: "id"}, {Value: "akm-plain", Kind: "key"},
+		}}
+		return true
+	}))
+	assert.NotContains(t, loadCompletionCache().Entries, completionApiKeys, "the old entry is dropped")
+
+	c := &cobra.Command{}
+	c.Flags().String("api-key", "", "")
+	c.Flags().String("api-key-id", "", "")
+	registerApiKeyFlagCompletion(c)
+	fn, ok := c.GetFlagCompletionFunc("api-key")
+	require.True(t, ok)
+	got, dir := fn(c, nil, "akm")
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, dir)
+}
@@ -54,6 +54,8 @@ func init() {
 	apiKeyConcurrencySetCmd.Flags().Int32Var(&apiKeyConcurrencySetValue, "concurrency", 0, "Maximum concurrent sessions (required, must be >= 1)")
 	apiKeyConcurrencySetCmd.MarkFlagRequired("concurrency")
 
+	registerApiKeyFlagCompletion(apiKeyConcurrencySetCmd)
+
 	ApiKeyConcurrencyCmd.AddCommand(apiKeyConcurrencySetCmd)
 }
 
@@ -171,7 +173,7 @@ func runApiKeyConcurrencySet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%-*s %s\n", 14, "ApiKeyId:", apiKeyId)
 	fmt.Printf("%-*s %d\n", 14, "Concurrency:", apiKeyConcurrencySetValue)
 	if apiKeyConcurrencySetApiKey != "" {
-		fmt.Printf("%-*s %s\n", 14, "ApiKey:", apiKeyConcurrencySetApiKey)
+		fmt.Printf("%-*s %s\n", 14, "ApiKey:", maskSecret(apiKeyConcurrencySetApiKey))
 	}
 
 	return nil
@@ -1,9 +1,11 @@
 // Copyright 2025 AgentBay CLI Contributors
 // SPDX-License-Identifier: Apache-2.0
 
-// docker.go implements "agentbay docker login|tag|push|share|unshare|list-shares" commands.
+// docker.go implements "agentbay docker login|tag|push|share|unshare|list-shares" commands
+// ("images" and "inspect" live in docker_images.go).
 // These wrap native docker CLI commands, with ACR credential management
-// via the GetACRRepoCredential POP Action (raw HTTP, POP RPC V1).
+// via the GetACRRepoCredential POP Action (raw HTTP, POP RPC V1). "docker push --from"
+// talks to the registry directly (see docker_push_native.go) and needs no docker daemon.
 
 package cmd
 
@@ -11,10 +13,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -32,61 +33,6 @@ import (
 
 const defaultRegistryURL = "ai-container-pre-9543-registry.cn-hangzhou.cr.aliyuncs.com"
 
-// ---------------------------------------------------------------------------
-// ACR credential cache
-// ---------------------------------------------------------------------------
-
-// acrCredentialCache is the on-disk structure saved after "docker login".
-type acrCredentialCache struct {
-	TempUsername       string `json:"temp_username"`
-	AuthorizationToken string `json:"authorization_token"`
-	Namespace          string `json:"namespace"`
-	RepoName           string `json:"repo_name"`
-	RegistryURL        string `json:"registry_url"`
-	ImageTag           string `json:"image_tag"`
-	ExpireTime         int64  `json:"expire_time"`
-	CachedAt           string `json:"cached_at"`
-}
-
-func acrCachePath() (string, error) {
-	dir, err := config.ConfigDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(dir, "acr_credential.json"), nil
-}
-
-func saveACRCredential(c *acrCredentialCache) error {
-	p, err := acrCachePath()
-	if err != nil {
-		return err
-	}
-	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
-		return err
-	}
-	data, err := json.MarshalIndent(c, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(p, data, 0600)
-}
-
-func loadACRCredential() (*acrCredentialCache, error) {
-	p, err := acrCachePath()
-	if err != nil {
-		return nil, err
-	}
-	data, err := os.ReadFile(p)
-	if err != nil {
-		return nil, fmt.Errorf("no cached ACR credential found. Run 'agentbay docker login' first: %w", err)
-	}
-	var c acrCredentialCache
-	if err := json.Unmarshal(data, &c); err != nil {
-		return nil, fmt.Errorf("failed to parse cached ACR credential: %w", err)
-	}
-	return &c, nil
-}
-
 // ---------------------------------------------------------------------------
 // Command tree
 // ---------------------------------------------------------------------------
@@ -94,7 +40,7 @@ func loadACRCredential() (*acrCredentialCache, error) {
 var DockerCmd = &cobra.Command{
 	Use:     "docker",
 	Short:   "Docker image build & push operations",
-	Long:    "Manage Docker images for AgentBay: login to ACR, tag images, push images.",
+	Long:    "Manage Docker images for AgentBay: login to ACR, tag images, push images, list and inspect pushed images.",
 	GroupID: "management",
 }
 
@@ -107,11 +53,13 @@ var dockerLoginCmd = &cobra.Command{
 
 This calls the GetACRRepoCredential API to obtain temporary Docker credentials,
 then executes "docker login" with those credentials. The credential info
-(RegistryUrl, Namespace, RepoName, ImageTag, etc.) is cached for subsequent
-tag/push commands.
+(RegistryUrl, Namespace, RepoName, ImageTag, etc.) is cached per registry for
+subsequent tag/push/images/inspect commands, which refresh it automatically through
+GetACRRepoCredential (and re-run "docker login") when it is about to expire.
 
 Examples:
-  agentbay docker login`,
+  agentbay docker login
+  agentbay docker login --status`,
 	Args: cobra.NoArgs,
 	RunE: runDockerLogin,
 }
@@ -137,17 +85,26 @@ Examples:
 // --- docker push ---
 
 var dockerPushCmd = &cobra.Command{
-	Use:   "push <image>",
+	Use:   "push <image|tag>",
 	Short: "Push a tagged image to the AgentBay ACR registry",
 	Long: `Push a Docker image to the AgentBay ACR registry.
 
 The image name must match the pattern $RegistryUrl/$Namespace/$RepoName[:tag].
 If it does not, the push is rejected to prevent accidental pushes to wrong repos.
 
+With --from, the image is read from an OCI image layout directory, an OCI archive
+(tar or tar.gz) or a "docker save" tarball and pushed straight to the registry over
+the Registry v2 API, so no docker daemon is needed (CI runners, buildah/kaniko output).
+The argument may then also be a bare tag. Layers are uploaded in chunks, blobs that
+already exist are skipped, and --mount-from links blobs from other repositories on
+the same registry instead of uploading them again.
+
 You must run "agentbay docker login" first.
 
 Examples:
-  agentbay docker push ai-container-pre-9543-registry.cn-hangzhou.cr.aliyuncs.com/ns/repo:v1.0`,
+  agentbay docker push ai-container-pre-9543-registry.cn-hangzhou.cr.aliyuncs.com/ns/repo:v1.0
+  agentbay docker push --from ./image-oci v1.0
+  agentbay docker push --from image.tar --source-ref myapp:latest v1.0`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDockerPush,
 }
@@ -155,20 +112,40 @@ Examples:
 func init() {
 	DockerCmd.AddCommand(dockerLoginCmd)
 	DockerCmd.AddCommand(dockerTagCmd)
+	DockerCmd.AddCommand(dockerBuildCmd)
 	DockerCmd.AddCommand(dockerPushCmd)
+	DockerCmd.AddCommand(dockerImagesCmd)
+	DockerCmd.AddCommand(dockerInspectCmd)
+	DockerCmd.AddCommand(dockerCredentialHelperCmd)
 	DockerCmd.AddCommand(dockerShareCmd)
 	DockerCmd.AddCommand(dockerUnshareCmd)
+	DockerCmd.AddCommand(dockerSharesCmd)
 	DockerCmd.AddCommand(dockerListSharesCmd)
 
+	dockerLoginCmd.Flags().Bool("status", false, "Show cached registry credentials and their remaining validity without logging in")
+	dockerTagCmd.Flags().String("registry", "", "Cached registry to use when several are cached (default: current)")
+	dockerPushCmd.Flags().String("registry", "", "Cached registry to use (default: the image's registry host, else current)")
+	dockerPushCmd.Flags().String("from", "", "Push from an OCI layout directory, OCI archive or docker save tarball without a docker daemon")
+	dockerPushCmd.Flags().String("source-ref", "", "Image to push when the --from archive holds several (name or tag)")
+	dockerPushCmd.Flags().StringArray("mount-from", nil, "Repository on the same registry to mount existing blobs from (with --from, repeatable)")
+
 	dockerShareCmd.Flags().Int64("target-uid", 0, "Target Alibaba Cloud account UID to share the Docker repo with")
+	dockerShareCmd.Flags().String("uid-file", "", "File with target UIDs (one per line, # comments; \"-\" for stdin)")
+	dockerShareCmd.Flags().String("expires-in", "", "Grant lifetime, e.g. 30d or 12h; revoked by 'docker shares reconcile'")
+	dockerShareCmd.Flags().String("expires-at", "", "Grant expiry (RFC 3339 or YYYY-MM-DD); revoked by 'docker shares reconcile'")
 
 	dockerUnshareCmd.Flags().Int64("target-uid", 0, "Target Alibaba Cloud account UID to cancel sharing with")
+	dockerUnshareCmd.Flags().String("uid-file", "", "File with target UIDs (one per line, # comments; \"-\" for stdin)")
+	dockerUnshareCmd.ValidArgsFunction = completeOnlyArg(completionShares, nil)
+	registerFlagCompletion(dockerUnshareCmd, "target-uid", completeFlag(completionShares, nil))
 
 	dockerListSharesCmd.Flags().String("direction", "Incoming", `Sharing direction: "Outgoing" (repos you shared) or "Incoming" (repos shared with you)`)
 	dockerListSharesCmd.Flags().Int64("aliuid", 0, "Filter by Alibaba Cloud account UID")
-	dockerListSharesCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+	dockerListSharesCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts), or "ndjson" for one object per line`)
 	dockerListSharesCmd.Flags().Int("page", 1, "Page number (default: 1)")
 	dockerListSharesCmd.Flags().Int("size", 10, "Page size (default: 10)")
+	addListPagingFlags(dockerListSharesCmd)
+	addCacheFlags(dockerListSharesCmd)
 }
 
 // ---------------------------------------------------------------------------
@@ -202,6 +179,15 @@ type dockerACRCredentialResponseData struct {
 // ---------------------------------------------------------------------------
 
 func runDockerLogin(cobraCmd *cobra.Command, args []string) error {
+	if status, _ := cobraCmd.Flags().GetBool("status"); status {
+		store, err := loadACRCredentialStore()
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		printACRCredentialStatus(store, time.Now())
+		return nil
+	}
+
 	// 1. Auth & API call
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -211,102 +197,39 @@ func runDockerLogin(cobraCmd *cobra.Command, args []string) error {
 		return config.ErrNotAuthenticated()
 	}
 
-	client, err := newACSClientFromConfig(cfg)
-	if err != nil {
-		return fmt.Errorf("[ERROR] Failed to create HTTP client: %w", err)
-	}
-
-	body, statusCode, err := client.callRPC("GetACRRepoCredential", map[string]string{})
+	cache, err := fetchACRCredential(cfg)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Request failed: %w", err)
-	}
-
-	if statusCode < 200 || statusCode >= 300 {
-		return fmt.Errorf("[ERROR] API returned HTTP %d: %s", statusCode, string(body))
-	}
-
-	var resp dockerACRCredentialResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return fmt.Errorf("[ERROR] Failed to parse response: %w", err)
-	}
-
-	if resp.Success != nil && !*resp.Success {
-		return fmt.Errorf("[ERROR] API error: Code=%s, Message=%s", ptrStr(resp.Code), ptrStr(resp.Message))
-	}
-	if resp.Data == nil {
-		return fmt.Errorf("[ERROR] API returned empty Data")
-	}
-
-	d := resp.Data
-	tempUsername := ptrStr(d.TempUsername)
-	authToken := ptrStr(d.AuthorizationToken)
-	namespace := ptrStr(d.Namespace)
-	repoName := ptrStr(d.RepoName)
-	imageTag := ptrStr(d.ImageTag)
-	registryURL := ptrStr(d.RegistryUrl)
-
-	// Fallback to default RegistryUrl
-	if registryURL == "" || registryURL == "<nil>" {
-		registryURL = defaultRegistryURL
-		log.Debugf("[DOCKER LOGIN] RegistryUrl not returned, using default: %s", registryURL)
-	}
-
-	var expireTime int64
-	if d.ExpireTime != nil {
-		expireTime = *d.ExpireTime
+		return fmt.Errorf("[ERROR] %w", err)
 	}
 
 	// 2. Print key credential info
-	fullRegistryPath := fmt.Sprintf("%s/%s/%s", registryURL, namespace, repoName)
-	if expireTime > 0 {
-		expireAt := time.Unix(expireTime/1000, 0)
-		fmt.Printf("Credential expires at: %s\n", expireAt.Format("2006-01-02 15:04:05"))
+	fullRegistryPath := cache.RepoPath()
+	if cache.ExpireTime > 0 {
+		fmt.Printf("Credential expires at: %s\n", time.UnixMilli(cache.ExpireTime).Format("2006-01-02 15:04:05"))
 	}
 	fmt.Printf("Image registry path:   %s\n", fullRegistryPath)
 
 	// 3. Cache credentials
-	cache := &acrCredentialCache{
-		TempUsername:       tempUsername,
-		AuthorizationToken: authToken,
-		Namespace:          namespace,
-		RepoName:           repoName,
-		RegistryURL:        registryURL,
-		ImageTag:           imageTag,
-		ExpireTime:         expireTime,
-		CachedAt:           time.Now().Format(time.RFC3339),
-	}
 	if err := saveACRCredential(cache); err != nil {
 		log.Debugf("Failed to cache credential: %v", err)
 	}
 
-	// 4. Execute: echo "$AuthorizationToken" | docker login $RegistryUrl -u "$TempUsername" --password-stdin
-	fmt.Println("[DOCKER LOGIN] Logging in via 'docker'...")
-	dockerCmd := exec.Command("docker", "login", registryURL, "-u", tempUsername, "--password-stdin")
-	dockerCmd.Stdin = strings.NewReader(authToken)
-	dockerCmd.Stdout = os.Stdout
-	dockerCmd.Stderr = os.Stderr
-
-	if err := dockerCmd.Run(); err != nil {
-		return fmt.Errorf("docker login failed: %w", err)
+	// Without a docker binary the cached credential is still usable by "docker push --from".
+	if _, lookErr := exec.LookPath("docker"); lookErr != nil {
+		fmt.Println("[INFO] docker not found in PATH, skipping 'docker login'.")
+		fmt.Println("[INFO] Credentials are cached; use 'agentbay docker push --from <path> <tag>' to push without docker.")
+		fmt.Printf("Note: Images are pushed to: %s:<your-tag>\n", fullRegistryPath)
+		return nil
 	}
 
-	// Also try: echo "$AuthorizationToken" | sudo -n docker login $RegistryUrl -u "$TempUsername" --password-stdin
-	// Compatible with rootful docker daemon. Failure here is non-fatal.
-	if _, lookErr := exec.LookPath("sudo"); lookErr == nil {
-		fmt.Println("[DOCKER LOGIN] Logging in via 'sudo docker'...")
-		sudoDockerCmd := exec.Command("sudo", "-n", "docker", "login", registryURL, "-u", tempUsername, "--password-stdin")
-		sudoDockerCmd.Stdin = strings.NewReader(authToken)
-		sudoDockerCmd.Stdout = os.Stdout
-		sudoDockerCmd.Stderr = os.Stderr
-
-		if err := sudoDockerCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "[WARN] sudo docker login failed (skipped): %v\n", err)
-		}
+	// 4. docker login (plus sudo docker login for rootful daemons)
+	if err := dockerRegistryLogin(cache); err != nil {
+		return err
 	}
 
 	fmt.Println()
-	if expireTime > 0 {
-		fmt.Println("Note: Credentials will expire after the time above. You can run 'agentbay docker login' again to refresh.")
+	if cache.ExpireTime > 0 {
+		fmt.Println("Note: Credentials are refreshed automatically by agentbay docker commands shortly before they expire. Run 'agentbay docker login --status' to check.")
 	}
 	fmt.Printf("Note: When tagging images, use: %s:<your-tag>\n", fullRegistryPath)
 	return nil
@@ -319,9 +242,10 @@ func runDockerLogin(cobraCmd *cobra.Command, args []string) error {
 func runDockerTag(cobraCmd *cobra.Command, args []string) error {
 	sourceImage := args[0]
 	targetTag := args[1]
+	registryURL, _ := cobraCmd.Flags().GetString("registry")
 
-	// Load cached credentials
-	cache, err := loadACRCredential()
+	// Load cached credentials, refreshing them (and the docker login) when about to expire
+	cache, err := ensureACRCredential(registryURL, true)
 	if err != nil {
 		return fmt.Errorf("[ERROR] %w", err)
 	}
@@ -355,15 +279,39 @@ func runDockerTag(cobraCmd *cobra.Command, args []string) error {
 
 func runDockerPush(cobraCmd *cobra.Command, args []string) error {
 	pushImage := args[0]
+	from, _ := cobraCmd.Flags().GetString("from")
+	sourceRef, _ := cobraCmd.Flags().GetString("source-ref")
+	mountFrom, _ := cobraCmd.Flags().GetStringArray("mount-from")
+	if from == "" && (sourceRef != "" || len(mountFrom) > 0) {
+		return fmt.Errorf("[ERROR] --source-ref and --mount-from require --from")
+	}
 
-	// Load cached credentials
-	cache, err := loadACRCredential()
+	// The credential is picked by --registry, else by the registry host of a full image name.
+	registryURL, _ := cobraCmd.Flags().GetString("registry")
+	if registryURL == "" && strings.Contains(pushImage, "/") {
+		registryURL = pushImage[:strings.Index(pushImage, "/")]
+	}
+	// Load cached credentials, refreshing them when about to expire. Only the docker CLI path needs
+	// the local docker to be logged in again.
+	cache, err := ensureACRCredential(registryURL, from == "")
 	if err != nil {
 		return fmt.Errorf("[ERROR] %w", err)
 	}
 
+	if from != "" {
+		tag, err := resolveNativePushTag(cache, pushImage)
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		if err := pushImageNative(context.Background(), cache, from, tag, sourceRef, mountFrom); err != nil {
+			return fmt.Errorf("[ERROR] Push failed: %w", err)
+		}
+		fmt.Println("\n[SUCCESS] Image pushed.")
+		return nil
+	}
+
 	// Validate: image must match $RegistryUrl/$Namespace/$RepoName
-	expectedPrefix := fmt.Sprintf("%s/%s/%s", cache.RegistryURL, cache.Namespace, cache.RepoName)
+	expectedPrefix := cache.RepoPath()
 	if !strings.HasPrefix(pushImage, expectedPrefix) {
 		return fmt.Errorf("[ERROR] Image name '%s' does not match the authorized registry path.\n"+
 			"  Expected prefix: %s\n"+
@@ -371,6 +319,10 @@ func runDockerPush(cobraCmd *cobra.Command, args []string) error {
 			pushImage, expectedPrefix)
 	}
 
+	if _, lookErr := exec.LookPath("docker"); lookErr != nil {
+		return fmt.Errorf("[ERROR] docker not found in PATH. Use 'agentbay docker push --from <path> <tag>' to push an OCI layout or docker save archive without docker")
+	}
+
 	fmt.Printf("[DOCKER PUSH] Pushing image...\n")
 	fmt.Printf("  Image: %s\n", pushImage)
 
@@ -398,27 +350,34 @@ var dockerShareCmd = &cobra.Command{
 
 The target account will be able to pull images from your repository.
 
+Use --uid-file to share with several accounts at once. With --expires-in or --expires-at
+the grant is recorded locally with its expiry and revoked by
+"agentbay docker shares reconcile" once it has passed. Every grant is written to the
+local audit log (docker_share_audit.jsonl in the config directory) with its RequestId.
+
 Examples:
   agentbay docker share 1234567890
-  agentbay docker share --target-uid 1234567890`,
+  agentbay docker share --target-uid 1234567890
+  agentbay docker share 1234567890 --expires-in 30d
+  agentbay docker share --uid-file partners.txt --expires-at 2026-12-31`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runDockerShare,
 }
 
 func runDockerShare(cobraCmd *cobra.Command, args []string) error {
 	targetUID, _ := cobraCmd.Flags().GetInt64("target-uid")
+	uidFile, _ := cobraCmd.Flags().GetString("uid-file")
+	expiresIn, _ := cobraCmd.Flags().GetString("expires-in")
+	expiresAt, _ := cobraCmd.Flags().GetString("expires-at")
 
-	// Prefer positional arg if provided, otherwise fall back to flag
-	if len(args) > 0 {
-		parsed, err := strconv.ParseInt(args[0], 10, 64)
-		if err != nil {
-			return fmt.Errorf("[ERROR] Invalid target-uid %q: %w", args[0], err)
-		}
-		targetUID = parsed
+	// Prefer positional arg if provided, otherwise fall back to flag; --uid-file adds more targets
+	targets, err := collectShareTargets(args, targetUID, uidFile)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
 	}
-
-	if targetUID == 0 {
-		return fmt.Errorf("[ERROR] target-uid is required. Provide it as a positional argument or use --target-uid")
+	expiry, err := parseShareExpiry(expiresIn, expiresAt, time.Now())
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
 	}
 
 	cfg, err := config.GetConfig()
@@ -429,50 +388,15 @@ func runDockerShare(cobraCmd *cobra.Command, args []string) error {
 	apiClient := agentbay.NewClientFromConfig(cfg)
 	ctx := context.Background()
 
-	fmt.Printf("[STEP 1/1] Sharing Docker repo with UID %d...\n", targetUID)
-
-	req := &client.ShareDockerRepoRequest{TargetAliUid: &targetUID}
-	resp, err := apiClient.ShareDockerRepo(ctx, req)
-	if err != nil {
-		if reqID := extractRequestIDFromErr(err); reqID != "" {
-			fmt.Printf("[INFO] ShareDockerRepo Request ID: %s\n", reqID)
-		}
-		return fmt.Errorf("[ERROR] Failed to share Docker repo: %w", err)
+	failed := shareDockerRepoTargets(ctx, apiClient.ShareDockerRepo, targets, expiry)
+	if len(targets) == 1 && failed == 1 {
+		return fmt.Errorf("[ERROR] Failed to share Docker repo with UID %d", targets[0])
 	}
-
-	if resp.Body != nil {
-		if reqID := resp.Body.GetRequestId(); reqID != "" {
-			fmt.Printf("[INFO] ShareDockerRepo Request ID: %s\n", reqID)
-		}
+	if failed > 0 {
+		return fmt.Errorf("[ERROR] %d of %d shares failed", failed, len(targets))
 	}
-
-	if resp.Body == nil {
-		return fmt.Errorf("[ERROR] Invalid response: missing body")
-	}
-
-	code := resp.Body.GetCode()
-	successPtr := resp.Body.Success
-	if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
-		msg := resp.Body.GetMessage()
-		return fmt.Errorf("[ERROR] Failed to share Docker repo: Code=%s, Message=%s", code, msg)
-	}
-
-	fmt.Println()
-	fmt.Printf("[SUCCESS] Docker repo shared successfully!\n")
-	if resp.Body.Data != nil {
-		d := resp.Body.Data
-		if d.TargetAliUid != nil {
-			fmt.Printf("  TargetAliUid : %d\n", *d.TargetAliUid)
-		}
-		if d.OwnerAliUid != nil {
-			fmt.Printf("  OwnerAliUid  : %d\n", *d.OwnerAliUid)
-		}
-		if d.AcrRepoName != nil {
-			fmt.Printf("  AcrRepoName  : %s\n", *d.AcrRepoName)
-		}
-		if d.Status != nil {
-			fmt.Printf("  Status       : %s\n", *d.Status)
-		}
+	if !expiry.IsZero() {
+		fmt.Println("\nNote: Run 'agentbay docker shares reconcile' (e.g. from cron) to revoke grants once they expire.")
 	}
 	return nil
 }
@@ -486,27 +410,25 @@ var dockerUnshareCmd = &cobra.Command{
 	Short: "Cancel sharing the Docker repo with another Alibaba Cloud account",
 	Long: `Cancel sharing your AgentBay Docker image repository with a specific Alibaba Cloud account.
 
+Use --uid-file to cancel sharing with several accounts at once. Revokes are written
+to the local audit log and remove the local grant record.
+
 Examples:
   agentbay docker unshare 1234567890
-  agentbay docker unshare --target-uid 1234567890`,
+  agentbay docker unshare --target-uid 1234567890
+  agentbay docker unshare --uid-file partners.txt`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runDockerUnshare,
 }
 
 func runDockerUnshare(cobraCmd *cobra.Command, args []string) error {
 	targetUID, _ := cobraCmd.Flags().GetInt64("target-uid")
+	uidFile, _ := cobraCmd.Flags().GetString("uid-file")
 
-	// Prefer positional arg if provided, otherwise fall back to flag
-	if len(args) > 0 {
-		parsed, err := strconv.ParseInt(args[0], 10, 64)
-		if err != nil {
-			return fmt.Errorf("[ERROR] Invalid target-uid %q: %w", args[0], err)
-		}
-		targetUID = parsed
-	}
-
-	if targetUID == 0 {
-		return fmt.Errorf("[ERROR] target-uid is required. Provide it as a positional argument or use --target-uid")
+	// Prefer positional arg if provided, otherwise fall back to flag; --uid-file adds more targets
+	targets, err := collectShareTargets(args, targetUID, uidFile)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
 	}
 
 	cfg, err := config.GetConfig()
@@ -517,42 +439,13 @@ func runDockerUnshare(cobraCmd *cobra.Command, args []string) error {
 	apiClient := agentbay.NewClientFromConfig(cfg)
 	ctx := context.Background()
 
-	fmt.Printf("[STEP 1/1] Cancelling Docker repo sharing with UID %d...\n", targetUID)
-
-	req := &client.UnshareDockerRepoRequest{TargetAliUid: &targetUID}
-	resp, err := apiClient.UnshareDockerRepo(ctx, req)
-	if err != nil {
-		if reqID := extractRequestIDFromErr(err); reqID != "" {
-			fmt.Printf("[INFO] UnshareDockerRepo Request ID: %s\n", reqID)
-		}
-		return fmt.Errorf("[ERROR] Failed to cancel Docker repo sharing: %w", err)
-	}
-
-	if resp.Body != nil {
-		if reqID := resp.Body.GetRequestId(); reqID != "" {
-			fmt.Printf("[INFO] UnshareDockerRepo Request ID: %s\n", reqID)
-		}
-	}
-
-	if resp.Body == nil {
-		return fmt.Errorf("[ERROR] Invalid response: missing body")
-	}
-
-	code := resp.Body.GetCode()
-	successPtr := resp.Body.Success
-	if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
-		msg := resp.Body.GetMessage()
-		return fmt.Errorf("[ERROR] Failed to cancel Docker repo sharing: Code=%s, Message=%s", code, msg)
+	failed := unshareDockerRepoTargets(ctx, apiClient.UnshareDockerRepo, targets, "cli")
+	if len(targets) == 1 && failed == 1 {
+		return fmt.Errorf("[ERROR] Failed to cancel Docker repo sharing with UID %d", targets[0])
 	}
-
-	revoked := false
-	if resp.Body.Data != nil && resp.Body.Data.Revoked != nil {
-		revoked = *resp.Body.Data.Revoked
+	if failed > 0 {
+		return fmt.Errorf("[ERROR] %d of %d unshares failed", failed, len(targets))
 	}
-
-	fmt.Println()
-	fmt.Printf("[SUCCESS] Docker repo sharing cancelled.\n")
-	fmt.Printf("  Revoked : %v\n", revoked)
 	return nil
 }
 
@@ -570,14 +463,17 @@ Use --direction to specify the sharing direction. If omitted, it defaults to Inc
   Incoming  Repos that other accounts have shared with you
 
 Use --aliuid to filter by Alibaba Cloud account UID.
-Use --page and --size to paginate results (both optional, default page=1, size=10).
+Use --page and --size to paginate results (both optional, default page=1, size=10), or
+--all / --limit to walk pages; -o ndjson writes one share per line as pages arrive.
+Outgoing shares made with an expiry also show it (from the local grant record).
 
 Examples:
   agentbay docker list-shares
   agentbay docker list-shares --direction Outgoing
   agentbay docker list-shares --direction Incoming --aliuid 1234
   agentbay docker list-shares --direction Outgoing --page 2 --size 5
-  agentbay docker list-shares --direction Outgoing --output json`,
+  agentbay docker list-shares --direction Outgoing --output json
+  agentbay docker list-shares --direction Outgoing --all -o ndjson`,
 	Args: cobra.NoArgs,
 	RunE: runDockerListShares,
 }
@@ -588,15 +484,32 @@ func runDockerListShares(cobraCmd *cobra.Command, args []string) error {
 	outputFmt, _ := cobraCmd.Flags().GetString("output")
 	page, _ := cobraCmd.Flags().GetInt("page")
 	size, _ := cobraCmd.Flags().GetInt("size")
+	paging, err := listPagingFromFlags(cobraCmd)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	format := listOutputFormat(outputFmt)
 
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
 	}
 
-	apiClient := agentbay.NewClientFromConfig(cfg)
+	apiClient, err := newCachedClient(cobraCmd, cfg)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	defer func() { printCacheAge(cacheNoteWriter(format), apiClient, time.Now()) }()
 	ctx := context.Background()
 
+	if paging.walks() || format == "ndjson" {
+		if page < 1 {
+			page = 1
+		}
+		fetch := sharedRepoPages(apiClient, direction, aliuid, size, listProgress(true, format))
+		return listSharesPaged(ctx, fetch, paging, pageCursor{PageNo: page}, direction, format)
+	}
+
 	req := &client.ListSharedDockerReposRequest{Direction: &direction}
 	if aliuid > 0 {
 		req.QueryAliUid = &aliuid
@@ -639,32 +552,24 @@ func runDockerListShares(cobraCmd *cobra.Command, args []string) error {
 		items = []*client.ListSharedDockerReposResponseBodyDataItem{}
 	}
 
-	if strings.EqualFold(outputFmt, "json") {
-		type itemJSON struct {
-			PeerAliUid int64  `json:"peerAliUid"`
-			Status     string `json:"status"`
-		}
+	// Outgoing shares made with an expiry show it from the local grant record.
+	expiries := shareGrantExpiries(direction)
+
+	if format == "json" {
 		type outputJSON struct {
-			TotalCount int        `json:"totalCount"`
-			PageNumber int        `json:"pageNumber"`
-			PageSize   int        `json:"pageSize"`
-			Items      []itemJSON `json:"items"`
+			TotalCount int             `json:"totalCount"`
+			PageNumber int             `json:"pageNumber"`
+			PageSize   int             `json:"pageSize"`
+			Items      []shareListItem `json:"items"`
 		}
 		out := outputJSON{
 			TotalCount: len(items),
 			PageNumber: page,
 			PageSize:   size,
-			Items:      []itemJSON{},
+			Items:      []shareListItem{},
 		}
 		for _, item := range items {
-			j := itemJSON{}
-			if item.PeerAliUid != nil {
-				j.PeerAliUid = *item.PeerAliUid
-			}
-			if item.Status != nil {
-				j.Status = *item.Status
-			}
-			out.Items = append(out.Items, j)
+			out.Items = append(out.Items, newShareListItem(item, expiries))
 		}
 		b, err := json.MarshalIndent(out, "", "  ")
 		if err != nil {
@@ -679,8 +584,21 @@ func runDockerListShares(cobraCmd *cobra.Command, args []string) error {
 		fmt.Printf("No shared Docker repos found for direction: %s\n", direction)
 		return nil
 	}
-	fmt.Printf("%-20s  %-15s\n", "PeerAliUid", "Status")
-	fmt.Printf("%-20s  %-15s\n", "--------------------", "---------------")
+	printSharedReposTable(items, direction, expiries)
+	fmt.Printf("\nTotal: %d\n", len(items))
+	return nil
+}
+
+// printSharedReposTable prints shared repos; outgoing shares get an ExpiresAt column.
+func printSharedReposTable(items []*client.ListSharedDockerReposResponseBodyDataItem, direction string, expiries map[int64]string) {
+	outgoing := strings.EqualFold(direction, "Outgoing")
+	if outgoing {
+		fmt.Printf("%-20s  %-15s  %-20s\n", "PeerAliUid", "Status", "ExpiresAt")
+		fmt.Printf("%-20s  %-15s  %-20s\n", "--------------------", "---------------", "--------------------")
+	} else {
+		fmt.Printf("%-20s  %-15s\n", "PeerAliUid", "Status")
+		fmt.Printf("%-20s  %-15s\n", "--------------------", "---------------")
+	}
 	for _, item := range items {
 		uid := int64(0)
 		if item.PeerAliUid != nil {
@@ -690,8 +608,121 @@ func runDockerListShares(cobraCmd *cobra.Command, args []string) error {
 		if item.Status != nil {
 			status = *item.Status
 		}
-		fmt.Printf("%-20d  %-15s\n", uid, status)
+		if outgoing {
+			fmt.Printf("%-20d  %-15s  %-20s\n", uid, status, valueOrDash(expiries[uid]))
+		} else {
+			fmt.Printf("%-20d  %-15s\n", uid, status)
+		}
 	}
-	fmt.Printf("\nTotal: %d\n", len(items))
+}
+
+// shareGrantExpiries maps target UIDs of the current account's outgoing grants made with an
+// expiry to that expiry.
+func shareGrantExpiries(direction string) map[int64]string {
+	expiries := map[int64]string{}
+	if strings.EqualFold(direction, "Outgoing") {
+		if grants, err := loadShareGrants(); err == nil {
+			for _, g := range grants.forAccount(currentShareAccount()) {
+				if t, ok := g.expiry(); ok {
+					expiries[g.TargetUID] = t.Local().Format("2006-01-02 15:04:05")
+				}
+			}
+		}
+	}
+	return expiries
+}
+
+// shareListItem is one share in list-shares -o json / ndjson output.
+type shareListItem struct {
+	PeerAliUid int64  `json:"peerAliUid"`
+	Status     string `json:"status"`
+	ExpiresAt  string `json:"expiresAt,omitempty"`
+}
+
+func newShareListItem(item *client.ListSharedDockerReposResponseBodyDataItem, expiries map[int64]string) shareListItem {
+	j := shareListItem{}
+	if item.PeerAliUid != nil {
+		j.PeerAliUid = *item.PeerAliUid
+	}
+	if item.Status != nil {
+		j.Status = *item.Status
+	}
+	j.ExpiresAt = expiries[j.PeerAliUid]
+	return j
+}
+
+// sharedRepoPages fetches ListSharedDockerRepos pages. The API reports no total, so a short
+// page ends the walk.
+func sharedRepoPages(apiClient agentbay.Client, direction string, aliuid int64, size int, progress io.Writer) pageFetcher[*client.ListSharedDockerReposResponseBodyDataItem] {
+	return func(ctx context.Context, at pageCursor) (*listPage[*client.ListSharedDockerReposResponseBodyDataItem], error) {
+		req := &client.ListSharedDockerReposRequest{Direction: &direction}
+		if aliuid > 0 {
+			req.QueryAliUid = &aliuid
+		}
+		if size > 0 {
+			sizeInt32 := int32(size)
+			req.PageSize = &sizeInt32
+		}
+		pageInt32 := int32(at.PageNo)
+		req.PageStart = &pageInt32
+		resp, err := apiClient.ListSharedDockerRepos(ctx, req)
+		if err != nil {
+			if reqID := extractRequestIDFromErr(err); reqID != "" {
+				fmt.Fprintf(progress, "[INFO] ListSharedDockerRepos Request ID: %s\n", reqID)
+			}
+			return nil, fmt.Errorf("failed to list shared Docker repos: %w", err)
+		}
+		if resp == nil || resp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing body")
+		}
+		if reqID := resp.Body.GetRequestId(); reqID != "" {
+			fmt.Fprintf(progress, "[INFO] ListSharedDockerRepos Request ID: %s\n", reqID)
+		}
+		code := resp.Body.GetCode()
+		successPtr := resp.Body.Success
+		if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
+			return nil, fmt.Errorf("failed to list shared Docker repos: Code=%s, Message=%s", code, resp.Body.GetMessage())
+		}
+		return &listPage[*client.ListSharedDockerReposResponseBodyDataItem]{
+			Items: resp.Body.Data,
+			Next:  nextPageNo(at.PageNo, size, len(resp.Body.Data), -1),
+		}, nil
+	}
+}
+
+// listSharesPaged is list-shares with --all, --limit or -o ndjson.
+func listSharesPaged(ctx context.Context, fetch pageFetcher[*client.ListSharedDockerReposResponseBodyDataItem], paging listPaging, start pageCursor, direction, format string) error {
+	expiries := shareGrantExpiries(direction)
+	if format != "" {
+		stream := newListStream(os.Stdout, format, "items")
+		walk, err := walkPages(ctx, paging, start, fetch, func(item *client.ListSharedDockerReposResponseBodyDataItem) error {
+			if item == nil {
+				return nil
+			}
+			return stream.write(newShareListItem(item, expiries))
+		})
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", stream.fail(err))
+		}
+		return stream.close(listStreamField{"totalCount", walk.totalCount()})
+	}
+
+	var items []*client.ListSharedDockerReposResponseBodyDataItem
+	walk, err := walkPages(ctx, paging, start, fetch, func(item *client.ListSharedDockerReposResponseBodyDataItem) error {
+		if item != nil {
+			items = append(items, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if len(items) == 0 {
+		fmt.Printf("No shared Docker repos found for direction: %s\n", direction)
+		return nil
+	}
+	printSharedReposTable(items, direction, expiries)
+	fmt.Printf("\nTotal: %d (%d page(s))\n", len(items), walk.Pages)
+	printMorePagesHint(os.Stdout, walk, "--page")
 	return nil
 }
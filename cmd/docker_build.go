@@ -0,0 +1,283 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// docker_build.go implements "agentbay docker build": a docker buildx build whose target is
+// computed from the cached ACR credential, optionally multi-platform and pushed as a manifest
+// list, and optionally followed by "image create-from-template".
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// buildxBuilderName is the docker-container builder created for multi-platform builds when the
+// selected builder uses the plain docker driver, which cannot build or push manifest lists.
+const buildxBuilderName = "agentbay-multiarch"
+
+var dockerBuildCmd = &cobra.Command{
+	Use:   "build [context]",
+	Short: "Build (and push) an image for the AgentBay ACR registry with docker buildx",
+	Long: `Build an image with docker buildx and tag it for the AgentBay ACR registry.
+
+The image name is constructed from the cached login as
+  $RegistryUrl/$Namespace/$RepoName:<tag>
+so no separate "agentbay docker tag" is needed. The build context defaults to ".".
+
+With --push the image is pushed by BuildKit directly; several --platform values
+(comma-separated) produce a manifest list. Multi-platform builds need --push and a
+builder with the docker-container driver: when the selected builder uses the docker
+driver, a "` + buildxBuilderName + `" builder is created (once) and used for the build.
+Without --push a single-platform image is loaded into the local docker.
+
+With --create-image-name and --template-image-id the pushed image is then passed to
+"agentbay image create-from-template".
+
+You must run "agentbay login" first; the ACR credential is refreshed as needed.
+
+Examples:
+  agentbay docker build -t v1.0 .
+  agentbay docker build -f Dockerfile -t v1.0 --platform linux/amd64,linux/arm64 --push .
+  agentbay docker build -t v1.0 --push --create-image-name my-image --template-image-id <id> .`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDockerBuild,
+}
+
+func init() {
+	dockerBuildCmd.Flags().StringP("file", "f", "", "Path to the Dockerfile (default: <context>/Dockerfile)")
+	dockerBuildCmd.Flags().StringP("tag", "t", "", "Image tag in your ACR repository (required)")
+	dockerBuildCmd.Flags().String("platform", "", "Target platforms, comma-separated (e.g. linux/amd64,linux/arm64)")
+	dockerBuildCmd.Flags().Bool("push", false, "Push the image (or manifest list) to the ACR registry")
+	dockerBuildCmd.Flags().StringArray("build-arg", nil, "Build-time variable KEY=VALUE (repeatable)")
+	dockerBuildCmd.Flags().String("target", "", "Build stage to build")
+	dockerBuildCmd.Flags().Bool("no-cache", false, "Do not use the build cache")
+	dockerBuildCmd.Flags().String("builder", "", "buildx builder to use (default: the current builder, or "+buildxBuilderName+" for multi-platform builds)")
+	dockerBuildCmd.Flags().String("registry", "", "Cached registry to use when several are cached (default: current)")
+	dockerBuildCmd.Flags().String("create-image-name", "", "After pushing, create a custom image with this name via create-from-template")
+	dockerBuildCmd.Flags().String("template-image-id", "", "System (template) image ID for --create-image-name")
+	dockerBuildCmd.MarkFlagRequired("tag")
+}
+
+// dockerBuildOptions are the inputs of one buildx invocation.
+type dockerBuildOptions struct {
+	Context      string
+	File         string
+	Image        string
+	Platforms    []string
+	Push         bool
+	BuildArgs    []string
+	Target       string
+	NoCache      bool
+	Builder      string
+	MetadataFile string
+}
+
+func runDockerBuild(cobraCmd *cobra.Command, args []string) error {
+	opts := dockerBuildOptions{Context: "."}
+	if len(args) == 1 {
+		opts.Context = args[0]
+	}
+	opts.File, _ = cobraCmd.Flags().GetString("file")
+	tag, _ := cobraCmd.Flags().GetString("tag")
+	platform, _ := cobraCmd.Flags().GetString("platform")
+	opts.Push, _ = cobraCmd.Flags().GetBool("push")
+	opts.BuildArgs, _ = cobraCmd.Flags().GetStringArray("build-arg")
+	opts.Target, _ = cobraCmd.Flags().GetString("target")
+	opts.NoCache, _ = cobraCmd.Flags().GetBool("no-cache")
+	opts.Builder, _ = cobraCmd.Flags().GetString("builder")
+	registryURL, _ := cobraCmd.Flags().GetString("registry")
+	imageName, _ := cobraCmd.Flags().GetString("create-image-name")
+	templateImageId, _ := cobraCmd.Flags().GetString("template-image-id")
+
+	opts.Platforms = parseBuildPlatforms(platform)
+	if err := validateDockerBuildOptions(tag, opts, imageName, templateImageId); err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
+	if _, lookErr := exec.LookPath("docker"); lookErr != nil {
+		return fmt.Errorf("[ERROR] docker not found in PATH. Build the image with another tool and use 'agentbay docker push --from <path> <tag>'")
+	}
+	if out, err := exec.Command("docker", "buildx", "version").CombinedOutput(); err != nil {
+		return fmt.Errorf("[ERROR] docker buildx is not available: %s", strings.TrimSpace(string(out)))
+	}
+
+	// Load cached credentials, refreshing them (and the docker login BuildKit pushes with) when
+	// about to expire.
+	cache, err := ensureACRCredential(registryURL, opts.Push)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	opts.Image = fmt.Sprintf("%s:%s", cache.RepoPath(), tag)
+
+	if len(opts.Platforms) > 1 && opts.Builder == "" {
+		builder, err := ensureMultiPlatformBuilder()
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		opts.Builder = builder
+	}
+
+	metadata, err := os.CreateTemp("", "agentbay-build-metadata-*.json")
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	metadata.Close()
+	defer os.Remove(metadata.Name())
+	opts.MetadataFile = metadata.Name()
+
+	fmt.Printf("[DOCKER BUILD] Building image...\n")
+	fmt.Printf("  Context:   %s\n", opts.Context)
+	if opts.File != "" {
+		fmt.Printf("  File:      %s\n", opts.File)
+	}
+	fmt.Printf("  Image:     %s\n", opts.Image)
+	if len(opts.Platforms) > 0 {
+		fmt.Printf("  Platforms: %s\n", strings.Join(opts.Platforms, ", "))
+	}
+	if opts.Builder != "" {
+		fmt.Printf("  Builder:   %s\n", opts.Builder)
+	}
+	fmt.Println()
+
+	buildCmd := exec.Command("docker", buildxBuildArgs(opts)...)
+	buildCmd.Stdout = os.Stdout
+	buildCmd.Stderr = os.Stderr
+	if err := buildCmd.Run(); err != nil {
+		return fmt.Errorf("[ERROR] docker buildx build failed: %w", err)
+	}
+
+	if !opts.Push {
+		fmt.Printf("\n[SUCCESS] Image built and loaded as: %s\n", opts.Image)
+		fmt.Printf("Note: Push it with: agentbay docker push %s\n", opts.Image)
+		return nil
+	}
+
+	fmt.Printf("\n[SUCCESS] Image pushed: %s\n", opts.Image)
+	if digest := readBuildxDigest(opts.MetadataFile); digest != "" {
+		fmt.Printf("  Digest: %s\n", digest)
+	}
+
+	if imageName == "" {
+		return nil
+	}
+	fmt.Println()
+	return createImageFromTemplate(opts.Image, imageName, templateImageId, false)
+}
+
+// parseBuildPlatforms splits a comma-separated --platform value.
+func parseBuildPlatforms(s string) []string {
+	var platforms []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			platforms = append(platforms, p)
+		}
+	}
+	return platforms
+}
+
+func validateDockerBuildOptions(tag string, opts dockerBuildOptions, imageName, templateImageId string) error {
+	if tag == "" || strings.ContainsAny(tag, "/:@") {
+		return fmt.Errorf("--tag must be a bare tag such as v1.0; the repository is taken from 'agentbay docker login'")
+	}
+	if len(opts.Platforms) > 1 && !opts.Push {
+		return fmt.Errorf("a multi-platform build cannot be loaded into the local docker; add --push")
+	}
+	if (imageName == "") != (templateImageId == "") {
+		return fmt.Errorf("--create-image-name and --template-image-id must be used together")
+	}
+	if imageName != "" && !opts.Push {
+		return fmt.Errorf("--create-image-name requires --push")
+	}
+	return nil
+}
+
+// buildxBuildArgs returns the docker arguments for opts.
+func buildxBuildArgs(opts dockerBuildOptions) []string {
+	args := []string{"buildx", "build"}
+	if opts.Builder != "" {
+		args = append(args, "--builder", opts.Builder)
+	}
+	if opts.File != "" {
+		args = append(args, "--file", opts.File)
+	}
+	if len(opts.Platforms) > 0 {
+		args = append(args, "--platform", strings.Join(opts.Platforms, ","))
+	}
+	args = append(args, "--tag", opts.Image)
+	for _, a := range opts.BuildArgs {
+		args = append(args, "--build-arg", a)
+	}
+	if opts.Target != "" {
+		args = append(args, "--target", opts.Target)
+	}
+	if opts.NoCache {
+		args = append(args, "--no-cache")
+	}
+	if opts.Push {
+		// Provenance attestations would add unknown/unknown entries to the pushed index; keep it a
+		// plain manifest list.
+		args = append(args, "--push", "--provenance=false")
+	} else {
+		args = append(args, "--load")
+	}
+	if opts.MetadataFile != "" {
+		args = append(args, "--metadata-file", opts.MetadataFile)
+	}
+	return append(args, filepath.Clean(opts.Context))
+}
+
+// buildxDriver extracts the driver of the first node from "docker buildx inspect" output.
+func buildxDriver(inspectOutput string) string {
+	scanner := bufio.NewScanner(strings.NewReader(inspectOutput))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok && strings.TrimSpace(key) == "Driver" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// ensureMultiPlatformBuilder returns "" when the current builder can build manifest lists, else
+// the name of a docker-container builder, creating it on first use. The user's default builder
+// is left unchanged.
+func ensureMultiPlatformBuilder() (string, error) {
+	out, err := exec.Command("docker", "buildx", "inspect").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker buildx inspect failed: %s", strings.TrimSpace(string(out)))
+	}
+	if buildxDriver(string(out)) != "docker" {
+		return "", nil
+	}
+	if exec.Command("docker", "buildx", "inspect", buildxBuilderName).Run() == nil {
+		return buildxBuilderName, nil
+	}
+	fmt.Printf("[INFO] Creating buildx builder %q (docker-container driver) for multi-platform builds...\n", buildxBuilderName)
+	if out, err := exec.Command("docker", "buildx", "create", "--name", buildxBuilderName, "--driver", "docker-container").CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to create buildx builder %s: %s", buildxBuilderName, strings.TrimSpace(string(out)))
+	}
+	return buildxBuilderName, nil
+}
+
+// readBuildxDigest returns the pushed image digest recorded by --metadata-file, or "".
+func readBuildxDigest(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var metadata struct {
+		Digest string `json:"containerimage.digest"`
+	}
+	if json.Unmarshal(data, &metadata) != nil {
+		return ""
+	}
+	return metadata.Digest
+}
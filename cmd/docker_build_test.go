@@ -0,0 +1,64 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBuildPlatforms(t *testing.T) {
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, parseBuildPlatforms(" linux/amd64, linux/arm64,"))
+	assert.Nil(t, parseBuildPlatforms(""))
+}
+
+func TestValidateDockerBuildOptions(t *testing.T) {
+	multi := dockerBuildOptions{Platforms: []string{"linux/amd64", "linux/arm64"}}
+	assert.NoError(t, validateDockerBuildOptions("v1", dockerBuildOptions{}, "", ""))
+	assert.ErrorContains(t, validateDockerBuildOptions("repo:v1", dockerBuildOptions{}, "", ""), "bare tag")
+	assert.ErrorContains(t, validateDockerBuildOptions("v1", multi, "", ""), "add --push")
+	multi.Push = true
+	assert.NoError(t, validateDockerBuildOptions("v1", multi, "img", "tpl"))
+	assert.ErrorContains(t, validateDockerBuildOptions("v1", multi, "img", ""), "used together")
+	assert.ErrorContains(t, validateDockerBuildOptions("v1", dockerBuildOptions{}, "img", "tpl"), "requires --push")
+}
+
+func TestBuildxBuildArgs(t *testing.T) {
+	args := buildxBuildArgs(dockerBuildOptions{
+		Context:      "./app/",
+		File:         "app/Dockerfile",
+		Image:        "reg/customer_cli/123:v1",
+		Platforms:    []string{"linux/amd64", "linux/arm64"},
+		Push:         true,
+		BuildArgs:    []string{"A=1"},
+		Builder:      buildxBuilderName,
+		MetadataFile: "/tmp/m.json",
+	})
+	assert.Equal(t, []string{
+		"buildx", "build", "--builder", buildxBuilderName, "--file", "app/Dockerfile",
+		"--platform", "linux/amd64,linux/arm64", "--tag", "reg/customer_cli/123:v1", "--build-arg", "A=1",
+		"--push", "--provenance=false", "--metadata-file", "/tmp/m.json", "app",
+	}, args)
+
+	args = buildxBuildArgs(dockerBuildOptions{Context: ".", Image: "reg/ns/r:v1", NoCache: true, Target: "final"})
+	assert.Equal(t, []string{"buildx", "build", "--tag", "reg/ns/r:v1", "--target", "final", "--no-cache", "--load", "."}, args)
+}
+
+func TestBuildxDriver(t *testing.T) {
+	out := "Name:          default\nDriver:        docker\n\nNodes:\nName:      default\n"
+	assert.Equal(t, "docker", buildxDriver(out))
+	assert.Equal(t, "docker-container", buildxDriver("Name: x\nDriver: docker-container\n"))
+	assert.Equal(t, "", buildxDriver(""))
+}
+
+func TestReadBuildxDigest(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "metadata.json")
+	require.NoError(t, os.WriteFile(p, []byte(`{"containerimage.digest":"sha256:abc","image.name":"x"}`), 0600))
+	assert.Equal(t, "sha256:abc", readBuildxDigest(p))
+	assert.Equal(t, "", readBuildxDigest(filepath.Join(t.TempDir(), "missing.json")))
+}
@@ -0,0 +1,402 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// docker_credential.go manages the ACR credentials cached by "agentbay docker login": one entry per
+// registry host, automatic refresh through GetACRRepoCredential shortly before expiry, and the
+// registry login that native docker commands rely on.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// acrCredentialRefreshWindow is how long before expiry a cached credential is refreshed.
+const acrCredentialRefreshWindow = 5 * time.Minute
+
+// acrCredentialCache is one cached credential, as returned by GetACRRepoCredential.
+type acrCredentialCache struct {
+	TempUsername       string `json:"temp_username"`
+	AuthorizationToken string `json:"authorization_token"`
+	Namespace          string `json:"namespace"`
+	RepoName           string `json:"repo_name"`
+	RegistryURL        string `json:"registry_url"`
+	ImageTag           string `json:"image_tag"`
+	ExpireTime         int64  `json:"expire_time"`
+	CachedAt           string `json:"cached_at"`
+	// Endpoint is the AgentBay API endpoint the credential was issued by; refreshes go there.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// RepoPath returns $RegistryUrl/$Namespace/$RepoName.
+func (c *acrCredentialCache) RepoPath() string {
+	return fmt.Sprintf("%s/%s/%s", c.RegistryURL, c.Namespace, c.RepoName)
+}
+
+// timeLeft returns how long the credential stays valid; ok is false when no expiry is known.
+func (c *acrCredentialCache) timeLeft(now time.Time) (left time.Duration, ok bool) {
+	if c.ExpireTime <= 0 {
+		return 0, false
+	}
+	return time.UnixMilli(c.ExpireTime).Sub(now), true
+}
+
+// acrCredentialStore is the on-disk structure of acr_credential.json.
+type acrCredentialStore struct {
+	// Current is the registry of the most recent "docker login".
+	Current    string                         `json:"current"`
+	Registries map[string]*acrCredentialCache `json:"registries"`
+}
+
+func acrCachePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "acr_credential.json"), nil
+}
+
+// loadACRCredentialStore reads the credential store. A missing file yields an empty store, and the
+// single-credential format written by older versions is migrated on the fly.
+func loadACRCredentialStore() (*acrCredentialStore, error) {
+	store := &acrCredentialStore{Registries: map[string]*acrCredentialCache{}}
+	p, err := acrCachePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse cached ACR credential: %w", err)
+	}
+	if _, legacy := probe["temp_username"]; legacy {
+		var c acrCredentialCache
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("failed to parse cached ACR credential: %w", err)
+		}
+		store.Current = c.RegistryURL
+		store.Registries[c.RegistryURL] = &c
+		return store, nil
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse cached ACR credential: %w", err)
+	}
+	if store.Registries == nil {
+		store.Registries = map[string]*acrCredentialCache{}
+	}
+	return store, nil
+}
+
+func saveACRCredentialStore(store *acrCredentialStore) error {
+	p, err := acrCachePath()
+	if err != nil {
+		return err
+	}
+	return (&config.StateFile{Path: p}).Save(store)
+}
+
+// saveACRCredential stores c under its registry and makes it the current one.
+func saveACRCredential(c *acrCredentialCache) error {
+	p, err := acrCachePath()
+	if err != nil {
+		return err
+	}
+	unlock, err := (&config.StateFile{Path: p}).Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	store, err := loadACRCredentialStore()
+	if err != nil {
+		log.Debugf("Discarding unreadable ACR credential cache: %v", err)
+		store = &acrCredentialStore{Registries: map[string]*acrCredentialCache{}}
+	}
+	store.Registries[c.RegistryURL] = c
+	store.Current = c.RegistryURL
+	return saveACRCredentialStore(store)
+}
+
+// loadACRCredential returns the default cached credential (see loadACRCredentialFor).
+func loadACRCredential() (*acrCredentialCache, error) {
+	return loadACRCredentialFor("")
+}
+
+// loadACRCredentialFor returns the cached credential for registry, or the default one when
+// registry is empty. No expiry check is made.
+func loadACRCredentialFor(registry string) (*acrCredentialCache, error) {
+	store, err := loadACRCredentialStore()
+	if err != nil {
+		return nil, err
+	}
+	return selectACRCredential(store, registry, config.LoadAPIConfig(nil).Endpoint)
+}
+
+// selectACRCredential picks the credential for registry. Without one it prefers the only entry
+// issued by the active API endpoint, then the most recent login, then the only entry.
+func selectACRCredential(store *acrCredentialStore, registry, endpoint string) (*acrCredentialCache, error) {
+	if len(store.Registries) == 0 {
+		return nil, fmt.Errorf("no cached ACR credential found. Run 'agentbay docker login' first")
+	}
+	if registry != "" {
+		if c, ok := store.Registries[registry]; ok {
+			return c, nil
+		}
+		return nil, fmt.Errorf("no cached ACR credential for registry %s (cached: %s). Run 'agentbay docker login' against the matching endpoint",
+			registry, strings.Join(store.registryNames(), ", "))
+	}
+	var byEndpoint []*acrCredentialCache
+	for _, c := range store.Registries {
+		if c.Endpoint != "" && c.Endpoint == endpoint {
+			byEndpoint = append(byEndpoint, c)
+		}
+	}
+	if len(byEndpoint) == 1 {
+		return byEndpoint[0], nil
+	}
+	if c, ok := store.Registries[store.Current]; ok {
+		return c, nil
+	}
+	if len(store.Registries) == 1 {
+		for _, c := range store.Registries {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("several ACR registries are cached (%s); choose one with --registry", strings.Join(store.registryNames(), ", "))
+}
+
+func (s *acrCredentialStore) registryNames() []string {
+	names := make([]string, 0, len(s.Registries))
+	for name := range s.Registries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fetchACRCredential calls GetACRRepoCredential for the authenticated account.
+func fetchACRCredential(cfg *config.Config) (*acrCredentialCache, error) {
+	client, err := newACSClientFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	body, statusCode, err := client.callRPC("GetACRRepoCredential", map[string]string{})
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return nil, fmt.Errorf("API returned HTTP %d: %s", statusCode, string(body))
+	}
+
+	var resp dockerACRCredentialResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.Success != nil && !*resp.Success {
+		return nil, fmt.Errorf("API error: Code=%s, Message=%s", ptrStr(resp.Code), ptrStr(resp.Message))
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("API returned empty Data")
+	}
+
+	d := resp.Data
+	c := &acrCredentialCache{
+		TempUsername:       ptrStr(d.TempUsername),
+		AuthorizationToken: ptrStr(d.AuthorizationToken),
+		Namespace:          ptrStr(d.Namespace),
+		RepoName:           ptrStr(d.RepoName),
+		RegistryURL:        ptrStr(d.RegistryUrl),
+		ImageTag:           ptrStr(d.ImageTag),
+		CachedAt:           time.Now().Format(time.RFC3339),
+		Endpoint:           config.LoadAPIConfig(nil).Endpoint,
+	}
+	// Fallback to default RegistryUrl
+	if c.RegistryURL == "" || c.RegistryURL == "<nil>" {
+		c.RegistryURL = defaultRegistryURL
+		log.Debugf("[DOCKER LOGIN] RegistryUrl not returned, using default: %s", c.RegistryURL)
+	}
+	if d.ExpireTime != nil {
+		c.ExpireTime = *d.ExpireTime
+	}
+	return c, nil
+}
+
+// refreshACRCredentialIfNeeded returns c when it is valid for longer than the refresh window and
+// otherwise a fresh credential from fetch. Credentials issued by another API endpoint are never
+// refreshed from the active one; an expired one is then an error. A failed refresh is only an
+// error once c has actually expired. refreshed reports whether fetch was used.
+func refreshACRCredentialIfNeeded(c *acrCredentialCache, now time.Time, endpoint string, fetch func() (*acrCredentialCache, error)) (out *acrCredentialCache, refreshed bool, err error) {
+	left, known := c.timeLeft(now)
+	if !known || left > acrCredentialRefreshWindow {
+		return c, false, nil
+	}
+	if c.Endpoint != "" && c.Endpoint != endpoint {
+		if left <= 0 {
+			return nil, false, fmt.Errorf("cached ACR credential for %s expired and was issued by endpoint %s (active: %s). Switch to that endpoint and run 'agentbay docker login'",
+				c.RegistryURL, c.Endpoint, endpoint)
+		}
+		return c, false, nil
+	}
+	fresh, err := fetch()
+	if err != nil {
+		if left <= 0 {
+			return nil, false, fmt.Errorf("cached ACR credential expired and refresh failed: %w", err)
+		}
+		log.Debugf("ACR credential refresh failed, using the cached one (%s left): %v", left.Round(time.Second), err)
+		return c, false, nil
+	}
+	return fresh, true, nil
+}
+
+// ensureACRCredential loads the cached credential for registry (default when empty) and refreshes
+// it when it expires within acrCredentialRefreshWindow. With dockerLogin the local docker is logged
+// in again after a refresh, for commands that shell out to docker.
+func ensureACRCredential(registry string, dockerLogin bool) (*acrCredentialCache, error) {
+	cache, err := loadACRCredentialFor(registry)
+	if err != nil {
+		return nil, err
+	}
+	fetch := func() (*acrCredentialCache, error) {
+		cfg, err := config.GetConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if !cfg.IsAuthenticated() {
+			return nil, config.ErrNotAuthenticated()
+		}
+		return fetchACRCredential(cfg)
+	}
+	endpoint := config.LoadAPIConfig(nil).Endpoint
+	if left, ok := cache.timeLeft(time.Now()); ok && left <= acrCredentialRefreshWindow {
+		fmt.Printf("[INFO] ACR credential for %s %s, refreshing...\n", cache.RegistryURL, describeTimeLeft(left))
+	}
+	fresh, refreshed, err := refreshACRCredentialIfNeeded(cache, time.Now(), endpoint, fetch)
+	if err != nil {
+		return nil, err
+	}
+	if !refreshed {
+		return fresh, nil
+	}
+	if err := saveACRCredential(fresh); err != nil {
+		log.Debugf("Failed to cache credential: %v", err)
+	}
+	if left, ok := fresh.timeLeft(time.Now()); ok {
+		fmt.Printf("[OK] ACR credential refreshed, valid until %s\n", time.UnixMilli(fresh.ExpireTime).Format("2006-01-02 15:04:05"))
+		log.Debugf("Refreshed ACR credential valid for %s", left.Round(time.Second))
+	}
+	if dockerLogin {
+		if err := dockerRegistryLogin(fresh); err != nil {
+			return nil, err
+		}
+	}
+	return fresh, nil
+}
+
+// dockerRegistryLogin runs "docker login" (and, best effort, "sudo -n docker login" for rootful
+// daemons) with c. It does nothing when docker is not installed or already uses the agentbay
+// credential helper for the registry.
+func dockerRegistryLogin(c *acrCredentialCache) error {
+	if _, lookErr := exec.LookPath("docker"); lookErr != nil {
+		log.Debugf("docker not found in PATH, skipping docker login")
+		return nil
+	}
+	// With the credential helper configured docker fetches tokens itself; nothing to store.
+	if dockerUsesCredentialHelper(c.RegistryURL) {
+		fmt.Printf("[INFO] docker uses %s for %s, skipping 'docker login'.\n", credentialHelperBinary, c.RegistryURL)
+		return nil
+	}
+
+	// Execute: echo "$AuthorizationToken" | docker login $RegistryUrl -u "$TempUsername" --password-stdin
+	fmt.Println("[DOCKER LOGIN] Logging in via 'docker'...")
+	dockerCmd := exec.Command("docker", "login", c.RegistryURL, "-u", c.TempUsername, "--password-stdin")
+	dockerCmd.Stdin = strings.NewReader(c.AuthorizationToken)
+	dockerCmd.Stdout = os.Stdout
+	dockerCmd.Stderr = os.Stderr
+	if err := dockerCmd.Run(); err != nil {
+		return fmt.Errorf("docker login failed: %w", err)
+	}
+
+	// Also try: echo "$AuthorizationToken" | sudo -n docker login $RegistryUrl -u "$TempUsername" --password-stdin
+	// Compatible with rootful docker daemon. Failure here is non-fatal.
+	if _, lookErr := exec.LookPath("sudo"); lookErr == nil {
+		fmt.Println("[DOCKER LOGIN] Logging in via 'sudo docker'...")
+		sudoDockerCmd := exec.Command("sudo", "-n", "docker", "login", c.RegistryURL, "-u", c.TempUsername, "--password-stdin")
+		sudoDockerCmd.Stdin = strings.NewReader(c.AuthorizationToken)
+		sudoDockerCmd.Stdout = os.Stdout
+		sudoDockerCmd.Stderr = os.Stderr
+		if err := sudoDockerCmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] sudo docker login failed (skipped): %v\n", err)
+		}
+	}
+	return nil
+}
+
+// describeTimeLeft renders a remaining validity as "expires in 42m" or "expired 3m ago".
+func describeTimeLeft(left time.Duration) string {
+	if left <= 0 {
+		return fmt.Sprintf("expired %s ago", formatShortDuration(-left))
+	}
+	return fmt.Sprintf("expires in %s", formatShortDuration(left))
+}
+
+func formatShortDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+// printACRCredentialStatus prints every cached credential and its remaining validity.
+func printACRCredentialStatus(store *acrCredentialStore, now time.Time) {
+	if len(store.Registries) == 0 {
+		fmt.Println("[INFO] No cached ACR credential. Run 'agentbay docker login' first.")
+		return
+	}
+	fmt.Printf("[ACR] %d cached registry credential(s)\n", len(store.Registries))
+	for _, name := range store.registryNames() {
+		c := store.Registries[name]
+		marker := " "
+		if name == store.Current {
+			marker = "*"
+		}
+		fmt.Printf("\n%s %s\n", marker, c.RepoPath())
+		if left, ok := c.timeLeft(now); ok {
+			state := "valid"
+			switch {
+			case left <= 0:
+				state = "EXPIRED"
+			case left <= acrCredentialRefreshWindow:
+				state = "refresh due"
+			}
+			fmt.Printf("    Status:     %s (%s)\n", state, describeTimeLeft(left))
+			fmt.Printf("    Expires at: %s\n", time.UnixMilli(c.ExpireTime).Format("2006-01-02 15:04:05"))
+		} else {
+			fmt.Printf("    Status:     unknown expiry\n")
+		}
+		fmt.Printf("    Endpoint:   %s\n", valueOrDash(c.Endpoint))
+		fmt.Printf("    Cached at:  %s\n", valueOrDash(c.CachedAt))
+	}
+	fmt.Printf("\n* current registry. Credentials are refreshed automatically when less than %s is left.\n", formatShortDuration(acrCredentialRefreshWindow))
+}
@@ -0,0 +1,462 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// docker_credential_helper.go lets agentbay act as a Docker credential helper
+// (https://github.com/docker/docker-credential-helpers) for the AgentBay ACR registry. When the
+// binary is invoked as "docker-credential-agentbay" (a symlink created by
+// "agentbay docker credential-helper install"), main dispatches to RunCredentialHelper, which
+// answers get/store/erase/list with tokens minted on demand through GetACRRepoCredential.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+const (
+	// credentialHelperSuffix is the name docker looks up as docker-credential-<suffix>.
+	credentialHelperSuffix = "agentbay"
+	credentialHelperBinary = "docker-credential-" + credentialHelperSuffix
+	// errCredentialsNotFound is the exact message docker expects for an unknown server.
+	errCredentialsNotFound = "credentials not found in native keychain"
+)
+
+// IsCredentialHelperInvocation reports whether the binary was started as docker-credential-agentbay.
+func IsCredentialHelperInvocation(argv0 string) bool {
+	name := filepath.Base(argv0)
+	name = strings.TrimSuffix(name, ".exe")
+	return name == credentialHelperBinary
+}
+
+// RunCredentialHelper serves one credential helper request (get, store, erase or list) and returns
+// the process exit code. Errors are written to out, as the protocol requires.
+func RunCredentialHelper(args []string, in io.Reader, out io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintf(out, "Usage: %s <get|store|erase|list>\n", credentialHelperBinary)
+		return 1
+	}
+	if err := serveCredentialHelper(args[0], in, out, fetchACRCredentialForHelper); err != nil {
+		fmt.Fprintln(out, err.Error())
+		return 1
+	}
+	return 0
+}
+
+// fetchACRCredentialForHelper mints a credential for the authenticated account.
+func fetchACRCredentialForHelper() (*acrCredentialCache, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return nil, config.ErrNotAuthenticated()
+	}
+	return fetchACRCredential(cfg)
+}
+
+// credentialHelperPayload is the JSON exchanged with docker for get and store.
+type credentialHelperPayload struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func serveCredentialHelper(action string, in io.Reader, out io.Writer, fetch func() (*acrCredentialCache, error)) error {
+	switch action {
+	case "get":
+		host, err := readCredentialHelperServer(in)
+		if err != nil {
+			return err
+		}
+		c, err := credentialHelperGet(host, time.Now(), fetch)
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(out).Encode(credentialHelperPayload{ServerURL: host, Username: c.TempUsername, Secret: c.AuthorizationToken})
+	case "store":
+		// Tokens are minted on demand, so whatever docker hands over (e.g. after a manual
+		// "docker login") is not kept.
+		var p credentialHelperPayload
+		if err := json.NewDecoder(in).Decode(&p); err != nil {
+			return fmt.Errorf("invalid store request: %w", err)
+		}
+		return nil
+	case "erase":
+		host, err := readCredentialHelperServer(in)
+		if err != nil {
+			return err
+		}
+		store, err := loadACRCredentialStore()
+		if err != nil {
+			return err
+		}
+		if _, ok := store.Registries[host]; !ok {
+			return nil
+		}
+		delete(store.Registries, host)
+		if store.Current == host {
+			store.Current = ""
+		}
+		return saveACRCredentialStore(store)
+	case "list":
+		store, err := loadACRCredentialStore()
+		if err != nil {
+			return err
+		}
+		list := make(map[string]string, len(store.Registries))
+		for host, c := range store.Registries {
+			list[host] = c.TempUsername
+		}
+		return json.NewEncoder(out).Encode(list)
+	default:
+		return fmt.Errorf("unknown credential helper action %q", action)
+	}
+}
+
+// readCredentialHelperServer reads the server URL docker writes to stdin and reduces it to a host.
+func readCredentialHelperServer(in io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(in, 4096))
+	if err != nil {
+		return "", err
+	}
+	host := normalizeRegistryHost(string(data))
+	if host == "" {
+		return "", fmt.Errorf("no server URL given")
+	}
+	return host, nil
+}
+
+// normalizeRegistryHost turns "https://host/v2/" or "host" into "host".
+func normalizeRegistryHost(server string) string {
+	host := strings.TrimSpace(server)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.IndexByte(host, '/'); i >= 0 {
+		host = host[:i]
+	}
+	return host
+}
+
+// credentialHelperGet returns a valid credential for host: the cached one, refreshed when near
+// expiry, or a freshly minted one when host is the account's registry but nothing is cached yet.
+// Nothing is printed, since docker parses stdout.
+func credentialHelperGet(host string, now time.Time, fetch func() (*acrCredentialCache, error)) (*acrCredentialCache, error) {
+	store, err := loadACRCredentialStore()
+	if err != nil {
+		return nil, err
+	}
+	cached, ok := store.Registries[host]
+	if !ok {
+		fresh, err := fetch()
+		if err != nil {
+			log.Debugf("credential helper: cannot mint a credential for %s: %v", host, err)
+			return nil, fmt.Errorf(errCredentialsNotFound)
+		}
+		if fresh.RegistryURL != host {
+			return nil, fmt.Errorf(errCredentialsNotFound)
+		}
+		if err := saveACRCredential(fresh); err != nil {
+			log.Debugf("Failed to cache credential: %v", err)
+		}
+		return fresh, nil
+	}
+	c, refreshed, err := refreshACRCredentialIfNeeded(cached, now, config.LoadAPIConfig(nil).Endpoint, fetch)
+	if err != nil {
+		return nil, err
+	}
+	if refreshed {
+		if c.RegistryURL != host {
+			return nil, fmt.Errorf("account registry changed from %s to %s; run 'agentbay docker login'", host, c.RegistryURL)
+		}
+		if err := saveACRCredential(c); err != nil {
+			log.Debugf("Failed to cache credential: %v", err)
+		}
+	}
+	return c, nil
+}
+
+// ---------------------------------------------------------------------------
+// docker config.json
+// ---------------------------------------------------------------------------
+
+// dockerConfigPath returns $DOCKER_CONFIG/config.json or ~/.docker/config.json.
+func dockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// loadDockerConfig reads the docker config as raw top-level fields so unknown settings survive a
+// rewrite. A missing file yields an empty config.
+func loadDockerConfig(p string) (map[string]json.RawMessage, error) {
+	cfg := map[string]json.RawMessage{}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", p, err)
+	}
+	return cfg, nil
+}
+
+// dockerUsesCredentialHelper reports whether docker is configured to use this helper for host.
+func dockerUsesCredentialHelper(host string) bool {
+	p, err := dockerConfigPath()
+	if err != nil {
+		return false
+	}
+	cfg, err := loadDockerConfig(p)
+	if err != nil {
+		return false
+	}
+	var helpers map[string]string
+	if raw, ok := cfg["credHelpers"]; !ok || json.Unmarshal(raw, &helpers) != nil {
+		return false
+	}
+	return helpers[host] == credentialHelperSuffix
+}
+
+// configureDockerCredentialHelper points credHelpers for each host at this helper and drops any
+// token "docker login" stored for it under auths. It returns the hosts whose auths were removed.
+// The file is replaced through a temporary file, so Docker never reads a half-written config.
+func configureDockerCredentialHelper(p string, hosts []string) (removedAuths []string, err error) {
+	cfg, err := loadDockerConfig(p)
+	if err != nil {
+		return nil, err
+	}
+	helpers := map[string]string{}
+	if raw, ok := cfg["credHelpers"]; ok {
+		if err := json.Unmarshal(raw, &helpers); err != nil {
+			return nil, fmt.Errorf("failed to parse credHelpers in %s: %w", p, err)
+		}
+	}
+	auths := map[string]json.RawMessage{}
+	if raw, ok := cfg["auths"]; ok {
+		if err := json.Unmarshal(raw, &auths); err != nil {
+			return nil, fmt.Errorf("failed to parse auths in %s: %w", p, err)
+		}
+	}
+	for _, h := range hosts {
+		helpers[h] = credentialHelperSuffix
+		for _, key := range []string{h, "https://" + h} {
+			if _, ok := auths[key]; ok {
+				delete(auths, key)
+				removedAuths = append(removedAuths, key)
+			}
+		}
+	}
+	if cfg["credHelpers"], err = json.Marshal(helpers); err != nil {
+		return nil, err
+	}
+	if _, ok := cfg["auths"]; ok {
+		if cfg["auths"], err = json.Marshal(auths); err != nil {
+			return nil, err
+		}
+	}
+	data, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return nil, err
+	}
+	return removedAuths, config.WriteFileAtomic(p, data, 0600)
+}
+
+// ---------------------------------------------------------------------------
+// agentbay docker credential-helper
+// ---------------------------------------------------------------------------
+
+var dockerCredentialHelperCmd = &cobra.Command{
+	Use:   "credential-helper <get|store|erase|list>",
+	Short: "Docker credential helper for the AgentBay ACR registry",
+	Long: `Serve the Docker credential helper protocol for the AgentBay ACR registry.
+
+Docker, Podman and BuildKit call "docker-credential-agentbay <action>" for every registry
+listed under "credHelpers" in ~/.docker/config.json. "get" returns a temporary ACR token,
+minted through GetACRRepoCredential when none is cached or the cached one is about to
+expire, so no separate "agentbay docker login" is needed and no token is written to the
+docker config. "store" is accepted and ignored, "erase" drops the cached token and "list"
+shows the cached registries.
+
+Run "agentbay docker credential-helper install" once to set this up.
+
+Examples:
+  agentbay docker credential-helper install
+  echo ai-container-pre-9543-registry.cn-hangzhou.cr.aliyuncs.com | agentbay docker credential-helper get`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"get", "store", "erase", "list"},
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		if code := RunCredentialHelper(args, os.Stdin, os.Stdout); code != 0 {
+			os.Exit(code)
+		}
+		return nil
+	},
+}
+
+var dockerCredentialHelperInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install docker-credential-agentbay and register it in the docker config",
+	Long: `Install the AgentBay Docker credential helper.
+
+This creates a "docker-credential-agentbay" symlink to the agentbay binary (in --dir,
+default: the directory of the agentbay binary) and sets "credHelpers" in the docker
+config ($DOCKER_CONFIG/config.json or ~/.docker/config.json) to "agentbay" for each
+registry: every cached registry plus --registry. When nothing is cached, the account's
+registry is looked up through GetACRRepoCredential. Tokens that "docker login" stored for
+those registries under "auths" are removed.
+
+The --dir directory must be on PATH for docker to find the helper.
+
+Examples:
+  agentbay docker credential-helper install
+  agentbay docker credential-helper install --dir ~/.local/bin`,
+	Args: cobra.NoArgs,
+	RunE: runDockerCredentialHelperInstall,
+}
+
+func init() {
+	dockerCredentialHelperInstallCmd.Flags().String("dir", "", "Directory for the docker-credential-agentbay symlink (default: next to the agentbay binary)")
+	dockerCredentialHelperInstallCmd.Flags().StringArray("registry", nil, "Registry host to route through the helper (repeatable, in addition to cached ones)")
+	dockerCredentialHelperCmd.AddCommand(dockerCredentialHelperInstallCmd)
+}
+
+func runDockerCredentialHelperInstall(cobraCmd *cobra.Command, args []string) error {
+	dir, _ := cobraCmd.Flags().GetString("dir")
+	extra, _ := cobraCmd.Flags().GetStringArray("registry")
+
+	hosts, err := credentialHelperHosts(extra)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to locate the agentbay binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(self); err == nil {
+		self = resolved
+	}
+	if dir == "" {
+		dir = filepath.Dir(self)
+	}
+	link := filepath.Join(dir, credentialHelperBinary)
+	if runtime.GOOS == "windows" {
+		link += ".exe"
+	}
+	if err := installCredentialHelperLink(self, link); err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	fmt.Printf("[OK] Helper installed: %s -> %s\n", link, self)
+
+	p, err := dockerConfigPath()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to locate the docker config: %w", err)
+	}
+	removed, err := configureDockerCredentialHelper(p, hosts)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	for _, h := range hosts {
+		fmt.Printf("[OK] %s: credHelpers -> %s\n", h, credentialHelperSuffix)
+	}
+	for _, a := range removed {
+		fmt.Printf("[INFO] Removed the token stored by 'docker login' for %s\n", a)
+	}
+	fmt.Printf("[INFO] Docker config updated: %s\n", p)
+
+	if !dirOnPath(dir) {
+		fmt.Printf("[WARN] %s is not on PATH; add it so docker can find %s.\n", dir, credentialHelperBinary)
+	}
+	return nil
+}
+
+// credentialHelperHosts returns the cached registries plus extra, looking the account registry up
+// through GetACRRepoCredential when nothing is known yet.
+func credentialHelperHosts(extra []string) ([]string, error) {
+	set := map[string]bool{}
+	for _, h := range extra {
+		if h = normalizeRegistryHost(h); h != "" {
+			set[h] = true
+		}
+	}
+	store, err := loadACRCredentialStore()
+	if err != nil {
+		return nil, err
+	}
+	for h := range store.Registries {
+		set[h] = true
+	}
+	if len(set) == 0 {
+		fmt.Printf("Requesting registry of the current account...")
+		c, err := fetchACRCredentialForHelper()
+		if err != nil {
+			fmt.Println(" Failed.")
+			return nil, fmt.Errorf("failed to look up the ACR registry (or pass --registry): %w", err)
+		}
+		fmt.Println(" Done.")
+		if err := saveACRCredential(c); err != nil {
+			log.Debugf("Failed to cache credential: %v", err)
+		}
+		set[c.RegistryURL] = true
+	}
+	hosts := make([]string, 0, len(set))
+	for h := range set {
+		hosts = append(hosts, h)
+	}
+	sort.Strings(hosts)
+	return hosts, nil
+}
+
+// installCredentialHelperLink points link at target, replacing an existing symlink but never a
+// regular file.
+func installCredentialHelperLink(target, link string) error {
+	if info, err := os.Lstat(link); err == nil {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return fmt.Errorf("%s already exists and is not a symlink; remove it first", link)
+		}
+		if err := os.Remove(link); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+		return err
+	}
+	if err := os.Symlink(target, link); err != nil {
+		return fmt.Errorf("failed to create %s: %w (copy or link the agentbay binary to that name manually)", link, err)
+	}
+	return nil
+}
+
+func dirOnPath(dir string) bool {
+	clean := filepath.Clean(dir)
+	for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+		if filepath.Clean(p) == clean {
+			return true
+		}
+	}
+	return false
+}
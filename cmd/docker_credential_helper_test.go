@@ -0,0 +1,136 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCredentialHelperInvocation(t *testing.T) {
+	assert.True(t, IsCredentialHelperInvocation("/usr/local/bin/docker-credential-agentbay"))
+	assert.True(t, IsCredentialHelperInvocation(`docker-credential-agentbay.exe`))
+	assert.False(t, IsCredentialHelperInvocation("/usr/local/bin/agentbay"))
+}
+
+func TestNormalizeRegistryHost(t *testing.T) {
+	assert.Equal(t, "reg.example.com", normalizeRegistryHost("https://reg.example.com/v2/\n"))
+	assert.Equal(t, "reg.example.com:5000", normalizeRegistryHost("reg.example.com:5000"))
+	assert.Equal(t, "", normalizeRegistryHost("  "))
+}
+
+func TestCredentialHelperGet(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	now := time.Now()
+	calls := 0
+	fetch := func() (*acrCredentialCache, error) {
+		calls++
+		return &acrCredentialCache{TempUsername: "u", AuthorizationToken: "fresh", RegistryURL: "reg.example.com", ExpireTime: now.Add(time.Hour).UnixMilli()}, nil
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, serveCredentialHelper("get", strings.NewReader("https://reg.example.com\n"), &out, fetch))
+	var p credentialHelperPayload
+	require.NoError(t, json.Unmarshal(out.Bytes(), &p))
+	assert.Equal(t, credentialHelperPayload{ServerURL: "reg.example.com", Username: "u", Secret: "fresh"}, p)
+	assert.Equal(t, 1, calls, "nothing cached: minted on demand")
+
+	// The minted token is cached and reused while it is valid.
+	out.Reset()
+	require.NoError(t, serveCredentialHelper("get", strings.NewReader("reg.example.com"), &out, fetch))
+	assert.Equal(t, 1, calls)
+
+	// A registry the account does not own is reported as not found.
+	out.Reset()
+	err := serveCredentialHelper("get", strings.NewReader("docker.io"), &out, fetch)
+	require.Error(t, err)
+	assert.Equal(t, errCredentialsNotFound, err.Error())
+	assert.Empty(t, out.String(), "stdout carries only protocol output")
+
+	_, err = credentialHelperGet("other.example.com", now, func() (*acrCredentialCache, error) { return nil, errors.New("offline") })
+	require.Error(t, err)
+	assert.Equal(t, errCredentialsNotFound, err.Error())
+}
+
+func TestCredentialHelperListAndErase(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	require.NoError(t, saveACRCredential(&acrCredentialCache{TempUsername: "ua", RegistryURL: "reg-a"}))
+	require.NoError(t, saveACRCredential(&acrCredentialCache{TempUsername: "ub", RegistryURL: "reg-b"}))
+	noFetch := func() (*acrCredentialCache, error) { return nil, errors.New("unexpected fetch") }
+
+	var out bytes.Buffer
+	require.NoError(t, serveCredentialHelper("list", nil, &out, noFetch))
+	var list map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &list))
+	assert.Equal(t, map[string]string{"reg-a": "ua", "reg-b": "ub"}, list)
+
+	require.NoError(t, serveCredentialHelper("erase", strings.NewReader("https://reg-b"), &out, noFetch))
+	store, err := loadACRCredentialStore()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reg-a"}, store.registryNames())
+	assert.Empty(t, store.Current)
+
+	require.NoError(t, serveCredentialHelper("store", strings.NewReader(`{"ServerURL":"reg-a","Username":"x","Secret":"y"}`), &out, noFetch))
+	assert.Error(t, serveCredentialHelper("bogus", nil, &out, noFetch))
+}
+
+func TestConfigureDockerCredentialHelper(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "docker", "config.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+	existing := `{"auths":{"reg-a":{"auth":"dG9rZW4="},"docker.io":{"auth":"eA=="}},"credHelpers":{"gcr.io":"gcloud"},"psFormat":"table"}`
+	require.NoError(t, os.WriteFile(p, []byte(existing), 0600))
+
+	removed, err := configureDockerCredentialHelper(p, []string{"reg-a", "reg-b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reg-a"}, removed)
+
+	var cfg struct {
+		Auths       map[string]json.RawMessage `json:"auths"`
+		CredHelpers map[string]string          `json:"credHelpers"`
+		PsFormat    string                     `json:"psFormat"`
+	}
+	data, err := os.ReadFile(p)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &cfg))
+	assert.Equal(t, map[string]string{"gcr.io": "gcloud", "reg-a": "agentbay", "reg-b": "agentbay"}, cfg.CredHelpers)
+	assert.Contains(t, cfg.Auths, "docker.io")
+	assert.NotContains(t, cfg.Auths, "reg-a")
+	assert.Equal(t, "table", cfg.PsFormat)
+	info, err := os.Stat(p)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	entries, err := os.ReadDir(filepath.Dir(p))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+
+	t.Setenv("DOCKER_CONFIG", filepath.Dir(p))
+	assert.True(t, dockerUsesCredentialHelper("reg-b"))
+	assert.False(t, dockerUsesCredentialHelper("docker.io"))
+}
+
+func TestInstallCredentialHelperLink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "agentbay")
+	require.NoError(t, os.WriteFile(target, []byte("bin"), 0755))
+	link := filepath.Join(dir, "bin", credentialHelperBinary)
+
+	require.NoError(t, installCredentialHelperLink(target, link))
+	require.NoError(t, installCredentialHelperLink(target, link), "re-install replaces the symlink")
+	got, err := os.Readlink(link)
+	require.NoError(t, err)
+	assert.Equal(t, target, got)
+
+	regular := filepath.Join(dir, "regular")
+	require.NoError(t, os.WriteFile(regular, nil, 0644))
+	assert.Error(t, installCredentialHelperLink(target, regular))
+}
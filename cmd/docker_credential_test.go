@@ -0,0 +1,123 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACRCredentialStoreMigratesLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", dir)
+	legacy := `{"temp_username":"u","authorization_token":"p","namespace":"customer_cli","repo_name":"123","registry_url":"reg-a.example.com","expire_time":0}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acr_credential.json"), []byte(legacy), 0600))
+
+	c, err := loadACRCredential()
+	require.NoError(t, err)
+	assert.Equal(t, "reg-a.example.com/customer_cli/123", c.RepoPath())
+
+	// A second login keeps the migrated entry and becomes current.
+	require.NoError(t, saveACRCredential(&acrCredentialCache{RegistryURL: "reg-b.example.com", Namespace: "customer_cli", RepoName: "456"}))
+	store, err := loadACRCredentialStore()
+	require.NoError(t, err)
+	assert.Equal(t, "reg-b.example.com", store.Current)
+	assert.Equal(t, []string{"reg-a.example.com", "reg-b.example.com"}, store.registryNames())
+
+	info, err := os.Stat(filepath.Join(dir, "acr_credential.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestSelectACRCredential(t *testing.T) {
+	a := &acrCredentialCache{RegistryURL: "reg-a", Endpoint: "ep-a"}
+	b := &acrCredentialCache{RegistryURL: "reg-b", Endpoint: "ep-b"}
+	legacy := &acrCredentialCache{RegistryURL: "reg-c"}
+	store := &acrCredentialStore{Current: "reg-b", Registries: map[string]*acrCredentialCache{"reg-a": a, "reg-b": b, "reg-c": legacy}}
+
+	got, err := selectACRCredential(store, "reg-c", "ep-a")
+	require.NoError(t, err)
+	assert.Same(t, legacy, got)
+
+	got, err = selectACRCredential(store, "", "ep-a")
+	require.NoError(t, err)
+	assert.Same(t, a, got, "entry issued by the active endpoint wins")
+
+	got, err = selectACRCredential(store, "", "ep-other")
+	require.NoError(t, err)
+	assert.Same(t, b, got, "falls back to the current registry")
+
+	_, err = selectACRCredential(store, "reg-x", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cached: reg-a, reg-b, reg-c")
+
+	store.Current = ""
+	_, err = selectACRCredential(store, "", "ep-other")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--registry")
+
+	_, err = selectACRCredential(&acrCredentialStore{}, "", "")
+	assert.Error(t, err)
+}
+
+func TestRefreshACRCredentialIfNeeded(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiringIn := func(d time.Duration, endpoint string) *acrCredentialCache {
+		return &acrCredentialCache{RegistryURL: "reg", Endpoint: endpoint, ExpireTime: now.Add(d).UnixMilli()}
+	}
+	fresh := &acrCredentialCache{RegistryURL: "reg", ExpireTime: now.Add(time.Hour).UnixMilli()}
+	var calls int
+	ok := func() (*acrCredentialCache, error) { calls++; return fresh, nil }
+	fail := func() (*acrCredentialCache, error) { calls++; return nil, errors.New("boom") }
+
+	cases := []struct {
+		name      string
+		cache     *acrCredentialCache
+		fetch     func() (*acrCredentialCache, error)
+		wantFresh bool
+		wantErr   string
+		wantCalls int
+	}{
+		{"valid long enough", expiringIn(30*time.Minute, "ep"), ok, false, "", 0},
+		{"unknown expiry", &acrCredentialCache{RegistryURL: "reg"}, ok, false, "", 0},
+		{"inside window", expiringIn(2*time.Minute, "ep"), ok, true, "", 1},
+		{"expired", expiringIn(-time.Minute, "ep"), ok, true, "", 1},
+		{"legacy entry without endpoint", expiringIn(-time.Minute, ""), ok, true, "", 1},
+		{"refresh fails before expiry", expiringIn(2*time.Minute, "ep"), fail, false, "", 1},
+		{"refresh fails after expiry", expiringIn(-time.Minute, "ep"), fail, false, "refresh failed: boom", 1},
+		{"other endpoint, not yet expired", expiringIn(2*time.Minute, "ep-other"), ok, false, "", 0},
+		{"other endpoint, expired", expiringIn(-time.Minute, "ep-other"), ok, false, "issued by endpoint ep-other", 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = 0
+			got, refreshed, err := refreshACRCredentialIfNeeded(tc.cache, now, "ep", tc.fetch)
+			assert.Equal(t, tc.wantCalls, calls)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantFresh, refreshed)
+			if tc.wantFresh {
+				assert.Same(t, fresh, got)
+			} else {
+				assert.Same(t, tc.cache, got)
+			}
+		})
+	}
+}
+
+func TestDescribeTimeLeft(t *testing.T) {
+	assert.Equal(t, "expires in 1h05m", describeTimeLeft(65*time.Minute))
+	assert.Equal(t, "expires in 4m30s", describeTimeLeft(270*time.Second))
+	assert.Equal(t, "expired 12s ago", describeTimeLeft(-12*time.Second))
+}
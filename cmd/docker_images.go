@@ -0,0 +1,591 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// docker_images.go implements "agentbay docker images" and "agentbay docker inspect", which read
+// the ACR repository through the Registry v2 API using the credential cached by "docker login".
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/registry"
+)
+
+// registryTagConcurrency bounds parallel manifest/config requests in "docker images".
+const registryTagConcurrency = 4
+
+// registryReader is the part of *registry.Client used to list and inspect images.
+type registryReader interface {
+	ListTags(ctx context.Context, repo string) ([]string, error)
+	GetManifest(ctx context.Context, repo, reference string) (*registry.RemoteManifest, error)
+	GetImageConfig(ctx context.Context, repo, digest string) (*registry.ImageConfig, error)
+	ManifestExists(ctx context.Context, repo, reference string) (bool, error)
+}
+
+var dockerImagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "List the image tags in your AgentBay ACR repository",
+	Long: `List the tags in your AgentBay ACR repository with digest, platforms, compressed size,
+creation time and, when the registry reports it, the push time.
+
+Uses the Docker Registry v2 API with the credential cached by "agentbay docker login";
+no docker daemon is needed.
+
+Examples:
+  agentbay docker images
+  agentbay docker images -o json`,
+	Args: cobra.NoArgs,
+	RunE: runDockerImages,
+}
+
+var dockerInspectCmd = &cobra.Command{
+	Use:   "inspect <tag|digest|image>",
+	Short: "Show the manifest, config and layers of an image in your ACR repository",
+	Long: `Show the manifest, config and layers of an image in your AgentBay ACR repository.
+
+The reference may be a bare tag, a digest (sha256:...) or a full image name matching
+$RegistryUrl/$Namespace/$RepoName[:tag|@digest]. For multi-platform images the index is
+listed and the manifest for --platform (default linux/amd64, else the first entry) is shown.
+
+Examples:
+  agentbay docker inspect v1.0
+  agentbay docker inspect v1.0 --platform linux/arm64
+  agentbay docker inspect sha256:0123... -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDockerInspect,
+}
+
+func init() {
+	dockerImagesCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+	dockerImagesCmd.Flags().String("registry", "", "Cached registry to list when several are cached (default: current)")
+	dockerInspectCmd.Flags().String("registry", "", "Cached registry to use (default: the image's registry host, else current)")
+	dockerInspectCmd.Flags().String("platform", "", "Platform to show for multi-platform images, e.g. linux/arm64")
+	dockerInspectCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+}
+
+// registryImageSummary is one row of "docker images".
+type registryImageSummary struct {
+	Tag       string     `json:"tag"`
+	Digest    string     `json:"digest,omitempty"`
+	MediaType string     `json:"mediaType,omitempty"`
+	Platforms []string   `json:"platforms,omitempty"`
+	Size      int64      `json:"size"`
+	Created   *time.Time `json:"created,omitempty"`
+	Pushed    *time.Time `json:"pushed,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// loadRegistryReader loads (and if needed refreshes) the cached ACR credential for registryURL and
+// returns a registry client for it.
+func loadRegistryReader(registryURL string) (*acrCredentialCache, *registry.Client, error) {
+	cache, err := ensureACRCredential(registryURL, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := newACRRegistryClient(cache)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cache, rc, nil
+}
+
+func runDockerImages(cobraCmd *cobra.Command, args []string) error {
+	outputFmt, _ := cobraCmd.Flags().GetString("output")
+	if outputFmt != "" && outputFmt != "json" {
+		return fmt.Errorf("[ERROR] Unsupported output format %q. Use \"json\"", outputFmt)
+	}
+	registryURL, _ := cobraCmd.Flags().GetString("registry")
+	cache, rc, err := loadRegistryReader(registryURL)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	repo := cache.Namespace + "/" + cache.RepoName
+	repoPath := cache.RepoPath()
+
+	if outputFmt != "json" {
+		fmt.Printf("Requesting tags of %s...", repoPath)
+	}
+	images, err := listRegistryImages(context.Background(), rc, repo)
+	if err != nil {
+		if outputFmt != "json" {
+			fmt.Println(" Failed.")
+		}
+		if registry.IsNotFound(err) {
+			return fmt.Errorf("[ERROR] Repository %s not found. Push an image first with 'agentbay docker push'", repoPath)
+		}
+		return fmt.Errorf("[ERROR] Failed to list images: %w", err)
+	}
+
+	if outputFmt == "json" {
+		b, err := json.MarshalIndent(map[string]interface{}{"repository": repoPath, "images": images}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("[ERROR] Failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	fmt.Println(" Done.")
+
+	if len(images) == 0 {
+		fmt.Printf("\n[EMPTY] No tags found in %s\n", repoPath)
+		return nil
+	}
+	fmt.Printf("\n[OK] Found %d tag(s) in %s\n\n", len(images), repoPath)
+	printRegistryImages(images)
+	return nil
+}
+
+// listRegistryImages lists every tag in repo and summarizes each, newest first. Per-tag failures
+// are recorded on the row rather than aborting the listing.
+func listRegistryImages(ctx context.Context, rc registryReader, repo string) ([]registryImageSummary, error) {
+	tags, err := rc.ListTags(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	images := make([]registryImageSummary, len(tags))
+	sem := make(chan struct{}, registryTagConcurrency)
+	var wg sync.WaitGroup
+	for i, tag := range tags {
+		wg.Add(1)
+		go func(i int, tag string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			images[i] = summarizeRegistryTag(ctx, rc, repo, tag)
+		}(i, tag)
+	}
+	wg.Wait()
+
+	sort.SliceStable(images, func(i, j int) bool {
+		ti, tj := images[i].sortTime(), images[j].sortTime()
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return images[i].Tag < images[j].Tag
+	})
+	return images, nil
+}
+
+func (s registryImageSummary) sortTime() time.Time {
+	if s.Pushed != nil {
+		return *s.Pushed
+	}
+	if s.Created != nil {
+		return *s.Created
+	}
+	return time.Time{}
+}
+
+// summarizeRegistryTag resolves tag to its manifest and sums the distinct blobs it references.
+// For an index every platform manifest is read; Created is the newest config creation time.
+func summarizeRegistryTag(ctx context.Context, rc registryReader, repo, tag string) registryImageSummary {
+	s := registryImageSummary{Tag: tag}
+	m, err := rc.GetManifest(ctx, repo, tag)
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	s.Digest, s.MediaType = m.Digest, m.MediaType
+	s.Size = int64(len(m.Data))
+	if !m.LastModified.IsZero() {
+		t := m.LastModified
+		s.Pushed = &t
+	}
+	parsed, err := m.Parse()
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+
+	seen := make(map[string]bool)
+	addImage := func(man *registry.Manifest) error {
+		if man.Config == nil {
+			return fmt.Errorf("manifest has no config")
+		}
+		for _, d := range append([]registry.Descriptor{*man.Config}, man.Layers...) {
+			if !seen[d.Digest] {
+				seen[d.Digest] = true
+				s.Size += d.Size
+			}
+		}
+		cfg, err := rc.GetImageConfig(ctx, repo, man.Config.Digest)
+		if err != nil {
+			return err
+		}
+		s.Platforms = append(s.Platforms, imageConfigPlatform(cfg))
+		if cfg.Created != nil && (s.Created == nil || cfg.Created.After(*s.Created)) {
+			s.Created = cfg.Created
+		}
+		return nil
+	}
+
+	if !parsed.IsIndex() {
+		if err := addImage(parsed); err != nil {
+			s.Error = err.Error()
+		}
+		return s
+	}
+	for _, child := range indexImageManifests(parsed) {
+		cm, err := rc.GetManifest(ctx, repo, child.Digest)
+		if err == nil {
+			var cp *registry.Manifest
+			if cp, err = cm.Parse(); err == nil {
+				s.Size += int64(len(cm.Data))
+				err = addImage(cp)
+			}
+		}
+		if err != nil {
+			s.Error = fmt.Sprintf("%s: %v", child.Digest, err)
+			return s
+		}
+	}
+	return s
+}
+
+// indexImageManifests returns the platform manifests of an index, skipping attestation
+// manifests (platform unknown/unknown) that buildx adds.
+func indexImageManifests(index *registry.Manifest) []registry.Descriptor {
+	var out []registry.Descriptor
+	for _, d := range index.Manifests {
+		if d.Platform != nil && d.Platform.OS == "unknown" {
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func imageConfigPlatform(cfg *registry.ImageConfig) string {
+	p := cfg.OS + "/" + cfg.Architecture
+	if cfg.Variant != "" {
+		p += "/" + cfg.Variant
+	}
+	return p
+}
+
+func descriptorPlatform(d registry.Descriptor) string {
+	if d.Platform == nil {
+		return ""
+	}
+	p := d.Platform.OS + "/" + d.Platform.Architecture
+	if d.Platform.Variant != "" {
+		p += "/" + d.Platform.Variant
+	}
+	return p
+}
+
+func shortDigest(d string) string {
+	d = strings.TrimPrefix(d, "sha256:")
+	if len(d) > 12 {
+		return d[:12]
+	}
+	return d
+}
+
+func formatRegistryTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func printRegistryImages(images []registryImageSummary) {
+	fmt.Printf("%s %s %s %s %s %s\n",
+		padString("TAG", 24),
+		padString("DIGEST", 14),
+		padString("PLATFORMS", 26),
+		padString("SIZE", 10),
+		padString("CREATED", 20),
+		"PUSHED")
+	fmt.Printf("%s %s %s %s %s %s\n",
+		padString("---", 24),
+		padString("------", 14),
+		padString("---------", 26),
+		padString("----", 10),
+		padString("-------", 20),
+		"------")
+	var failed int
+	for _, img := range images {
+		if img.Error != "" {
+			failed++
+			fmt.Printf("%s %s\n", padString(truncateString(img.Tag, 24), 24), "[ERROR] "+img.Error)
+			continue
+		}
+		fmt.Printf("%s %s %s %s %s %s\n",
+			padString(truncateString(img.Tag, 24), 24),
+			padString(shortDigest(img.Digest), 14),
+			padString(truncateString(valueOrDash(strings.Join(img.Platforms, ",")), 26), 26),
+			padString(formatBytes(img.Size), 10),
+			padString(formatRegistryTime(img.Created), 20),
+			formatRegistryTime(img.Pushed))
+	}
+	if failed > 0 {
+		fmt.Printf("\n[WARN] %d tag(s) could not be read.\n", failed)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// docker inspect
+// ---------------------------------------------------------------------------
+
+// registryInspectResult is the "docker inspect -o json" document.
+type registryInspectResult struct {
+	Reference string `json:"reference"`
+	Digest    string `json:"digest"`
+	MediaType string `json:"mediaType"`
+	// Index is set for multi-platform images; Manifest and Config then describe Platform.
+	Index          *registry.Manifest    `json:"index,omitempty"`
+	Platform       string                `json:"platform,omitempty"`
+	ManifestDigest string                `json:"manifestDigest"`
+	Manifest       *registry.Manifest    `json:"manifest"`
+	Config         *registry.ImageConfig `json:"config"`
+	Size           int64                 `json:"size"`
+}
+
+// resolveRegistryReference turns a bare tag, a digest or a full image name into the tag or digest
+// to look up in the authorized $RegistryUrl/$Namespace/$RepoName repository.
+func resolveRegistryReference(cache *acrCredentialCache, target string) (string, error) {
+	repoPath := cache.RepoPath()
+	if !strings.Contains(target, "/") {
+		if strings.HasPrefix(target, "sha256:") {
+			return target, nil
+		}
+		if target == "" || strings.ContainsAny(target, ":@") {
+			return "", fmt.Errorf("invalid tag %q", target)
+		}
+		return target, nil
+	}
+	name, ref := target, "latest"
+	if i := strings.Index(target, "@"); i >= 0 {
+		name, ref = target[:i], target[i+1:]
+	} else if i := strings.LastIndex(target, ":"); i > strings.LastIndex(target, "/") {
+		name, ref = target[:i], target[i+1:]
+	}
+	if name != repoPath {
+		return "", fmt.Errorf("image name '%s' does not match the authorized registry path.\n"+
+			"  Expected: %s[:tag]", target, repoPath)
+	}
+	return ref, nil
+}
+
+func runDockerInspect(cobraCmd *cobra.Command, args []string) error {
+	platform, _ := cobraCmd.Flags().GetString("platform")
+	outputFmt, _ := cobraCmd.Flags().GetString("output")
+	if outputFmt != "" && outputFmt != "json" {
+		return fmt.Errorf("[ERROR] Unsupported output format %q. Use \"json\"", outputFmt)
+	}
+	registryURL, _ := cobraCmd.Flags().GetString("registry")
+	if registryURL == "" && strings.Contains(args[0], "/") {
+		registryURL = args[0][:strings.Index(args[0], "/")]
+	}
+	cache, rc, err := loadRegistryReader(registryURL)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	reference, err := resolveRegistryReference(cache, args[0])
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	repo := cache.Namespace + "/" + cache.RepoName
+	sep := ":"
+	if strings.HasPrefix(reference, "sha256:") {
+		sep = "@"
+	}
+	fullRef := cache.RegistryURL + "/" + repo + sep + reference
+
+	result, err := inspectRegistryImage(context.Background(), rc, repo, reference, platform)
+	if err != nil {
+		if registry.IsNotFound(err) {
+			return fmt.Errorf("[ERROR] %s not found. Run 'agentbay docker images' to list available tags", fullRef)
+		}
+		return fmt.Errorf("[ERROR] Failed to inspect %s: %w", fullRef, err)
+	}
+	result.Reference = fullRef
+
+	if outputFmt == "json" {
+		b, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("[ERROR] Failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	printRegistryInspect(result)
+	return nil
+}
+
+// inspectRegistryImage reads the manifest for reference and, for an index, the manifest of the
+// requested platform, plus its config.
+func inspectRegistryImage(ctx context.Context, rc registryReader, repo, reference, platform string) (*registryInspectResult, error) {
+	root, err := rc.GetManifest(ctx, repo, reference)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := root.Parse()
+	if err != nil {
+		return nil, err
+	}
+	result := &registryInspectResult{Digest: root.Digest, MediaType: root.MediaType, ManifestDigest: root.Digest}
+
+	if parsed.IsIndex() {
+		result.Index = parsed
+		child, err := selectIndexPlatform(parsed, platform)
+		if err != nil {
+			return nil, err
+		}
+		result.Platform = descriptorPlatform(child)
+		cm, err := rc.GetManifest(ctx, repo, child.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if parsed, err = cm.Parse(); err != nil {
+			return nil, err
+		}
+		result.ManifestDigest = cm.Digest
+	} else if platform != "" {
+		return nil, fmt.Errorf("--platform is only valid for multi-platform images")
+	}
+	if parsed.Config == nil {
+		return nil, fmt.Errorf("manifest %s has no config", result.ManifestDigest)
+	}
+	result.Manifest = parsed
+	cfg, err := rc.GetImageConfig(ctx, repo, parsed.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	result.Config = cfg
+	if result.Platform == "" {
+		result.Platform = imageConfigPlatform(cfg)
+	}
+	result.Size = parsed.Config.Size
+	for _, l := range parsed.Layers {
+		result.Size += l.Size
+	}
+	return result, nil
+}
+
+// selectIndexPlatform picks the index entry for platform ("os/arch[/variant]"). With no platform it
+// prefers linux/amd64 and falls back to the first image manifest.
+func selectIndexPlatform(index *registry.Manifest, platform string) (registry.Descriptor, error) {
+	children := indexImageManifests(index)
+	if len(children) == 0 {
+		return registry.Descriptor{}, fmt.Errorf("image index has no platform manifests")
+	}
+	want := platform
+	if want == "" {
+		want = "linux/amd64"
+	}
+	var available []string
+	for _, d := range children {
+		p := descriptorPlatform(d)
+		if p == want || (d.Platform != nil && d.Platform.Variant != "" && d.Platform.OS+"/"+d.Platform.Architecture == want) {
+			return d, nil
+		}
+		available = append(available, valueOrDash(p))
+	}
+	if platform == "" {
+		return children[0], nil
+	}
+	return registry.Descriptor{}, fmt.Errorf("platform %s not found in image index (available: %s)", platform, strings.Join(available, ", "))
+}
+
+func printRegistryInspect(r *registryInspectResult) {
+	fmt.Printf("Reference:    %s\n", r.Reference)
+	fmt.Printf("Digest:       %s\n", r.Digest)
+	fmt.Printf("MediaType:    %s\n", r.MediaType)
+	if r.Index != nil {
+		fmt.Printf("\n[INDEX] %d manifest(s)\n", len(r.Index.Manifests))
+		for _, d := range r.Index.Manifests {
+			marker := " "
+			if d.Digest == r.ManifestDigest {
+				marker = "*"
+			}
+			fmt.Printf("  %s %s %s %s\n", marker, padString(valueOrDash(descriptorPlatform(d)), 20), padString(shortDigest(d.Digest), 14), formatBytes(d.Size))
+		}
+		fmt.Printf("\nShowing:      %s (%s)\n", r.Platform, r.ManifestDigest)
+	}
+
+	cfg := r.Config
+	fmt.Printf("Platform:     %s\n", r.Platform)
+	fmt.Printf("Created:      %s\n", formatRegistryTime(cfg.Created))
+	fmt.Printf("Size:         %s (compressed)\n", formatBytes(r.Size))
+
+	fmt.Printf("\n[CONFIG]\n")
+	fmt.Printf("  User:        %s\n", valueOrDash(cfg.Config.User))
+	fmt.Printf("  WorkingDir:  %s\n", valueOrDash(cfg.Config.WorkingDir))
+	fmt.Printf("  Entrypoint:  %s\n", formatCommandLine(cfg.Config.Entrypoint))
+	fmt.Printf("  Cmd:         %s\n", formatCommandLine(cfg.Config.Cmd))
+	if len(cfg.Config.ExposedPorts) > 0 {
+		ports := make([]string, 0, len(cfg.Config.ExposedPorts))
+		for p := range cfg.Config.ExposedPorts {
+			ports = append(ports, p)
+		}
+		sort.Strings(ports)
+		fmt.Printf("  Ports:       %s\n", strings.Join(ports, ", "))
+	}
+	if len(cfg.Config.Env) > 0 {
+		fmt.Printf("  Env:\n")
+		for _, e := range cfg.Config.Env {
+			fmt.Printf("    %s\n", e)
+		}
+	}
+	if len(cfg.Config.Labels) > 0 {
+		keys := make([]string, 0, len(cfg.Config.Labels))
+		for k := range cfg.Config.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Printf("  Labels:\n")
+		for _, k := range keys {
+			fmt.Printf("    %s=%s\n", k, cfg.Config.Labels[k])
+		}
+	}
+
+	fmt.Printf("\n[LAYERS] %d layer(s)\n", len(r.Manifest.Layers))
+	fmt.Printf("  %s %s %s %s\n", padString("#", 4), padString("DIGEST", 73), padString("SIZE", 10), "MEDIA TYPE")
+	for i, l := range r.Manifest.Layers {
+		fmt.Printf("  %s %s %s %s\n", padString(fmt.Sprintf("%d", i+1), 4), padString(l.Digest, 73), padString(formatBytes(l.Size), 10), l.MediaType)
+	}
+}
+
+func formatCommandLine(args []string) string {
+	if len(args) == 0 {
+		return "-"
+	}
+	b, _ := json.Marshal(args)
+	return string(b)
+}
+
+// ---------------------------------------------------------------------------
+// Source image tag check (image create-from-template)
+// ---------------------------------------------------------------------------
+
+// verifySourceImageTag checks that the tag of an own-repository source image exists in the
+// registry. It only fails when the registry confirms the tag is missing; any other problem is
+// reported as a warning so the template call is not blocked by a registry hiccup.
+func verifySourceImageTag(ctx context.Context, rc registryReader, cache *acrCredentialCache, tag string) error {
+	repo := cache.Namespace + "/" + cache.RepoName
+	fmt.Printf("Checking source image tag in registry...")
+	ok, err := rc.ManifestExists(ctx, repo, tag)
+	if err != nil {
+		fmt.Println(" Skipped.")
+		fmt.Printf("[WARN] Could not verify tag %q: %v\n", tag, err)
+		return nil
+	}
+	if !ok {
+		fmt.Println(" Not found.")
+		return fmt.Errorf("[ERROR] Tag %q does not exist in %s/%s. Push it first with 'agentbay docker push', or list tags with 'agentbay docker images'",
+			tag, cache.RegistryURL, repo)
+	}
+	fmt.Println(" Done.")
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/registry"
+)
+
+// fakeRegistry is an in-memory registryReader keyed by reference (tag or digest).
+type fakeRegistry struct {
+	manifests map[string]*registry.RemoteManifest
+	configs   map[string]*registry.ImageConfig
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{manifests: map[string]*registry.RemoteManifest{}, configs: map[string]*registry.ImageConfig{}}
+}
+
+func (f *fakeRegistry) ListTags(ctx context.Context, repo string) ([]string, error) {
+	var tags []string
+	for ref := range f.manifests {
+		if len(ref) < 7 || ref[:7] != "sha256:" {
+			tags = append(tags, ref)
+		}
+	}
+	return tags, nil
+}
+
+func (f *fakeRegistry) GetManifest(ctx context.Context, repo, reference string) (*registry.RemoteManifest, error) {
+	if m, ok := f.manifests[reference]; ok {
+		return m, nil
+	}
+	return nil, &registry.Error{StatusCode: http.StatusNotFound, Code: "MANIFEST_UNKNOWN"}
+}
+
+func (f *fakeRegistry) GetImageConfig(ctx context.Context, repo, digest string) (*registry.ImageConfig, error) {
+	if c, ok := f.configs[digest]; ok {
+		return c, nil
+	}
+	return nil, &registry.Error{StatusCode: http.StatusNotFound, Code: "BLOB_UNKNOWN"}
+}
+
+func (f *fakeRegistry) ManifestExists(ctx context.Context, repo, reference string) (bool, error) {
+	_, ok := f.manifests[reference]
+	return ok, nil
+}
+
+// addImage stores a single-platform image with one shared and one unique layer and returns its
+// manifest descriptor.
+func (f *fakeRegistry) addImage(t *testing.T, arch string, created time.Time, refs ...string) registry.Descriptor {
+	t.Helper()
+	cfgDigest := fmt.Sprintf("sha256:cfg-%s-%d", arch, created.Unix())
+	f.configs[cfgDigest] = &registry.ImageConfig{Created: &created, Architecture: arch, OS: "linux"}
+	m := registry.Manifest{
+		SchemaVersion: 2,
+		MediaType:     registry.MediaTypeOCIManifest,
+		Config:        &registry.Descriptor{Digest: cfgDigest, Size: 100},
+		Layers: []registry.Descriptor{
+			{Digest: "sha256:shared", Size: 1000},
+			{Digest: "sha256:layer-" + arch, Size: 500},
+		},
+	}
+	return f.put(t, m, refs...)
+}
+
+func (f *fakeRegistry) put(t *testing.T, m registry.Manifest, refs ...string) registry.Descriptor {
+	t.Helper()
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	rm := &registry.RemoteManifest{MediaType: m.MediaType, Digest: fmt.Sprintf("sha256:%x", sha256.Sum256(data)), Data: data}
+	f.manifests[rm.Digest] = rm
+	for _, r := range refs {
+		f.manifests[r] = rm
+	}
+	return registry.Descriptor{MediaType: m.MediaType, Digest: rm.Digest, Size: int64(len(data))}
+}
+
+func TestResolveRegistryReference(t *testing.T) {
+	cache := &acrCredentialCache{RegistryURL: "reg.example.com", Namespace: "ns", RepoName: "repo"}
+
+	ref, err := resolveRegistryReference(cache, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", ref)
+
+	ref, err = resolveRegistryReference(cache, "sha256:abc")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc", ref)
+
+	ref, err = resolveRegistryReference(cache, "reg.example.com/ns/repo@sha256:abc")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc", ref)
+
+	_, err = resolveRegistryReference(cache, "reg.example.com/ns/other:v1")
+	assert.Error(t, err)
+
+	_, err = resolveNativePushTag(cache, "sha256:abc")
+	assert.Error(t, err)
+}
+
+func TestListRegistryImages(t *testing.T) {
+	f := newFakeRegistry()
+	t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(24 * time.Hour)
+	f.addImage(t, "amd64", t1, "old")
+	amd := f.addImage(t, "amd64", t2)
+	arm := f.addImage(t, "arm64", t2)
+	amd.Platform = &registry.Platform{OS: "linux", Architecture: "amd64"}
+	arm.Platform = &registry.Platform{OS: "linux", Architecture: "arm64"}
+	attestation := registry.Descriptor{Digest: "sha256:att", Platform: &registry.Platform{OS: "unknown", Architecture: "unknown"}}
+	f.put(t, registry.Manifest{SchemaVersion: 2, MediaType: registry.MediaTypeOCIIndex,
+		Manifests: []registry.Descriptor{amd, arm, attestation}}, "multi")
+	f.manifests["broken"] = &registry.RemoteManifest{Digest: "sha256:broken", Data: []byte(`{"schemaVersion":2,"config":{"digest":"sha256:missing"}}`)}
+
+	images, err := listRegistryImages(context.Background(), f, "ns/repo")
+	require.NoError(t, err)
+	require.Len(t, images, 3)
+
+	assert.Equal(t, "multi", images[0].Tag)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, images[0].Platforms)
+	assert.Equal(t, t2, *images[0].Created)
+	// shared layer counted once: 2 configs + shared + 2 unique layers + index + 2 child manifests
+	wantSize := int64(100+100+1000+500+500) + int64(len(f.manifests["multi"].Data)) + amd.Size + arm.Size
+	assert.Equal(t, wantSize, images[0].Size)
+
+	assert.Equal(t, "old", images[1].Tag)
+	assert.Equal(t, []string{"linux/amd64"}, images[1].Platforms)
+	assert.Empty(t, images[1].Error)
+
+	assert.Equal(t, "broken", images[2].Tag)
+	assert.Contains(t, images[2].Error, "BLOB_UNKNOWN")
+}
+
+func TestInspectRegistryImagePlatform(t *testing.T) {
+	f := newFakeRegistry()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	amd := f.addImage(t, "amd64", created)
+	arm := f.addImage(t, "arm64", created)
+	amd.Platform = &registry.Platform{OS: "linux", Architecture: "amd64"}
+	arm.Platform = &registry.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}
+	f.put(t, registry.Manifest{SchemaVersion: 2, MediaType: registry.MediaTypeOCIIndex,
+		Manifests: []registry.Descriptor{arm, amd}}, "multi")
+	f.addImage(t, "amd64", created.Add(time.Hour), "single")
+
+	res, err := inspectRegistryImage(context.Background(), f, "ns/repo", "multi", "")
+	require.NoError(t, err)
+	assert.Equal(t, "linux/amd64", res.Platform)
+	assert.Equal(t, amd.Digest, res.ManifestDigest)
+	assert.Equal(t, int64(1600), res.Size)
+
+	res, err = inspectRegistryImage(context.Background(), f, "ns/repo", "multi", "linux/arm64")
+	require.NoError(t, err)
+	assert.Equal(t, "linux/arm64/v8", res.Platform)
+	assert.Equal(t, "arm64", res.Config.Architecture)
+
+	_, err = inspectRegistryImage(context.Background(), f, "ns/repo", "multi", "linux/s390x")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: linux/arm64/v8, linux/amd64")
+
+	res, err = inspectRegistryImage(context.Background(), f, "ns/repo", "single", "")
+	require.NoError(t, err)
+	assert.Nil(t, res.Index)
+	assert.Equal(t, "linux/amd64", res.Platform)
+
+	_, err = inspectRegistryImage(context.Background(), f, "ns/repo", "single", "linux/arm64")
+	assert.Error(t, err)
+
+	_, err = inspectRegistryImage(context.Background(), f, "ns/repo", "missing", "")
+	assert.True(t, registry.IsNotFound(err))
+}
+
+func TestVerifySourceImageTag(t *testing.T) {
+	f := newFakeRegistry()
+	f.addImage(t, "amd64", time.Now(), "v1")
+	cache := &acrCredentialCache{RegistryURL: "reg.example.com", Namespace: "customer_cli", RepoName: "123"}
+
+	assert.NoError(t, verifySourceImageTag(context.Background(), f, cache, "v1"))
+	err := verifySourceImageTag(context.Background(), f, cache, "v2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `Tag "v2" does not exist`)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agentbay/agentbay-cli/internal/registry"
+)
+
+// newACRRegistryClient returns a registry client authenticated with the cached ACR credential.
+func newACRRegistryClient(cache *acrCredentialCache) (*registry.Client, error) {
+	if cache.ExpireTime > 0 && time.Now().After(time.UnixMilli(cache.ExpireTime)) {
+		return nil, fmt.Errorf("cached ACR credential expired at %s. Run 'agentbay docker login' again",
+			time.UnixMilli(cache.ExpireTime).Format("2006-01-02 15:04:05"))
+	}
+	return registry.NewClient(cache.RegistryURL, cache.TempUsername, cache.AuthorizationToken), nil
+}
+
+// resolveNativePushTag returns the tag to push to for 'docker push --from'. target is either a bare
+// tag or a full image reference, which must point at the authorized $RegistryUrl/$Namespace/$RepoName.
+func resolveNativePushTag(cache *acrCredentialCache, target string) (string, error) {
+	ref, err := resolveRegistryReference(cache, target)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(ref, "sha256:") {
+		return "", fmt.Errorf("push needs a tag, not a digest: %q", target)
+	}
+	return ref, nil
+}
+
+// pushImageNative pushes an OCI layout, OCI archive or docker save tarball at from to the
+// authorized ACR repository without a docker daemon.
+func pushImageNative(ctx context.Context, cache *acrCredentialCache, from, tag, sourceRef string, mountFrom []string) error {
+	regClient, err := newACRRegistryClient(cache)
+	if err != nil {
+		return err
+	}
+	repo := cache.Namespace + "/" + cache.RepoName
+
+	fmt.Printf("[DOCKER PUSH] Reading %s...\n", from)
+	img, err := registry.LoadImage(from, sourceRef)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+	defer img.Close()
+	if img.Name != "" {
+		fmt.Printf("  Source: %s\n", img.Name)
+	}
+	fmt.Printf("  Target: %s/%s:%s\n", cache.RegistryURL, repo, tag)
+
+	digest, err := regClient.Push(ctx, repo, tag, img, registry.PushOptions{
+		MountFrom: mountFrom,
+		Progress: func(e registry.PushEvent) {
+			short := e.Digest
+			if len(short) > 19 {
+				short = short[:19]
+			}
+			switch e.Action {
+			case registry.ActionExists:
+				fmt.Printf("  %s: Layer already exists (%s)\n", short, formatBytes(e.Size))
+			case registry.ActionMounted:
+				fmt.Printf("  %s: Mounted from %s (%s)\n", short, e.From, formatBytes(e.Size))
+			case registry.ActionUploaded:
+				fmt.Printf("  %s: Pushed (%s)\n", short, formatBytes(e.Size))
+			case registry.ActionManifest:
+				fmt.Printf("  %s: Manifest pushed\n", short)
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: digest: %s\n", tag, digest)
+	return nil
+}
+
+// formatBytes renders a blob size the way docker push progress does (1.5MB, 512B).
+func formatBytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveNativePushTag(t *testing.T) {
+	cache := &acrCredentialCache{RegistryURL: "reg.example.com:5000", Namespace: "ns", RepoName: "repo"}
+
+	cases := []struct {
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{"v1.0", "v1.0", false},
+		{"reg.example.com:5000/ns/repo:v2", "v2", false},
+		{"reg.example.com:5000/ns/repo", "latest", false},
+		{"reg.example.com:5000/ns/other:v2", "", true},
+		{"reg.example.com:5000/ns/repo-x:v2", "", true},
+		{"bad:tag", "", true},
+		{"", "", true},
+	}
+	for _, tc := range cases {
+		got, err := resolveNativePushTag(cache, tc.target)
+		if tc.wantErr {
+			assert.Error(t, err, tc.target)
+			continue
+		}
+		require.NoError(t, err, tc.target)
+		assert.Equal(t, tc.want, got, tc.target)
+	}
+}
+
+func TestNewACRRegistryClientExpired(t *testing.T) {
+	cache := &acrCredentialCache{RegistryURL: "reg.example.com", TempUsername: "u", AuthorizationToken: "p",
+		ExpireTime: time.Now().Add(-time.Minute).UnixMilli()}
+	_, err := newACRRegistryClient(cache)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "docker login")
+
+	cache.ExpireTime = time.Now().Add(time.Hour).UnixMilli()
+	c, err := newACRRegistryClient(cache)
+	require.NoError(t, err)
+	assert.Equal(t, "reg.example.com", c.Registry)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512B", formatBytes(512))
+	assert.Equal(t, "1.5kB", formatBytes(1500))
+	assert.Equal(t, "12.3MB", formatBytes(12_300_000))
+}
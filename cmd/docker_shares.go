@@ -0,0 +1,564 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// docker_shares.go holds the local side of Docker repo sharing: grants with an optional expiry
+// (docker_share_grants.json), an append-only audit log of every grant and revoke
+// (docker_share_audit.jsonl), bulk targets from a UID file, and "agentbay docker shares reconcile",
+// which revokes expired grants through UnshareDockerRepo.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+type shareDockerRepoFunc func(context.Context, *client.ShareDockerRepoRequest) (*client.ShareDockerRepoResponse, error)
+
+type unshareDockerRepoFunc func(context.Context, *client.UnshareDockerRepoRequest) (*client.UnshareDockerRepoResponse, error)
+
+// ---------------------------------------------------------------------------
+// Grants
+// ---------------------------------------------------------------------------
+
+// shareGrantStore records the grants made by "docker share", keyed by shareAccount.grantKey, so
+// grants made with different endpoints or credentials never overwrite or revoke each other.
+type shareGrantStore struct {
+	Grants map[string]*shareGrant `json:"grants"`
+}
+
+type shareGrant struct {
+	Endpoint  string `json:"endpoint,omitempty"`
+	Account   string `json:"account,omitempty"`
+	TargetUID int64  `json:"target_uid"`
+	GrantedAt string `json:"granted_at"`
+	// ExpiresAt is RFC 3339; empty means the grant does not expire.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// expiry returns the parsed expiry and whether the grant has one.
+func (g *shareGrant) expiry() (time.Time, bool) {
+	if g.ExpiresAt == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, g.ExpiresAt)
+	return t, err == nil
+}
+
+// shareAccount is the endpoint and account a grant is made with; see agentbay.CurrentAccount.
+type shareAccount struct {
+	Endpoint string
+	Account  string
+}
+
+func currentShareAccount() shareAccount {
+	endpoint, account := agentbay.CurrentAccount()
+	return shareAccount{Endpoint: endpoint, Account: account}
+}
+
+// grantKey is the key of the grant to uid made by a.
+func (a shareAccount) grantKey(uid int64) string {
+	return a.Endpoint + "|" + a.Account + "|" + strconv.FormatInt(uid, 10)
+}
+
+// owns reports whether g was made by a. Grants recorded before grants carried their account
+// belong to no account.
+func (a shareAccount) owns(g *shareGrant) bool {
+	return g.Endpoint == a.Endpoint && g.Account == a.Account
+}
+
+func shareGrantsFile() (*config.StateFile, error) {
+	return config.NewStateFile("docker_share_grants.json")
+}
+
+func loadShareGrants() (*shareGrantStore, error) {
+	f, err := shareGrantsFile()
+	if err != nil {
+		return nil, err
+	}
+	store := &shareGrantStore{}
+	if err := f.Load(store); err != nil {
+		return nil, err
+	}
+	if store.Grants == nil {
+		store.Grants = map[string]*shareGrant{}
+	}
+	return store, nil
+}
+
+// updateShareGrants applies fn to the recorded grants while holding the lock of the grants file,
+// so that "docker share" and a "shares reconcile" run from cron do not overwrite each other.
+func updateShareGrants(fn func(store *shareGrantStore)) error {
+	f, err := shareGrantsFile()
+	if err != nil {
+		return err
+	}
+	store := &shareGrantStore{}
+	return f.Update(store, func() error {
+		if store.Grants == nil {
+			store.Grants = map[string]*shareGrant{}
+		}
+		fn(store)
+		return nil
+	})
+}
+
+// forAccount returns the grants made by acct.
+func (s *shareGrantStore) forAccount(acct shareAccount) []*shareGrant {
+	var out []*shareGrant
+	for _, g := range s.Grants {
+		if acct.owns(g) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// expired returns the grants of acct whose expiry is at or before now, oldest expiry first.
+func (s *shareGrantStore) expired(acct shareAccount, now time.Time) []*shareGrant {
+	var out []*shareGrant
+	for _, g := range s.forAccount(acct) {
+		if t, ok := g.expiry(); ok && !t.After(now) {
+			out = append(out, g)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ExpiresAt != out[j].ExpiresAt {
+			return out[i].ExpiresAt < out[j].ExpiresAt
+		}
+		return out[i].TargetUID < out[j].TargetUID
+	})
+	return out
+}
+
+// ---------------------------------------------------------------------------
+// Audit log
+// ---------------------------------------------------------------------------
+
+// shareAuditEntry is one line of docker_share_audit.jsonl.
+type shareAuditEntry struct {
+	Time      string `json:"time"`
+	Endpoint  string `json:"endpoint"`
+	Account   string `json:"account"` // AccessKey ID, or "oauth" for the login session
+	Action    string `json:"action"`  // share | unshare
+	TargetUID int64  `json:"target_uid"`
+	RequestID string `json:"request_id,omitempty"`
+	Result    string `json:"result"` // ok | error
+	Error     string `json:"error,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// Trigger is "cli" for share/unshare and "reconcile" for expiry revokes.
+	Trigger string `json:"trigger"`
+}
+
+func shareAuditPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docker_share_audit.jsonl"), nil
+}
+
+// appendShareAudit appends e to the audit log. Failures are reported but never fail the command,
+// since the API call has already happened.
+func appendShareAudit(e shareAuditEntry) {
+	if err := writeShareAudit(e); err != nil {
+		fmt.Printf("[WARN] Failed to write share audit log: %v\n", err)
+	}
+}
+
+func writeShareAudit(e shareAuditEntry) error {
+	p, err := shareAuditPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(p, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// ---------------------------------------------------------------------------
+// Targets and expiry
+// ---------------------------------------------------------------------------
+
+// collectShareTargets merges the positional UID, --target-uid and --uid-file, keeping the first
+// occurrence of each UID.
+func collectShareTargets(args []string, flagUID int64, uidFile string) ([]int64, error) {
+	var uids []int64
+	if len(args) > 0 {
+		parsed, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid target-uid %q: %w", args[0], err)
+		}
+		uids = append(uids, parsed)
+	} else if flagUID != 0 {
+		uids = append(uids, flagUID)
+	}
+	if uidFile != "" {
+		fromFile, err := readUIDFile(uidFile)
+		if err != nil {
+			return nil, err
+		}
+		uids = append(uids, fromFile...)
+	}
+
+	seen := map[int64]bool{}
+	var out []int64
+	for _, uid := range uids {
+		if uid <= 0 {
+			return nil, fmt.Errorf("Invalid target-uid %d: must be a positive integer", uid)
+		}
+		if !seen[uid] {
+			seen[uid] = true
+			out = append(out, uid)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("target-uid is required. Provide it as a positional argument or use --target-uid or --uid-file")
+	}
+	return out, nil
+}
+
+// readUIDFile reads UIDs separated by newlines, commas or spaces; "#" starts a comment. "-" reads
+// standard input.
+func readUIDFile(path string) ([]int64, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read UID file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	var uids []int64
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		for _, field := range strings.FieldsFunc(text, func(c rune) bool { return c == ',' || c == ' ' || c == '\t' }) {
+			uid, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid UID %q", path, line, field)
+			}
+			uids = append(uids, uid)
+		}
+	}
+	return uids, scanner.Err()
+}
+
+// parseShareExpiry turns --expires-in (a Go duration or a number of days such as "30d") or
+// --expires-at (RFC 3339 or YYYY-MM-DD, local midnight) into an expiry; zero means none.
+func parseShareExpiry(expiresIn, expiresAt string, now time.Time) (time.Time, error) {
+	if expiresIn != "" && expiresAt != "" {
+		return time.Time{}, fmt.Errorf("--expires-in and --expires-at are mutually exclusive")
+	}
+	var expiry time.Time
+	switch {
+	case expiresIn != "":
+		var d time.Duration
+		if days, ok := strings.CutSuffix(expiresIn, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid --expires-in %q", expiresIn)
+			}
+			d = time.Duration(n) * 24 * time.Hour
+		} else {
+			var err error
+			if d, err = time.ParseDuration(expiresIn); err != nil {
+				return time.Time{}, fmt.Errorf("invalid --expires-in %q (use e.g. 30d or 12h)", expiresIn)
+			}
+		}
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("--expires-in must be positive")
+		}
+		expiry = now.Add(d)
+	case expiresAt != "":
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			if t, err = time.ParseInLocation("2006-01-02", expiresAt, now.Location()); err != nil {
+				return time.Time{}, fmt.Errorf("invalid --expires-at %q (use RFC 3339 or YYYY-MM-DD)", expiresAt)
+			}
+		}
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("--expires-at %s is in the past", expiresAt)
+		}
+		expiry = t
+	}
+	return expiry.Truncate(time.Second), nil
+}
+
+// ---------------------------------------------------------------------------
+// Grant and revoke
+// ---------------------------------------------------------------------------
+
+// shareDockerRepoTargets shares the repo with each target, recording grants and audit entries. A
+// failure for one target does not stop the others; the number of failures is returned.
+func shareDockerRepoTargets(ctx context.Context, share shareDockerRepoFunc, targets []int64, expiry time.Time) int {
+	var expiresAt string
+	if !expiry.IsZero() {
+		expiresAt = expiry.Format(time.RFC3339)
+		// An expiring grant that cannot be recorded would never be revoked: share nothing.
+		if _, err := loadShareGrants(); err != nil {
+			fmt.Printf("[ERROR] %v; the expiry could not be recorded, so nothing was shared\n", err)
+			return len(targets)
+		}
+	}
+
+	acct := currentShareAccount()
+	failed := 0
+	for i, uid := range targets {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("[STEP %d/%d] Sharing Docker repo with UID %d...\n", i+1, len(targets), uid)
+		data, reqID, err := shareDockerRepoOnce(ctx, share, uid)
+		entry := shareAuditEntry{Time: time.Now().Format(time.RFC3339), Endpoint: acct.Endpoint, Account: acct.Account, Action: "share", TargetUID: uid, RequestID: reqID, Result: "ok", ExpiresAt: expiresAt, Trigger: "cli"}
+		if err != nil {
+			entry.Result, entry.Error = "error", err.Error()
+			appendShareAudit(entry)
+			fmt.Printf("[ERROR] Failed to share Docker repo: %v\n", err)
+			failed++
+			continue
+		}
+		appendShareAudit(entry)
+		// Re-sharing replaces the expiry; sharing without one makes the grant permanent.
+		grant := &shareGrant{Endpoint: acct.Endpoint, Account: acct.Account, TargetUID: uid, GrantedAt: entry.Time, ExpiresAt: expiresAt, RequestID: reqID}
+		if err := updateShareGrants(func(store *shareGrantStore) { store.Grants[acct.grantKey(uid)] = grant }); err != nil {
+			if expiresAt != "" {
+				fmt.Printf("[ERROR] Shared, but the grant could not be recorded: %v. Its expiry will not be enforced; revoke it with 'agentbay docker unshare --target-uid %d'\n", err, uid)
+				failed++
+				continue
+			}
+			fmt.Printf("[WARN] Failed to record share grant: %v\n", err)
+		}
+
+		fmt.Println()
+		fmt.Printf("[SUCCESS] Docker repo shared successfully!\n")
+		if data != nil {
+			if data.TargetAliUid != nil {
+				fmt.Printf("  TargetAliUid : %d\n", *data.TargetAliUid)
+			}
+			if data.OwnerAliUid != nil {
+				fmt.Printf("  OwnerAliUid  : %d\n", *data.OwnerAliUid)
+			}
+			if data.AcrRepoName != nil {
+				fmt.Printf("  AcrRepoName  : %s\n", *data.AcrRepoName)
+			}
+			if data.Status != nil {
+				fmt.Printf("  Status       : %s\n", *data.Status)
+			}
+		}
+		if expiresAt != "" {
+			fmt.Printf("  ExpiresAt    : %s\n", expiry.Local().Format("2006-01-02 15:04:05"))
+		}
+	}
+	return failed
+}
+
+func shareDockerRepoOnce(ctx context.Context, share shareDockerRepoFunc, uid int64) (*client.ShareDockerRepoResponseBodyData, string, error) {
+	resp, err := share(ctx, &client.ShareDockerRepoRequest{TargetAliUid: &uid})
+	if err != nil {
+		reqID := extractRequestIDFromErr(err)
+		if reqID != "" {
+			fmt.Printf("[INFO] ShareDockerRepo Request ID: %s\n", reqID)
+		}
+		return nil, reqID, err
+	}
+	if resp.Body == nil {
+		return nil, "", fmt.Errorf("invalid response: missing body")
+	}
+	reqID := resp.Body.GetRequestId()
+	if reqID != "" {
+		fmt.Printf("[INFO] ShareDockerRepo Request ID: %s\n", reqID)
+	}
+	code := resp.Body.GetCode()
+	successPtr := resp.Body.Success
+	if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
+		return nil, reqID, fmt.Errorf("Code=%s, Message=%s", code, resp.Body.GetMessage())
+	}
+	return resp.Body.Data, reqID, nil
+}
+
+// unshareDockerRepoTargets revokes each target, dropping the current account's grant and
+// recording audit entries. trigger is "cli" or "reconcile". The number of failures is returned.
+func unshareDockerRepoTargets(ctx context.Context, unshare unshareDockerRepoFunc, targets []int64, trigger string) int {
+	grants, err := loadShareGrants()
+	if err != nil {
+		fmt.Printf("[WARN] %v; expiries will be missing from the audit log\n", err)
+	}
+
+	acct := currentShareAccount()
+	failed := 0
+	for i, uid := range targets {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("[STEP %d/%d] Cancelling Docker repo sharing with UID %d...\n", i+1, len(targets), uid)
+		revoked, reqID, err := unshareDockerRepoOnce(ctx, unshare, uid)
+		entry := shareAuditEntry{Time: time.Now().Format(time.RFC3339), Endpoint: acct.Endpoint, Account: acct.Account, Action: "unshare", TargetUID: uid, RequestID: reqID, Result: "ok", Trigger: trigger}
+		if grants != nil {
+			if g, ok := grants.Grants[acct.grantKey(uid)]; ok {
+				entry.ExpiresAt = g.ExpiresAt
+			}
+		}
+		if err != nil {
+			entry.Result, entry.Error = "error", err.Error()
+			appendShareAudit(entry)
+			fmt.Printf("[ERROR] Failed to cancel Docker repo sharing: %v\n", err)
+			failed++
+			continue
+		}
+		appendShareAudit(entry)
+		if err := updateShareGrants(func(store *shareGrantStore) { delete(store.Grants, acct.grantKey(uid)) }); err != nil {
+			fmt.Printf("[WARN] Failed to update share grants: %v\n", err)
+		}
+
+		fmt.Println()
+		fmt.Printf("[SUCCESS] Docker repo sharing cancelled.\n")
+		fmt.Printf("  Revoked : %v\n", revoked)
+	}
+	return failed
+}
+
+func unshareDockerRepoOnce(ctx context.Context, unshare unshareDockerRepoFunc, uid int64) (bool, string, error) {
+	resp, err := unshare(ctx, &client.UnshareDockerRepoRequest{TargetAliUid: &uid})
+	if err != nil {
+		reqID := extractRequestIDFromErr(err)
+		if reqID != "" {
+			fmt.Printf("[INFO] UnshareDockerRepo Request ID: %s\n", reqID)
+		}
+		return false, reqID, err
+	}
+	if resp.Body == nil {
+		return false, "", fmt.Errorf("invalid response: missing body")
+	}
+	reqID := resp.Body.GetRequestId()
+	if reqID != "" {
+		fmt.Printf("[INFO] UnshareDockerRepo Request ID: %s\n", reqID)
+	}
+	code := resp.Body.GetCode()
+	successPtr := resp.Body.Success
+	if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
+		return false, reqID, fmt.Errorf("Code=%s, Message=%s", code, resp.Body.GetMessage())
+	}
+	revoked := false
+	if resp.Body.Data != nil && resp.Body.Data.Revoked != nil {
+		revoked = *resp.Body.Data.Revoked
+	}
+	return revoked, reqID, nil
+}
+
+// ---------------------------------------------------------------------------
+// docker shares reconcile
+// ---------------------------------------------------------------------------
+
+var dockerSharesCmd = &cobra.Command{
+	Use:   "shares",
+	Short: "Manage Docker repo share grants",
+	Long: `Manage the share grants recorded by "agentbay docker share".
+
+Grants made with --expires-in or --expires-at are revoked by "shares reconcile" once
+they expire. Every grant and revoke is appended to the audit log
+docker_share_audit.jsonl in the agentbay config directory.`,
+}
+
+var dockerSharesReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Revoke expired Docker repo share grants",
+	Long: `Revoke every share grant whose expiry has passed, through UnshareDockerRepo.
+
+Grants are recorded locally by "agentbay docker share --expires-in/--expires-at", so run
+this on the machine (or with the config directory) that made them, e.g. from cron:
+  0 * * * * agentbay docker shares reconcile
+
+Only the grants made with the current endpoint and credentials (the AccessKey ID from
+the environment, or the login session) are reconciled; run it once per account.
+Revoked grants are removed from the local record and written to the audit log. A grant
+whose revoke fails is kept and retried on the next run.
+
+Examples:
+  agentbay docker shares reconcile --dry-run
+  agentbay docker shares reconcile`,
+	Args: cobra.NoArgs,
+	RunE: runDockerSharesReconcile,
+}
+
+func init() {
+	dockerSharesReconcileCmd.Flags().Bool("dry-run", false, "List expired grants without revoking them")
+	dockerSharesCmd.AddCommand(dockerSharesReconcileCmd)
+}
+
+func runDockerSharesReconcile(cobraCmd *cobra.Command, args []string) error {
+	dryRun, _ := cobraCmd.Flags().GetBool("dry-run")
+
+	grants, err := loadShareGrants()
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	acct := currentShareAccount()
+	expired := grants.expired(acct, time.Now())
+	if len(expired) == 0 {
+		fmt.Printf("[EMPTY] No expired share grants (%d recorded for this account).\n", len(grants.forAccount(acct)))
+		return nil
+	}
+
+	fmt.Printf("%-20s  %-20s\n", "TargetAliUid", "ExpiredAt")
+	targets := make([]int64, 0, len(expired))
+	for _, g := range expired {
+		t, _ := g.expiry()
+		fmt.Printf("%-20d  %-20s\n", g.TargetUID, t.Local().Format("2006-01-02 15:04:05"))
+		targets = append(targets, g.TargetUID)
+	}
+	fmt.Println()
+	if dryRun {
+		fmt.Printf("[SKIP] Dry run: %d expired grant(s) not revoked.\n", len(expired))
+		return nil
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+
+	failed := unshareDockerRepoTargets(context.Background(), apiClient.UnshareDockerRepo, targets, "reconcile")
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("[ERROR] %d of %d expired grant(s) could not be revoked; they are retried on the next run", failed, len(targets))
+	}
+	fmt.Printf("[OK] Revoked %d expired grant(s).\n", len(targets))
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestCollectShareTargets(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "uids.txt")
+	require.NoError(t, os.WriteFile(file, []byte("# partners\n111, 222\n\n333 # trial\n111\n"), 0600))
+
+	uids, err := collectShareTargets([]string{"999"}, 0, file)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{999, 111, 222, 333}, uids)
+
+	uids, err = collectShareTargets(nil, 42, "")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{42}, uids)
+
+	_, err = collectShareTargets(nil, 0, "")
+	assert.ErrorContains(t, err, "target-uid is required")
+	_, err = collectShareTargets([]string{"abc"}, 0, "")
+	assert.ErrorContains(t, err, "Invalid target-uid")
+
+	require.NoError(t, os.WriteFile(file, []byte("111\nnope\n"), 0600))
+	_, err = collectShareTargets(nil, 0, file)
+	assert.ErrorContains(t, err, ":2: invalid UID")
+}
+
+func TestParseShareExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseShareExpiry("30d", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(30*24*time.Hour), got)
+
+	got, err = parseShareExpiry("12h", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(12*time.Hour), got)
+
+	got, err = parseShareExpiry("", "2026-12-31", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = parseShareExpiry("", "", now)
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	_, err = parseShareExpiry("1d", "2026-12-31", now)
+	assert.ErrorContains(t, err, "mutually exclusive")
+	_, err = parseShareExpiry("", "2026-01-01", now)
+	assert.ErrorContains(t, err, "in the past")
+	_, err = parseShareExpiry("soon", "", now)
+	assert.Error(t, err)
+}
+
+func shareOK(uid int64) *client.ShareDockerRepoResponse {
+	ok, reqID := "ok", "req-share"
+	return &client.ShareDockerRepoResponse{Body: &client.ShareDockerRepoResponseBody{Code: &ok, RequestId: &reqID, Data: &client.ShareDockerRepoResponseBodyData{TargetAliUid: &uid}}}
+}
+
+func readShareAudit(t *testing.T) []shareAuditEntry {
+	p, err := shareAuditPath()
+	require.NoError(t, err)
+	f, err := os.Open(p)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []shareAuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e shareAuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestShareAndReconcileGrants(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	ctx := context.Background()
+
+	share := func(_ context.Context, req *client.ShareDockerRepoRequest) (*client.ShareDockerRepoResponse, error) {
+		if *req.TargetAliUid == 222 {
+			return nil, errors.New("target account not found")
+		}
+		return shareOK(*req.TargetAliUid), nil
+	}
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	failed := shareDockerRepoTargets(ctx, share, []int64{111, 222, 333}, expiry)
+	assert.Equal(t, 1, failed)
+
+	acct := currentShareAccount()
+	grants, err := loadShareGrants()
+	require.NoError(t, err)
+	require.Len(t, grants.Grants, 2)
+	assert.Equal(t, expiry.Format(time.RFC3339), grants.Grants[acct.grantKey(111)].ExpiresAt)
+	assert.Equal(t, "req-share", grants.Grants[acct.grantKey(333)].RequestID)
+	assert.Empty(t, grants.expired(acct, time.Now()))
+
+	audit := readShareAudit(t)
+	require.Len(t, audit, 3)
+	assert.Equal(t, "error", audit[1].Result)
+	assert.Equal(t, "target account not found", audit[1].Error)
+	assert.Equal(t, "cli", audit[0].Trigger)
+	assert.Equal(t, acct.Account, audit[0].Account)
+	assert.Equal(t, acct.Endpoint, audit[0].Endpoint)
+
+	// Once expired, reconcile revokes them; a failed revoke keeps the grant for the next run.
+	expired := grants.expired(acct, expiry.Add(time.Minute))
+	require.Len(t, expired, 2)
+	var revoked []int64
+	unshare := func(_ context.Context, req *client.UnshareDockerRepoRequest) (*client.UnshareDockerRepoResponse, error) {
+		if *req.TargetAliUid == 333 {
+			return nil, errors.New("throttled")
+		}
+		revoked = append(revoked, *req.TargetAliUid)
+		yes := true
+		return &client.UnshareDockerRepoResponse{Body: &client.UnshareDockerRepoResponseBody{Data: &client.UnshareDockerRepoResponseBodyData{Revoked: &yes}}}, nil
+	}
+	failed = unshareDockerRepoTargets(ctx, unshare, []int64{expired[0].TargetUID, expired[1].TargetUID}, "reconcile")
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, []int64{111}, revoked)
+
+	grants, err = loadShareGrants()
+	require.NoError(t, err)
+	assert.Contains(t, grants.Grants, acct.grantKey(333))
+	assert.NotContains(t, grants.Grants, acct.grantKey(111))
+
+	audit = readShareAudit(t)
+	require.Len(t, audit, 5)
+	assert.Equal(t, "unshare", audit[3].Action)
+	assert.Equal(t, "reconcile", audit[3].Trigger)
+	assert.Equal(t, expiry.Format(time.RFC3339), audit[3].ExpiresAt)
+}
+
+func TestShareWithoutExpiryMakesGrantPermanent(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	share := func(_ context.Context, req *client.ShareDockerRepoRequest) (*client.ShareDockerRepoResponse, error) {
+		return shareOK(*req.TargetAliUid), nil
+	}
+	require.Zero(t, shareDockerRepoTargets(context.Background(), share, []int64{111}, time.Now().Add(time.Hour)))
+	require.Zero(t, shareDockerRepoTargets(context.Background(), share, []int64{111}, time.Time{}))
+
+	acct := currentShareAccount()
+	grants, err := loadShareGrants()
+	require.NoError(t, err)
+	assert.Empty(t, grants.Grants[acct.grantKey(111)].ExpiresAt)
+	assert.Empty(t, grants.expired(acct, time.Now().Add(24*time.Hour)))
+}
+
+func TestShareGrantsAreKeptPerAccount(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("AGENTBAY_ACCESS_KEY_SECRET", "secret")
+	share := func(_ context.Context, req *client.ShareDockerRepoRequest) (*client.ShareDockerRepoResponse, error) {
+		return shareOK(*req.TargetAliUid), nil
+	}
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	t.Setenv("AGENTBAY_ACCESS_KEY_ID", "AK-A")
+	a := currentShareAccount()
+	require.Zero(t, shareDockerRepoTargets(context.Background(), share, []int64{111}, expiry))
+	t.Setenv("AGENTBAY_ACCESS_KEY_ID", "AK-B")
+	b := currentShareAccount()
+	require.Zero(t, shareDockerRepoTargets(context.Background(), share, []int64{111}, time.Time{}))
+
+	grants, err := loadShareGrants()
+	require.NoError(t, err)
+	require.Len(t, grants.Grants, 2, "the same UID shared by two accounts is two grants")
+	assert.Equal(t, expiry.Format(time.RFC3339), grants.Grants[a.grantKey(111)].ExpiresAt)
+	assert.Empty(t, grants.Grants[b.grantKey(111)].ExpiresAt)
+
+	later := expiry.Add(time.Minute)
+	assert.Len(t, grants.expired(a, later), 1)
+	assert.Empty(t, grants.expired(b, later), "reconcile only sees the current account's grants")
+
+	audit := readShareAudit(t)
+	require.Len(t, audit, 2)
+	assert.Equal(t, "AK-A", audit[0].Account)
+	assert.Equal(t, "AK-B", audit[1].Account)
+}
@@ -6,6 +6,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 	"github.com/agentbay/agentbay-cli/internal/agentbay"
 	"github.com/agentbay/agentbay-cli/internal/client"
 	"github.com/agentbay/agentbay-cli/internal/config"
+	sdk "github.com/agentbay/agentbay-cli/pkg/agentbay"
 	"github.com/alibabacloud-go/tea/dara"
 )
 
@@ -81,7 +83,10 @@ Examples:
   agentbay image list --os-type Linux
 
   # List images with pagination
-  agentbay image list --page 2 --size 5`,
+  agentbay image list --page 2 --size 5
+
+  # List every user image, streaming JSON as pages arrive
+  agentbay image list --all --output ndjson`,
 	RunE: runImageList,
 }
 
@@ -230,7 +235,9 @@ func init() {
 	imageListCmd.Flags().Bool("system-only", false, "Show only system images")
 	imageListCmd.Flags().IntP("page", "p", 1, "Page number (default: 1)")
 	imageListCmd.Flags().IntP("size", "s", 10, "Page size (default: 10)")
-	imageListCmd.Flags().String("output", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+	imageListCmd.Flags().String("output", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts), or "ndjson" for one object per line`)
+	addListPagingFlags(imageListCmd)
+	addCacheFlags(imageListCmd)
 
 	// Add required flag for image init command - use sourceImageId to match API field name
 	imageInitCmd.Flags().StringP("sourceImageId", "i", "", "Source image ID (required)")
@@ -241,6 +248,15 @@ func init() {
 	// Add flags to image delete command
 	imageDeleteCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt (required in non-interactive mode)")
 
+	// Complete image IDs; only user images can be activated, deactivated or deleted
+	imageActivateCmd.ValidArgsFunction = completeOnlyArg(completionImages, userImagesOnly)
+	imageDeactivateCmd.ValidArgsFunction = completeOnlyArg(completionImages, userImagesOnly)
+	imageDeleteCmd.ValidArgsFunction = completeOnlyArg(completionImages, userImagesOnly)
+	imageStatusCmd.ValidArgsFunction = completeOnlyArg(completionImages, nil)
+
+	// Add flags to image status command
+	addCacheFlags(imageStatusCmd)
+
 	// Add subcommands to image command
 	ImageCmd.AddCommand(imageCreateCmd)
 	ImageCmd.AddCommand(imageListCmd)
@@ -728,6 +744,12 @@ func runImageList(cmd *cobra.Command, args []string) error {
 	page, _ := cmd.Flags().GetInt("page")
 	pageSize, _ := cmd.Flags().GetInt("size")
 	outputFmt, _ := cmd.Flags().GetString("output")
+	paging, err := listPagingFromFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	format := listOutputFormat(outputFmt)
+	paged := paging.walks() || format == "ndjson"
 
 	// Determine what type of images to fetch
 	var fetchMessage string
@@ -738,7 +760,7 @@ func runImageList(cmd *cobra.Command, args []string) error {
 	} else {
 		fetchMessage = "[LIST] Fetching available AgentBay user images...\n"
 	}
-	fmt.Print(fetchMessage)
+	fmt.Fprint(listProgress(paged, format), fetchMessage)
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -751,7 +773,23 @@ func runImageList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	apiClient := agentbay.NewClientFromConfig(cfg)
+	apiClient, err := newCachedClient(cmd, cfg)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	defer func() { printCacheAge(cacheNoteWriter(format), apiClient, time.Now()) }()
+
+	if paged {
+		imageTypes := []string{"User"}
+		if systemOnly {
+			imageTypes = []string{"System"}
+		} else if includeSystem {
+			imageTypes = []string{"User", "System"}
+		}
+		// Each page has its own timeout; --all over many pages has no overall deadline.
+		return listImagesPaged(context.Background(), apiClient, imageTypes, osType, page, pageSize, paging, format)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -1190,8 +1228,8 @@ func uploadFileToOSS(localPath, ossUrl string) error {
 }
 
 // DefaultActivateCPU and DefaultActivateMemory are the default resource allocation when user does not specify --cpu/--memory
-const DefaultActivateCPU = 2
-const DefaultActivateMemory = 4
+const DefaultActivateCPU = sdk.DefaultActivateCPU
+const DefaultActivateMemory = sdk.DefaultActivateMemory
 
 func runImageActivate(cmd *cobra.Command, args []string) error {
 	imageId := args[0]
@@ -1203,24 +1241,15 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 	vpcId, _ := cmd.Flags().GetString("vpc-id")
 	vswitchId, _ := cmd.Flags().GetString("vswitch-id")
 
-	// Parse lifecycle parameters
-	lifecycleMode, _ := cmd.Flags().GetString("lifecycle-mode")
-	lifecycleMaxRuntime, _ := cmd.Flags().GetFloat64("lifecycle-max-runtime")
-	lifecycleHibernate, _ := cmd.Flags().GetFloat64("lifecycle-hibernate")
-	lifecycleIdleTimeout, _ := cmd.Flags().GetFloat64("lifecycle-idle-timeout")
+	// Parse lifecycle parameters; only flags the user set override the saved policy
+	lifecycle := lifecycleOverrides(cmd)
 
 	// Parse region parameter
 	regionId, _ := cmd.Flags().GetString("region-id")
 
-	// Detect which lifecycle flags were explicitly set by the user
-	lifecycleModeSet := cmd.Flags().Changed("lifecycle-mode")
-	lifecycleMaxRuntimeSet := cmd.Flags().Changed("lifecycle-max-runtime")
-	lifecycleHibernateSet := cmd.Flags().Changed("lifecycle-hibernate")
-	lifecycleIdleTimeoutSet := cmd.Flags().Changed("lifecycle-idle-timeout")
-
 	// Validate lifecycle-mode
-	if lifecycleModeSet && lifecycleMode != "auto" && lifecycleMode != "manual" {
-		return fmt.Errorf("[ERROR] Invalid lifecycle-mode: %s. Must be auto or manual", lifecycleMode)
+	if lifecycle.Mode != nil && *lifecycle.Mode != "auto" && *lifecycle.Mode != "manual" {
+		return fmt.Errorf("[ERROR] Invalid lifecycle-mode: %s. Must be auto or manual", *lifecycle.Mode)
 	}
 
 	// Validate network type
@@ -1261,6 +1290,14 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Validate ID formats, DNS addresses, bandwidth and region locally, reporting all problems at once
+	if problems := ValidateNetworkParams(networkType, vpcId, vswitchId, dnsAddresses, sessionBandwidth, regionId); len(problems) > 0 {
+		return networkValidationError("Invalid network parameters:", problems)
+	}
+	if warning := SessionBandwidthWarning(networkType, sessionBandwidth); warning != "" {
+		fmt.Printf("[WARN] %s\n", warning)
+	}
+
 	// Apply default 2c4g when not specified
 	if cpu == 0 && memory == 0 {
 		cpu = DefaultActivateCPU
@@ -1356,203 +1393,35 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot activate image in current state: %s", TranslateImageResourceStatus(imageInfo.ResourceStatus))
 	}
 
-	// Build lifecycle params struct to pass to handlers
-	lifecycleParams := &lifecycleFlags{
-		mode:           lifecycleMode,
-		maxRuntime:     lifecycleMaxRuntime,
-		hibernate:      lifecycleHibernate,
-		idleTimeout:    lifecycleIdleTimeout,
-		modeSet:        lifecycleModeSet,
-		maxRuntimeSet:  lifecycleMaxRuntimeSet,
-		hibernateSet:   lifecycleHibernateSet,
-		idleTimeoutSet: lifecycleIdleTimeoutSet,
-	}
-
-	var appInstanceType string
-	var serverRegionId string
-
-	// Handle network-specific flow - must be done BEFORE CreateResourceGroup
-	// Because CreateResourceGroup will start the image, and SaveMcpPolicyData will fail if image is running
-	var effectiveDnsAddresses []string
-	if networkType == "ADVANCED" && shouldCreateResourceGroup {
-		var err error
-		appInstanceType, err = getAppInstanceType(statusCtx, apiClient, imageId, cpu, memory, 7)
-		if err != nil {
-			return err
-		}
-
-		// DescribeMcpPolicyData + Create/ModifyMcpPolicyData + DescribeOfficeSites + SaveMcpPolicyData BEFORE CreateResourceGroup
-		_, effectiveDnsAddresses, serverRegionId, err = handleAdvancedNetworkActivation(statusCtx, apiClient, imageId, appInstanceType, cpu, memory, sessionBandwidth, dnsAddresses, lifecycleParams, imageInfo.OsName, regionId)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Handle DEFAULT network flow - must be done BEFORE CreateResourceGroup
-	if networkType == "DEFAULT" && shouldCreateResourceGroup {
-		var err error
-		appInstanceType, err = getAppInstanceType(statusCtx, apiClient, imageId, cpu, memory, 6)
-		if err != nil {
-			return err
-		}
-
-		// DescribeMcpPolicyData + Create/ModifyMcpPolicyData + SaveMcpPolicyData BEFORE CreateResourceGroup
-		serverRegionId, err = handleDefaultNetworkActivation(statusCtx, apiClient, imageId, appInstanceType, cpu, memory, lifecycleParams, imageInfo.OsName, regionId)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Handle CUSTOMIZED network flow - must be done BEFORE CreateResourceGroup
-	var effectiveOfficeSiteId string
-	if networkType == "CUSTOMIZED" && shouldCreateResourceGroup {
-		var err error
-		appInstanceType, err = getAppInstanceType(statusCtx, apiClient, imageId, cpu, memory, 8)
-		if err != nil {
-			return err
-		}
-
-		// DescribeMcpPolicyData + Create/ModifyMcpPolicyData + DescribeOfficeSites + (CreateSimpleOfficeSite) + SaveMcpPolicyData
-		serverRegionId, effectiveDnsAddresses, effectiveOfficeSiteId, err = handleCustomizedNetworkActivation(statusCtx, apiClient, imageId, appInstanceType, cpu, memory, vpcId, vswitchId, dnsAddresses, lifecycleParams, imageInfo.OsName, regionId)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Create resource group if needed
+	// Saves the network and lifecycle policy BEFORE CreateResourceGroup, because CreateResourceGroup
+	// starts the image and SaveMcpPolicyData fails once it is running
 	if shouldCreateResourceGroup {
-		// ADVANCED: STEP 1=DescribeInstanceTypes, STEP 2=DescribeMcpPolicyData, STEP 3=Create/ModifyMcpPolicyData, STEP 4=DescribeOfficeSites, STEP 5=SaveMcpPolicyData, STEP 6=CreateResourceGroup, STEP 7=Polling
-		// DEFAULT: STEP 1=DescribeInstanceTypes, STEP 2=DescribeMcpPolicyData, STEP 3=Create/ModifyMcpPolicyData, STEP 4=SaveMcpPolicyData, STEP 5=CreateResourceGroup, STEP 6=Polling
-		if networkType == "ADVANCED" {
-			fmt.Printf("[STEP 6/7] Creating resource group...")
-		} else if networkType == "CUSTOMIZED" {
-			fmt.Printf("[STEP 6/8] Creating resource group...")
-		} else {
-			fmt.Printf("[STEP 5/6] Creating resource group...")
-		}
-		createReq := &client.CreateResourceGroupRequest{
-			ImageId: dara.String(imageId),
-		}
-
-		// Add CPU and Memory if specified
-		if cpu > 0 {
-			log.Debugf("[DEBUG] Setting Cpu to %d", cpu)
-			createReq.SetCpu(int32(cpu))
-			log.Debugf("[DEBUG] After SetCpu, createReq.Cpu = %v", createReq.Cpu)
-		}
-		if memory > 0 {
-			log.Debugf("[DEBUG] Setting Memory to %d", memory)
-			createReq.SetMemory(int32(memory))
-			log.Debugf("[DEBUG] After SetMemory, createReq.Memory = %v", createReq.Memory)
-		}
-
-		// Add network parameters based on network type
-		if networkType == "ADVANCED" {
-			createReq.SetOfficeSiteType("ADVANCED")
-			if sessionBandwidth > 0 {
-				createReq.SetSessionBandwidth(int32(sessionBandwidth))
-			}
-			if appInstanceType != "" {
-				createReq.SetAppInstanceType(appInstanceType)
-			}
-			if len(effectiveDnsAddresses) > 0 {
-				createReq.SetDnsAddress(effectiveDnsAddresses)
-			}
-		} else if networkType == "DEFAULT" {
-			createReq.SetOfficeSiteType("DEFAULT")
-			if appInstanceType != "" {
-				createReq.SetAppInstanceType(appInstanceType)
-			}
-		} else if networkType == "CUSTOMIZED" {
-			createReq.SetOfficeSiteType("CUSTOMIZED")
-			if appInstanceType != "" {
-				createReq.SetAppInstanceType(appInstanceType)
-			}
-			createReq.SetVpcId(vpcId)
-			createReq.SetVSwitchId(vswitchId)
-			if effectiveOfficeSiteId != "" {
-				createReq.SetOfficeSiteId(effectiveOfficeSiteId)
-			}
-			if len(effectiveDnsAddresses) > 0 {
-				createReq.SetDnsAddress(effectiveDnsAddresses)
-			}
-		}
-
-		// Set BizRegionId: user-specified regionId takes priority, otherwise use server default
-		effectiveBizRegionId := regionId
-		if effectiveBizRegionId == "" {
-			effectiveBizRegionId = serverRegionId
-		}
-		if effectiveBizRegionId != "" {
-			createReq.SetBizRegionId(effectiveBizRegionId)
-		}
-
-		// Debug: Print request details
-		if log.GetLevel() >= log.DebugLevel {
-			log.Debugf("[DEBUG] CreateResourceGroup Request:")
-			if createReq.ImageId != nil {
-				log.Debugf("[DEBUG] - ImageId: %s", *createReq.ImageId)
-			}
-			if createReq.Cpu != nil {
-				log.Debugf("[DEBUG] - Cpu: %d", *createReq.Cpu)
-			} else {
-				log.Debugf("[DEBUG] - Cpu: nil")
-			}
-			if createReq.Memory != nil {
-				log.Debugf("[DEBUG] - Memory: %d", *createReq.Memory)
-			} else {
-				log.Debugf("[DEBUG] - Memory: nil")
-			}
-			if createReq.OfficeSiteType != nil {
-				log.Debugf("[DEBUG] - OfficeSiteType: %s", *createReq.OfficeSiteType)
-			}
-			if createReq.SessionBandwidth != nil {
-				log.Debugf("[DEBUG] - SessionBandwidth: %d", *createReq.SessionBandwidth)
-			}
-		}
-
-		// Use a separate context for the create operation
-		createCtx, createCancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer createCancel()
-
-		createResp, err := apiClient.CreateResourceGroup(createCtx, createReq)
+		activateCtx, activateCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer activateCancel()
+
+		_, err := sdk.StartActivation(activateCtx, apiClient, &sdk.ImageInfo{
+			ImageId:   imageId,
+			Status:    ImageResourceStatus(imageInfo.ResourceStatus),
+			ImageType: imageInfo.ImageType,
+			OsName:    imageInfo.OsName,
+		}, sdk.ActivateOptions{
+			CPU:              cpu,
+			Memory:           memory,
+			NetworkType:      sdk.NetworkType(networkType),
+			SessionBandwidth: sessionBandwidth,
+			DNSAddresses:     dnsAddresses,
+			VpcId:            vpcId,
+			VSwitchId:        vswitchId,
+			RegionId:         regionId,
+			Lifecycle:        lifecycle,
+			OnProgress:       printActivationProgress,
+		})
 		if err != nil {
-			fmt.Printf(" Failed.\n")
-			return fmt.Errorf("failed to create resource group: %w", err)
-		}
-
-		// Check response
-		if createResp.Body == nil {
-			fmt.Printf(" Failed.\n")
-			return fmt.Errorf("invalid response from server")
-		}
-
-		success := createResp.Body.GetSuccess()
-		if success == nil || !*success {
-			fmt.Printf(" Failed.\n")
-			code := createResp.Body.GetCode()
-			message := createResp.Body.GetMessage()
-			if createResp.Body.GetRequestId() != nil {
-				if code != nil && message != nil {
-					return fmt.Errorf("failed to create resource group: %s - %s (Request ID: %s)", *code, *message, *createResp.Body.GetRequestId())
-				}
-				return fmt.Errorf("failed to create resource group (Request ID: %s)", *createResp.Body.GetRequestId())
-			}
-			if code != nil && message != nil {
-				return fmt.Errorf("failed to create resource group: %s - %s", *code, *message)
-			}
-			return fmt.Errorf("failed to create resource group")
-		}
-
-		// Log Request ID for debugging
-		if createResp.Body.GetRequestId() != nil {
-			fmt.Printf(" Done. (Action: CreateResourceGroup, Request ID: %s)\n", *createResp.Body.GetRequestId())
-		} else {
-			fmt.Printf(" Done. (Action: CreateResourceGroup)\n")
+			return activationError(err, regionId)
 		}
 	}
 
-	// Poll for activation completion (STEP 7/7 for ADVANCED, STEP 6/6 for DEFAULT)
+	// Poll for activation completion (last step)
 	fmt.Printf("Waiting for activation to complete...\n")
 	pollingCtx := context.Background() // Don't use timeout context, polling has its own timeout
 	pollingConfig := DefaultActivatePollingConfig()
@@ -1895,7 +1764,7 @@ func runImageInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runImageStatus(_ *cobra.Command, args []string) error {
+func runImageStatus(cmd *cobra.Command, args []string) error {
 	imageId := args[0]
 
 	cfg, err := config.GetConfig()
@@ -1906,7 +1775,10 @@ func runImageStatus(_ *cobra.Command, args []string) error {
 		return config.ErrNotAuthenticated()
 	}
 
-	apiClient := agentbay.NewClientFromConfig(cfg)
+	apiClient, err := newCachedClient(cmd, cfg)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
@@ -1930,6 +1802,7 @@ func runImageStatus(_ *cobra.Command, args []string) error {
 	if IsSystemImage(imageInfo.ImageType) {
 		fmt.Printf("[NOTE] System images do not use activate/deactivate; status is informational.\n")
 	}
+	printCacheAge(os.Stdout, apiClient, time.Now())
 
 	return nil
 }
@@ -2016,693 +1889,77 @@ func isDockerfileValidationError(taskMsg string) bool {
 	return strings.Contains(taskMsg, validationErrorMsg)
 }
 
-// lifecycleFlags holds parsed lifecycle CLI flag values and their "changed" status.
-type lifecycleFlags struct {
-	mode           string
-	maxRuntime     float64
-	hibernate      float64
-	idleTimeout    float64
-	modeSet        bool
-	maxRuntimeSet  bool
-	hibernateSet   bool
-	idleTimeoutSet bool
-}
-
-// mergeSandboxLifeCycle merges user-specified lifecycle params with existing SandboxLifeCycle from DescribeMcpPolicyData.
-func mergeSandboxLifeCycle(existing *client.SandboxLifeCycle, lf *lifecycleFlags) *client.SandboxLifeCycle {
-	result := &client.SandboxLifeCycle{}
-
-	// 1. Copy existing values as base
-	if existing != nil {
-		result.Mode = existing.Mode
-		result.HibernateTimeout = existing.HibernateTimeout
-		result.DesktopMaxRuntime = existing.DesktopMaxRuntime
-		result.UserIdleTimeout = existing.UserIdleTimeout
-		result.IdleTimeoutSwitch = existing.IdleTimeoutSwitch
-	}
-
-	// 2. User-specified values override
-	if lf.modeSet {
-		result.Mode = dara.String(lf.mode)
-	}
-	if lf.maxRuntimeSet {
-		result.DesktopMaxRuntime = dara.Float64(lf.maxRuntime)
-	}
-	if lf.hibernateSet {
-		result.HibernateTimeout = dara.Float64(lf.hibernate)
-	}
-	if lf.idleTimeoutSet {
-		result.UserIdleTimeout = dara.Float64(lf.idleTimeout)
-	}
-
-	// 3. IdleTimeoutSwitch linked to UserIdleTimeout
-	// If UserIdleTimeout has a value (including 0) -> true, otherwise false
-	if result.UserIdleTimeout != nil {
-		result.IdleTimeoutSwitch = dara.Bool(true)
-	} else {
-		result.IdleTimeoutSwitch = dara.Bool(false)
-	}
-
-	return result
-}
-
-// handlePolicyDataCreateOrModify calls CreateMcpPolicyData or ModifyMcpPolicyData
-// based on IsDefaultData from DescribeMcpPolicyData response.
-// Returns the edsPolicyId from CreateMcpPolicyData response (empty string for Modify path).
-func handlePolicyDataCreateOrModify(
-	ctx context.Context,
-	apiClient agentbay.Client,
-	imageId string,
-	policyData *client.DescribeMcpPolicyDataResponseBodyData,
-	mergedSandboxLifeCycle *client.SandboxLifeCycle,
-	osName string,
-	regionId string,
-	stepNum, totalSteps int,
-) (string, error) {
-	isDefaultData := policyData.IsDefaultData != nil && *policyData.IsDefaultData
-
-	actionName := "ModifyMcpPolicyData"
-	if isDefaultData {
-		actionName = "CreateMcpPolicyData"
-	}
-
-	fmt.Printf("[STEP %d/%d] %s...", stepNum, totalSteps, actionName)
-
-	req := &client.CreateModifyMcpPolicyDataRequest{
-		ImageId:               dara.String(imageId),
-		SandboxLifeCycle:      mergedSandboxLifeCycle,
-		NetworkConfig:         policyData.NetworkConfig,
-		DisplayConfig:         policyData.DisplayConfig,
-		BusinessType:          dara.Int32(1),
-		ResourceType:          dara.String("AIAgent"),
-		DisconnectKeepSession: dara.String("persistent"),
-		Name:                  dara.String(fmt.Sprintf("policy-agentbay-cli-%s", time.Now().Format("20060102-15:04:05"))),
-	}
-
-	// ScreenSettings flattened
-	if policyData.ScreenSettings != nil {
-		req.Taskbar = policyData.ScreenSettings.Taskbar
-		req.ScreenDisplayMode = policyData.ScreenSettings.ScreenDisplayMode
-		req.ClientControlMenu = policyData.ScreenSettings.ClientControlMenu
-	}
-
-	// RegionName from user-specified regionId or GroupSpec.RegionId
-	if regionId != "" {
-		req.RegionName = dara.String(regionId)
-	} else if policyData.GroupSpec != nil {
-		req.RegionName = policyData.GroupSpec.RegionId
-	}
-
-	// OsName-dependent fields
-	if osName == "Android" {
-		req.InternetCommunicationProtocol = dara.String("rtc")
-		req.ResolutionWidth = dara.Int32(1080)
-		req.ResolutionHeight = dara.Int32(1920)
-	} else {
-		req.InternetCommunicationProtocol = dara.String("auto")
-	}
-
-	if isDefaultData {
-		resp, err := apiClient.CreateMcpPolicyData(ctx, req)
-		if err != nil {
-			fmt.Printf(" Failed.\n")
-			return "", fmt.Errorf("failed to create policy data: %w", err)
-		}
-		if resp.Body != nil && resp.Body.GetRequestId() != nil {
-			fmt.Printf(" Done. (Action: CreateMcpPolicyData, Request ID: %s)\n", *resp.Body.GetRequestId())
-		} else {
-			fmt.Printf(" Done. (Action: CreateMcpPolicyData)\n")
-		}
-		// Extract edsPolicyId from the response PolicyId field
-		var edsPolicyId string
-		if resp.Body != nil && resp.Body.GetPolicyId() != nil {
-			edsPolicyId = *resp.Body.GetPolicyId()
-		}
-		return edsPolicyId, nil
-	} else {
-		resp, err := apiClient.ModifyMcpPolicyData(ctx, req)
-		if err != nil {
-			fmt.Printf(" Failed.\n")
-			return "", fmt.Errorf("failed to modify policy data: %w", err)
-		}
-		if resp.Body != nil && resp.Body.GetRequestId() != nil {
-			fmt.Printf(" Done. (Action: ModifyMcpPolicyData, Request ID: %s)\n", *resp.Body.GetRequestId())
-		} else {
-			fmt.Printf(" Done. (Action: ModifyMcpPolicyData)\n")
-		}
-		return "", nil
-	}
-}
-
-// getAppInstanceType queries DescribeInstanceTypes to get AppInstanceType for given cpu and memory
-func getAppInstanceType(ctx context.Context, apiClient agentbay.Client, imageId string, cpu, memory int, totalSteps int) (string, error) {
-	fmt.Printf("[STEP 1/%d] Querying instance types...", totalSteps)
-	instanceTypesReq := &client.DescribeInstanceTypesRequest{
-		ImageId: dara.String(imageId),
-	}
-	instanceTypesResp, err := apiClient.DescribeInstanceTypes(ctx, instanceTypesReq)
-	if err != nil {
-		fmt.Printf(" Failed.\n")
-		return "", fmt.Errorf("failed to query instance types: %w", err)
-	}
-	// Log Request ID for debugging
-	if instanceTypesResp.Body != nil && instanceTypesResp.Body.GetRequestId() != nil {
-		fmt.Printf(" Done. (Action: DescribeInstanceTypes, Request ID: %s)\n", *instanceTypesResp.Body.GetRequestId())
-	} else {
-		fmt.Printf(" Done. (Action: DescribeInstanceTypes)\n")
+// lifecycleOverrides returns the lifecycle overrides for the --lifecycle-* flags the user set;
+// sdk.Lifecycle.Merge applies them to the saved policy.
+func lifecycleOverrides(cmd *cobra.Command) sdk.Lifecycle {
+	var l sdk.Lifecycle
+	if cmd.Flags().Changed("lifecycle-mode") {
+		mode, _ := cmd.Flags().GetString("lifecycle-mode")
+		l.Mode = dara.String(mode)
 	}
-
-	// Find matching instance type
-	var matchedInstanceType *client.DescribeInstanceTypesResponseBodyDataInstanceType
-	if instanceTypesResp.Body != nil && instanceTypesResp.Body.Data != nil {
-		for _, item := range instanceTypesResp.Body.Data {
-			if item.Cpu != nil && item.Memory != nil && *item.Cpu == int32(cpu) && *item.Memory == int32(memory) {
-				matchedInstanceType = item
-				break
-			}
-		}
+	if cmd.Flags().Changed("lifecycle-max-runtime") {
+		maxRuntime, _ := cmd.Flags().GetFloat64("lifecycle-max-runtime")
+		l.MaxRuntime = dara.Float64(maxRuntime)
 	}
-
-	if matchedInstanceType == nil {
-		// Build list of available options
-		var availableOptions []string
-		if instanceTypesResp.Body != nil && instanceTypesResp.Body.Data != nil {
-			for _, item := range instanceTypesResp.Body.Data {
-				if item.Cpu != nil && item.Memory != nil {
-					opt := fmt.Sprintf("%dc%dg", *item.Cpu, *item.Memory)
-					availableOptions = append(availableOptions, opt)
-				}
-			}
-		}
-		errMsg := fmt.Sprintf("[ERROR] No matching instance type for %dc%dg.\n[TIP] Available options: %s", cpu, memory, strings.Join(availableOptions, ", "))
-		return "", fmt.Errorf("%s", errMsg)
+	if cmd.Flags().Changed("lifecycle-hibernate") {
+		hibernate, _ := cmd.Flags().GetFloat64("lifecycle-hibernate")
+		l.Hibernate = dara.Float64(hibernate)
 	}
-
-	var appInstanceType string
-	if matchedInstanceType.AppInstanceType != nil {
-		appInstanceType = *matchedInstanceType.AppInstanceType
-		fmt.Printf("[INFO] Matched instance type: %s\n", appInstanceType)
+	if cmd.Flags().Changed("lifecycle-idle-timeout") {
+		idleTimeout, _ := cmd.Flags().GetFloat64("lifecycle-idle-timeout")
+		l.IdleTimeout = dara.Float64(idleTimeout)
 	}
-
-	return appInstanceType, nil
+	return l
 }
 
-// handleAdvancedNetworkActivation handles the advanced network activation flow
-// Flow: DescribeMcpPolicyData -> Create/ModifyMcpPolicyData -> DescribeOfficeSites -> SaveMcpPolicyData
-// Returns: policyId, effectiveDnsAddresses (default from DescribeOfficeSites if user not specified), serverRegionId, error
-func handleAdvancedNetworkActivation(ctx context.Context, apiClient agentbay.Client, imageId, appInstanceType string, cpu, memory, sessionBandwidth int, dnsAddresses []string, lf *lifecycleFlags, osName string, regionId string) (string, []string, string, error) {
-	// Step 1: DescribeMcpPolicyData - Get policy data
-	fmt.Printf("[STEP 2/7] Fetching policy data...")
-	policyReq := &client.DescribeMcpPolicyDataRequest{
-		ImageId: dara.String(imageId),
-	}
-	policyResp, err := apiClient.DescribeMcpPolicyData(ctx, policyReq)
-	if err != nil {
-		fmt.Printf(" Failed.\n")
-		return "", nil, "", fmt.Errorf("failed to fetch policy data: %w", err)
-	}
-	// Log Request ID for debugging
-	if policyResp.Body != nil && policyResp.Body.GetRequestId() != nil {
-		fmt.Printf(" Done. (Action: DescribeMcpPolicyData, Request ID: %s)\n", *policyResp.Body.GetRequestId())
-	} else {
-		fmt.Printf(" Done. (Action: DescribeMcpPolicyData)\n")
-	}
-
-	var policyId string
-	if policyResp.Body != nil && policyResp.Body.Data != nil && policyResp.Body.Data.PolicyId != nil {
-		policyId = *policyResp.Body.Data.PolicyId
-	}
-
-	// Get RegionName from user-specified regionId or GroupSpec.RegionId for DescribeOfficeSites
-	var serverRegionId string
-	var regionName string
-	if policyResp.Body != nil && policyResp.Body.Data != nil && policyResp.Body.Data.GroupSpec != nil && policyResp.Body.Data.GroupSpec.RegionId != nil {
-		serverRegionId = *policyResp.Body.Data.GroupSpec.RegionId
-	}
-	if regionId != "" {
-		regionName = regionId
-	} else {
-		regionName = serverRegionId
-	}
-
-	// Merge SandboxLifeCycle
-	var existingSandboxLifeCycle *client.SandboxLifeCycle
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		existingSandboxLifeCycle = policyResp.Body.Data.SandboxLifeCycle
-	}
-	mergedSandboxLifeCycle := mergeSandboxLifeCycle(existingSandboxLifeCycle, lf)
-
-	var createdEdsPolicyId string
-	// Step 2: Create/ModifyMcpPolicyData
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		edsPolicyId, err := handlePolicyDataCreateOrModify(ctx, apiClient, imageId, policyResp.Body.Data, mergedSandboxLifeCycle, osName, regionId, 3, 7)
-		if err != nil {
-			return "", nil, "", err
-		}
-		// Store edsPolicyId for use in SaveMcpPolicyData
-		createdEdsPolicyId = edsPolicyId
-	}
-
-	// Step 3: DescribeOfficeSites - Query office network to get default DNS addresses
-	var defaultDnsAddresses []string
-	fmt.Printf("[STEP 4/7] Querying office network...")
-	if regionName != "" {
-		officeSiteReq := &client.DescribeOfficeSitesRequest{
-			OfficeSiteType: dara.String("ADVANCED"),
-			RegionName:     dara.String(regionName),
-		}
-		officeSiteResp, err := apiClient.DescribeOfficeSites(ctx, officeSiteReq)
-		if err != nil {
-			fmt.Printf(" Failed.\n")
-			return "", nil, "", fmt.Errorf("failed to query office network: %w", err)
-		}
-		if officeSiteResp.Body != nil && officeSiteResp.Body.GetRequestId() != nil {
-			fmt.Printf(" Done. (Action: DescribeOfficeSites, Request ID: %s)\n", *officeSiteResp.Body.GetRequestId())
+// printActivationProgress prints the steps reported by sdk.StartActivation as "[STEP n/m] Title... Done." lines.
+func printActivationProgress(e sdk.ProgressEvent) {
+	switch e.State {
+	case sdk.StepStarted:
+		if e.Step == 0 {
+			fmt.Printf("[PRE-FLIGHT] %s...", e.Title)
 		} else {
-			fmt.Printf(" Done. (Action: DescribeOfficeSites)\n")
-		}
-		if officeSiteResp.Body != nil && officeSiteResp.Body.Data != nil {
-			defaultDnsAddresses = officeSiteResp.Body.Data.DnsAddress
-			if len(defaultDnsAddresses) > 0 {
-				fmt.Printf("[INFO] Office network default DNS: %s\n", strings.Join(defaultDnsAddresses, ", "))
-			}
-		}
-	} else {
-		fmt.Printf(" Skipped. (RegionName not available)\n")
-	}
-
-	// Determine effective DNS addresses: user-specified takes priority, otherwise use default from DescribeOfficeSites
-	effectiveDnsAddresses := dnsAddresses
-	if len(effectiveDnsAddresses) == 0 && len(defaultDnsAddresses) > 0 {
-		effectiveDnsAddresses = defaultDnsAddresses
-		fmt.Printf("[INFO] Using default DNS addresses from office network: %s\n", strings.Join(effectiveDnsAddresses, ", "))
-	}
-
-	// Step 4: SaveMcpPolicyData - Save updated policy data
-	fmt.Printf("[STEP 5/7] Saving policy configuration...")
-	saveReq := &client.SaveMcpPolicyDataRequest{}
-
-	// Copy existing data
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		data := policyResp.Body.Data
-
-		// Set ImageId and PolicyId
-		saveReq.ImageId = dara.String(imageId)
-		if createdEdsPolicyId != "" {
-			saveReq.PolicyId = dara.String(createdEdsPolicyId)
-		} else if data.PolicyId != nil {
-			saveReq.PolicyId = data.PolicyId
-		}
-
-		// Copy GroupSpec and update with new values
-		if data.GroupSpec != nil {
-			saveReq.GroupSpec = &client.GroupSpec{
-				AppInstanceType: dara.String(appInstanceType),
-				RegionName:      data.GroupSpec.RegionName,
-				Memory:          dara.Int32(int32(memory)),
-				Cpu:             dara.Int32(int32(cpu)),
-				RegionId:        data.GroupSpec.RegionId,
-			}
-			if regionId != "" {
-				saveReq.GroupSpec.RegionName = dara.String(regionId)
-				saveReq.GroupSpec.RegionId = dara.String(regionId)
-			}
-		}
-
-		// Copy other sections - use merged SandboxLifeCycle
-		saveReq.SandboxLifeCycle = mergedSandboxLifeCycle
-		saveReq.ScreenSettings = data.ScreenSettings
-		saveReq.NetworkConfig = data.NetworkConfig
-		saveReq.DisplayConfig = data.DisplayConfig
-		if regionId != "" {
-			saveReq.RegionId = dara.String(regionId)
-		} else if data.GroupSpec != nil && data.GroupSpec.RegionId != nil {
-			saveReq.RegionId = data.GroupSpec.RegionId
-		}
-
-		// Update NetworkData with advanced network settings
-		saveReq.NetworkData = &client.NetworkData{
-			OfficeSiteType: dara.String("ADVANCED"),
-		}
-		if sessionBandwidth > 0 {
-			saveReq.NetworkData.SessionBandwidth = dara.Int32(int32(sessionBandwidth))
-		}
-		if data.NetworkData != nil {
-			saveReq.NetworkData.VpcId = data.NetworkData.VpcId
-			saveReq.NetworkData.VpcName = data.NetworkData.VpcName
-		}
-		if len(effectiveDnsAddresses) > 0 {
-			// Convert DNS addresses array to comma-separated string
-			saveReq.NetworkData.DnsAddress = dara.String(strings.Join(effectiveDnsAddresses, ","))
-		}
-	} else {
-		fmt.Printf(" Failed.\n")
-		return "", nil, "", fmt.Errorf("invalid policy data response")
-	}
-
-	saveResp, err := apiClient.SaveMcpPolicyData(ctx, saveReq)
-	if err != nil {
+			fmt.Printf("[STEP %d/%d] %s...", e.Step, e.Steps, e.Title)
+		}
+	case sdk.StepDone:
+		switch {
+		case e.Action != "" && e.RequestId != "":
+			fmt.Printf(" Done. (Action: %s, Request ID: %s)\n", e.Action, e.RequestId)
+		case e.Action != "":
+			fmt.Printf(" Done. (Action: %s)\n", e.Action)
+		default:
+			fmt.Printf(" Done.\n")
+		}
+	case sdk.StepFailed:
 		fmt.Printf(" Failed.\n")
-		return "", nil, "", fmt.Errorf("failed to save policy data: %w", err)
-	}
-	// Log Request ID for debugging
-	if saveResp.Body != nil && saveResp.Body.GetRequestId() != nil {
-		fmt.Printf(" Done. (Action: SaveMcpPolicyData, Request ID: %s)\n", *saveResp.Body.GetRequestId())
-	} else {
-		fmt.Printf(" Done. (Action: SaveMcpPolicyData)\n")
-	}
-
-	return policyId, effectiveDnsAddresses, serverRegionId, nil
-}
-
-// handleDefaultNetworkActivation handles the DEFAULT network activation flow
-// Flow: DescribeMcpPolicyData -> Create/ModifyMcpPolicyData -> SaveMcpPolicyData
-// Returns: serverRegionId, error
-func handleDefaultNetworkActivation(ctx context.Context, apiClient agentbay.Client, imageId, appInstanceType string, cpu, memory int, lf *lifecycleFlags, osName string, regionId string) (string, error) {
-	// Step 1: DescribeMcpPolicyData - Get policy data
-	fmt.Printf("[STEP 2/6] Fetching policy data...")
-	policyReq := &client.DescribeMcpPolicyDataRequest{
-		ImageId: dara.String(imageId),
-	}
-	policyResp, err := apiClient.DescribeMcpPolicyData(ctx, policyReq)
-	if err != nil {
-		fmt.Printf(" Failed.\n")
-		return "", fmt.Errorf("failed to fetch policy data: %w", err)
-	}
-	// Log Request ID for debugging
-	if policyResp.Body != nil && policyResp.Body.GetRequestId() != nil {
-		fmt.Printf(" Done. (Action: DescribeMcpPolicyData, Request ID: %s)\n", *policyResp.Body.GetRequestId())
-	} else {
-		fmt.Printf(" Done. (Action: DescribeMcpPolicyData)\n")
-	}
-
-	// Extract serverRegionId from GroupSpec
-	var serverRegionId string
-	if policyResp.Body != nil && policyResp.Body.Data != nil && policyResp.Body.Data.GroupSpec != nil && policyResp.Body.Data.GroupSpec.RegionId != nil {
-		serverRegionId = *policyResp.Body.Data.GroupSpec.RegionId
-	}
-
-	// Merge SandboxLifeCycle
-	var existingSandboxLifeCycle *client.SandboxLifeCycle
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		existingSandboxLifeCycle = policyResp.Body.Data.SandboxLifeCycle
-	}
-	mergedSandboxLifeCycle := mergeSandboxLifeCycle(existingSandboxLifeCycle, lf)
-
-	// Step 2: Create/ModifyMcpPolicyData
-	var createdEdsPolicyId string
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		edsPolicyId, err := handlePolicyDataCreateOrModify(ctx, apiClient, imageId, policyResp.Body.Data, mergedSandboxLifeCycle, osName, regionId, 3, 6)
-		if err != nil {
-			return "", err
-		}
-		createdEdsPolicyId = edsPolicyId
-	}
-
-	// Step 3: SaveMcpPolicyData - Save updated policy data with DEFAULT network settings
-	fmt.Printf("[STEP 4/6] Saving policy configuration...")
-	saveReq := &client.SaveMcpPolicyDataRequest{}
-
-	// Copy existing data
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		data := policyResp.Body.Data
-
-		// Set ImageId and PolicyId
-		saveReq.ImageId = dara.String(imageId)
-		if createdEdsPolicyId != "" {
-			saveReq.PolicyId = dara.String(createdEdsPolicyId)
-		} else if data.PolicyId != nil {
-			saveReq.PolicyId = data.PolicyId
+		if e.RequestId != "" {
+			fmt.Printf("[INFO] %s Request ID: %s\n", e.Action, e.RequestId)
 		}
-
-		// Copy GroupSpec and update with new values
-		if data.GroupSpec != nil {
-			saveReq.GroupSpec = &client.GroupSpec{
-				AppInstanceType: dara.String(appInstanceType),
-				RegionName:      data.GroupSpec.RegionName,
-				Memory:          dara.Int32(int32(memory)),
-				Cpu:             dara.Int32(int32(cpu)),
-				RegionId:        data.GroupSpec.RegionId,
-			}
-			if regionId != "" {
-				saveReq.GroupSpec.RegionName = dara.String(regionId)
-				saveReq.GroupSpec.RegionId = dara.String(regionId)
-			}
-		}
-
-		// Copy other sections - use merged SandboxLifeCycle
-		saveReq.SandboxLifeCycle = mergedSandboxLifeCycle
-		saveReq.ScreenSettings = data.ScreenSettings
-		saveReq.NetworkConfig = data.NetworkConfig
-		saveReq.DisplayConfig = data.DisplayConfig
-		if regionId != "" {
-			saveReq.RegionId = dara.String(regionId)
-		} else if data.GroupSpec != nil && data.GroupSpec.RegionId != nil {
-			saveReq.RegionId = data.GroupSpec.RegionId
-		}
-
-		// Update NetworkData with DEFAULT network settings
-		// For DEFAULT network, set VpcId, DnsAddress, VpcName to empty strings
-		saveReq.NetworkData = &client.NetworkData{
-			OfficeSiteType: dara.String("DEFAULT"),
-			VpcId:          dara.String(""),
-			DnsAddress:     dara.String(""),
-			VpcName:        dara.String(""),
-		}
-	} else {
-		fmt.Printf(" Failed.\n")
-		return "", fmt.Errorf("invalid policy data response")
+	case sdk.StepSkipped:
+		fmt.Printf(" Skipped. (%s)\n", e.Message)
+	case sdk.StepNote:
+		fmt.Printf("[INFO] %s\n", e.Message)
 	}
-
-	saveResp, err := apiClient.SaveMcpPolicyData(ctx, saveReq)
-	if err != nil {
-		fmt.Printf(" Failed.\n")
-		return "", fmt.Errorf("failed to save policy data: %w", err)
-	}
-	// Log Request ID for debugging
-	if saveResp.Body != nil && saveResp.Body.GetRequestId() != nil {
-		fmt.Printf(" Done. (Action: SaveMcpPolicyData, Request ID: %s)\n", *saveResp.Body.GetRequestId())
-	} else {
-		fmt.Printf(" Done. (Action: SaveMcpPolicyData)\n")
-	}
-
-	return serverRegionId, nil
 }
 
-// handleCustomizedNetworkActivation handles the CUSTOMIZED network activation flow
-// Flow: DescribeMcpPolicyData -> Create/ModifyMcpPolicyData -> DescribeOfficeSites -> (CreateSimpleOfficeSite) -> SaveMcpPolicyData
-// Returns: serverRegionId, effectiveDnsAddresses, effectiveOfficeSiteId, error
-func handleCustomizedNetworkActivation(ctx context.Context, apiClient agentbay.Client, imageId, appInstanceType string, cpu, memory int, vpcId, vswitchId string, dnsAddresses []string, lf *lifecycleFlags, osName string, regionId string) (string, []string, string, error) {
-	// STEP 2/8: DescribeMcpPolicyData
-	fmt.Printf("[STEP 2/8] Fetching policy data...")
-	policyReq := &client.DescribeMcpPolicyDataRequest{
-		ImageId: dara.String(imageId),
-	}
-	policyResp, err := apiClient.DescribeMcpPolicyData(ctx, policyReq)
-	if err != nil {
-		fmt.Printf(" Failed.\n")
-		return "", nil, "", fmt.Errorf("failed to fetch policy data: %w", err)
-	}
-	if policyResp.Body != nil && policyResp.Body.GetRequestId() != nil {
-		fmt.Printf(" Done. (Action: DescribeMcpPolicyData, Request ID: %s)\n", *policyResp.Body.GetRequestId())
-	} else {
-		fmt.Printf(" Done. (Action: DescribeMcpPolicyData)\n")
-	}
-
-	var serverRegionId string
-	var regionName string
-	if policyResp.Body != nil && policyResp.Body.Data != nil && policyResp.Body.Data.GroupSpec != nil && policyResp.Body.Data.GroupSpec.RegionId != nil {
-		serverRegionId = *policyResp.Body.Data.GroupSpec.RegionId
-	}
-	if regionId != "" {
-		regionName = regionId
-	} else {
-		regionName = serverRegionId
-	}
-
-	// Merge SandboxLifeCycle
-	var existingSandboxLifeCycle *client.SandboxLifeCycle
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		existingSandboxLifeCycle = policyResp.Body.Data.SandboxLifeCycle
-	}
-	mergedSandboxLifeCycle := mergeSandboxLifeCycle(existingSandboxLifeCycle, lf)
-
-	// STEP 3/8: Create/ModifyMcpPolicyData
-	var createdEdsPolicyId string
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		edsPolicyId, err := handlePolicyDataCreateOrModify(ctx, apiClient, imageId, policyResp.Body.Data, mergedSandboxLifeCycle, osName, regionId, 3, 8)
-		if err != nil {
-			return "", nil, "", err
-		}
-		createdEdsPolicyId = edsPolicyId
-	}
-
-	// STEP 4/8: DescribeOfficeSites with CUSTOMIZED type and VpcId
-	var defaultDnsAddresses []string
-	var officeSiteId string
-	fmt.Printf("[STEP 4/8] Querying customized office network...")
-	if regionName != "" {
-		officeSiteReq := &client.DescribeOfficeSitesRequest{
-			OfficeSiteType: dara.String("CUSTOMIZED"),
-			RegionName:     dara.String(regionName),
-			VpcId:          dara.String(vpcId),
-		}
-		officeSiteResp, err := apiClient.DescribeOfficeSites(ctx, officeSiteReq)
-		if err != nil {
-			fmt.Printf(" Failed.\n")
-			return "", nil, "", fmt.Errorf("failed to query customized office network: %w", err)
-		}
-		if officeSiteResp.Body != nil && officeSiteResp.Body.GetRequestId() != nil {
-			fmt.Printf(" Done. (Action: DescribeOfficeSites, Request ID: %s)\n", *officeSiteResp.Body.GetRequestId())
-		} else {
-			fmt.Printf(" Done. (Action: DescribeOfficeSites)\n")
-		}
-		if officeSiteResp.Body != nil && officeSiteResp.Body.Data != nil {
-			defaultDnsAddresses = officeSiteResp.Body.Data.DnsAddress
-			if officeSiteResp.Body.Data.OfficeSiteId != nil {
-				officeSiteId = *officeSiteResp.Body.Data.OfficeSiteId
-			}
-			if officeSiteId != "" {
-				fmt.Printf("[INFO] Found existing office site: %s\n", officeSiteId)
-			}
-			if len(defaultDnsAddresses) > 0 {
-				fmt.Printf("[INFO] Office network default DNS: %s\n", strings.Join(defaultDnsAddresses, ", "))
-			}
-		}
-	} else {
-		fmt.Printf(" Skipped. (RegionName not available)\n")
-	}
-
-	// STEP 5/8: CreateSimpleOfficeSite (conditional - only if no OfficeSiteId found)
-	if officeSiteId == "" {
-		fmt.Printf("[STEP 5/8] Creating simple office site...")
-		effectiveRegionId := regionId
-		if effectiveRegionId == "" {
-			effectiveRegionId = serverRegionId
+// activationError formats the sdk.StartActivation errors that have dedicated CLI messages.
+func activationError(err error, regionId string) error {
+	var typeErr *sdk.InstanceTypeError
+	if errors.As(err, &typeErr) {
+		lines := []string{fmt.Sprintf("[ERROR] No matching instance type for %dc%dg.", typeErr.CPU, typeErr.Memory)}
+		if len(typeErr.Available) > 0 {
+			lines = append(lines, "[TIP] Available options: "+strings.Join(typeErr.Available, ", "))
+		} else if regionId != "" {
+			lines = append(lines, fmt.Sprintf("[TIP] No instance types are available in region %s.", regionId))
 		}
-		createOfficeSiteReq := &client.CreateSimpleOfficeSiteRequest{
-			VpcType:           dara.String("customized"),
-			OfficeSiteName:    dara.String(fmt.Sprintf("AgentBay-%s", time.Now().Format("20060102-15:04:05"))),
-			VpcId:             dara.String(vpcId),
-			RegionId:          dara.String(effectiveRegionId),
-			RegionName:        dara.String(effectiveRegionId),
-			DesktopAccessType: dara.String("INTERNET"),
-		}
-		createOfficeSiteResp, err := apiClient.CreateSimpleOfficeSite(ctx, createOfficeSiteReq)
-		if err != nil {
-			fmt.Printf(" Failed.\n")
-			return "", nil, "", fmt.Errorf("failed to create simple office site: %w", err)
-		}
-		if createOfficeSiteResp.Body != nil && createOfficeSiteResp.Body.GetRequestId() != nil {
-			fmt.Printf(" Done. (Action: CreateSimpleOfficeSite, Request ID: %s)\n", *createOfficeSiteResp.Body.GetRequestId())
-		} else {
-			fmt.Printf(" Done. (Action: CreateSimpleOfficeSite)\n")
-		}
-
-		// Success determination: Code-based (new API, Success field may not be returned)
-		if createOfficeSiteResp.Body != nil {
-			code := createOfficeSiteResp.Body.GetCode()
-			successPtr := createOfficeSiteResp.Body.Success
-			if (successPtr != nil && !*successPtr) || (code != nil && *code != "" && !strings.EqualFold(*code, "ok")) {
-				msg := createOfficeSiteResp.Body.GetMessage()
-				codeStr := ""
-				msgStr := ""
-				if code != nil {
-					codeStr = *code
-				}
-				if msg != nil {
-					msgStr = *msg
-				}
-				return "", nil, "", fmt.Errorf("failed to create simple office site: Code=%s, Message=%s", codeStr, msgStr)
-			}
-			if createOfficeSiteResp.Body.Data != nil {
-				officeSiteId = *createOfficeSiteResp.Body.Data
-			}
-			if officeSiteId == "" {
-				return "", nil, "", fmt.Errorf("create simple office site succeeded but returned empty OfficeSiteId")
-			}
-			fmt.Printf("[INFO] Created office site: %s\n", officeSiteId)
-		} else {
-			return "", nil, "", fmt.Errorf("invalid response from CreateSimpleOfficeSite")
-		}
-	} else {
-		fmt.Printf("[STEP 5/8] Skipped. (Office site already exists: %s)\n", officeSiteId)
-	}
-
-	// Determine effective DNS addresses
-	effectiveDnsAddresses := dnsAddresses
-	if len(effectiveDnsAddresses) == 0 && len(defaultDnsAddresses) > 0 {
-		effectiveDnsAddresses = defaultDnsAddresses
-		fmt.Printf("[INFO] Using default DNS addresses from office network: %s\n", strings.Join(effectiveDnsAddresses, ", "))
-	}
-
-	// STEP 6/8: SaveMcpPolicyData
-	fmt.Printf("[STEP 6/8] Saving policy configuration...")
-	saveReq := &client.SaveMcpPolicyDataRequest{}
-
-	if policyResp.Body != nil && policyResp.Body.Data != nil {
-		data := policyResp.Body.Data
-
-		saveReq.ImageId = dara.String(imageId)
-		if createdEdsPolicyId != "" {
-			saveReq.PolicyId = dara.String(createdEdsPolicyId)
-		} else if data.PolicyId != nil {
-			saveReq.PolicyId = data.PolicyId
-		}
-
-		if data.GroupSpec != nil {
-			saveReq.GroupSpec = &client.GroupSpec{
-				AppInstanceType: dara.String(appInstanceType),
-				RegionName:      data.GroupSpec.RegionName,
-				Memory:          dara.Int32(int32(memory)),
-				Cpu:             dara.Int32(int32(cpu)),
-				RegionId:        data.GroupSpec.RegionId,
-			}
-			if regionId != "" {
-				saveReq.GroupSpec.RegionName = dara.String(regionId)
-				saveReq.GroupSpec.RegionId = dara.String(regionId)
-			}
-		}
-
-		saveReq.SandboxLifeCycle = mergedSandboxLifeCycle
-		saveReq.ScreenSettings = data.ScreenSettings
-		saveReq.NetworkConfig = data.NetworkConfig
-		saveReq.DisplayConfig = data.DisplayConfig
-		if regionId != "" {
-			saveReq.RegionId = dara.String(regionId)
-		} else if data.GroupSpec != nil && data.GroupSpec.RegionId != nil {
-			saveReq.RegionId = data.GroupSpec.RegionId
-		}
-
-		// NetworkData with CUSTOMIZED settings
-		saveReq.NetworkData = &client.NetworkData{
-			OfficeSiteType: dara.String("CUSTOMIZED"),
-			VpcId:          dara.String(vpcId),
-			VSwitchId:      dara.String(vswitchId),
-		}
-		if data.NetworkData != nil {
-			saveReq.NetworkData.VpcName = data.NetworkData.VpcName
-		}
-		if len(effectiveDnsAddresses) > 0 {
-			saveReq.NetworkData.DnsAddress = dara.String(strings.Join(effectiveDnsAddresses, ","))
-		}
-	} else {
-		fmt.Printf(" Failed.\n")
-		return "", nil, "", fmt.Errorf("invalid policy data response")
+		lines = append(lines, "[TIP] Run 'agentbay instance-types list' to see all instance types.")
+		return printErrorMessage(lines...)
 	}
-
-	saveResp, err := apiClient.SaveMcpPolicyData(ctx, saveReq)
-	if err != nil {
-		fmt.Printf(" Failed.\n")
-		return "", nil, "", fmt.Errorf("failed to save policy data: %w", err)
-	}
-	if saveResp.Body != nil && saveResp.Body.GetRequestId() != nil {
-		fmt.Printf(" Done. (Action: SaveMcpPolicyData, Request ID: %s)\n", *saveResp.Body.GetRequestId())
-	} else {
-		fmt.Printf(" Done. (Action: SaveMcpPolicyData)\n")
+	var networkErr *sdk.NetworkCheckError
+	if errors.As(err, &networkErr) {
+		return networkValidationError("Network pre-flight validation failed:", networkErr.Problems)
 	}
-
-	return serverRegionId, effectiveDnsAddresses, officeSiteId, nil
+	return err
 }
 
 func runImageDelete(cmd *cobra.Command, args []string) error {
@@ -0,0 +1,432 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var imageCapacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Show max sessions, pre-open target and warmed instances per image",
+	Long: `Show the capacity of each image in one table by joining three sources:
+
+  - DescribeWarmUpStatusOpen       account session quota, max sessions and warmed instances per image
+  - DescribeImageReserveMinAmount  configured pre-open target (sum over all resource groups)
+  - ListMcpImages                  deployment and resource group state of the image
+
+Without --image-id, every image that appears in the warm-up status or has a
+pre-open configuration is shown.
+
+Use --watch to refresh the view periodically until interrupted. With
+--output json each refresh prints one JSON document; combined with --watch the
+documents are printed one per line (NDJSON), suitable for a monitoring exporter.
+
+Examples:
+  agentbay image capacity
+  agentbay image capacity --image-id imgc-aaa --image-id imgc-bbb
+  agentbay image capacity --watch --interval 5s
+  agentbay image capacity -o json
+  agentbay image capacity --watch -o json > capacity.ndjson`,
+	Args: cobra.NoArgs,
+	RunE: runImageCapacity,
+}
+
+func init() {
+	imageCapacityCmd.Flags().StringArray("image-id", nil, "Image ID to show (repeatable; omit to show all images with capacity configured)")
+	imageCapacityCmd.Flags().Bool("watch", false, "Refresh the view periodically until interrupted")
+	imageCapacityCmd.Flags().Duration("interval", 10*time.Second, "Refresh interval for --watch")
+	imageCapacityCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+
+	registerFlagCompletion(imageCapacityCmd, "image-id", completeFlag(completionImages, userImagesOnly))
+
+	ImageCmd.AddCommand(imageCapacityCmd)
+}
+
+// capacityAccount is the account-wide session quota from DescribeWarmUpStatusOpen.
+type capacityAccount struct {
+	MaxSessionNumLimit    int32 `json:"maxSessionNumLimit"`
+	TotalUsedSessionQuota int32 `json:"totalUsedSessionQuota"`
+	AvailableSessionQuota int32 `json:"availableSessionQuota"`
+}
+
+// capacityResourceGroup is one resource group from DescribeImageReserveMinAmount.
+type capacityResourceGroup struct {
+	ResourceGroupId   string `json:"resourceGroupId"`
+	ResourceGroupType string `json:"resourceGroupType"`
+	ReserveMinAmount  int32  `json:"reserveMinAmount"`
+	MaxAmount         int32  `json:"maxAmount"`
+	Status            string `json:"status"`
+}
+
+// capacityEntry is one image's row in the capacity view.
+type capacityEntry struct {
+	ImageId             string                  `json:"imageId"`
+	Status              string                  `json:"status"`
+	StatusText          string                  `json:"statusText"`
+	ResourceGroupReady  bool                    `json:"resourceGroupReady"`
+	ResourceGroupStatus string                  `json:"resourceGroupStatus,omitempty"`
+	MaxSessions         int32                   `json:"maxSessions"`
+	PreOpenTarget       int32                   `json:"preOpenTarget"`
+	WarmedInstances     int32                   `json:"warmedInstances"`
+	GroupCount          int32                   `json:"groupCount"`
+	ResourceGroups      []capacityResourceGroup `json:"resourceGroups"`
+	Error               string                  `json:"error,omitempty"`
+}
+
+// capacityReport is the joined view printed by 'image capacity'.
+type capacityReport struct {
+	Timestamp  string           `json:"timestamp"`
+	Account    *capacityAccount `json:"account,omitempty"`
+	TotalCount int              `json:"totalCount"`
+	Images     []capacityEntry  `json:"images"`
+	Warnings   []string         `json:"warnings,omitempty"`
+}
+
+func runImageCapacity(cmd *cobra.Command, args []string) error {
+	imageIds, _ := cmd.Flags().GetStringArray("image-id")
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	outputFmt, _ := cmd.Flags().GetString("output")
+	jsonOutput := strings.EqualFold(outputFmt, "json")
+
+	// Mirror backend MAX_DESCRIBE_IMAGE_RESERVE_MIN_AMOUNT_IMAGE_IDS = 100
+	if len(imageIds) > 100 {
+		return fmt.Errorf("--image-id supports at most 100 image IDs")
+	}
+	if watch && interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return config.ErrNotAuthenticated()
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+
+	if !watch {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		if !jsonOutput {
+			fmt.Printf("[CAPACITY] Collecting warm-up, pre-open and status data...\n")
+		}
+		report, err := collectImageCapacity(ctx, apiClient, imageIds)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Failed to collect capacity: %w", err)
+		}
+		if jsonOutput {
+			return printCapacityJSON(report, true)
+		}
+		printCapacityTable(report)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Only redraw in place when writing to a terminal; otherwise append so logs stay readable.
+	redraw := !jsonOutput && term.IsTerminal(int(os.Stdout.Fd()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		iterCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+		report, err := collectImageCapacity(iterCtx, apiClient, imageIds)
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			// Interrupted mid-refresh; fall through to exit below.
+		case jsonOutput:
+			if err != nil {
+				report = &capacityReport{Timestamp: time.Now().Format(time.RFC3339), Images: []capacityEntry{}, Warnings: []string{err.Error()}}
+			}
+			if perr := printCapacityJSON(report, false); perr != nil {
+				return perr
+			}
+		default:
+			if redraw {
+				fmt.Print("\033[H\033[2J")
+			}
+			fmt.Printf("[CAPACITY] Every %s, Ctrl+C to stop\n", interval)
+			if err != nil {
+				fmt.Printf("[ERROR] Failed to collect capacity: %v\n", err)
+			} else {
+				printCapacityTable(report)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if !jsonOutput {
+				fmt.Printf("\n[CAPACITY] Stopped.\n")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectImageCapacity joins warm-up status, pre-open configuration and image status per image.
+// Failure of the warm-up or pre-open query is reported as a warning so the other sources are still
+// shown; it only fails when neither source could be read. Image status comes from one paged walk
+// of the User image list joined on image ID; an image missing from it is recorded in the entry.
+func collectImageCapacity(ctx context.Context, apiClient agentbay.Client, imageIds []string) (*capacityReport, error) {
+	report := &capacityReport{Timestamp: time.Now().Format(time.RFC3339), Images: []capacityEntry{}}
+	entries := make(map[string]*capacityEntry)
+	entry := func(id string) *capacityEntry {
+		e, ok := entries[id]
+		if !ok {
+			e = &capacityEntry{ImageId: id, ResourceGroups: []capacityResourceGroup{}}
+			entries[id] = e
+		}
+		return e
+	}
+	wanted := make(map[string]bool)
+	for _, id := range imageIds {
+		wanted[id] = true
+		entry(id)
+	}
+
+	warmupErr := func() error {
+		resp, err := apiClient.DescribeWarmUpStatusOpen(ctx, &client.DescribeWarmUpStatusOpenRequest{})
+		if err != nil {
+			return err
+		}
+		if resp == nil || resp.Body == nil {
+			return fmt.Errorf("invalid response: missing response body")
+		}
+		if resp.Body.GetSuccess() != nil && !*resp.Body.GetSuccess() {
+			return fmt.Errorf("API request failed: %s", getStringValue(resp.Body.GetMessage()))
+		}
+		data := resp.Body.GetData()
+		if data == nil {
+			return nil
+		}
+		report.Account = &capacityAccount{
+			MaxSessionNumLimit:    data.GetMaxSessionNumLimit(),
+			TotalUsedSessionQuota: data.GetTotalUsedSessionQuota(),
+			AvailableSessionQuota: data.GetAvailableSessionQuota(),
+		}
+		for _, img := range data.GetImages() {
+			id := img.GetImageId()
+			if id == "" || (len(wanted) > 0 && !wanted[id]) {
+				continue
+			}
+			e := entry(id)
+			e.MaxSessions = img.GetTotalMaxSize()
+			e.WarmedInstances = img.GetAvailableInstanceSize()
+			e.GroupCount = img.GetGroupCount()
+		}
+		return nil
+	}()
+	if warmupErr != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("DescribeWarmUpStatusOpen: %v", warmupErr))
+	}
+
+	preOpenErr := func() error {
+		req := &client.DescribeImageReserveMinAmountRequest{}
+		if len(imageIds) > 0 {
+			req.SetImageIds(imageIds)
+		}
+		req.SetMaxResults(500)
+		for {
+			var resp *client.DescribeImageReserveMinAmountResponse
+			err := withTransientRetry(ctx, client.DefaultRetryConfig(), "DescribeImageReserveMinAmount", func() error {
+				var e error
+				resp, e = apiClient.DescribeImageReserveMinAmount(ctx, req)
+				return e
+			})
+			if err != nil {
+				return err
+			}
+			if resp == nil || resp.Body == nil {
+				return fmt.Errorf("invalid response: missing response body")
+			}
+			if resp.Body.Success != nil && !*resp.Body.Success {
+				return fmt.Errorf("API request failed: %s", getStringValue(resp.Body.GetMessage()))
+			}
+			for _, img := range resp.Body.GetData().GetImages() {
+				id := img.GetImageId()
+				if id == "" {
+					continue
+				}
+				e := entry(id)
+				e.PreOpenTarget = 0
+				e.ResourceGroups = e.ResourceGroups[:0]
+				for _, rg := range img.GetResourceGroups() {
+					if rg == nil {
+						continue
+					}
+					e.PreOpenTarget += rg.GetReserveMinAmount()
+					e.ResourceGroups = append(e.ResourceGroups, capacityResourceGroup{
+						ResourceGroupId:   rg.GetResourceGroupId(),
+						ResourceGroupType: rg.GetResourceGroupType(),
+						ReserveMinAmount:  rg.GetReserveMinAmount(),
+						MaxAmount:         rg.GetMaxAmount(),
+						Status:            rg.GetStatus(),
+					})
+				}
+			}
+			token := resp.Body.GetData().GetNextToken()
+			if token == "" {
+				return nil
+			}
+			req.SetNextToken(token)
+		}
+	}()
+	if preOpenErr != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("DescribeImageReserveMinAmount: %v", preOpenErr))
+	}
+
+	if warmupErr != nil && preOpenErr != nil {
+		return nil, fmt.Errorf("%s; %s", report.Warnings[0], report.Warnings[1])
+	}
+
+	images := make(map[string]*client.ListMcpImagesResponseBodyData)
+	_, listErr := walkPages(ctx, listPaging{All: true}, pageCursor{PageNo: 1}, imagePages(apiClient, "User", "", capacityImagePageSize, io.Discard),
+		func(img *client.ListMcpImagesResponseBodyData) error {
+			if img != nil && img.ImageId != nil {
+				images[*img.ImageId] = img
+			}
+			return nil
+		})
+	if listErr != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("ListMcpImages: %v", listErr))
+	}
+
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		e := entries[id]
+		if img, ok := images[id]; ok {
+			e.Status = getStringValue(img.GetImageResourceStatus())
+			e.StatusText = TranslateImageResourceStatus(e.Status)
+			if rg := img.GetImageResourceGroupInfo(); rg != nil {
+				e.ResourceGroupStatus = getStringValue(rg.GetResourceGroupStatus())
+				e.ResourceGroupReady = resourceGroupStatusReady(e.ResourceGroupStatus)
+			}
+		} else if listErr == nil {
+			e.Error = "not found in the User image list"
+		}
+		report.Images = append(report.Images, *e)
+	}
+	report.TotalCount = len(report.Images)
+	return report, nil
+}
+
+// capacityImagePageSize is the ListMcpImages page size used to look up image status.
+const capacityImagePageSize = 100
+
+// resourceGroupStatusReady reports whether a ResourceGroupStatus from ListMcpImages means the
+// image's resource group can serve sessions.
+func resourceGroupStatusReady(status string) bool {
+	switch strings.ToUpper(status) {
+	case "AVAILABLE", "READY", "RUNNING":
+		return true
+	}
+	return false
+}
+
+func printCapacityJSON(report *capacityReport, indent bool) error {
+	var b []byte
+	var err error
+	if indent {
+		b, err = json.MarshalIndent(report, "", "  ")
+	} else {
+		b, err = json.Marshal(report)
+	}
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+func printCapacityTable(report *capacityReport) {
+	fmt.Printf("\n[CAPACITY] As of %s\n", report.Timestamp)
+	if report.Account != nil {
+		fmt.Printf("  Session Quota: %d used / %d limit (%d available)\n",
+			report.Account.TotalUsedSessionQuota, report.Account.MaxSessionNumLimit, report.Account.AvailableSessionQuota)
+	}
+	fmt.Println()
+
+	if len(report.Images) == 0 {
+		fmt.Println("[EMPTY] No images with capacity configured.")
+	} else {
+		fmt.Printf("  %s %s %s %s %s %s\n",
+			padString("IMAGE ID", 25),
+			padString("STATUS", 22),
+			padString("MAX SESSIONS", 13),
+			padString("PRE-OPEN", 9),
+			padString("WARMED", 7),
+			"GROUPS")
+		fmt.Printf("  %s %s %s %s %s %s\n",
+			padString("--------", 25),
+			padString("------", 22),
+			padString("------------", 13),
+			padString("--------", 9),
+			padString("------", 7),
+			"------")
+		short := false
+		for _, e := range report.Images {
+			status := e.StatusText
+			if e.Error != "" || status == "" {
+				status = "Unknown"
+			}
+			warmed := fmt.Sprintf("%d", e.WarmedInstances)
+			if e.PreOpenTarget > 0 && e.WarmedInstances < e.PreOpenTarget {
+				warmed += "*"
+				short = true
+			}
+			fmt.Printf("  %s %s %s %s %s %d\n",
+				padString(truncateString(e.ImageId, 25), 25),
+				padString(truncateString(status, 22), 22),
+				padString(fmt.Sprintf("%d", e.MaxSessions), 13),
+				padString(fmt.Sprintf("%d", e.PreOpenTarget), 9),
+				padString(warmed, 7),
+				e.GroupCount)
+		}
+		if short {
+			fmt.Printf("\n  * fewer instances warmed than the pre-open target (warm-up in progress or capacity short)\n")
+		}
+	}
+
+	var failed []capacityEntry
+	for _, e := range report.Images {
+		if e.Error != "" {
+			failed = append(failed, e)
+		}
+	}
+	if len(failed) > 0 || len(report.Warnings) > 0 {
+		fmt.Println()
+	}
+	for _, w := range report.Warnings {
+		fmt.Printf("[WARN] %s\n", w)
+	}
+	for _, e := range failed {
+		fmt.Printf("[WARN] %s: %s\n", e.ImageId, e.Error)
+	}
+}
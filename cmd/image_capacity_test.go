@@ -0,0 +1,174 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// mockCapacityClient serves the three capacity sources for collectImageCapacity tests.
+type mockCapacityClient struct {
+	agentbay.Client
+	warmupErr  error
+	preOpenErr error
+	listErr    error
+	listCalls  int
+	// statuses and groupStatuses are the ImageResourceStatus and ResourceGroupStatus by image ID.
+	statuses      map[string]string
+	groupStatuses map[string]string
+}
+
+func (m *mockCapacityClient) DescribeWarmUpStatusOpen(ctx context.Context, req *client.DescribeWarmUpStatusOpenRequest) (*client.DescribeWarmUpStatusOpenResponse, error) {
+	if m.warmupErr != nil {
+		return nil, m.warmupErr
+	}
+	return &client.DescribeWarmUpStatusOpenResponse{Body: &client.DescribeWarmUpStatusOpenResponseBody{
+		Success: dara.Bool(true),
+		Data: &client.DescribeWarmUpStatusOpenResponseBodyData{
+			MaxSessionNumLimit:    dara.Int32(100),
+			TotalUsedSessionQuota: dara.Int32(30),
+			AvailableSessionQuota: dara.Int32(70),
+			Images: []*client.DescribeWarmUpStatusOpenResponseBodyDataImage{
+				{ImageId: dara.String("imgc-a"), TotalMaxSize: dara.Int32(20), GroupCount: dara.Int32(2), AvailableInstanceSize: dara.Int32(4)},
+				{ImageId: dara.String("imgc-b"), TotalMaxSize: dara.Int32(10), GroupCount: dara.Int32(1), AvailableInstanceSize: dara.Int32(1)},
+			},
+		},
+	}}, nil
+}
+
+func (m *mockCapacityClient) DescribeImageReserveMinAmount(ctx context.Context, req *client.DescribeImageReserveMinAmountRequest) (*client.DescribeImageReserveMinAmountResponse, error) {
+	if m.preOpenErr != nil {
+		return nil, m.preOpenErr
+	}
+	return &client.DescribeImageReserveMinAmountResponse{Body: &client.DescribeImageReserveMinAmountResponseBody{
+		Success: dara.Bool(true),
+		Data: &client.DescribeImageReserveMinAmountResponseBodyData{Images: []*client.DescribeImageReserveMinAmountImage{
+			{ImageId: dara.String("imgc-a"), ResourceGroups: []*client.DescribeImageReserveMinAmountResourceGroup{
+				{ResourceGroupId: dara.String("rg-1"), ReserveMinAmount: dara.Int32(3), ResourceGroupType: dara.String("DEFAULT")},
+				{ResourceGroupId: dara.String("rg-2"), ReserveMinAmount: dara.Int32(2), ResourceGroupType: dara.String("HIDE")},
+			}},
+			{ImageId: dara.String("imgc-c"), ResourceGroups: []*client.DescribeImageReserveMinAmountResourceGroup{
+				{ResourceGroupId: dara.String("rg-3"), ReserveMinAmount: dara.Int32(1)},
+			}},
+		}},
+	}}, nil
+}
+
+func (m *mockCapacityClient) ListMcpImages(ctx context.Context, req *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
+	m.listCalls++
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	ids := make([]string, 0, len(m.statuses))
+	for id := range m.statuses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	size := int(*req.PageSize)
+	start := min((int(*req.PageStart)-1)*size, len(ids))
+	var data []*client.ListMcpImagesResponseBodyData
+	for _, id := range ids[start:min(start+size, len(ids))] {
+		data = append(data, &client.ListMcpImagesResponseBodyData{
+			ImageId:                dara.String(id),
+			ImageResourceStatus:    dara.String(m.statuses[id]),
+			ImageResourceGroupInfo: &client.ListMcpImagesResponseBodyDataImageResourceGroupInfo{ResourceGroupStatus: dara.String(m.groupStatuses[id])},
+		})
+	}
+	return &client.ListMcpImagesResponse{Body: &client.ListMcpImagesResponseBody{
+		Success:    dara.Bool(true),
+		Data:       data,
+		PageSize:   req.PageSize,
+		TotalCount: dara.Int32(int32(len(ids))),
+	}}, nil
+}
+
+func TestCollectImageCapacity(t *testing.T) {
+	mock := &mockCapacityClient{
+		statuses: map[string]string{
+			"imgc-a": "RESOURCE_PUBLISHED",
+			"imgc-c": "RESOURCE_DEPLOYING",
+			"imgc-z": "IMAGE_AVAILABLE",
+		},
+		groupStatuses: map[string]string{"imgc-a": "AVAILABLE", "imgc-c": "CREATING"},
+	}
+	// Images without capacity fill the first page, so imgc-a and imgc-c are on the second.
+	for i := range capacityImagePageSize {
+		mock.statuses[fmt.Sprintf("imgc-0%03d", i)] = "IMAGE_AVAILABLE"
+	}
+
+	report, err := collectImageCapacity(context.Background(), mock, nil)
+	require.NoError(t, err)
+	require.NotNil(t, report.Account)
+	assert.Equal(t, int32(70), report.Account.AvailableSessionQuota)
+	require.Len(t, report.Images, 3)
+	assert.Equal(t, 3, report.TotalCount)
+
+	a := report.Images[0]
+	assert.Equal(t, "imgc-a", a.ImageId)
+	assert.Equal(t, int32(20), a.MaxSessions)
+	assert.Equal(t, int32(5), a.PreOpenTarget)
+	assert.Equal(t, int32(4), a.WarmedInstances)
+	assert.Len(t, a.ResourceGroups, 2)
+	assert.Equal(t, "RESOURCE_PUBLISHED", a.Status)
+	assert.True(t, a.ResourceGroupReady)
+	assert.Equal(t, "AVAILABLE", a.ResourceGroupStatus)
+	assert.Empty(t, a.Error)
+
+	b := report.Images[1]
+	assert.Equal(t, "imgc-b", b.ImageId)
+	assert.Equal(t, int32(0), b.PreOpenTarget)
+	assert.Equal(t, "not found in the User image list", b.Error, "an image missing from the list is recorded per image")
+
+	c := report.Images[2]
+	assert.Equal(t, "imgc-c", c.ImageId)
+	assert.Equal(t, int32(1), c.PreOpenTarget)
+	assert.Equal(t, int32(0), c.MaxSessions)
+	assert.Equal(t, "RESOURCE_DEPLOYING", c.Status)
+	assert.False(t, c.ResourceGroupReady)
+	assert.Equal(t, 2, mock.listCalls, "status comes from one paged list walk, not a call per image")
+	assert.Empty(t, report.Warnings)
+}
+
+func TestCollectImageCapacity_ListFailure(t *testing.T) {
+	mock := &mockCapacityClient{listErr: fmt.Errorf("throttled")}
+
+	report, err := collectImageCapacity(context.Background(), mock, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Images, 3)
+	require.Len(t, report.Warnings, 1)
+	assert.Contains(t, report.Warnings[0], "ListMcpImages")
+	for _, e := range report.Images {
+		assert.Empty(t, e.Status)
+		assert.Empty(t, e.Error, "a failed list is reported once, not per image")
+	}
+}
+
+func TestCollectImageCapacity_FilterAndPartialFailure(t *testing.T) {
+	mock := &mockCapacityClient{
+		preOpenErr: fmt.Errorf("forbidden"),
+		statuses:   map[string]string{"imgc-b": "RESOURCE_PUBLISHED"},
+	}
+
+	report, err := collectImageCapacity(context.Background(), mock, []string{"imgc-b"})
+	require.NoError(t, err)
+	require.Len(t, report.Images, 1)
+	assert.Equal(t, "imgc-b", report.Images[0].ImageId)
+	assert.Equal(t, int32(10), report.Images[0].MaxSessions)
+	require.Len(t, report.Warnings, 1)
+	assert.Contains(t, report.Warnings[0], "DescribeImageReserveMinAmount")
+
+	mock.warmupErr = fmt.Errorf("throttled")
+	_, err = collectImageCapacity(context.Background(), mock, []string{"imgc-b"})
+	assert.Error(t, err, "fails when neither capacity source can be read")
+}
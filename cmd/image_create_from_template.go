@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/agentbay/agentbay-cli/internal/agentbay"
@@ -26,7 +27,8 @@ var imageCreateFromTemplateCmd = &cobra.Command{
 	Long: `Create a custom image by specifying a source image (repository address with tag),
 image name, and a system (template) image ID.
 
-This calls the CreateImageFromTemplate POP Action.
+This calls the CreateImageFromTemplate POP Action. When the source image is in your own
+ACR repository, the tag is first checked against the registry (disable with --skip-tag-check).
 
 Examples:
   agentbay image create-from-template --source-image registry.cn-hangzhou.aliyuncs.com/myrepo/myimage:v1.0 --name my-custom-image --imageId <id>
@@ -39,6 +41,7 @@ func init() {
 	imageCreateFromTemplateCmd.Flags().StringP("source-image", "s", "", "Source image reference, e.g. registry.cn-hangzhou.aliyuncs.com/myrepo/myimage:v1.0 (required)")
 	imageCreateFromTemplateCmd.Flags().StringP("name", "n", "", "Name for the custom image (required)")
 	imageCreateFromTemplateCmd.Flags().StringP("imageId", "i", "", "System (template) image ID (required)")
+	imageCreateFromTemplateCmd.Flags().Bool("skip-tag-check", false, "Do not check that the source image tag exists in your ACR repository")
 	imageCreateFromTemplateCmd.MarkFlagRequired("source-image")
 	imageCreateFromTemplateCmd.MarkFlagRequired("name")
 	imageCreateFromTemplateCmd.MarkFlagRequired("imageId")
@@ -88,7 +91,13 @@ func runImageCreateFromTemplate(cmd *cobra.Command, args []string) error {
 	sourceImage, _ := cmd.Flags().GetString("source-image")
 	imageName, _ := cmd.Flags().GetString("name")
 	templateImageId, _ := cmd.Flags().GetString("imageId")
+	skipTagCheck, _ := cmd.Flags().GetBool("skip-tag-check")
+	return createImageFromTemplate(sourceImage, imageName, templateImageId, skipTagCheck)
+}
 
+// createImageFromTemplate authorizes sourceImage and calls CreateImageFromTemplate. It is shared by
+// "image create-from-template" and "docker build --create-image-name".
+func createImageFromTemplate(sourceImage, imageName, templateImageId string, skipTagCheck bool) error {
 	sourceRef, err := parseSourceImageRef(sourceImage)
 	if err != nil {
 		return fmt.Errorf("[ERROR] invalid source-image: %w", err)
@@ -102,7 +111,17 @@ func runImageCreateFromTemplate(cmd *cobra.Command, args []string) error {
 		return config.ErrNotAuthenticated()
 	}
 
-	cache, _ := loadACRCredential()
+	// A near-expiry credential is refreshed for the tag check; a stale one still identifies the
+	// own repository.
+	var cacheRegistry string
+	if sourceRef.IsFullPath {
+		cacheRegistry = sourceRef.Registry
+	}
+	cache, err := ensureACRCredential(cacheRegistry, false)
+	if err != nil {
+		log.Debugf("ACR credential not usable for the tag check: %v", err)
+		cache, _ = loadACRCredentialFor(cacheRegistry)
+	}
 	apiClient := agentbay.NewClientFromConfig(cfg)
 	ctx := context.Background()
 	authorization, err := authorizeSourceImage(ctx, sourceRef, cache, apiClient.ListSharedDockerRepos)
@@ -111,6 +130,16 @@ func runImageCreateFromTemplate(cmd *cobra.Command, args []string) error {
 	}
 	physicalImageId := sourceRef.PhysicalImageID
 
+	// Own-repository tags can be checked with the cached ACR credential before the template call.
+	if authorization.OwnRepository && !skipTagCheck {
+		rc, err := newACRRegistryClient(cache)
+		if err != nil {
+			fmt.Printf("[WARN] Skipping source image tag check: %v\n", err)
+		} else if err := verifySourceImageTag(ctx, rc, cache, sourceRef.Tag); err != nil {
+			return err
+		}
+	}
+
 	fmt.Println("[IMAGE] Creating custom image from template...")
 	fmt.Printf("  SourceImage:      %s\n", authorization.DisplaySourceImage)
 	fmt.Printf("  SourceType:       %s\n", authorization.SourceType)
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 
@@ -28,7 +30,8 @@ warmup-status, this command does not depend on set-max-session and returns
 per-resource-group details (reserve, max, type, status).
 
 Supports batch query with multiple --image-id flags, pagination via
---next-token / --max-results, and JSON output via --output json.
+--next-token / --max-results (or --all / --limit to walk pages), and JSON output via
+--output json or --output ndjson.
 
 Examples:
   # Query a single image
@@ -47,7 +50,10 @@ Examples:
   agentbay image describe-pre-open --max-results 50
 
   # JSON output
-  agentbay image describe-pre-open --output json`,
+  agentbay image describe-pre-open --output json
+
+  # Every image, one JSON object per line as pages arrive
+  agentbay image describe-pre-open --all --output ndjson`,
 	Args: cobra.NoArgs,
 	RunE: runImageDescribePreOpen,
 }
@@ -56,7 +62,9 @@ func init() {
 	imageDescribePreOpenCmd.Flags().StringArray("image-id", nil, "Image ID (optional, repeatable for batch query)")
 	imageDescribePreOpenCmd.Flags().String("next-token", "", "Pagination token from a previous response")
 	imageDescribePreOpenCmd.Flags().Int32("max-results", 20, "Page size (number of images per page, default 20, max 500)")
-	imageDescribePreOpenCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+	imageDescribePreOpenCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts), or "ndjson" for one object per line`)
+	addListPagingFlags(imageDescribePreOpenCmd)
+	registerFlagCompletion(imageDescribePreOpenCmd, "image-id", completeFlag(completionImages, userImagesOnly))
 }
 
 func runImageDescribePreOpen(cmd *cobra.Command, args []string) error {
@@ -75,6 +83,12 @@ func runImageDescribePreOpen(cmd *cobra.Command, args []string) error {
 	if len(imageIds) > 100 {
 		return fmt.Errorf("--image-id supports at most 100 image IDs")
 	}
+	paging, err := listPagingFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	outputFmt, _ := cmd.Flags().GetString("output")
+	format := listOutputFormat(outputFmt)
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -91,6 +105,11 @@ func runImageDescribePreOpen(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if paging.walks() || format == "ndjson" {
+		fetch := preOpenPages(apiClient, imageIds, maxResults, listProgress(true, format))
+		return describePreOpenPaged(ctx, fetch, paging, pageCursor{Token: nextToken}, format)
+	}
+
 	// Build request
 	req := &client.DescribeImageReserveMinAmountRequest{}
 	if len(imageIds) > 0 {
@@ -161,25 +180,11 @@ func runImageDescribePreOpen(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 
-	outputFmt, _ := cmd.Flags().GetString("output")
-	if strings.EqualFold(outputFmt, "json") {
-		type resourceGroupJSON struct {
-			ResourceGroupId    string `json:"resourceGroupId"`
-			AppInstanceGroupId string `json:"appInstanceGroupId"`
-			ReserveMinAmount   int32  `json:"reserveMinAmount"`
-			MaxAmount          int32  `json:"maxAmount"`
-			ResourceGroupType  string `json:"resourceGroupType"`
-			Status             string `json:"status"`
-		}
-		type imageJSON struct {
-			ImageId        string              `json:"imageId"`
-			GroupCount     int                 `json:"groupCount"`
-			ResourceGroups []resourceGroupJSON `json:"resourceGroups"`
-		}
+	if format == "json" {
 		type outputJSON struct {
-			TotalCount int         `json:"totalCount"`
-			NextToken  string      `json:"nextToken,omitempty"`
-			Images     []imageJSON `json:"images"`
+			TotalCount int                `json:"totalCount"`
+			NextToken  string             `json:"nextToken,omitempty"`
+			Images     []preOpenImageJSON `json:"images"`
 		}
 		out := outputJSON{TotalCount: len(images)}
 		if nt := data.GetNextToken(); nt != "" {
@@ -189,30 +194,10 @@ func runImageDescribePreOpen(cmd *cobra.Command, args []string) error {
 			if img == nil {
 				continue
 			}
-			entry := imageJSON{
-				ImageId:    img.GetImageId(),
-				GroupCount: len(img.GetResourceGroups()),
-			}
-			for _, rg := range img.GetResourceGroups() {
-				if rg == nil {
-					continue
-				}
-				entry.ResourceGroups = append(entry.ResourceGroups, resourceGroupJSON{
-					ResourceGroupId:    rg.GetResourceGroupId(),
-					AppInstanceGroupId: rg.GetAppInstanceGroupId(),
-					ReserveMinAmount:   rg.GetReserveMinAmount(),
-					MaxAmount:          rg.GetMaxAmount(),
-					ResourceGroupType:  rg.GetResourceGroupType(),
-					Status:             rg.GetStatus(),
-				})
-			}
-			if entry.ResourceGroups == nil {
-				entry.ResourceGroups = []resourceGroupJSON{}
-			}
-			out.Images = append(out.Images, entry)
+			out.Images = append(out.Images, newPreOpenImageJSON(img))
 		}
 		if out.Images == nil {
-			out.Images = []imageJSON{}
+			out.Images = []preOpenImageJSON{}
 		}
 		b, jerr := json.MarshalIndent(out, "", "  ")
 		if jerr != nil {
@@ -222,7 +207,60 @@ func runImageDescribePreOpen(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Print resource group details for each image
+	printPreOpenImages(images)
+
+	// Print pagination info
+	nextTokenStr := data.GetNextToken()
+	if nextTokenStr != "" {
+		fmt.Println()
+		fmt.Printf("(NextToken: %s, use --next-token to get the next page)\n", nextTokenStr)
+	}
+
+	return nil
+}
+
+// preOpenImageJSON is one image in describe-pre-open -o json / ndjson output.
+type preOpenImageJSON struct {
+	ImageId        string                     `json:"imageId"`
+	GroupCount     int                        `json:"groupCount"`
+	ResourceGroups []preOpenResourceGroupJSON `json:"resourceGroups"`
+}
+
+type preOpenResourceGroupJSON struct {
+	ResourceGroupId    string `json:"resourceGroupId"`
+	AppInstanceGroupId string `json:"appInstanceGroupId"`
+	ReserveMinAmount   int32  `json:"reserveMinAmount"`
+	MaxAmount          int32  `json:"maxAmount"`
+	ResourceGroupType  string `json:"resourceGroupType"`
+	Status             string `json:"status"`
+}
+
+func newPreOpenImageJSON(img *client.DescribeImageReserveMinAmountImage) preOpenImageJSON {
+	entry := preOpenImageJSON{
+		ImageId:    img.GetImageId(),
+		GroupCount: len(img.GetResourceGroups()),
+	}
+	for _, rg := range img.GetResourceGroups() {
+		if rg == nil {
+			continue
+		}
+		entry.ResourceGroups = append(entry.ResourceGroups, preOpenResourceGroupJSON{
+			ResourceGroupId:    rg.GetResourceGroupId(),
+			AppInstanceGroupId: rg.GetAppInstanceGroupId(),
+			ReserveMinAmount:   rg.GetReserveMinAmount(),
+			MaxAmount:          rg.GetMaxAmount(),
+			ResourceGroupType:  rg.GetResourceGroupType(),
+			Status:             rg.GetStatus(),
+		})
+	}
+	if entry.ResourceGroups == nil {
+		entry.ResourceGroups = []preOpenResourceGroupJSON{}
+	}
+	return entry
+}
+
+// printPreOpenImages prints the resource group details of each image.
+func printPreOpenImages(images []*client.DescribeImageReserveMinAmountImage) {
 	for _, img := range images {
 		groups := img.GetResourceGroups()
 		if len(groups) == 0 {
@@ -261,12 +299,94 @@ func runImageDescribePreOpen(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Print pagination info
-	nextTokenStr := data.GetNextToken()
-	if nextTokenStr != "" {
-		fmt.Println()
-		fmt.Printf("(NextToken: %s, use --next-token to get the next page)\n", nextTokenStr)
+}
+
+// preOpenPages fetches DescribeImageReserveMinAmount pages, reporting each request ID to progress.
+func preOpenPages(apiClient agentbay.Client, imageIds []string, maxResults int32, progress io.Writer) pageFetcher[*client.DescribeImageReserveMinAmountImage] {
+	return func(ctx context.Context, at pageCursor) (*listPage[*client.DescribeImageReserveMinAmountImage], error) {
+		req := &client.DescribeImageReserveMinAmountRequest{}
+		if len(imageIds) > 0 {
+			req.SetImageIds(imageIds)
+		}
+		if at.Token != "" {
+			req.SetNextToken(at.Token)
+		}
+		req.SetMaxResults(maxResults)
+
+		var resp *client.DescribeImageReserveMinAmountResponse
+		err := withTransientRetry(ctx, client.DefaultRetryConfig(), "DescribeImageReserveMinAmount", func() error {
+			var e error
+			resp, e = apiClient.DescribeImageReserveMinAmount(ctx, req)
+			return e
+		})
+		if err != nil {
+			if reqId := extractRequestIDFromErr(err); reqId != "" {
+				fmt.Fprintf(progress, "[INFO] DescribeImageReserveMinAmount Request ID: %s\n", reqId)
+			}
+			return nil, fmt.Errorf("failed to query pre-open values: %w", err)
+		}
+		if resp == nil || resp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing response body")
+		}
+		if reqId := resp.Body.GetRequestId(); reqId != "" {
+			fmt.Fprintf(progress, "[INFO] DescribeImageReserveMinAmount Request ID: %s\n", reqId)
+		}
+		code := resp.Body.GetCode()
+		if (resp.Body.Success != nil && !*resp.Body.Success) || (code != "" && !strings.EqualFold(code, "ok")) {
+			message := ""
+			if resp.Body.Message != nil {
+				message = *resp.Body.Message
+			}
+			return nil, fmt.Errorf("API request failed: code=%s, message=%s", code, message)
+		}
+		page := &listPage[*client.DescribeImageReserveMinAmountImage]{}
+		if data := resp.Body.GetData(); data != nil {
+			page.Items = data.GetImages()
+			if len(page.Items) > 0 {
+				page.Next = nextPageToken(data.GetNextToken())
+			}
+		}
+		return page, nil
 	}
+}
 
+// describePreOpenPaged is describe-pre-open with --all, --limit or -o ndjson.
+func describePreOpenPaged(ctx context.Context, fetch pageFetcher[*client.DescribeImageReserveMinAmountImage], paging listPaging, start pageCursor, format string) error {
+	if format != "" {
+		stream := newListStream(os.Stdout, format, "images")
+		walk, err := walkPages(ctx, paging, start, fetch, func(img *client.DescribeImageReserveMinAmountImage) error {
+			if img == nil {
+				return nil
+			}
+			return stream.write(newPreOpenImageJSON(img))
+		})
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", stream.fail(err))
+		}
+		fields := []listStreamField{{"totalCount", walk.totalCount()}}
+		if walk.Next != nil && walk.Next.Token != "" {
+			fields = append(fields, listStreamField{"nextToken", walk.Next.Token})
+		}
+		return stream.close(fields...)
+	}
+
+	fmt.Println("[DESCRIBE-PRE-OPEN] Querying pre-open values...")
+	var images []*client.DescribeImageReserveMinAmountImage
+	walk, err := walkPages(ctx, paging, start, fetch, func(img *client.DescribeImageReserveMinAmountImage) error {
+		if img != nil {
+			images = append(images, img)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if len(images) == 0 {
+		fmt.Println("No images found.")
+		return nil
+	}
+	fmt.Printf("\n[OK] Found %d image(s) in %d page(s)\n", len(images), walk.Pages)
+	printPreOpenImages(images)
+	printMorePagesHint(os.Stdout, walk, "--next-token")
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -40,21 +42,7 @@ func printImagesAsJSON(images []*client.ListMcpImagesResponseBodyData, totalCoun
 		if image == nil {
 			continue
 		}
-		item := imageItemJSON{
-			ImageId:       getStringValue(image.GetImageId()),
-			ImageName:     getStringValue(image.GetImageName()),
-			Type:          getStringValue(image.GetImageBuildType()),
-			Status:        getStringValue(image.GetImageResourceStatus()),
-			StatusDisplay: formatImageStatus(getStringValue(image.GetImageResourceStatus())),
-			ApplyScene:    getStringValue(image.GetImageApplyScene()),
-		}
-		if imgInfo := image.GetImageInfo(); imgInfo != nil {
-			item.OsName = getStringValue(imgInfo.GetOsName())
-			item.OsVersion = getStringValue(imgInfo.GetOsVersion())
-			item.OsDisplay = formatOSInfo(imgInfo)
-			item.PhysicalImage = getStringValue(imgInfo.GetPhysicalImage())
-		}
-		out.Images = append(out.Images, item)
+		out.Images = append(out.Images, newImageItemJSON(image))
 	}
 	if out.Images == nil {
 		out.Images = []imageItemJSON{}
@@ -271,3 +259,157 @@ func printImageTable(images []*client.ListMcpImagesResponseBodyData, showPhysica
 		}
 	}
 }
+
+func newImageItemJSON(image *client.ListMcpImagesResponseBodyData) imageItemJSON {
+	item := imageItemJSON{
+		ImageId:       getStringValue(image.GetImageId()),
+		ImageName:     getStringValue(image.GetImageName()),
+		Type:          getStringValue(image.GetImageBuildType()),
+		Status:        getStringValue(image.GetImageResourceStatus()),
+		StatusDisplay: formatImageStatus(getStringValue(image.GetImageResourceStatus())),
+		ApplyScene:    getStringValue(image.GetImageApplyScene()),
+	}
+	if imgInfo := image.GetImageInfo(); imgInfo != nil {
+		item.OsName = getStringValue(imgInfo.GetOsName())
+		item.OsVersion = getStringValue(imgInfo.GetOsVersion())
+		item.OsDisplay = formatOSInfo(imgInfo)
+		item.PhysicalImage = getStringValue(imgInfo.GetPhysicalImage())
+	}
+	return item
+}
+
+// imagePages fetches ListMcpImages pages of one image type, reporting each request ID to progress.
+func imagePages(apiClient agentbay.Client, imageType, osType string, pageSize int, progress io.Writer) pageFetcher[*client.ListMcpImagesResponseBodyData] {
+	return func(ctx context.Context, at pageCursor) (*listPage[*client.ListMcpImagesResponseBodyData], error) {
+		req := &client.ListMcpImagesRequest{}
+		req.ImageType = &imageType
+		if osType != "" {
+			req.OsType = &osType
+		}
+		if pageSize > 0 {
+			pageSizeInt32 := int32(pageSize)
+			req.PageSize = &pageSizeInt32
+		}
+		pageInt32 := int32(at.PageNo)
+		req.PageStart = &pageInt32
+
+		resp, err := apiClient.ListMcpImages(ctx, req)
+		if err != nil {
+			log.Debugf("[DEBUG] ListMcpImages API call failed: %v", err)
+			return nil, fmt.Errorf("failed to fetch %s images: %w", strings.ToLower(imageType), err)
+		}
+		if resp == nil || resp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing response body")
+		}
+		if resp.Body.GetRequestId() != nil {
+			fmt.Fprintf(progress, "[INFO] Request ID (%s images, page %d): %s\n", strings.ToLower(imageType), at.PageNo, *resp.Body.GetRequestId())
+		}
+		if resp.Body.GetSuccess() != nil && !*resp.Body.GetSuccess() {
+			errorMsg := "unknown error"
+			if resp.Body.GetMessage() != nil {
+				errorMsg = *resp.Body.GetMessage()
+			}
+			return nil, fmt.Errorf("API request failed: %s", errorMsg)
+		}
+		page := &listPage[*client.ListMcpImagesResponseBodyData]{Items: resp.Body.GetData()}
+		total := -1
+		if resp.Body.GetTotalCount() != nil {
+			total = int(*resp.Body.GetTotalCount())
+			page.Total = &total
+		}
+		page.Next = nextPageNo(at.PageNo, pageSize, len(page.Items), total)
+		return page, nil
+	}
+}
+
+// listImagesPaged is image list with --all, --limit or --output ndjson. Image types are walked in
+// order and share the --limit; JSON and NDJSON are written as pages arrive. Like the single-page
+// output, JSON reports the server's total over the listed image types as totalCount.
+func listImagesPaged(ctx context.Context, apiClient agentbay.Client, imageTypes []string, osType string, page, pageSize int, paging listPaging, format string) error {
+	if page < 1 {
+		page = 1
+	}
+	progress := listProgress(true, format)
+	var stream *listStream
+	if format != "" {
+		stream = newListStream(os.Stdout, format, "images")
+	}
+
+	var userImages, systemImages []*client.ListMcpImagesResponseBodyData
+	var walk pageWalk
+	total, totalKnown := 0, true
+	for i, imageType := range imageTypes {
+		typePaging := paging
+		if paging.Limit > 0 {
+			typePaging.Limit = paging.Limit - walk.Items
+			if typePaging.Limit <= 0 {
+				walk.More = true
+				break
+			}
+		}
+		// A second image type starts from its first page.
+		start := pageCursor{PageNo: page}
+		if i > 0 {
+			start.PageNo = 1
+		}
+		w, err := walkPages(ctx, typePaging, start, imagePages(apiClient, imageType, osType, pageSize, progress), func(image *client.ListMcpImagesResponseBodyData) error {
+			if image == nil {
+				return nil
+			}
+			if stream != nil {
+				return stream.write(newImageItemJSON(image))
+			}
+			if imageType == "System" {
+				systemImages = append(systemImages, image)
+			} else {
+				userImages = append(userImages, image)
+			}
+			return nil
+		})
+		if err != nil {
+			if stream != nil {
+				err = stream.fail(err)
+			}
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		if w.Total != nil {
+			total += *w.Total
+		} else {
+			totalKnown = false
+		}
+		walk.Items += w.Items
+		walk.Pages += w.Pages
+		walk.More = walk.More || w.More
+		if len(imageTypes) == 1 {
+			walk.Next = w.Next
+		}
+	}
+
+	if totalKnown && walk.Pages > 0 {
+		walk.Total = &total
+	}
+	if stream != nil {
+		return stream.close(listStreamField{"totalCount", walk.totalCount()})
+	}
+
+	if walk.Items == 0 {
+		fmt.Printf("\n[EMPTY] No images found.\n")
+		return nil
+	}
+	fmt.Printf("\n[OK] Found %d images in %d page(s)\n", walk.Items, walk.Pages)
+	if len(imageTypes) == 1 {
+		fmt.Println()
+		printImageTable(append(userImages, systemImages...), imageTypes[0] == "User")
+	} else {
+		if len(userImages) > 0 {
+			fmt.Printf("\n=== USER IMAGES (%d) ===\n", len(userImages))
+			printImageTable(userImages, true)
+		}
+		if len(systemImages) > 0 {
+			fmt.Printf("\n=== SYSTEM IMAGES (%d) ===\n", len(systemImages))
+			printImageTable(systemImages, false)
+		}
+	}
+	printMorePagesHint(os.Stdout, walk, "--page")
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Recommended session bandwidth range (Mbps) for ADVANCED network activation. Values outside it
+// are passed to the API with a warning; the API enforces the actual limits.
+const (
+	MinSessionBandwidth = 2
+	MaxSessionBandwidth = 200
+)
+
+var (
+	vpcIdPattern     = regexp.MustCompile(`^vpc-[a-z0-9]{8,32}$`)
+	vswitchIdPattern = regexp.MustCompile(`^vsw-[a-z0-9]{8,32}$`)
+	regionIdPattern  = regexp.MustCompile(`^[a-z]{2}(-[a-z0-9]+)+$`)
+)
+
+// ValidateNetworkParams checks the format of the network flags passed to 'image activate'
+// (VPC/VSwitch IDs, DNS addresses, session bandwidth and region ID) without calling any API.
+// It returns every problem found so they can be reported together; an empty result means valid.
+// Flag combinations (e.g. --vpc-id with ADVANCED) are checked separately by runImageActivate.
+func ValidateNetworkParams(networkType, vpcId, vswitchId string, dnsAddresses []string, sessionBandwidth int, regionId string) []string {
+	var problems []string
+
+	if regionId != "" && !regionIdPattern.MatchString(regionId) {
+		problems = append(problems, fmt.Sprintf("--region-id %q is not a valid region ID (expected e.g. cn-hangzhou)", regionId))
+	}
+
+	if networkType == "CUSTOMIZED" {
+		if vpcId != "" && !vpcIdPattern.MatchString(vpcId) {
+			problems = append(problems, fmt.Sprintf("--vpc-id %q is not a valid VPC ID (expected vpc- followed by 8-32 lowercase letters or digits)", vpcId))
+		}
+		if vswitchId != "" && !vswitchIdPattern.MatchString(vswitchId) {
+			problems = append(problems, fmt.Sprintf("--vswitch-id %q is not a valid VSwitch ID (expected vsw- followed by 8-32 lowercase letters or digits)", vswitchId))
+		}
+	}
+
+	if networkType == "ADVANCED" && sessionBandwidth < 0 {
+		problems = append(problems, fmt.Sprintf("--session-bandwidth %d must be a positive number of Mbps", sessionBandwidth))
+	}
+
+	seen := make(map[string]bool)
+	for _, dns := range dnsAddresses {
+		ip := net.ParseIP(strings.TrimSpace(dns))
+		if ip == nil || ip.To4() == nil {
+			problems = append(problems, fmt.Sprintf("--dns-address %q is not a valid IPv4 address", dns))
+			continue
+		}
+		if seen[ip.String()] {
+			problems = append(problems, fmt.Sprintf("--dns-address %s is specified more than once", ip.String()))
+		}
+		seen[ip.String()] = true
+	}
+
+	return problems
+}
+
+// SessionBandwidthWarning returns a warning when an ADVANCED network session bandwidth lies outside
+// the recommended range, or "" when there is nothing to warn about.
+func SessionBandwidthWarning(networkType string, sessionBandwidth int) string {
+	if networkType != "ADVANCED" || sessionBandwidth <= 0 {
+		return ""
+	}
+	if sessionBandwidth < MinSessionBandwidth || sessionBandwidth > MaxSessionBandwidth {
+		return fmt.Sprintf("--session-bandwidth %d Mbps is outside the recommended range of %d-%d Mbps; the API may reject it", sessionBandwidth, MinSessionBandwidth, MaxSessionBandwidth)
+	}
+	return ""
+}
+
+// networkValidationError formats a list of validation problems as a single error, one line per problem.
+func networkValidationError(header string, problems []string) error {
+	lines := []string{"[ERROR] " + header}
+	for _, p := range problems {
+		lines = append(lines, "  - "+p)
+	}
+	lines = append(lines, "[TIP] No changes were made. Fix the values above and run the command again.")
+	return printErrorMessage(lines...)
+}
@@ -0,0 +1,600 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var imageScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Drive pre-open and max-session values from a cron schedule",
+	Long: `Drive pre-open (reserveMinAmount) and max-session values from a schedule file.
+
+The schedule file lists, per image, rules made of a cron expression and the
+pre-open and/or max-session value that takes effect when the expression fires.
+At any point in time the value of a setting is taken from the rule that fired
+most recently. Values are only pushed to the server when they differ from the
+current ones, so 'apply' can safely be run every few minutes.
+
+Schedule file (YAML or JSON):
+
+  timezone: Asia/Shanghai        # optional, defaults to the local time zone
+  images:
+    - imageId: imgc-xxxxxxxxxxxxxx
+      rules:
+        - cron: "0 9 * * 1-5"    # business hours on weekdays
+          preOpen: 20
+          maxSession: 100
+        - cron: "0 20 * * *"     # every evening
+          preOpen: 1
+          maxSession: 10`,
+}
+
+var imageScheduleApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply the values scheduled for the current time once",
+	Long: `Compute the pre-open and max-session values scheduled for the current time and
+apply the ones that changed. Designed to be run from cron or a systemd timer.
+
+Examples:
+  # Apply once
+  agentbay image schedule apply --file schedule.yaml
+
+  # Show what would change without calling any write API
+  agentbay image schedule apply --file schedule.yaml --dry-run
+
+  # Preview the values scheduled for a specific time
+  agentbay image schedule apply --file schedule.yaml --dry-run --at 2025-06-02T09:30:00+08:00
+
+  # crontab entry: re-apply every 5 minutes
+  */5 * * * * agentbay image schedule apply --file /etc/agentbay/schedule.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runImageScheduleApply,
+}
+
+var imageScheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Keep applying the schedule in the foreground",
+	Long: `Apply the schedule immediately and then again at every interval until interrupted.
+The schedule file is re-read on every iteration, so edits take effect without a restart.
+Suitable for running as a long-lived systemd service.
+
+Examples:
+  agentbay image schedule run --file schedule.yaml
+  agentbay image schedule run --file schedule.yaml --interval 5m`,
+	Args: cobra.NoArgs,
+	RunE: runImageScheduleRun,
+}
+
+func init() {
+	imageScheduleApplyCmd.Flags().String("file", "", "Path to the schedule file (required)")
+	imageScheduleApplyCmd.Flags().Bool("dry-run", false, "Show the planned changes without calling any write API")
+	imageScheduleApplyCmd.Flags().String("at", "", "Evaluate the schedule at this RFC3339 time instead of now")
+	imageScheduleApplyCmd.Flags().Bool("force", false, "Apply the scheduled values even if they appear unchanged")
+	_ = imageScheduleApplyCmd.MarkFlagRequired("file")
+
+	imageScheduleRunCmd.Flags().String("file", "", "Path to the schedule file (required)")
+	imageScheduleRunCmd.Flags().Duration("interval", time.Minute, "How often to re-evaluate the schedule")
+	_ = imageScheduleRunCmd.MarkFlagRequired("file")
+
+	imageScheduleCmd.AddCommand(imageScheduleApplyCmd)
+	imageScheduleCmd.AddCommand(imageScheduleRunCmd)
+	ImageCmd.AddCommand(imageScheduleCmd)
+}
+
+// imageScheduleFile is the on-disk schedule file.
+type imageScheduleFile struct {
+	Timezone string               `yaml:"timezone" json:"timezone"`
+	Images   []imageScheduleEntry `yaml:"images" json:"images"`
+
+	location *time.Location
+}
+
+type imageScheduleEntry struct {
+	ImageId string              `yaml:"imageId" json:"imageId"`
+	Rules   []imageScheduleRule `yaml:"rules" json:"rules"`
+}
+
+type imageScheduleRule struct {
+	Cron       string `yaml:"cron" json:"cron"`
+	PreOpen    *int32 `yaml:"preOpen" json:"preOpen"`
+	MaxSession *int32 `yaml:"maxSession" json:"maxSession"`
+
+	schedule *cronSchedule
+}
+
+// imageScheduleTarget holds the values scheduled for one image at a point in time.
+// A nil value means no rule for that setting has fired yet.
+type imageScheduleTarget struct {
+	PreOpen        *int32
+	PreOpenCron    string
+	MaxSession     *int32
+	MaxSessionCron string
+}
+
+// loadImageSchedule reads and validates a schedule file. All validation problems are reported together.
+func loadImageSchedule(path string) (*imageScheduleFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule file: %w", err)
+	}
+	return parseImageSchedule(data)
+}
+
+func parseImageSchedule(data []byte) (*imageScheduleFile, error) {
+	var f imageScheduleFile
+	// JSON is a subset of YAML, so a single decoder handles both formats.
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse schedule file: %w", err)
+	}
+
+	var problems []string
+	f.location = time.Local
+	if f.Timezone != "" {
+		loc, err := time.LoadLocation(f.Timezone)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("timezone %q: %v", f.Timezone, err))
+		} else {
+			f.location = loc
+		}
+	}
+	if len(f.Images) == 0 {
+		problems = append(problems, "no images defined")
+	}
+
+	seen := make(map[string]bool)
+	for i := range f.Images {
+		entry := &f.Images[i]
+		label := fmt.Sprintf("images[%d]", i)
+		if entry.ImageId == "" {
+			problems = append(problems, label+": imageId is required")
+		} else {
+			label = entry.ImageId
+			if seen[entry.ImageId] {
+				problems = append(problems, label+": image is listed more than once")
+			}
+			seen[entry.ImageId] = true
+		}
+		if len(entry.Rules) == 0 {
+			problems = append(problems, label+": at least one rule is required")
+		}
+		for j := range entry.Rules {
+			rule := &entry.Rules[j]
+			ruleLabel := fmt.Sprintf("%s rules[%d]", label, j)
+			sched, err := parseCronExpr(rule.Cron)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", ruleLabel, err))
+			}
+			rule.schedule = sched
+			if rule.PreOpen == nil && rule.MaxSession == nil {
+				problems = append(problems, ruleLabel+": set preOpen and/or maxSession")
+			}
+			if rule.PreOpen != nil && *rule.PreOpen < 1 {
+				problems = append(problems, fmt.Sprintf("%s: preOpen must be >= 1 (got %d)", ruleLabel, *rule.PreOpen))
+			}
+			if rule.MaxSession != nil && *rule.MaxSession < 1 {
+				problems = append(problems, fmt.Sprintf("%s: maxSession must be >= 1 (got %d)", ruleLabel, *rule.MaxSession))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid schedule file:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return &f, nil
+}
+
+// targetAt returns the values scheduled for the image at time t. Each setting is taken from the rule
+// that fired most recently at or before t; when two rules fire at the same minute, the later one in
+// the file wins.
+func (e *imageScheduleEntry) targetAt(t time.Time) imageScheduleTarget {
+	var target imageScheduleTarget
+	var preOpenAt, maxSessionAt time.Time
+	for _, rule := range e.Rules {
+		if rule.schedule == nil {
+			continue
+		}
+		fired, ok := rule.schedule.Prev(t)
+		if !ok {
+			continue
+		}
+		if rule.PreOpen != nil && !fired.Before(preOpenAt) {
+			preOpenAt = fired
+			target.PreOpen = rule.PreOpen
+			target.PreOpenCron = rule.Cron
+		}
+		if rule.MaxSession != nil && !fired.Before(maxSessionAt) {
+			maxSessionAt = fired
+			target.MaxSession = rule.MaxSession
+			target.MaxSessionCron = rule.Cron
+		}
+	}
+	return target
+}
+
+// ---------------------------------------------------------------------------
+// Last-applied state
+// ---------------------------------------------------------------------------
+
+// imageScheduleState records the values last applied by 'image schedule', per image. It is the only
+// source for the current max-session value, which cannot be read back from the server.
+type imageScheduleState struct {
+	Images map[string]*imageScheduleImageState `json:"images"`
+}
+
+type imageScheduleImageState struct {
+	PreOpen    *int32 `json:"pre_open,omitempty"`
+	MaxSession *int32 `json:"max_session,omitempty"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+func imageScheduleStatePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "image_schedule_state.json"), nil
+}
+
+func loadImageScheduleState() (*imageScheduleState, error) {
+	state := &imageScheduleState{}
+	p, err := imageScheduleStatePath()
+	if err != nil {
+		return nil, err
+	}
+	if err := (&config.StateFile{Path: p}).Load(state); err != nil {
+		return nil, err
+	}
+	if state.Images == nil {
+		state.Images = map[string]*imageScheduleImageState{}
+	}
+	return state, nil
+}
+
+// updateImageScheduleState applies fn to the recorded state of imageId under the lock of the
+// state file, reloading it first, so that concurrent runs (e.g. cron and "image schedule apply")
+// do not overwrite each other's records of other images.
+func updateImageScheduleState(imageId string, fn func(st *imageScheduleImageState)) error {
+	p, err := imageScheduleStatePath()
+	if err != nil {
+		return err
+	}
+	state := &imageScheduleState{}
+	return (&config.StateFile{Path: p}).Update(state, func() error {
+		if state.Images == nil {
+			state.Images = map[string]*imageScheduleImageState{}
+		}
+		fn(state.image(imageId))
+		return nil
+	})
+}
+
+func (s *imageScheduleState) image(imageId string) *imageScheduleImageState {
+	st, ok := s.Images[imageId]
+	if !ok {
+		st = &imageScheduleImageState{}
+		s.Images[imageId] = st
+	}
+	return st
+}
+
+// ---------------------------------------------------------------------------
+// apply / run
+// ---------------------------------------------------------------------------
+
+type imageScheduleOptions struct {
+	dryRun bool
+	force  bool
+}
+
+func runImageScheduleApply(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	at, _ := cmd.Flags().GetString("at")
+
+	now := time.Now()
+	if at != "" {
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Invalid --at value %q: expected RFC3339, e.g. 2025-06-02T09:30:00+08:00", at)
+		}
+		now = t
+	}
+
+	sched, err := loadImageSchedule(path)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return config.ErrNotAuthenticated()
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+
+	return applyImageSchedule(context.Background(), apiClient, sched, now, imageScheduleOptions{dryRun: dryRun, force: force})
+}
+
+func runImageScheduleRun(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval < time.Minute {
+		return fmt.Errorf("[ERROR] --interval must be at least 1m")
+	}
+
+	// Validate the file up front so a broken schedule fails fast instead of looping.
+	if _, err := loadImageSchedule(path); err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return config.ErrNotAuthenticated()
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("[SCHEDULE] Applying %s every %s. Press Ctrl+C to stop.\n", path, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		sched, err := loadImageSchedule(path)
+		if err != nil {
+			fmt.Printf("[ERROR] %v\n[INFO] Keeping previous values; will retry in %s.\n", err, interval)
+		} else if err := applyImageSchedule(ctx, apiClient, sched, time.Now(), imageScheduleOptions{}); err != nil {
+			fmt.Printf("%v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("\n[SCHEDULE] Stopped.\n")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyImageSchedule evaluates the schedule at now and pushes the values that changed.
+// Pre-open is compared against DescribeImageReserveMinAmount, max-session against the last
+// value applied by this command. Failures are reported per image and summarized in the error.
+func applyImageSchedule(ctx context.Context, apiClient agentbay.Client, sched *imageScheduleFile, now time.Time, opts imageScheduleOptions) error {
+	now = now.In(sched.location)
+	fmt.Printf("[SCHEDULE] Evaluating schedule at %s\n", now.Format("2006-01-02 15:04 MST"))
+
+	state, err := loadImageScheduleState()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load schedule state: %w", err)
+	}
+
+	imageIds := make([]string, 0, len(sched.Images))
+	for _, entry := range sched.Images {
+		imageIds = append(imageIds, entry.ImageId)
+	}
+	livePreOpen, err := currentPreOpenValues(ctx, apiClient, imageIds)
+	if err != nil {
+		fmt.Printf("[WARN] Could not read current pre-open values, comparing with last applied values instead: %v\n", err)
+	}
+
+	failed := 0
+	for i := range sched.Images {
+		entry := &sched.Images[i]
+		target := entry.targetAt(now)
+		st := state.image(entry.ImageId)
+
+		currentPreOpen := st.PreOpen
+		if v, ok := livePreOpen[entry.ImageId]; ok {
+			currentPreOpen = v
+		}
+		needPreOpen := target.PreOpen != nil && (opts.force || !int32PtrEqual(currentPreOpen, target.PreOpen))
+		needMaxSession := target.MaxSession != nil && (opts.force || !int32PtrEqual(st.MaxSession, target.MaxSession))
+
+		if target.PreOpen == nil && target.MaxSession == nil {
+			fmt.Printf("[SKIP] %s: no rule has fired yet\n", entry.ImageId)
+			continue
+		}
+		if !needPreOpen && !needMaxSession {
+			fmt.Printf("[UNCHANGED] %s: %s\n", entry.ImageId, formatScheduleTarget(target))
+			continue
+		}
+
+		fmt.Printf("[CHANGE] %s:", entry.ImageId)
+		if needMaxSession {
+			fmt.Printf(" max-session %s -> %d (rule %q)", formatInt32Ptr(st.MaxSession), *target.MaxSession, target.MaxSessionCron)
+		}
+		if needPreOpen {
+			fmt.Printf(" pre-open %s -> %d (rule %q)", formatInt32Ptr(currentPreOpen), *target.PreOpen, target.PreOpenCron)
+		}
+		fmt.Printf("\n")
+		if opts.dryRun {
+			continue
+		}
+
+		if err := applyImageScheduleTarget(ctx, apiClient, entry.ImageId, target, needPreOpen, needMaxSession); err != nil {
+			fmt.Printf("[ERROR] %s: %v\n", entry.ImageId, err)
+			failed++
+		}
+	}
+
+	if opts.dryRun {
+		fmt.Printf("[INFO] Dry run: no changes were applied.\n")
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("[ERROR] %d of %d image(s) failed to apply the schedule", failed, len(sched.Images))
+	}
+	return nil
+}
+
+// applyImageScheduleTarget pushes the changed values for one image. Max-session is applied first
+// because it creates the hidden resource groups that pre-open is then applied to.
+func applyImageScheduleTarget(ctx context.Context, apiClient agentbay.Client, imageId string, target imageScheduleTarget, needPreOpen, needMaxSession bool) error {
+	statusCtx, statusCancel := context.WithTimeout(ctx, 60*time.Second)
+	defer statusCancel()
+	imageInfo, err := GetImageInfo(statusCtx, apiClient, imageId)
+	if err != nil {
+		return fmt.Errorf("failed to get image info: %w", err)
+	}
+	if !IsUserImage(imageInfo.ImageType) {
+		return fmt.Errorf("only User images can be scheduled (current type: %s)", imageInfo.ImageType)
+	}
+	if !IsActivated(imageInfo.ResourceStatus) {
+		return fmt.Errorf("image must be in activated state (current status: %s)", TranslateImageResourceStatus(imageInfo.ResourceStatus))
+	}
+
+	if needMaxSession {
+		apiCtx, apiCancel := context.WithTimeout(ctx, 60*time.Second)
+		requestId, err := setImageMaxSession(apiCtx, apiClient, imageId, *target.MaxSession)
+		apiCancel()
+		if requestId != "" {
+			fmt.Printf("[INFO] BatchCreateHideResourceGroupsWithMaxSession Request ID: %s\n", requestId)
+		}
+		if err != nil {
+			return err
+		}
+		if err := updateImageScheduleState(imageId, func(st *imageScheduleImageState) {
+			st.MaxSession = target.MaxSession
+			st.UpdatedAt = time.Now().Format(time.RFC3339)
+		}); err != nil {
+			fmt.Printf("[WARN] Failed to save schedule state: %v\n", err)
+		}
+		fmt.Printf("[OK] %s: max-session set to %d\n", imageId, *target.MaxSession)
+
+		if needPreOpen {
+			fmt.Printf("[INFO] Waiting for resource groups to be ready before updating pre-open...\n")
+			if err := PollForResourceGroupReady(ctx, apiClient, imageId, DefaultSetMaxSessionPollingConfig()); err != nil {
+				return fmt.Errorf("resource groups not ready: %w", err)
+			}
+		}
+	}
+
+	if needPreOpen {
+		apiCtx, apiCancel := context.WithTimeout(ctx, 60*time.Second)
+		requestId, err := updateImagePreOpen(apiCtx, apiClient, imageId, *target.PreOpen)
+		apiCancel()
+		if requestId != "" {
+			fmt.Printf("[INFO] UpdateImageReserveMinAmount Request ID: %s\n", requestId)
+		}
+		if err != nil {
+			return err
+		}
+		if err := updateImageScheduleState(imageId, func(st *imageScheduleImageState) {
+			st.PreOpen = target.PreOpen
+			st.UpdatedAt = time.Now().Format(time.RFC3339)
+		}); err != nil {
+			fmt.Printf("[WARN] Failed to save schedule state: %v\n", err)
+		}
+		fmt.Printf("[OK] %s: pre-open set to %d\n", imageId, *target.PreOpen)
+	}
+	return nil
+}
+
+// currentPreOpenValues reads the configured pre-open value of each image. An image whose resource
+// groups disagree maps to nil (treated as changed); images missing from the response are omitted.
+func currentPreOpenValues(ctx context.Context, apiClient agentbay.Client, imageIds []string) (map[string]*int32, error) {
+	values := make(map[string]*int32)
+	for start := 0; start < len(imageIds); start += 100 {
+		end := start + 100
+		if end > len(imageIds) {
+			end = len(imageIds)
+		}
+		req := &client.DescribeImageReserveMinAmountRequest{}
+		req.SetImageIds(imageIds[start:end])
+		req.SetMaxResults(500)
+		for {
+			var resp *client.DescribeImageReserveMinAmountResponse
+			apiCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			err := withTransientRetry(apiCtx, client.DefaultRetryConfig(), "DescribeImageReserveMinAmount", func() error {
+				var e error
+				resp, e = apiClient.DescribeImageReserveMinAmount(apiCtx, req)
+				return e
+			})
+			cancel()
+			if err != nil {
+				return nil, err
+			}
+			if resp == nil || resp.Body == nil {
+				return nil, fmt.Errorf("invalid response: missing body")
+			}
+			if resp.Body.Success != nil && !*resp.Body.Success {
+				return nil, fmt.Errorf("server returned error: code=%s, message=%s", resp.Body.GetCode(), getStringValue(resp.Body.Message))
+			}
+			for _, img := range resp.Body.GetData().GetImages() {
+				if img == nil || img.ImageId == nil {
+					continue
+				}
+				var value *int32
+				consistent := true
+				for _, rg := range img.GetResourceGroups() {
+					if rg == nil || rg.ReserveMinAmount == nil {
+						continue
+					}
+					if value != nil && *value != *rg.ReserveMinAmount {
+						consistent = false
+						break
+					}
+					value = rg.ReserveMinAmount
+				}
+				if !consistent {
+					value = nil
+				}
+				values[*img.ImageId] = value
+			}
+			token := resp.Body.GetData().GetNextToken()
+			if token == "" {
+				break
+			}
+			req.SetNextToken(token)
+		}
+	}
+	return values, nil
+}
+
+func int32PtrEqual(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func formatInt32Ptr(v *int32) string {
+	if v == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
+func formatScheduleTarget(t imageScheduleTarget) string {
+	var parts []string
+	if t.MaxSession != nil {
+		parts = append(parts, fmt.Sprintf("max-session %d", *t.MaxSession))
+	}
+	if t.PreOpen != nil {
+		parts = append(parts, fmt.Sprintf("pre-open %d", *t.PreOpen))
+	}
+	return strings.Join(parts, ", ")
+}
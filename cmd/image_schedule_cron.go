@@ -0,0 +1,197 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronLookbackDays bounds how far back cronSchedule.Prev searches for the last firing time.
+// Four years and a day cover every expression that can be written with the five standard fields,
+// including "0 0 29 2 *": leap days are at most 1461 days apart until 2100.
+const cronLookbackDays = 1462
+
+// cronSchedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week).
+// Each field is a bit set of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinuteField = cronField{name: "minute", min: 0, max: 59}
+	cronHourField   = cronField{name: "hour", min: 0, max: 23}
+	cronDomField    = cronField{name: "day-of-month", min: 1, max: 31}
+	cronMonthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDowField = cronField{name: "day-of-week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCronExpr parses a standard five-field cron expression. Fields support "*", lists ("1,3"),
+// ranges ("1-5"), steps ("*/15", "9-18/3"), month/weekday names ("jan", "mon-fri") and the
+// @hourly/@daily/@weekly/@monthly/@yearly macros. Day-of-week accepts both 0 and 7 for Sunday.
+func parseCronExpr(expr string) (*cronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	s := &cronSchedule{}
+	var err error
+	if s.minute, err = cronMinuteField.parse(fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if s.hour, err = cronHourField.parse(fields[1]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if s.dom, err = cronDomField.parse(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if s.month, err = cronMonthField.parse(fields[3]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if s.dow, err = cronDowField.parse(fields[4]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	// 7 is an alias for Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	return s, nil
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	if field == "?" {
+		field = "*"
+	}
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		if part == "" {
+			return 0, fmt.Errorf("empty value in %s field %q", f.name, field)
+		}
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			rangePart = part[:idx]
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, part)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// "5/15" means "5-max/15"
+				hi = f.max
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range in %s field %q", f.name, part)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q", f.name, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s value %d out of range (%d-%d)", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// dayMatches follows the usual cron rule: when both day-of-month and day-of-week are restricted,
+// a day matches if either field matches.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Prev returns the latest minute at or before t matched by the schedule, in t's location.
+// ok is false when the schedule has not fired within cronLookbackDays.
+func (s *cronSchedule) Prev(t time.Time) (time.Time, bool) {
+	loc := t.Location()
+	start := t.Truncate(time.Minute)
+	for offset := 0; offset <= cronLookbackDays; offset++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()-offset, 0, 0, 0, 0, loc)
+		if !s.dayMatches(day) {
+			continue
+		}
+		hourStart := 23
+		if offset == 0 {
+			hourStart = start.Hour()
+		}
+		for h := hourStart; h >= 0; h-- {
+			if s.hour&(1<<uint(h)) == 0 {
+				continue
+			}
+			minuteStart := 59
+			if offset == 0 && h == start.Hour() {
+				minuteStart = start.Minute()
+			}
+			for m := minuteStart; m >= 0; m-- {
+				if s.minute&(1<<uint(m)) != 0 {
+					return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, loc), true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
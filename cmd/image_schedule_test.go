@@ -0,0 +1,284 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestParseCronExpr(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"0 9 * * 1-5",
+		"*/15 8-18 * * mon-fri",
+		"30 2 1,15 * *",
+		"0 0 * jan,jul sun",
+		"5/10 * * * 7",
+		"@daily",
+		"@Hourly",
+	}
+	for _, expr := range valid {
+		_, err := parseCronExpr(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+		"@every5m",
+	}
+	for _, expr := range invalid {
+		_, err := parseCronExpr(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronSchedulePrev(t *testing.T) {
+	loc := time.UTC
+	// 2025-06-04 is a Wednesday
+	now := time.Date(2025, 6, 4, 10, 17, 42, 0, loc)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 6, 4, 10, 17, 0, 0, loc)},
+		{"0 9 * * *", time.Date(2025, 6, 4, 9, 0, 0, 0, loc)},
+		{"0 11 * * *", time.Date(2025, 6, 3, 11, 0, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2025, 6, 4, 10, 15, 0, 0, loc)},
+		{"0 9 * * sat,sun", time.Date(2025, 6, 1, 9, 0, 0, 0, loc)},
+		{"0 0 1 * *", time.Date(2025, 6, 1, 0, 0, 0, 0, loc)},
+		{"0 0 1 1 *", time.Date(2025, 1, 1, 0, 0, 0, 0, loc)},
+		{"0 9 * * 7", time.Date(2025, 6, 1, 9, 0, 0, 0, loc)},
+		// day-of-month OR day-of-week when both are restricted: the 2nd (Monday) matches via dom=2
+		{"0 8 2 * 5", time.Date(2025, 6, 2, 8, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		s, err := parseCronExpr(tt.expr)
+		require.NoError(t, err, tt.expr)
+		got, ok := s.Prev(now)
+		require.True(t, ok, tt.expr)
+		assert.Equal(t, tt.want, got, tt.expr)
+	}
+
+	t.Run("leap day", func(t *testing.T) {
+		s, err := parseCronExpr("0 0 29 2 *")
+		require.NoError(t, err)
+		got, ok := s.Prev(time.Date(2028, 2, 28, 23, 59, 0, 0, loc))
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, loc), got)
+	})
+
+	t.Run("never fires within lookback", func(t *testing.T) {
+		s, err := parseCronExpr("0 0 31 2 *")
+		require.NoError(t, err)
+		_, ok := s.Prev(now)
+		assert.False(t, ok)
+	})
+}
+
+func TestParseImageSchedule(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		sched, err := parseImageSchedule([]byte(`
+timezone: Asia/Shanghai
+images:
+  - imageId: imgc-aaa
+    rules:
+      - cron: "0 9 * * 1-5"
+        preOpen: 10
+        maxSession: 50
+      - cron: "0 20 * * *"
+        preOpen: 1
+`))
+		require.NoError(t, err)
+		require.Len(t, sched.Images, 1)
+		assert.Equal(t, "Asia/Shanghai", sched.location.String())
+		rules := sched.Images[0].Rules
+		require.Len(t, rules, 2)
+		assert.Equal(t, int32(10), *rules[0].PreOpen)
+		assert.Equal(t, int32(50), *rules[0].MaxSession)
+		assert.Nil(t, rules[1].MaxSession)
+		assert.NotNil(t, rules[1].schedule)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		sched, err := parseImageSchedule([]byte(`{"images":[{"imageId":"imgc-aaa","rules":[{"cron":"@daily","maxSession":5}]}]}`))
+		require.NoError(t, err)
+		assert.Equal(t, time.Local, sched.location)
+		assert.Equal(t, int32(5), *sched.Images[0].Rules[0].MaxSession)
+	})
+
+	t.Run("reports every problem", func(t *testing.T) {
+		_, err := parseImageSchedule([]byte(`
+timezone: Mars/Olympus
+images:
+  - imageId: imgc-aaa
+    rules:
+      - cron: "0 25 * * *"
+        preOpen: 0
+      - cron: "0 9 * * *"
+  - imageId: imgc-aaa
+    rules: []
+  - rules:
+      - cron: "@daily"
+        maxSession: -1
+`))
+		require.Error(t, err)
+		msg := err.Error()
+		assert.Contains(t, msg, "timezone")
+		assert.Contains(t, msg, "imgc-aaa rules[0]: invalid cron expression")
+		assert.Contains(t, msg, "preOpen must be >= 1")
+		assert.Contains(t, msg, "imgc-aaa rules[1]: set preOpen and/or maxSession")
+		assert.Contains(t, msg, "image is listed more than once")
+		assert.Contains(t, msg, "at least one rule is required")
+		assert.Contains(t, msg, "images[2]: imageId is required")
+		assert.Contains(t, msg, "maxSession must be >= 1")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := parseImageSchedule([]byte(`images: []`))
+		assert.ErrorContains(t, err, "no images defined")
+	})
+}
+
+func TestImageScheduleTargetAt(t *testing.T) {
+	sched, err := parseImageSchedule([]byte(`
+timezone: UTC
+images:
+  - imageId: imgc-aaa
+    rules:
+      - cron: "0 9 * * 1-5"
+        preOpen: 10
+        maxSession: 50
+      - cron: "0 20 * * *"
+        preOpen: 1
+        maxSession: 10
+      - cron: "0 12 * * *"
+        preOpen: 20
+`))
+	require.NoError(t, err)
+	entry := &sched.Images[0]
+
+	// Wednesday 10:00: the 09:00 weekday rule is the latest for both settings
+	target := entry.targetAt(time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, int32(10), *target.PreOpen)
+	assert.Equal(t, int32(50), *target.MaxSession)
+	assert.Equal(t, "0 9 * * 1-5", target.MaxSessionCron)
+
+	// Wednesday 13:00: pre-open follows the noon rule, max-session still follows 09:00
+	target = entry.targetAt(time.Date(2025, 6, 4, 13, 0, 0, 0, time.UTC))
+	assert.Equal(t, int32(20), *target.PreOpen)
+	assert.Equal(t, "0 12 * * *", target.PreOpenCron)
+	assert.Equal(t, int32(50), *target.MaxSession)
+
+	// Saturday 10:00: the weekday rule has not fired since Friday; Friday 20:00 wins
+	target = entry.targetAt(time.Date(2025, 6, 7, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, int32(1), *target.PreOpen)
+	assert.Equal(t, int32(10), *target.MaxSession)
+
+	t.Run("same minute: later rule wins", func(t *testing.T) {
+		s, err := parseImageSchedule([]byte(`
+images:
+  - imageId: imgc-aaa
+    rules:
+      - cron: "0 9 * * *"
+        maxSession: 5
+      - cron: "0 9 * * *"
+        maxSession: 7
+`))
+		require.NoError(t, err)
+		target := s.Images[0].targetAt(time.Date(2025, 6, 4, 9, 30, 0, 0, time.Local))
+		assert.Equal(t, int32(7), *target.MaxSession)
+		assert.Nil(t, target.PreOpen)
+	})
+}
+
+// mockScheduleClient serves DescribeImageReserveMinAmount for currentPreOpenValues tests.
+type mockScheduleClient struct {
+	agentbay.Client
+	pages [][]*client.DescribeImageReserveMinAmountImage
+	calls int
+}
+
+func (m *mockScheduleClient) DescribeImageReserveMinAmount(ctx context.Context, req *client.DescribeImageReserveMinAmountRequest) (*client.DescribeImageReserveMinAmountResponse, error) {
+	page := m.pages[m.calls]
+	m.calls++
+	data := &client.DescribeImageReserveMinAmountResponseBodyData{Images: page}
+	if m.calls < len(m.pages) {
+		data.NextToken = dara.String("next")
+	}
+	return &client.DescribeImageReserveMinAmountResponse{Body: &client.DescribeImageReserveMinAmountResponseBody{
+		Success: dara.Bool(true),
+		Data:    data,
+	}}, nil
+}
+
+func TestCurrentPreOpenValues(t *testing.T) {
+	rg := func(v int32) *client.DescribeImageReserveMinAmountResourceGroup {
+		return &client.DescribeImageReserveMinAmountResourceGroup{ReserveMinAmount: dara.Int32(v)}
+	}
+	mock := &mockScheduleClient{pages: [][]*client.DescribeImageReserveMinAmountImage{
+		{
+			{ImageId: dara.String("imgc-same"), ResourceGroups: []*client.DescribeImageReserveMinAmountResourceGroup{rg(3), rg(3)}},
+			{ImageId: dara.String("imgc-mixed"), ResourceGroups: []*client.DescribeImageReserveMinAmountResourceGroup{rg(3), rg(5)}},
+		},
+		{
+			{ImageId: dara.String("imgc-none")},
+		},
+	}}
+
+	values, err := currentPreOpenValues(context.Background(), mock, []string{"imgc-same", "imgc-mixed", "imgc-none", "imgc-missing"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, mock.calls)
+	require.Contains(t, values, "imgc-same")
+	assert.Equal(t, int32(3), *values["imgc-same"])
+	require.Contains(t, values, "imgc-mixed")
+	assert.Nil(t, values["imgc-mixed"])
+	require.Contains(t, values, "imgc-none")
+	assert.Nil(t, values["imgc-none"])
+	assert.NotContains(t, values, "imgc-missing")
+}
+
+func TestImageScheduleStateRoundTrip(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+
+	state, err := loadImageScheduleState()
+	require.NoError(t, err)
+	assert.Empty(t, state.Images)
+
+	require.NoError(t, updateImageScheduleState("imgc-aaa", func(st *imageScheduleImageState) {
+		st.MaxSession = dara.Int32(50)
+	}))
+	// Each update reloads the file, so recording imgc-bbb keeps imgc-aaa.
+	require.NoError(t, updateImageScheduleState("imgc-bbb", func(st *imageScheduleImageState) {
+		st.PreOpen = dara.Int32(3)
+	}))
+
+	loaded, err := loadImageScheduleState()
+	require.NoError(t, err)
+	require.Contains(t, loaded.Images, "imgc-aaa")
+	assert.Equal(t, int32(50), *loaded.Images["imgc-aaa"].MaxSession)
+	assert.Nil(t, loaded.Images["imgc-aaa"].PreOpen)
+	require.Contains(t, loaded.Images, "imgc-bbb")
+	assert.Equal(t, int32(3), *loaded.Images["imgc-bbb"].PreOpen)
+}
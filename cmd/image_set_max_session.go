@@ -45,6 +45,7 @@ func init() {
 	imageSetMaxSessionCmd.Flags().Int32("max-session-num", 0, "Maximum concurrent session count (required, must be >= 1)")
 
 	imageSetMaxSessionCmd.MarkFlagRequired("image-id")
+	registerFlagCompletion(imageSetMaxSessionCmd, "image-id", completeFlag(completionImages, userImagesOnly))
 	imageSetMaxSessionCmd.MarkFlagRequired("max-session-num")
 }
 
@@ -104,30 +105,13 @@ func runImageSetMaxSession(cmd *cobra.Command, args []string) error {
 	apiCtx, apiCancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer apiCancel()
 
-	request := &client.BatchCreateHideResourceGroupsWithMaxSessionRequest{}
-	request.SetImageId(imageId)
-	request.SetMaxSessionNum(maxSessionNum)
-
-	resp, err := apiClient.BatchCreateHideResourceGroupsWithMaxSession(apiCtx, request)
-	if err != nil {
-		return fmt.Errorf("failed to set max session: %w", err)
-	}
-
+	requestId, err := setImageMaxSession(apiCtx, apiClient, imageId, maxSessionNum)
 	// Print RequestId regardless of success/failure
-	if resp != nil && resp.Body != nil {
-		requestId := resp.Body.GetRequestId()
-		if requestId != "" {
-			fmt.Printf("[INFO] BatchCreateHideResourceGroupsWithMaxSession Request ID: %s\n", requestId)
-		}
-
-		if !resp.Body.GetSuccess() {
-			code := resp.Body.GetCode()
-			message := ""
-			if resp.Body.Message != nil {
-				message = dara.StringValue(resp.Body.Message)
-			}
-			return fmt.Errorf("server returned error: code=%s, message=%s", code, message)
-		}
+	if requestId != "" {
+		fmt.Printf("[INFO] BatchCreateHideResourceGroupsWithMaxSession Request ID: %s\n", requestId)
+	}
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("[OK] Max session count set successfully. Waiting for resource group to be ready...\n")
@@ -143,3 +127,31 @@ func runImageSetMaxSession(cmd *cobra.Command, args []string) error {
 	fmt.Printf("[DONE] Image '%s' max session count has been set to %d.\n", imageId, maxSessionNum)
 	return nil
 }
+
+// setImageMaxSession calls BatchCreateHideResourceGroupsWithMaxSession for imageId and returns the
+// Request ID, which is set even when the server rejects the request. It does not wait for the
+// resource groups to become ready; use PollForResourceGroupReady for that.
+func setImageMaxSession(ctx context.Context, apiClient agentbay.Client, imageId string, maxSessionNum int32) (string, error) {
+	request := &client.BatchCreateHideResourceGroupsWithMaxSessionRequest{}
+	request.SetImageId(imageId)
+	request.SetMaxSessionNum(maxSessionNum)
+
+	resp, err := apiClient.BatchCreateHideResourceGroupsWithMaxSession(ctx, request)
+	if err != nil {
+		return extractRequestIDFromErr(err), fmt.Errorf("failed to set max session: %w", err)
+	}
+	if resp == nil || resp.Body == nil {
+		return "", nil
+	}
+
+	requestId := resp.Body.GetRequestId()
+	if !resp.Body.GetSuccess() {
+		code := resp.Body.GetCode()
+		message := ""
+		if resp.Body.Message != nil {
+			message = dara.StringValue(resp.Body.Message)
+		}
+		return requestId, fmt.Errorf("server returned error: code=%s, message=%s", code, message)
+	}
+	return requestId, nil
+}
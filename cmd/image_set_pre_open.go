@@ -47,6 +47,7 @@ func init() {
 	imageSetPreOpenCmd.Flags().Int32("pre-open", 0, "Pre-open value (reserveMinAmount, required, must be >= 1)")
 
 	imageSetPreOpenCmd.MarkFlagRequired("image-id")
+	registerFlagCompletion(imageSetPreOpenCmd, "image-id", completeFlag(completionImages, userImagesOnly))
 	imageSetPreOpenCmd.MarkFlagRequired("pre-open")
 }
 
@@ -106,34 +107,44 @@ func runImageSetPreOpen(cmd *cobra.Command, args []string) error {
 	apiCtx, apiCancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer apiCancel()
 
+	requestId, err := updateImagePreOpen(apiCtx, apiClient, imageId, preOpen)
+	// Print RequestId regardless of success/failure
+	if requestId != "" {
+		fmt.Printf("[INFO] UpdateImageReserveMinAmount Request ID: %s\n", requestId)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("[OK] Pre-open has been set to %d for image '%s'.\n", preOpen, imageId)
+	fmt.Printf("[INFO] Expansion is processed asynchronously; shrinkage is processed synchronously. Use 'agentbay image describe-pre-open' to verify configured pre-open values (not runtime instance status).\n")
+	return nil
+}
+
+// updateImagePreOpen calls UpdateImageReserveMinAmount for all resource groups of imageId and
+// returns the Request ID, which is set even when the server rejects the request.
+func updateImagePreOpen(ctx context.Context, apiClient agentbay.Client, imageId string, preOpen int32) (string, error) {
 	request := &client.UpdateImageReserveMinAmountRequest{}
 	request.SetImageId(imageId)
 	request.SetReserveMinAmount(preOpen)
 
-	resp, err := apiClient.UpdateImageReserveMinAmount(apiCtx, request)
+	resp, err := apiClient.UpdateImageReserveMinAmount(ctx, request)
 	if err != nil {
-		return fmt.Errorf("failed to set pre-open: %w", err)
+		return extractRequestIDFromErr(err), fmt.Errorf("failed to set pre-open: %w", err)
+	}
+	if resp == nil || resp.Body == nil {
+		return "", nil
 	}
 
-	// Print RequestId regardless of success/failure
-	if resp != nil && resp.Body != nil {
-		requestId := resp.Body.GetRequestId()
-		if requestId != "" {
-			fmt.Printf("[INFO] UpdateImageReserveMinAmount Request ID: %s\n", requestId)
-		}
-
-		code := resp.Body.GetCode()
-		successPtr := resp.Body.Success
-		if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
-			message := ""
-			if resp.Body.Message != nil {
-				message = dara.StringValue(resp.Body.Message)
-			}
-			return fmt.Errorf("server returned error: code=%s, message=%s", code, message)
+	requestId := resp.Body.GetRequestId()
+	code := resp.Body.GetCode()
+	successPtr := resp.Body.Success
+	if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
+		message := ""
+		if resp.Body.Message != nil {
+			message = dara.StringValue(resp.Body.Message)
 		}
+		return requestId, fmt.Errorf("server returned error: code=%s, message=%s", code, message)
 	}
-
-	fmt.Printf("[OK] Pre-open has been set to %d for image '%s'.\n", preOpen, imageId)
-	fmt.Printf("[INFO] Expansion is processed asynchronously; shrinkage is processed synchronously. Use 'agentbay image describe-pre-open' to verify configured pre-open values (not runtime instance status).\n")
-	return nil
+	return requestId, nil
 }
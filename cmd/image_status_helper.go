@@ -5,57 +5,35 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/agentbay/agentbay-cli/internal/agentbay"
 	"github.com/agentbay/agentbay-cli/internal/client"
+	sdk "github.com/agentbay/agentbay-cli/pkg/agentbay"
 	"github.com/alibabacloud-go/tea/dara"
 )
 
 // ImageResourceStatus represents the possible states of an image resource
-type ImageResourceStatus string
+type ImageResourceStatus = sdk.ImageStatus
 
 const (
-	StatusImageCreating       ImageResourceStatus = "IMAGE_CREATING"
-	StatusImageCreateFailed   ImageResourceStatus = "IMAGE_CREATE_FAILED"
-	StatusImageAvailable      ImageResourceStatus = "IMAGE_AVAILABLE"
-	StatusResourceDeploying   ImageResourceStatus = "RESOURCE_DEPLOYING"
-	StatusResourcePublished   ImageResourceStatus = "RESOURCE_PUBLISHED"
-	StatusResourceDeleting    ImageResourceStatus = "RESOURCE_DELETING"
-	StatusResourceFailed      ImageResourceStatus = "RESOURCE_FAILED"
-	StatusResourceCeased      ImageResourceStatus = "RESOURCE_CEASED"
-	StatusResourceMaintaining ImageResourceStatus = "RESOURCE_MAINTAINING"
+	StatusImageCreating       = sdk.StatusImageCreating
+	StatusImageCreateFailed   = sdk.StatusImageCreateFailed
+	StatusImageAvailable      = sdk.StatusImageAvailable
+	StatusResourceDeploying   = sdk.StatusResourceDeploying
+	StatusResourcePublished   = sdk.StatusResourcePublished
+	StatusResourceDeleting    = sdk.StatusResourceDeleting
+	StatusResourceFailed      = sdk.StatusResourceFailed
+	StatusResourceCeased      = sdk.StatusResourceCeased
+	StatusResourceMaintaining = sdk.StatusResourceMaintaining
 )
 
 // TranslateImageResourceStatus translates the raw status to a human-readable format
 func TranslateImageResourceStatus(status string) string {
-	switch ImageResourceStatus(status) {
-	case StatusImageCreating:
-		return "Creating"
-	case StatusImageCreateFailed:
-		return "Create Failed"
-	case StatusImageAvailable:
-		return "Available (Deactivated)"
-	case StatusResourceDeploying:
-		return "Activating"
-	case StatusResourcePublished:
-		return "Activated"
-	case StatusResourceDeleting:
-		return "Deactivating"
-	case StatusResourceFailed:
-		return "Activation Failed"
-	case StatusResourceCeased:
-		return "Ceased"
-	case StatusResourceMaintaining:
-		return "Maintaining"
-	default:
-		if status == "" {
-			return "Unknown"
-		}
-		return status
-	}
+	return ImageResourceStatus(status).Text()
 }
 
 // IsActivated checks if the image is in activated state
@@ -80,35 +58,18 @@ func IsDeactivating(status string) bool {
 
 // IsFailed checks if the operation has failed
 func IsFailed(status string) bool {
-	s := ImageResourceStatus(status)
-	return s == StatusImageCreateFailed || s == StatusResourceFailed
+	return ImageResourceStatus(status).IsFailed()
 }
 
 // IsTerminalState checks if the status is a final state (success or failure)
 func IsTerminalState(status string) bool {
-	s := ImageResourceStatus(status)
-	return s == StatusResourcePublished ||
-		s == StatusImageAvailable ||
-		s == StatusImageCreateFailed ||
-		s == StatusResourceFailed ||
-		s == StatusResourceCeased
+	return ImageResourceStatus(status).IsTerminal()
 }
 
 // IsDeletable checks if the image can be physically deleted.
 // Returns false for statuses that are in-progress or active states where deletion is not allowed.
 func IsDeletable(status string) bool {
-	s := ImageResourceStatus(status)
-	switch s {
-	case StatusImageCreating,
-		StatusResourceDeploying,
-		StatusResourceDeleting,
-		StatusResourcePublished,
-		StatusResourceFailed,
-		StatusResourceMaintaining:
-		return false
-	default:
-		return true
-	}
+	return ImageResourceStatus(status).IsDeletable()
 }
 
 // IsAuthenticationError checks if the error is an authentication error
@@ -174,6 +135,16 @@ func DefaultDeactivatePollingConfig() PollingConfig {
 	}
 }
 
+// waitOptions converts the polling configuration to the options of the SDK wait helpers.
+func (c PollingConfig) waitOptions() sdk.WaitOptions {
+	return sdk.WaitOptions{
+		Interval:    c.InitialInterval,
+		MaxInterval: c.MaxInterval,
+		Timeout:     c.Timeout,
+		MaxAttempts: c.MaxAttempts,
+	}
+}
+
 // ImageInfo contains image status and type information
 type ImageInfo struct {
 	ResourceStatus     string // IMAGE_AVAILABLE, RESOURCE_PUBLISHED, etc.
@@ -185,89 +156,22 @@ type ImageInfo struct {
 
 // GetImageInfo retrieves the current status and type for the given image ID
 func GetImageInfo(ctx context.Context, apiClient agentbay.Client, imageId string) (*ImageInfo, error) {
-	request := &client.GetMcpImageInfoRequest{}
-	request.SetImageId(imageId)
-
-	resp, err := apiClient.GetMcpImageInfo(ctx, request)
+	info, err := sdk.GetImageInfo(ctx, apiClient, imageId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get image info: %w", err)
-	}
-
-	if resp == nil || resp.Body == nil {
-		return nil, fmt.Errorf("invalid response from GetMcpImageInfo")
-	}
-
-	if !dara.BoolValue(resp.Body.Success) {
-		code := dara.StringValue(resp.Body.Code)
-		message := dara.StringValue(resp.Body.Message)
-		return nil, fmt.Errorf("GetMcpImageInfo failed: %s - %s", code, message)
-	}
-
-	if resp.Body.Data == nil {
-		return nil, fmt.Errorf("no data in GetMcpImageInfo response")
-	}
-
-	info := &ImageInfo{}
-
-	// Capture RequestId for caller-side logging
-	if resp.Body.RequestId != nil {
-		info.RequestId = dara.StringValue(resp.Body.RequestId)
-	}
-
-	// Try to get ImageResourceStatus from response headers (stored during XML parsing or wrapper)
-	if resp.Headers != nil {
-		if resourceStatus, ok := resp.Headers["X-Image-Resource-Status"]; ok && resourceStatus != nil {
-			info.ResourceStatus = dara.StringValue(resourceStatus)
-		}
-
-		if imageType, ok := resp.Headers["X-Image-Type"]; ok && imageType != nil {
-			info.ImageType = dara.StringValue(imageType)
-		}
-	}
-
-	// JSON body fields (SDK path does not populate X-* headers)
-	if info.ResourceStatus == "" && resp.Body.Data.ImageResourceStatus != nil {
-		info.ResourceStatus = dara.StringValue(resp.Body.Data.ImageResourceStatus)
-	}
-
-	// Fallback to ImageInfo.Status if ImageResourceStatus not available
-	if info.ResourceStatus == "" && resp.Body.Data.ImageInfo != nil && resp.Body.Data.ImageInfo.Status != nil {
-		imageInfoStatus := dara.StringValue(resp.Body.Data.ImageInfo.Status)
-		info.ResourceStatus = imageInfoStatus
+		return nil, err
 	}
-
-	if info.ImageType == "" && resp.Body.Data.ImageInfo != nil && resp.Body.Data.ImageInfo.ImageType != nil {
-		info.ImageType = dara.StringValue(resp.Body.Data.ImageInfo.ImageType)
-	}
-
-	// Extract OsName from ImageInfo
-	if resp.Body.Data.ImageInfo != nil && resp.Body.Data.ImageInfo.OsName != nil {
-		info.OsName = dara.StringValue(resp.Body.Data.ImageInfo.OsName)
-	}
-
-	// Extract ResourceGroupReady
-	if resp.Body.Data.ResourceGroupReady != nil {
-		info.ResourceGroupReady = dara.BoolValue(resp.Body.Data.ResourceGroupReady)
-	}
-
-	// Match `image list` bucketing when the API omits ImageType on the JSON path
-	if info.ImageType == "" {
-		info.ImageType = inferImageTypeFromImageID(imageId)
-	}
-
-	if info.ResourceStatus == "" {
-		return nil, fmt.Errorf("no status information available in response")
-	}
-
-	return info, nil
+	return &ImageInfo{
+		ResourceStatus:     string(info.Status),
+		ImageType:          info.ImageType,
+		OsName:             info.OsName,
+		RequestId:          info.RequestId,
+		ResourceGroupReady: info.ResourceGroupReady,
+	}, nil
 }
 
 // inferImageTypeFromImageID returns User vs System using the same imgc- rule as runImageListWithBothTypes.
 func inferImageTypeFromImageID(imageId string) string {
-	if strings.HasPrefix(imageId, "imgc-") {
-		return "User"
-	}
-	return "System"
+	return sdk.ImageTypeFromID(imageId)
 }
 
 // GetImageResourceStatus retrieves the current ImageResourceStatus for the given image ID
@@ -345,90 +249,60 @@ func IsSystemImage(imageType string) bool {
 
 // PollForActivation polls the image status until it reaches RESOURCE_PUBLISHED or fails
 func PollForActivation(ctx context.Context, apiClient agentbay.Client, imageId string, config PollingConfig) error {
-	return pollForStatus(ctx, apiClient, imageId, config, []ImageResourceStatus{StatusResourcePublished}, "activation")
+	return pollForStatus(ctx, apiClient, imageId, config, StatusResourcePublished, "activation")
 }
 
 // PollForDeactivation polls the image status until it reaches IMAGE_AVAILABLE or fails
 func PollForDeactivation(ctx context.Context, apiClient agentbay.Client, imageId string, config PollingConfig) error {
-	return pollForStatus(ctx, apiClient, imageId, config, []ImageResourceStatus{StatusImageAvailable}, "deactivation")
+	return pollForStatus(ctx, apiClient, imageId, config, StatusImageAvailable, "deactivation")
 }
 
-// pollForStatus is the generic polling function
+// pollForStatus waits for the expected status, printing every status check
 func pollForStatus(
 	ctx context.Context,
 	apiClient agentbay.Client,
 	imageId string,
 	config PollingConfig,
-	expectedStatuses []ImageResourceStatus,
+	expectedStatus ImageResourceStatus,
 	operationName string,
 ) error {
 	startTime := time.Now()
-	interval := config.InitialInterval
-	attempts := 0
-
-	// Create a timeout context
-	timeoutCtx, cancel := context.WithTimeout(ctx, config.Timeout)
-	defer cancel()
-
-	for {
-		attempts++
-
-		// Check if we've exceeded max attempts
-		if attempts > config.MaxAttempts {
-			return fmt.Errorf("%s polling exceeded maximum attempts (%d)", operationName, config.MaxAttempts)
+	opts := config.waitOptions()
+	opts.OnPoll = func(e sdk.PollEvent) {
+		// Failed checks are retried silently
+		if e.Info == nil {
+			return
 		}
-
-		// Check if context is cancelled or timed out
-		select {
-		case <-timeoutCtx.Done():
-			return fmt.Errorf("%s polling timed out after %v", operationName, time.Since(startTime))
-		default:
+		// Print RequestId for every poll iteration so users can trace backend calls
+		if e.Info.RequestId != "" {
+			fmt.Printf("[INFO] GetMcpImageInfo Request ID: %s\n", e.Info.RequestId)
 		}
-
-		// Get current status
-		info, err := GetImageInfo(timeoutCtx, apiClient, imageId)
-		if err != nil {
-			// Don't fail immediately on API errors, continue polling
-		} else {
-			status := info.ResourceStatus
-			currentStatus := ImageResourceStatus(status)
-			translatedStatus := TranslateImageResourceStatus(status)
-
-			// Print RequestId for every poll iteration so users can trace backend calls
-			if info.RequestId != "" {
-				fmt.Printf("[INFO] GetMcpImageInfo Request ID: %s\n", info.RequestId)
-			}
-
-			// Check if we've reached a target status
-			for _, expectedStatus := range expectedStatuses {
-				if currentStatus == expectedStatus {
-					fmt.Printf("[SUCCESS] %s completed! Current status: %s\n",
-						operationName, translatedStatus)
-					return nil
-				}
-			}
-
-			// Check if we've reached a failed state
-			if IsFailed(status) {
-				return fmt.Errorf("%s failed with status: %s", operationName, translatedStatus)
-			}
-
-			// Update user with current status
-			fmt.Printf("  Status: %s (elapsed: %v, attempt: %d/%d)\n",
-				translatedStatus, time.Since(startTime).Round(time.Second), attempts, config.MaxAttempts)
+		if e.Info.Status == expectedStatus || e.Info.Status.IsFailed() {
+			return
 		}
+		fmt.Printf("  Status: %s (elapsed: %v, attempt: %d/%d)\n",
+			e.Info.Status.Text(), e.Elapsed.Round(time.Second), e.Attempt, config.MaxAttempts)
+	}
 
-		// Wait before next attempt
-		select {
-		case <-timeoutCtx.Done():
-			return fmt.Errorf("%s polling timed out after %v", operationName, time.Since(startTime))
-		case <-time.After(interval):
-			// Exponential backoff with max interval
-			interval = interval * 3 / 2 // 1.5x multiplier
-			if interval > config.MaxInterval {
-				interval = config.MaxInterval
-			}
-		}
+	info, err := sdk.WaitForStatus(ctx, apiClient, imageId, expectedStatus, opts)
+	if err != nil {
+		return pollError(err, info, operationName, config, startTime)
+	}
+	fmt.Printf("[SUCCESS] %s completed! Current status: %s\n", operationName, info.Status.Text())
+	return nil
+}
+
+// pollError maps the errors of the SDK wait helpers to the CLI messages
+func pollError(err error, info *sdk.ImageInfo, operationName string, config PollingConfig, startTime time.Time) error {
+	switch {
+	case errors.Is(err, sdk.ErrWaitMaxAttempts):
+		return fmt.Errorf("%s polling exceeded maximum attempts (%d)", operationName, config.MaxAttempts)
+	case errors.Is(err, sdk.ErrWaitTimeout):
+		return fmt.Errorf("%s polling timed out after %v", operationName, time.Since(startTime))
+	case errors.Is(err, sdk.ErrImageFailed) && info != nil:
+		return fmt.Errorf("%s failed with status: %s", operationName, info.Status.Text())
+	default:
+		return err
 	}
 }
 
@@ -445,66 +319,25 @@ func DefaultSetMaxSessionPollingConfig() PollingConfig {
 // PollForResourceGroupReady polls the image until ResourceGroupReady becomes true
 func PollForResourceGroupReady(ctx context.Context, apiClient agentbay.Client, imageId string, config PollingConfig) error {
 	startTime := time.Now()
-	interval := config.InitialInterval
-	attempts := 0
-
-	// Create a timeout context
-	timeoutCtx, cancel := context.WithTimeout(ctx, config.Timeout)
-	defer cancel()
-
-	for {
-		attempts++
-
-		// Check if we've exceeded max attempts
-		if attempts > config.MaxAttempts {
-			return fmt.Errorf("set-max-session polling exceeded maximum attempts (%d)", config.MaxAttempts)
+	opts := config.waitOptions()
+	opts.OnPoll = func(e sdk.PollEvent) {
+		if e.Info == nil {
+			return
 		}
-
-		// Check if context is cancelled or timed out
-		select {
-		case <-timeoutCtx.Done():
-			return fmt.Errorf("set-max-session polling timed out after %v", time.Since(startTime))
-		default:
+		if e.Info.RequestId != "" {
+			fmt.Printf("[INFO] GetMcpImageInfo Request ID: %s\n", e.Info.RequestId)
 		}
-
-		// Get current status
-		info, err := GetImageInfo(timeoutCtx, apiClient, imageId)
-		if err != nil {
-			// Don't fail immediately on API errors, continue polling
-		} else {
-			// Print RequestId for every poll iteration so users can trace backend calls
-			if info.RequestId != "" {
-				fmt.Printf("[INFO] GetMcpImageInfo Request ID: %s\n", info.RequestId)
-			}
-
-			// Check if ResourceGroupReady is true
-			if info.ResourceGroupReady {
-				fmt.Printf("[SUCCESS] Resource group is ready! Max session configuration applied.\n")
-				return nil
-			}
-
-			// Check if we've reached a failed state
-			if IsFailed(info.ResourceStatus) {
-				translatedStatus := TranslateImageResourceStatus(info.ResourceStatus)
-				return fmt.Errorf("set-max-session failed with status: %s", translatedStatus)
-			}
-
-			// Update user with current status
-			translatedStatus := TranslateImageResourceStatus(info.ResourceStatus)
-			fmt.Printf("  Status: %s, ResourceGroupReady: %v (elapsed: %v, attempt: %d/%d)\n",
-				translatedStatus, info.ResourceGroupReady, time.Since(startTime).Round(time.Second), attempts, config.MaxAttempts)
+		if e.Info.ResourceGroupReady || e.Info.Status.IsFailed() {
+			return
 		}
+		fmt.Printf("  Status: %s, ResourceGroupReady: %v (elapsed: %v, attempt: %d/%d)\n",
+			e.Info.Status.Text(), e.Info.ResourceGroupReady, e.Elapsed.Round(time.Second), e.Attempt, config.MaxAttempts)
+	}
 
-		// Wait before next attempt
-		select {
-		case <-timeoutCtx.Done():
-			return fmt.Errorf("set-max-session polling timed out after %v", time.Since(startTime))
-		case <-time.After(interval):
-			// Exponential backoff with max interval
-			interval = interval * 3 / 2 // 1.5x multiplier
-			if interval > config.MaxInterval {
-				interval = config.MaxInterval
-			}
-		}
+	info, err := sdk.WaitForResourceGroup(ctx, apiClient, imageId, opts)
+	if err != nil {
+		return pollError(err, info, "set-max-session", config, startTime)
 	}
+	fmt.Printf("[SUCCESS] Resource group is ready! Max session configuration applied.\n")
+	return nil
 }
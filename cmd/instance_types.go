@@ -0,0 +1,166 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+	sdk "github.com/agentbay/agentbay-cli/pkg/agentbay"
+)
+
+var InstanceTypesCmd = &cobra.Command{
+	Use:     "instance-types",
+	Short:   "Explore available instance types",
+	Long:    "Query the instance types (AppInstanceType) and CPU/memory combinations available for image activation.",
+	GroupID: "management",
+}
+
+var instanceTypesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available instance types",
+	Long: `List the instance types (AppInstanceType) that can be used when activating an image,
+together with their CPU, memory and regions.
+
+The CPU/memory combinations shown here are the values accepted by
+'agentbay image activate --cpu <n> --memory <n>'.
+
+Examples:
+  # List all available instance types
+  agentbay instance-types list
+
+  # List instance types available in a specific region
+  agentbay instance-types list --region cn-shanghai
+
+  # List instance types available for a specific image
+  agentbay instance-types list --image-id imgc-xxxxxxxxxxxxxx
+
+  # Machine-readable output
+  agentbay instance-types list --output json`,
+	Args: cobra.NoArgs,
+	RunE: runInstanceTypesList,
+}
+
+func init() {
+	instanceTypesListCmd.Flags().String("region", "", "Only show instance types available in this region (e.g. cn-hangzhou)")
+	instanceTypesListCmd.Flags().String("image-id", "", "Only show instance types supported by this image (optional)")
+	instanceTypesListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+
+	registerFlagCompletion(instanceTypesListCmd, "image-id", completeFlag(completionImages, nil))
+
+	InstanceTypesCmd.AddCommand(instanceTypesListCmd)
+}
+
+func runInstanceTypesList(cmd *cobra.Command, args []string) error {
+	regionId, _ := cmd.Flags().GetString("region")
+	imageId, _ := cmd.Flags().GetString("image-id")
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	fmt.Printf("[LIST] Fetching available instance types...\n")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+
+	if !cfg.IsAuthenticated() {
+		return config.ErrNotAuthenticated()
+	}
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	types, requestId, err := sdk.ListInstanceTypes(ctx, apiClient, imageId, regionId)
+	if requestId != "" {
+		fmt.Printf("[INFO] DescribeInstanceTypes Request ID: %s\n", requestId)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to query instance types: %w", err)
+	}
+
+	if strings.EqualFold(outputFmt, "json") {
+		type instanceTypeJSON struct {
+			AppInstanceType string   `json:"appInstanceType"`
+			Cpu             int32    `json:"cpu"`
+			Memory          int32    `json:"memory"`
+			RegionIds       []string `json:"regionIds"`
+			IsSelected      bool     `json:"isSelected"`
+		}
+		type instanceTypesOutput struct {
+			TotalCount    int                `json:"totalCount"`
+			InstanceTypes []instanceTypeJSON `json:"instanceTypes"`
+		}
+		out := instanceTypesOutput{TotalCount: len(types), InstanceTypes: []instanceTypeJSON{}}
+		for _, t := range types {
+			regions := t.GetRegionIds()
+			if regions == nil {
+				regions = []string{}
+			}
+			out.InstanceTypes = append(out.InstanceTypes, instanceTypeJSON{
+				AppInstanceType: getStringValue(t.GetAppInstanceType()),
+				Cpu:             *t.GetCpu(),
+				Memory:          *t.GetMemory(),
+				RegionIds:       regions,
+				IsSelected:      t.GetIsSelected() != nil && *t.GetIsSelected(),
+			})
+		}
+		b, jerr := json.MarshalIndent(out, "", "  ")
+		if jerr != nil {
+			return fmt.Errorf("json marshal: %w", jerr)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	if len(types) == 0 {
+		if regionId != "" {
+			fmt.Printf("\n[EMPTY] No instance types found in region %s.\n", regionId)
+		} else {
+			fmt.Printf("\n[EMPTY] No instance types found.\n")
+		}
+		return nil
+	}
+
+	fmt.Printf("\n[OK] Found %d instance type(s)\n\n", len(types))
+	fmt.Printf("%s %s %s %s\n",
+		padString("APP INSTANCE TYPE", 32),
+		padString("CPU", 6),
+		padString("MEMORY", 8),
+		"REGIONS")
+	fmt.Printf("%s %s %s %s\n",
+		padString("-----------------", 32),
+		padString("---", 6),
+		padString("------", 8),
+		"-------")
+	for _, t := range types {
+		regions := "-"
+		if len(t.GetRegionIds()) > 0 {
+			regions = strings.Join(t.GetRegionIds(), ", ")
+		}
+		fmt.Printf("%s %s %s %s\n",
+			padString(truncateString(getStringValue(t.GetAppInstanceType()), 32), 32),
+			padString(fmt.Sprintf("%d", *t.GetCpu()), 6),
+			padString(fmt.Sprintf("%d GB", *t.GetMemory()), 8),
+			regions)
+	}
+
+	fmt.Printf("\n[TIP] Activate with a specific combination: agentbay image activate <image-id> --cpu <cpu> --memory <memory>\n")
+	return nil
+}
+
+// ValidateInstanceTypeCombo checks a CPU/memory pair against the instance types returned by
+// DescribeInstanceTypes and returns the matching entry. The error lists every combination that
+// is actually available, e.g. "2c4g, 4c8g, 8c16g".
+func ValidateInstanceTypeCombo(cpu, memory int, types []*client.DescribeInstanceTypesResponseBodyDataInstanceType) (*client.DescribeInstanceTypesResponseBodyDataInstanceType, error) {
+	return sdk.MatchInstanceType(cpu, memory, types)
+}
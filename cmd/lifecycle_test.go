@@ -5,35 +5,40 @@ import (
 
 	"github.com/agentbay/agentbay-cli/internal/client"
 	"github.com/alibabacloud-go/tea/tea"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestMergeSandboxLifeCycle_NilExisting_NoFlags(t *testing.T) {
-	lf := &lifecycleFlags{}
-	result := mergeSandboxLifeCycle(nil, lf)
+// newLifecycleFlagsCmd returns a command with the lifecycle flags of image activate, parsed from args.
+func newLifecycleFlagsCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	c := &cobra.Command{Use: "activate"}
+	c.Flags().String("lifecycle-mode", "", "")
+	c.Flags().Float64("lifecycle-max-runtime", 0, "")
+	c.Flags().Float64("lifecycle-hibernate", 0, "")
+	c.Flags().Float64("lifecycle-idle-timeout", 0, "")
+	require.NoError(t, c.Flags().Parse(args))
+	return c
+}
+
+func TestLifecycleOverrides_NoFlags(t *testing.T) {
+	l := lifecycleOverrides(newLifecycleFlagsCmd(t))
 
-	assert.NotNil(t, result)
-	assert.Nil(t, result.Mode)
-	assert.Nil(t, result.DesktopMaxRuntime)
-	assert.Nil(t, result.HibernateTimeout)
-	assert.Nil(t, result.UserIdleTimeout)
-	// No UserIdleTimeout -> IdleTimeoutSwitch = false
-	assert.NotNil(t, result.IdleTimeoutSwitch)
-	assert.False(t, *result.IdleTimeoutSwitch)
+	assert.Nil(t, l.Mode)
+	assert.Nil(t, l.MaxRuntime)
+	assert.Nil(t, l.Hibernate)
+	assert.Nil(t, l.IdleTimeout)
 }
 
-func TestMergeSandboxLifeCycle_NilExisting_AllFlagsSet(t *testing.T) {
-	lf := &lifecycleFlags{
-		mode:           "auto",
-		maxRuntime:     3600,
-		hibernate:      1800,
-		idleTimeout:    600,
-		modeSet:        true,
-		maxRuntimeSet:  true,
-		hibernateSet:   true,
-		idleTimeoutSet: true,
-	}
-	result := mergeSandboxLifeCycle(nil, lf)
+func TestLifecycleOverrides_AllFlagsSet(t *testing.T) {
+	l := lifecycleOverrides(newLifecycleFlagsCmd(t,
+		"--lifecycle-mode", "auto",
+		"--lifecycle-max-runtime", "3600",
+		"--lifecycle-hibernate", "1800",
+		"--lifecycle-idle-timeout", "600",
+	))
+	result := l.Merge(nil)
 
 	assert.Equal(t, "auto", *result.Mode)
 	assert.Equal(t, 3600.0, *result.DesktopMaxRuntime)
@@ -42,25 +47,7 @@ func TestMergeSandboxLifeCycle_NilExisting_AllFlagsSet(t *testing.T) {
 	assert.True(t, *result.IdleTimeoutSwitch)
 }
 
-func TestMergeSandboxLifeCycle_ExistingValues_NoOverride(t *testing.T) {
-	existing := &client.SandboxLifeCycle{
-		Mode:              tea.String("manual"),
-		DesktopMaxRuntime: tea.Float64(7200),
-		HibernateTimeout:  tea.Float64(3600),
-		UserIdleTimeout:   tea.Float64(900),
-		IdleTimeoutSwitch: tea.Bool(true),
-	}
-	lf := &lifecycleFlags{}
-	result := mergeSandboxLifeCycle(existing, lf)
-
-	assert.Equal(t, "manual", *result.Mode)
-	assert.Equal(t, 7200.0, *result.DesktopMaxRuntime)
-	assert.Equal(t, 3600.0, *result.HibernateTimeout)
-	assert.Equal(t, 900.0, *result.UserIdleTimeout)
-	assert.True(t, *result.IdleTimeoutSwitch)
-}
-
-func TestMergeSandboxLifeCycle_PartialOverride(t *testing.T) {
+func TestLifecycleOverrides_PartialOverride(t *testing.T) {
 	existing := &client.SandboxLifeCycle{
 		Mode:              tea.String("manual"),
 		DesktopMaxRuntime: tea.Float64(7200),
@@ -68,11 +55,7 @@ func TestMergeSandboxLifeCycle_PartialOverride(t *testing.T) {
 		UserIdleTimeout:   tea.Float64(900),
 		IdleTimeoutSwitch: tea.Bool(true),
 	}
-	lf := &lifecycleFlags{
-		mode:    "auto",
-		modeSet: true,
-	}
-	result := mergeSandboxLifeCycle(existing, lf)
+	result := lifecycleOverrides(newLifecycleFlagsCmd(t, "--lifecycle-mode", "auto")).Merge(existing)
 
 	assert.Equal(t, "auto", *result.Mode)
 	assert.Equal(t, 7200.0, *result.DesktopMaxRuntime)
@@ -81,41 +64,13 @@ func TestMergeSandboxLifeCycle_PartialOverride(t *testing.T) {
 	assert.True(t, *result.IdleTimeoutSwitch)
 }
 
-func TestMergeSandboxLifeCycle_IdleTimeoutZero_SwitchTrue(t *testing.T) {
-	lf := &lifecycleFlags{
-		idleTimeout:    0,
-		idleTimeoutSet: true,
-	}
-	result := mergeSandboxLifeCycle(nil, lf)
+func TestLifecycleOverrides_IdleTimeoutZeroIsSet(t *testing.T) {
+	l := lifecycleOverrides(newLifecycleFlagsCmd(t, "--lifecycle-idle-timeout", "0"))
 
-	assert.NotNil(t, result.UserIdleTimeout)
-	assert.Equal(t, 0.0, *result.UserIdleTimeout)
+	require.NotNil(t, l.IdleTimeout)
+	assert.Equal(t, 0.0, *l.IdleTimeout)
 	// UserIdleTimeout has value (0) -> IdleTimeoutSwitch = true
-	assert.True(t, *result.IdleTimeoutSwitch)
-}
-
-func TestMergeSandboxLifeCycle_ClearIdleTimeout_SwitchFalse(t *testing.T) {
-	// Existing has UserIdleTimeout, but user doesn't set it -> keep existing
-	existing := &client.SandboxLifeCycle{
-		UserIdleTimeout:   tea.Float64(300),
-		IdleTimeoutSwitch: tea.Bool(true),
-	}
-	lf := &lifecycleFlags{}
-	result := mergeSandboxLifeCycle(existing, lf)
-
-	assert.Equal(t, 300.0, *result.UserIdleTimeout)
-	assert.True(t, *result.IdleTimeoutSwitch)
-}
-
-func TestMergeSandboxLifeCycle_ExistingNoIdleTimeout_SwitchFalse(t *testing.T) {
-	existing := &client.SandboxLifeCycle{
-		Mode: tea.String("auto"),
-	}
-	lf := &lifecycleFlags{}
-	result := mergeSandboxLifeCycle(existing, lf)
-
-	assert.Nil(t, result.UserIdleTimeout)
-	assert.False(t, *result.IdleTimeoutSwitch)
+	assert.True(t, *l.Merge(nil).IdleTimeoutSwitch)
 }
 
 func TestLifecycleModeValidation(t *testing.T) {
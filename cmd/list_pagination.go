@@ -0,0 +1,251 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// listPageTimeout bounds each page request of a walk. A walk as a whole has no deadline: --all
+// over a large account takes as long as it needs.
+const listPageTimeout = 30 * time.Second
+
+// listPaging is the --all / --limit setting shared by the list commands.
+type listPaging struct {
+	All   bool
+	Limit int
+}
+
+// addListPagingFlags registers --all and --limit on a list command.
+func addListPagingFlags(c *cobra.Command) {
+	c.Flags().Bool("all", false, "Fetch every page instead of a single one")
+	c.Flags().Int("limit", 0, "Return at most this many items, fetching further pages as needed (0 = no limit)")
+}
+
+func listPagingFromFlags(c *cobra.Command) (listPaging, error) {
+	var p listPaging
+	p.All, _ = c.Flags().GetBool("all")
+	p.Limit, _ = c.Flags().GetInt("limit")
+	if p.Limit < 0 {
+		return p, fmt.Errorf("--limit must be greater than or equal to 0")
+	}
+	return p, nil
+}
+
+// walks reports whether more than the requested page may be fetched.
+func (p listPaging) walks() bool {
+	return p.All || p.Limit > 0
+}
+
+// pageCursor addresses one page: by NextToken for token-paged APIs, by page number otherwise.
+type pageCursor struct {
+	Token  string
+	PageNo int
+}
+
+// listPage is one page of items; Next is nil on the last page. Total is the server-reported
+// number of matching items, nil when the API does not report one.
+type listPage[T any] struct {
+	Items []T
+	Next  *pageCursor
+	Total *int
+}
+
+type pageFetcher[T any] func(ctx context.Context, at pageCursor) (*listPage[T], error)
+
+// nextPageToken returns the cursor for a NextToken, or nil when there is no further page.
+func nextPageToken(token string) *pageCursor {
+	if token == "" {
+		return nil
+	}
+	return &pageCursor{Token: token}
+}
+
+// nextPageNo returns the cursor after page pageNo, or nil when it was the last one. total is the
+// server-reported item count, or a negative value when the API does not report it.
+func nextPageNo(pageNo, pageSize, got, total int) *pageCursor {
+	if got == 0 || (pageSize > 0 && got < pageSize) {
+		return nil
+	}
+	if total >= 0 && pageSize > 0 && pageNo*pageSize >= total {
+		return nil
+	}
+	return &pageCursor{PageNo: pageNo + 1}
+}
+
+// pageWalk summarises a walkPages run. More is set when items were left unfetched; Next is the
+// cursor to resume from when the walk stopped on a page boundary. Total is the server-reported
+// count of the first page, nil when the API does not report one.
+type pageWalk struct {
+	Items int
+	Pages int
+	More  bool
+	Next  *pageCursor
+	Total *int
+}
+
+// totalCount is the "totalCount" of JSON list output: the server-reported number of matching
+// items, as in the single-page output, or the number of items listed when the API reports none.
+func (w pageWalk) totalCount() int {
+	if w.Total != nil {
+		return *w.Total
+	}
+	return w.Items
+}
+
+// walkPages fetches pages from start and hands each item to emit as soon as its page arrives.
+// Without --all or --limit only the first page is fetched; --limit stops after that many items.
+func walkPages[T any](ctx context.Context, paging listPaging, start pageCursor, fetch pageFetcher[T], emit func(T) error) (pageWalk, error) {
+	var w pageWalk
+	at := start
+	for {
+		pageCtx, cancel := context.WithTimeout(ctx, listPageTimeout)
+		page, err := fetch(pageCtx, at)
+		cancel()
+		if err != nil {
+			return w, err
+		}
+		if w.Pages == 0 {
+			w.Total = page.Total
+		}
+		w.Pages++
+		for _, item := range page.Items {
+			if paging.Limit > 0 && w.Items >= paging.Limit {
+				w.More = true
+				return w, nil
+			}
+			if err := emit(item); err != nil {
+				return w, err
+			}
+			w.Items++
+		}
+		if page.Next == nil {
+			return w, nil
+		}
+		if !paging.walks() || (paging.Limit > 0 && w.Items >= paging.Limit) {
+			w.More, w.Next = true, page.Next
+			return w, nil
+		}
+		at = *page.Next
+	}
+}
+
+// listOutputFormat normalises -o for the list commands: "json", "ndjson" or "" for a table.
+func listOutputFormat(s string) string {
+	switch strings.ToLower(s) {
+	case "json":
+		return "json"
+	case "ndjson":
+		return "ndjson"
+	}
+	return ""
+}
+
+// listStreamField is a trailing top-level field of a streamed JSON document.
+type listStreamField struct {
+	Name  string
+	Value interface{}
+}
+
+// listStream writes list items as they are fetched. "json" produces the command's usual
+// {"<key>": [...], ...} document with the other fields after the array; "ndjson" writes one
+// compact object per line.
+type listStream struct {
+	w      io.Writer
+	format string
+	key    string
+	n      int
+}
+
+func newListStream(w io.Writer, format, key string) *listStream {
+	s := &listStream{w: w, format: format, key: key}
+	if format == "json" {
+		fmt.Fprintf(w, "{\n  %q: [", key)
+	}
+	return s
+}
+
+func (s *listStream) write(v interface{}) error {
+	if s.format == "ndjson" {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		_, err = fmt.Fprintf(s.w, "%s\n", b)
+		return err
+	}
+	b, err := json.MarshalIndent(v, "    ", "  ")
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	sep := ","
+	if s.n == 0 {
+		sep = ""
+	}
+	s.n++
+	_, err = fmt.Fprintf(s.w, "%s\n    %s", sep, b)
+	return err
+}
+
+// close ends the JSON document with fields; it writes nothing for NDJSON.
+func (s *listStream) close(fields ...listStreamField) error {
+	if s.format != "json" {
+		return nil
+	}
+	if s.n > 0 {
+		fmt.Fprint(s.w, "\n  ")
+	}
+	fmt.Fprint(s.w, "]")
+	for _, f := range fields {
+		b, err := json.Marshal(f.Value)
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		fmt.Fprintf(s.w, ",\n  %q: %s", f.Name, b)
+	}
+	_, err := fmt.Fprint(s.w, "\n}\n")
+	return err
+}
+
+// fail ends a JSON document that a failed walk left open, with the error after the items written
+// so far, so that stdout still parses; it writes nothing for NDJSON. err is returned.
+func (s *listStream) fail(err error) error {
+	if s.format == "json" {
+		_ = s.close(listStreamField{"error", err.Error()})
+	}
+	return err
+}
+
+// listProgress is where a list command reports progress: stderr while a paged walk streams JSON
+// or NDJSON to stdout, stdout otherwise.
+func listProgress(paged bool, format string) io.Writer {
+	if paged && format != "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// printMorePagesHint tells the user how to continue after a partial walk.
+func printMorePagesHint(w io.Writer, walk pageWalk, resumeFlag string) {
+	if !walk.More {
+		return
+	}
+	if walk.Next != nil && walk.Next.Token != "" && resumeFlag != "" {
+		fmt.Fprintf(w, "\n[INFO] More results available. Use %s %s to continue, or --all to fetch every page.\n", resumeFlag, walk.Next.Token)
+		return
+	}
+	if walk.Next != nil && walk.Next.PageNo > 0 && resumeFlag != "" {
+		fmt.Fprintf(w, "\n[INFO] More results available. Use %s %d to continue, or --all to fetch every page.\n", resumeFlag, walk.Next.PageNo)
+		return
+	}
+	fmt.Fprintf(w, "\n[INFO] More results available. Use --all to fetch every page.\n")
+}
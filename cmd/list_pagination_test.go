@@ -0,0 +1,219 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// numberPages serves pages of consecutive integers, pageSize per page, total items in all.
+func numberPages(pageSize, total int, calls *[]pageCursor) pageFetcher[int] {
+	return func(ctx context.Context, at pageCursor) (*listPage[int], error) {
+		*calls = append(*calls, at)
+		page := &listPage[int]{}
+		for i := (at.PageNo - 1) * pageSize; i < at.PageNo*pageSize && i < total; i++ {
+			page.Items = append(page.Items, i)
+		}
+		page.Next = nextPageNo(at.PageNo, pageSize, len(page.Items), total)
+		return page, nil
+	}
+}
+
+func TestWalkPagesPageNo(t *testing.T) {
+	collect := func(paging listPaging) ([]int, pageWalk, []pageCursor) {
+		var got []int
+		var calls []pageCursor
+		walk, err := walkPages(context.Background(), paging, pageCursor{PageNo: 1}, numberPages(3, 7, &calls), func(v int) error {
+			got = append(got, v)
+			return nil
+		})
+		require.NoError(t, err)
+		return got, walk, calls
+	}
+
+	got, walk, calls := collect(listPaging{})
+	assert.Equal(t, []int{0, 1, 2}, got, "without --all only the first page is fetched")
+	assert.True(t, walk.More)
+	require.NotNil(t, walk.Next)
+	assert.Equal(t, 2, walk.Next.PageNo)
+	assert.Len(t, calls, 1)
+
+	got, walk, calls = collect(listPaging{All: true})
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, got)
+	assert.False(t, walk.More)
+	assert.Equal(t, 3, walk.Pages)
+	assert.Len(t, calls, 3)
+
+	got, walk, calls = collect(listPaging{Limit: 4})
+	assert.Equal(t, []int{0, 1, 2, 3}, got, "--limit walks pages on its own")
+	assert.True(t, walk.More)
+	assert.Nil(t, walk.Next, "stopped mid-page, so there is no page to resume from")
+	assert.Len(t, calls, 2)
+
+	got, walk, _ = collect(listPaging{Limit: 6})
+	assert.Len(t, got, 6)
+	require.NotNil(t, walk.Next)
+	assert.Equal(t, 3, walk.Next.PageNo)
+
+	got, walk, _ = collect(listPaging{All: true, Limit: 100})
+	assert.Len(t, got, 7)
+	assert.False(t, walk.More)
+}
+
+func TestWalkPagesToken(t *testing.T) {
+	pages := map[string]*listPage[string]{
+		"":   {Items: []string{"a", "b"}, Next: nextPageToken("t1")},
+		"t1": {Items: []string{"c"}, Next: nextPageToken("t2")},
+		"t2": {Items: []string{"d"}, Next: nextPageToken("")},
+	}
+	fetch := func(ctx context.Context, at pageCursor) (*listPage[string], error) {
+		return pages[at.Token], nil
+	}
+	var got []string
+	walk, err := walkPages(context.Background(), listPaging{All: true}, pageCursor{Token: "t1"}, fetch, func(s string) error {
+		got = append(got, s)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, got, "the walk resumes from the given token")
+	assert.Equal(t, 2, walk.Pages)
+
+	_, err = walkPages(context.Background(), listPaging{All: true}, pageCursor{}, fetch, func(s string) error {
+		if s == "b" {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "stop")
+}
+
+func TestWalkPagesTotalAndPageDeadline(t *testing.T) {
+	total := 7
+	var deadlines []time.Time
+	fetch := func(ctx context.Context, at pageCursor) (*listPage[int], error) {
+		d, ok := ctx.Deadline()
+		require.True(t, ok, "every page request has a deadline")
+		deadlines = append(deadlines, d)
+		page := &listPage[int]{Items: []int{at.PageNo}, Total: &total}
+		if at.PageNo < 3 {
+			page.Next = &pageCursor{PageNo: at.PageNo + 1}
+		}
+		return page, nil
+	}
+	walk, err := walkPages(context.Background(), listPaging{All: true}, pageCursor{PageNo: 1}, fetch, func(int) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, 3, walk.Items)
+	assert.Equal(t, 7, walk.totalCount(), "the server-reported total, not the items listed")
+	require.Len(t, deadlines, 3)
+	assert.False(t, deadlines[2].Before(deadlines[0]), "the deadline is per page, not for the whole walk")
+
+	assert.Equal(t, 3, pageWalk{Items: 3}.totalCount(), "without a server total, the items listed")
+}
+
+func TestNextPageNo(t *testing.T) {
+	assert.Nil(t, nextPageNo(1, 10, 0, -1), "empty page")
+	assert.Nil(t, nextPageNo(1, 10, 4, -1), "short page")
+	assert.Nil(t, nextPageNo(2, 10, 10, 20), "total reached")
+	assert.Equal(t, &pageCursor{PageNo: 2}, nextPageNo(1, 10, 10, -1))
+	assert.Equal(t, &pageCursor{PageNo: 3}, nextPageNo(2, 10, 10, 25))
+}
+
+func TestListStream(t *testing.T) {
+	type item struct {
+		ID string `json:"id"`
+	}
+
+	var buf bytes.Buffer
+	s := newListStream(&buf, "json", "items")
+	require.NoError(t, s.write(item{"a"}))
+	require.NoError(t, s.write(item{"b"}))
+	require.NoError(t, s.close(listStreamField{"totalCount", 2}, listStreamField{"nextToken", "t"}))
+	var doc struct {
+		Items      []item `json:"items"`
+		TotalCount int    `json:"totalCount"`
+		NextToken  string `json:"nextToken"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc), buf.String())
+	assert.Equal(t, []item{{"a"}, {"b"}}, doc.Items)
+	assert.Equal(t, 2, doc.TotalCount)
+	assert.Equal(t, "t", doc.NextToken)
+
+	buf.Reset()
+	s = newListStream(&buf, "json", "items")
+	require.NoError(t, s.close(listStreamField{"totalCount", 0}))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc), buf.String())
+	assert.Empty(t, doc.Items)
+
+	buf.Reset()
+	s = newListStream(&buf, "ndjson", "items")
+	require.NoError(t, s.write(item{"a"}))
+	require.NoError(t, s.write(item{"b"}))
+	require.NoError(t, s.close(listStreamField{"totalCount", 2}))
+	assert.Equal(t, "{\"id\":\"a\"}\n{\"id\":\"b\"}\n", buf.String())
+}
+
+func TestListStreamFailKeepsJSONValid(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	fetch := func(ctx context.Context, at pageCursor) (*listPage[*client.DescribeApiKeysResponseBodyDataApiKey], error) {
+		if at.Token == "" {
+			return &listPage[*client.DescribeApiKeysResponseBodyDataApiKey]{
+				Items: []*client.DescribeApiKeysResponseBodyDataApiKey{{KeyId: dara.String("ak-1")}},
+				Next:  nextPageToken("t1"),
+			}, nil
+		}
+		return nil, errors.New("throttled")
+	}
+
+	var err error
+	out := captureImageCreateFromTemplateStdout(t, func() {
+		err = listApiKeysPaged(context.Background(), fetch, listPaging{All: true}, pageCursor{}, "json", false, &bytes.Buffer{})
+	})
+	assert.ErrorContains(t, err, "throttled")
+	var doc struct {
+		ApiKeys []apiKeyListItem `json:"apiKeys"`
+		Error   string           `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &doc), out)
+	require.Len(t, doc.ApiKeys, 1)
+	assert.Equal(t, "ak-1", doc.ApiKeys[0].KeyId)
+	assert.Equal(t, "throttled", doc.Error)
+
+	var buf bytes.Buffer
+	s := newListStream(&buf, "ndjson", "items")
+	assert.EqualError(t, s.fail(errors.New("boom")), "boom")
+	assert.Empty(t, buf.String(), "NDJSON lines are complete on their own")
+}
+
+func TestListApiKeysPagedNDJSON(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	k := func(id string) *client.DescribeApiKeysResponseBodyDataApiKey {
+		return &client.DescribeApiKeysResponseBodyDataApiKey{KeyId: dara.String(id), ApiKey: dara.String("akm-0123456789abcdef")}
+	}
+	mock := &mockListKeysClient{pages: [][]*client.DescribeApiKeysResponseBodyDataApiKey{{k("ak-1"), k("ak-2")}, {k("ak-3")}}}
+	fetch := apiKeyPages(mock, 2, "", &bytes.Buffer{})
+
+	out := captureImageCreateFromTemplateStdout(t, func() {
+		require.NoError(t, listApiKeysPaged(context.Background(), fetch, listPaging{All: true}, pageCursor{}, "ndjson", false, &bytes.Buffer{}))
+	})
+	lines := strings.Split(out, "\n")
+	require.Len(t, lines, 3)
+	var first apiKeyListItem
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "ak-1", first.KeyId)
+	assert.Equal(t, "akm-****cdef", first.ApiKey, "secrets stay masked without --show-secret")
+	assert.Equal(t, 2, mock.calls)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
 
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
 	"github.com/agentbay/agentbay-cli/internal/auth"
 	"github.com/agentbay/agentbay-cli/internal/config"
 )
@@ -239,6 +240,10 @@ func runLogin(cmd *cobra.Command) error {
 		}
 
 		fmt.Println("Authentication tokens saved successfully!")
+		// Responses cached for a previous account must not be served to this one.
+		if err := agentbay.ClearCache(); err != nil {
+			fmt.Printf("Warning: Could not clear cached API responses: %v\n", err)
+		}
 		fmt.Println("You are now logged in to AgentBay!")
 
 		return nil
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
 	"github.com/agentbay/agentbay-cli/internal/auth"
 	"github.com/agentbay/agentbay-cli/internal/config"
 )
@@ -51,6 +52,9 @@ func runLogout(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("failed to clear local authentication data: %w", err)
 	}
+	if err := agentbay.ClearCache(); err != nil {
+		fmt.Printf("Warning: Could not clear cached API responses: %v\n", err)
+	}
 
 	if config.HasAccessKeyFromEnv() {
 		fmt.Printf("Note: %s and %s are still set; unset them to stop using access key authentication.\n",
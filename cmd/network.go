@@ -5,7 +5,9 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -45,6 +47,19 @@ Examples:
 	RunE: runNetworkPackageList,
 }
 
+var networkPackageDescribeCmd = &cobra.Command{
+	Use:   "describe <network-package-id>",
+	Short: "Show details of a network package",
+	Long: `Show details of a single network package, including its EIP addresses and
+the office site it belongs to.
+
+Examples:
+  agentbay network package describe np-xxxxxxxxxxxx
+  agentbay network package describe np-xxxxxxxxxxxx --biz-region-id cn-shanghai -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNetworkPackageDescribe,
+}
+
 var (
 	networkPackageBizRegionId string
 )
@@ -52,7 +67,11 @@ var (
 func init() {
 	networkPackageListCmd.Flags().StringVar(&networkPackageBizRegionId, "biz-region-id", "cn-hangzhou", "Biz Region ID (default: cn-hangzhou)")
 
+	networkPackageDescribeCmd.Flags().String("biz-region-id", "cn-hangzhou", "Biz Region ID (default: cn-hangzhou)")
+	networkPackageDescribeCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+
 	NetworkPackageCmd.AddCommand(networkPackageListCmd)
+	NetworkPackageCmd.AddCommand(networkPackageDescribeCmd)
 	NetworkCmd.AddCommand(NetworkPackageCmd)
 }
 
@@ -140,3 +159,113 @@ func runNetworkPackageList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runNetworkPackageDescribe(cmd *cobra.Command, args []string) error {
+	networkPackageId := args[0]
+	bizRegionId, _ := cmd.Flags().GetString("biz-region-id")
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fmt.Printf("Requesting network packages...")
+	resp, err := apiClient.DescribeNetworkPackages(ctx, &client.DescribeNetworkPackagesRequest{
+		BizRegionId: &bizRegionId,
+	})
+	if err != nil {
+		fmt.Printf(" Failed.\n")
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] Failed to fetch network packages: %w", err)
+	}
+	if resp.Body == nil {
+		fmt.Printf(" Failed.\n")
+		return fmt.Errorf("[ERROR] Invalid response: missing body")
+	}
+	if resp.Body.GetRequestId() != nil {
+		fmt.Printf(" Done. (Action: DescribeNetworkPackages, Request ID: %s)\n", *resp.Body.GetRequestId())
+	} else {
+		fmt.Printf(" Done. (Action: DescribeNetworkPackages)\n")
+	}
+	if code := resp.Body.GetCode(); (resp.Body.GetSuccess() != nil && !*resp.Body.GetSuccess()) || (code != "" && !isSuccessCode(code)) {
+		return fmt.Errorf("[ERROR] API request failed: Code=%s, Message=%s", code, getStringValue(resp.Body.GetMessage()))
+	}
+
+	// DescribeNetworkPackages has no paging: one response holds every package of the region, and
+	// the package is looked up in it.
+	var pkg *client.DescribeNetworkPackagesResponseBodyDataItem
+	var searched int
+	if data := resp.Body.GetData(); data != nil {
+		searched = len(data.Items)
+		for _, item := range data.Items {
+			if item != nil && item.GetNetworkPackageId() == networkPackageId {
+				pkg = item
+				break
+			}
+		}
+	}
+	if pkg == nil {
+		return fmt.Errorf("[ERROR] Network package %s not found among the %d package(s) DescribeNetworkPackages returned for region %s", networkPackageId, searched, bizRegionId)
+	}
+
+	// Resolve the office site the package belongs to; a lookup failure is not fatal.
+	var site *client.DescribeOfficeSitesResponseBodyData
+	if officeSiteId := pkg.GetOfficeSiteId(); officeSiteId != "" {
+		req := &client.DescribeOfficeSitesRequest{}
+		req.SetOfficeSiteId(officeSiteId)
+		req.SetRegionName(bizRegionId)
+		fmt.Printf("Requesting office site...")
+		sites, serr := describeOfficeSites(ctx, cmd, apiClient, req)
+		if serr != nil {
+			fmt.Printf("[WARN] Failed to fetch office site %s: %v\n", officeSiteId, serr)
+		}
+		for _, s := range sites {
+			if getStringValue(s.GetOfficeSiteId()) == officeSiteId {
+				site = s
+				break
+			}
+		}
+	}
+
+	if strings.EqualFold(outputFmt, "json") {
+		type packageOutput struct {
+			NetworkPackageId string          `json:"networkPackageId"`
+			BizRegionId      string          `json:"bizRegionId"`
+			OfficeSiteId     string          `json:"officeSiteId"`
+			EipAddresses     string          `json:"eipAddresses"`
+			OfficeSite       *officeSiteJSON `json:"officeSite,omitempty"`
+		}
+		out := packageOutput{
+			NetworkPackageId: pkg.GetNetworkPackageId(),
+			BizRegionId:      bizRegionId,
+			OfficeSiteId:     pkg.GetOfficeSiteId(),
+			EipAddresses:     pkg.GetEipAddresses(),
+		}
+		if site != nil {
+			siteJSON := toOfficeSiteJSON(site)
+			out.OfficeSite = &siteJSON
+		}
+		b, jerr := json.MarshalIndent(out, "", "  ")
+		if jerr != nil {
+			return fmt.Errorf("json marshal: %w", jerr)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	fmt.Printf("\n[OK] Network package %s\n\n", pkg.GetNetworkPackageId())
+	fmt.Printf("Network Package ID: %s\n", pkg.GetNetworkPackageId())
+	fmt.Printf("Region:             %s\n", bizRegionId)
+	fmt.Printf("EIP Addresses:      %s\n", valueOrDash(pkg.GetEipAddresses()))
+	fmt.Printf("Office Site ID:     %s\n", valueOrDash(pkg.GetOfficeSiteId()))
+	if site != nil {
+		fmt.Printf("\nOffice Site:\n")
+		printOfficeSiteDetails(site, "  ")
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+	sdk "github.com/agentbay/agentbay-cli/pkg/agentbay"
+)
+
+var NetworkOfficeSiteCmd = &cobra.Command{
+	Use:   "office-site",
+	Short: "Manage office sites",
+	Long: `Query and create office sites (office networks) used by ADVANCED and CUSTOMIZED
+image activations.`,
+}
+
+var networkOfficeSiteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List office sites",
+	Long: `List office sites for a region.
+
+Examples:
+  # List office sites (uses default region cn-hangzhou)
+  agentbay network office-site list
+
+  # List only customized office sites bound to a VPC
+  agentbay network office-site list --region-id cn-shanghai --type CUSTOMIZED --vpc-id vpc-xxxxxxxx
+
+  # Machine-readable output
+  agentbay network office-site list -o json`,
+	Args: cobra.NoArgs,
+	RunE: runNetworkOfficeSiteList,
+}
+
+var networkOfficeSiteDescribeCmd = &cobra.Command{
+	Use:   "describe <office-site-id>",
+	Short: "Show details of an office site",
+	Long: `Show details of a single office site, including its type, VPC and default DNS addresses.
+
+Examples:
+  agentbay network office-site describe cn-hangzhou+dir-xxxxxxxxxxxx
+  agentbay network office-site describe cn-hangzhou+dir-xxxxxxxxxxxx -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNetworkOfficeSiteDescribe,
+}
+
+var networkOfficeSiteCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a customized office site for a VPC",
+	Long: `Create a customized office site bound to an existing VPC.
+
+This is the same office site that 'agentbay image activate --network-type CUSTOMIZED'
+creates on first use; creating it up front lets you review it before activating images.
+If an office site already exists for the VPC, it is reported and nothing is created.
+
+Examples:
+  agentbay network office-site create --vpc-id vpc-xxxxxxxx --region-id cn-hangzhou
+  agentbay network office-site create --vpc-id vpc-xxxxxxxx --region-id cn-hangzhou --name my-office`,
+	Args: cobra.NoArgs,
+	RunE: runNetworkOfficeSiteCreate,
+}
+
+func init() {
+	networkOfficeSiteListCmd.Flags().String("region-id", "cn-hangzhou", "Region ID (default: cn-hangzhou)")
+	networkOfficeSiteListCmd.Flags().String("type", "", "Office site type filter (e.g. ADVANCED, CUSTOMIZED)")
+	networkOfficeSiteListCmd.Flags().String("vpc-id", "", "Only show office sites bound to this VPC")
+	networkOfficeSiteListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+
+	networkOfficeSiteDescribeCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+
+	networkOfficeSiteCreateCmd.Flags().String("vpc-id", "", "VPC ID to bind the office site to (required)")
+	networkOfficeSiteCreateCmd.Flags().String("region-id", "", "Region ID of the VPC, e.g. cn-hangzhou (required)")
+	networkOfficeSiteCreateCmd.Flags().String("name", "", "Office site name (default: AgentBay-<timestamp>)")
+	_ = networkOfficeSiteCreateCmd.MarkFlagRequired("vpc-id")
+	_ = networkOfficeSiteCreateCmd.MarkFlagRequired("region-id")
+
+	NetworkOfficeSiteCmd.AddCommand(networkOfficeSiteListCmd)
+	NetworkOfficeSiteCmd.AddCommand(networkOfficeSiteDescribeCmd)
+	NetworkOfficeSiteCmd.AddCommand(networkOfficeSiteCreateCmd)
+	NetworkCmd.AddCommand(NetworkOfficeSiteCmd)
+}
+
+// officeSiteJSON is the JSON representation of a single office site.
+type officeSiteJSON struct {
+	OfficeSiteId   string   `json:"officeSiteId"`
+	OfficeSiteName string   `json:"officeSiteName"`
+	OfficeSiteType string   `json:"officeSiteType"`
+	VpcId          string   `json:"vpcId"`
+	RegionId       string   `json:"regionId"`
+	Status         string   `json:"status"`
+	DnsAddress     []string `json:"dnsAddress"`
+	CreationTime   string   `json:"creationTime,omitempty"`
+}
+
+func toOfficeSiteJSON(site *client.DescribeOfficeSitesResponseBodyData) officeSiteJSON {
+	dns := site.GetDnsAddress()
+	if dns == nil {
+		dns = []string{}
+	}
+	return officeSiteJSON{
+		OfficeSiteId:   getStringValue(site.GetOfficeSiteId()),
+		OfficeSiteName: getStringValue(site.GetOfficeSiteName()),
+		OfficeSiteType: getStringValue(site.GetOfficeSiteType()),
+		VpcId:          getStringValue(site.GetVpcId()),
+		RegionId:       getStringValue(site.GetRegionId()),
+		Status:         getStringValue(site.GetStatus()),
+		DnsAddress:     dns,
+		CreationTime:   getStringValue(site.GetCreationTime()),
+	}
+}
+
+func runNetworkOfficeSiteList(cmd *cobra.Command, args []string) error {
+	regionId, _ := cmd.Flags().GetString("region-id")
+	siteType, _ := cmd.Flags().GetString("type")
+	vpcId, _ := cmd.Flags().GetString("vpc-id")
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	fmt.Printf("[LIST] Fetching office sites...\n")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req := &client.DescribeOfficeSitesRequest{
+		RegionName: dara.String(regionId),
+	}
+	if siteType != "" {
+		req.SetOfficeSiteType(strings.ToUpper(siteType))
+	}
+	if vpcId != "" {
+		req.SetVpcId(vpcId)
+	}
+
+	fmt.Printf("Requesting office sites...")
+	sites, err := describeOfficeSites(ctx, cmd, apiClient, req)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to fetch office sites: %w", err)
+	}
+
+	if strings.EqualFold(outputFmt, "json") {
+		type officeSitesOutput struct {
+			TotalCount  int              `json:"totalCount"`
+			OfficeSites []officeSiteJSON `json:"officeSites"`
+		}
+		out := officeSitesOutput{TotalCount: len(sites), OfficeSites: []officeSiteJSON{}}
+		for _, site := range sites {
+			out.OfficeSites = append(out.OfficeSites, toOfficeSiteJSON(site))
+		}
+		b, jerr := json.MarshalIndent(out, "", "  ")
+		if jerr != nil {
+			return fmt.Errorf("json marshal: %w", jerr)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	if len(sites) == 0 {
+		fmt.Printf("\n[EMPTY] No office sites found.\n")
+		return nil
+	}
+
+	fmt.Printf("\n[OK] Found %d office site(s)\n\n", len(sites))
+	fmt.Printf("%s %s %s %s %s\n",
+		padString("OFFICE SITE ID", 34),
+		padString("NAME", 26),
+		padString("TYPE", 12),
+		padString("VPC ID", 26),
+		"DNS")
+	fmt.Printf("%s %s %s %s %s\n",
+		padString("--------------", 34),
+		padString("----", 26),
+		padString("----", 12),
+		padString("------", 26),
+		"---")
+	for _, site := range sites {
+		dns := "-"
+		if len(site.GetDnsAddress()) > 0 {
+			dns = strings.Join(site.GetDnsAddress(), ", ")
+		}
+		fmt.Printf("%s %s %s %s %s\n",
+			padString(truncateString(getStringValue(site.GetOfficeSiteId()), 34), 34),
+			padString(truncateString(valueOrDash(getStringValue(site.GetOfficeSiteName())), 26), 26),
+			padString(valueOrDash(getStringValue(site.GetOfficeSiteType())), 12),
+			padString(truncateString(valueOrDash(getStringValue(site.GetVpcId())), 26), 26),
+			dns)
+	}
+
+	return nil
+}
+
+func runNetworkOfficeSiteDescribe(cmd *cobra.Command, args []string) error {
+	officeSiteId := args[0]
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req := &client.DescribeOfficeSitesRequest{}
+	req.SetOfficeSiteId(officeSiteId)
+	// Office site IDs are prefixed with their region, e.g. cn-hangzhou+dir-xxxx.
+	if idx := strings.Index(officeSiteId, "+"); idx > 0 {
+		req.SetRegionName(officeSiteId[:idx])
+	}
+
+	fmt.Printf("Requesting office site...")
+	sites, err := describeOfficeSites(ctx, cmd, apiClient, req)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to fetch office site: %w", err)
+	}
+
+	var site *client.DescribeOfficeSitesResponseBodyData
+	for _, s := range sites {
+		if getStringValue(s.GetOfficeSiteId()) == officeSiteId {
+			site = s
+			break
+		}
+	}
+	if site == nil {
+		return fmt.Errorf("[ERROR] Office site not found: %s", officeSiteId)
+	}
+
+	if strings.EqualFold(outputFmt, "json") {
+		b, jerr := json.MarshalIndent(toOfficeSiteJSON(site), "", "  ")
+		if jerr != nil {
+			return fmt.Errorf("json marshal: %w", jerr)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	printOfficeSiteDetails(site, "")
+	return nil
+}
+
+func runNetworkOfficeSiteCreate(cmd *cobra.Command, args []string) error {
+	vpcId, _ := cmd.Flags().GetString("vpc-id")
+	regionId, _ := cmd.Flags().GetString("region-id")
+	name, _ := cmd.Flags().GetString("name")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	fmt.Printf("[STEP 1/2] Checking for an existing office site...")
+	existing, err := describeOfficeSites(ctx, cmd, apiClient, &client.DescribeOfficeSitesRequest{
+		OfficeSiteType: dara.String("CUSTOMIZED"),
+		RegionName:     dara.String(regionId),
+		VpcId:          dara.String(vpcId),
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to query office sites: %w", err)
+	}
+	for _, site := range existing {
+		if id := getStringValue(site.GetOfficeSiteId()); id != "" {
+			fmt.Printf("[STEP 2/2] Skipped. (Office site already exists: %s)\n", id)
+			fmt.Printf("[TIP] View details: agentbay network office-site describe %s\n", id)
+			return nil
+		}
+	}
+
+	fmt.Printf("[STEP 2/2] Creating office site...")
+	officeSiteId, requestId, err := sdk.CreateCustomizedOfficeSite(ctx, apiClient, vpcId, regionId, name)
+	if err != nil {
+		fmt.Printf(" Failed.\n")
+		if requestId != "" {
+			fmt.Printf("[INFO] Request ID: %s\n", requestId)
+		}
+		return fmt.Errorf("[ERROR] Failed to create office site: %w", err)
+	}
+	fmt.Printf(" Done. (Action: CreateSimpleOfficeSite, Request ID: %s)\n", requestId)
+
+	fmt.Printf("\n[SUCCESS] Office site created: %s\n", officeSiteId)
+	fmt.Printf("[TIP] Activate an image on this VPC: agentbay image activate <image-id> --network-type CUSTOMIZED --vpc-id %s --vswitch-id <vswitch-id> --region-id %s\n", vpcId, regionId)
+	return nil
+}
+
+// describeOfficeSites calls DescribeOfficeSites and returns every office site in the response.
+// It prints the inline " Done. (Action: ..., Request ID: ...)" suffix, so callers print the step prefix first.
+func describeOfficeSites(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, req *client.DescribeOfficeSitesRequest) ([]*client.DescribeOfficeSitesResponseBodyData, error) {
+	resp, err := apiClient.DescribeOfficeSites(ctx, req)
+	if err != nil {
+		fmt.Printf(" Failed.\n")
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return nil, err
+	}
+	if resp.Body == nil {
+		fmt.Printf(" Failed.\n")
+		return nil, fmt.Errorf("invalid response: missing body")
+	}
+	if resp.Body.GetRequestId() != nil && *resp.Body.GetRequestId() != "" {
+		fmt.Printf(" Done. (Action: DescribeOfficeSites, Request ID: %s)\n", *resp.Body.GetRequestId())
+	} else {
+		fmt.Printf(" Done. (Action: DescribeOfficeSites)\n")
+	}
+
+	code := getStringValue(resp.Body.GetCode())
+	if (resp.Body.GetSuccess() != nil && !*resp.Body.GetSuccess()) || (code != "" && !isSuccessCode(code)) {
+		return nil, fmt.Errorf("API request failed: Code=%s, Message=%s", code, getStringValue(resp.Body.GetMessage()))
+	}
+
+	var sites []*client.DescribeOfficeSitesResponseBodyData
+	for _, site := range resp.Body.GetOfficeSites() {
+		if site != nil {
+			sites = append(sites, site)
+		}
+	}
+	return sites, nil
+}
+
+// printOfficeSiteDetails prints an office site as indented "key: value" lines.
+func printOfficeSiteDetails(site *client.DescribeOfficeSitesResponseBodyData, indent string) {
+	dns := "-"
+	if len(site.GetDnsAddress()) > 0 {
+		dns = strings.Join(site.GetDnsAddress(), ", ")
+	}
+	fmt.Printf("%sOffice Site ID:   %s\n", indent, getStringValue(site.GetOfficeSiteId()))
+	fmt.Printf("%sName:             %s\n", indent, valueOrDash(getStringValue(site.GetOfficeSiteName())))
+	fmt.Printf("%sType:             %s\n", indent, valueOrDash(getStringValue(site.GetOfficeSiteType())))
+	fmt.Printf("%sVPC ID:           %s\n", indent, valueOrDash(getStringValue(site.GetVpcId())))
+	fmt.Printf("%sRegion:           %s\n", indent, valueOrDash(getStringValue(site.GetRegionId())))
+	fmt.Printf("%sStatus:           %s\n", indent, valueOrDash(getStringValue(site.GetStatus())))
+	fmt.Printf("%sDNS Addresses:    %s\n", indent, dns)
+	if created := getStringValue(site.GetCreationTime()); created != "" {
+		fmt.Printf("%sCreated:          %s\n", indent, created)
+	}
+}
+
+// valueOrDash returns "-" for empty strings so table cells never collapse.
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,300 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var networkReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Show which images use which office site and network settings",
+	Long: `Show, for every user image, the network configuration (NetworkData) saved in its
+policy and the office site it resolves to.
+
+Images without a saved network configuration are reported with network type DEFAULT.
+
+Examples:
+  agentbay network report
+  agentbay network report --image-id imgc-xxxxxxxxxxxxxx
+  agentbay network report -o json`,
+	Args: cobra.NoArgs,
+	RunE: runNetworkReport,
+}
+
+func init() {
+	networkReportCmd.Flags().String("image-id", "", "Only report this image (optional)")
+	networkReportCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+
+	registerFlagCompletion(networkReportCmd, "image-id", completeFlag(completionImages, nil))
+
+	NetworkCmd.AddCommand(networkReportCmd)
+}
+
+// networkReportEntry is one image's network configuration in the report.
+type networkReportEntry struct {
+	ImageId          string   `json:"imageId"`
+	ImageName        string   `json:"imageName"`
+	NetworkType      string   `json:"networkType"`
+	RegionId         string   `json:"regionId"`
+	OfficeSiteId     string   `json:"officeSiteId"`
+	VpcId            string   `json:"vpcId"`
+	VpcName          string   `json:"vpcName"`
+	VSwitchId        string   `json:"vSwitchId"`
+	DnsAddress       []string `json:"dnsAddress"`
+	SessionBandwidth *int32   `json:"sessionBandwidth,omitempty"`
+	Error            string   `json:"error,omitempty"`
+}
+
+func runNetworkReport(cmd *cobra.Command, args []string) error {
+	imageId, _ := cmd.Flags().GetString("image-id")
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	fmt.Printf("[REPORT] Collecting image network configuration...\n")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	fmt.Printf("Requesting user images...")
+	images, err := listAllUserImages(ctx, apiClient, imageId)
+	if err != nil {
+		fmt.Printf(" Failed.\n")
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] Failed to list images: %w", err)
+	}
+	fmt.Printf(" Done. (%d image(s))\n", len(images))
+
+	if len(images) > 0 {
+		fmt.Printf("Requesting policy data and office sites...")
+	}
+	entries := buildNetworkReport(ctx, apiClient, images)
+	if len(images) > 0 {
+		fmt.Printf(" Done.\n")
+	}
+
+	if strings.EqualFold(outputFmt, "json") {
+		type reportOutput struct {
+			TotalCount int                  `json:"totalCount"`
+			Images     []networkReportEntry `json:"images"`
+		}
+		out := reportOutput{TotalCount: len(entries), Images: entries}
+		if out.Images == nil {
+			out.Images = []networkReportEntry{}
+		}
+		b, jerr := json.MarshalIndent(out, "", "  ")
+		if jerr != nil {
+			return fmt.Errorf("json marshal: %w", jerr)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("\n[EMPTY] No user images found.\n")
+		return nil
+	}
+
+	fmt.Printf("\n[OK] Network configuration of %d image(s)\n\n", len(entries))
+	fmt.Printf("%s %s %s %s %s %s %s\n",
+		padString("IMAGE ID", 25),
+		padString("IMAGE NAME", 20),
+		padString("NETWORK TYPE", 13),
+		padString("OFFICE SITE ID", 34),
+		padString("VPC ID", 26),
+		padString("VSWITCH ID", 26),
+		"DNS")
+	fmt.Printf("%s %s %s %s %s %s %s\n",
+		padString("--------", 25),
+		padString("----------", 20),
+		padString("------------", 13),
+		padString("--------------", 34),
+		padString("------", 26),
+		padString("----------", 26),
+		"---")
+	failed := 0
+	for _, e := range entries {
+		dns := "-"
+		if len(e.DnsAddress) > 0 {
+			dns = strings.Join(e.DnsAddress, ", ")
+		}
+		networkType := e.NetworkType
+		if e.Error != "" {
+			networkType = "UNKNOWN"
+			failed++
+		}
+		fmt.Printf("%s %s %s %s %s %s %s\n",
+			padString(truncateString(e.ImageId, 25), 25),
+			padString(truncateString(valueOrDash(e.ImageName), 20), 20),
+			padString(networkType, 13),
+			padString(truncateString(valueOrDash(e.OfficeSiteId), 34), 34),
+			padString(truncateString(valueOrDash(e.VpcId), 26), 26),
+			padString(truncateString(valueOrDash(e.VSwitchId), 26), 26),
+			dns)
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n[WARN] Could not read the network configuration of %d image(s):\n", failed)
+		for _, e := range entries {
+			if e.Error != "" {
+				fmt.Printf("  - %s: %s\n", e.ImageId, e.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// listAllUserImages returns every user image, following PageStart pagination until all pages are read.
+// When imageId is set only that image is requested.
+func listAllUserImages(ctx context.Context, apiClient agentbay.Client, imageId string) ([]*client.ListMcpImagesResponseBodyData, error) {
+	const pageSize int32 = 100
+	var images []*client.ListMcpImagesResponseBodyData
+	for page := int32(1); ; page++ {
+		req := &client.ListMcpImagesRequest{
+			ImageType: dara.String("User"),
+			PageSize:  dara.Int32(pageSize),
+			PageStart: dara.Int32(page),
+		}
+		if imageId != "" {
+			req.ImageIds = []string{imageId}
+		}
+		resp, err := apiClient.ListMcpImages(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil || resp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing body")
+		}
+		if resp.Body.Success != nil && !*resp.Body.Success {
+			return nil, fmt.Errorf("API request failed: %s", getStringValue(resp.Body.Message))
+		}
+		for _, img := range resp.Body.Data {
+			if img != nil {
+				images = append(images, img)
+			}
+		}
+		total := int32(-1)
+		if resp.Body.TotalCount != nil {
+			total = *resp.Body.TotalCount
+		}
+		if int32(len(resp.Body.Data)) < pageSize || (total >= 0 && int32(len(images)) >= total) {
+			return images, nil
+		}
+	}
+}
+
+// buildNetworkReport reads each image's policy NetworkData and resolves the office site it uses.
+// Office site lookups are cached per (type, region, VPC) so images sharing a network cost one call.
+// Per-image failures are recorded in the entry instead of aborting the report.
+func buildNetworkReport(ctx context.Context, apiClient agentbay.Client, images []*client.ListMcpImagesResponseBodyData) []networkReportEntry {
+	type siteResult struct {
+		site *client.DescribeOfficeSitesResponseBodyData
+		err  error
+	}
+	siteCache := make(map[string]siteResult)
+
+	var entries []networkReportEntry
+	for _, img := range images {
+		entry := networkReportEntry{
+			ImageId:     getStringValue(img.GetImageId()),
+			ImageName:   getStringValue(img.GetImageName()),
+			NetworkType: "DEFAULT",
+			DnsAddress:  []string{},
+		}
+
+		policyResp, err := apiClient.DescribeMcpPolicyData(ctx, &client.DescribeMcpPolicyDataRequest{
+			ImageId: dara.String(entry.ImageId),
+		})
+		if err != nil {
+			entry.Error = fmt.Sprintf("DescribeMcpPolicyData: %v", err)
+			entries = append(entries, entry)
+			continue
+		}
+		if policyResp == nil || policyResp.Body == nil {
+			entries = append(entries, entry)
+			continue
+		}
+		if code := getStringValue(policyResp.Body.Code); (policyResp.Body.Success != nil && !*policyResp.Body.Success) || (code != "" && !isSuccessCode(code)) {
+			entry.Error = fmt.Sprintf("DescribeMcpPolicyData: API request failed: Code=%s", code)
+			entries = append(entries, entry)
+			continue
+		}
+		if policyResp.Body.Data == nil {
+			entries = append(entries, entry)
+			continue
+		}
+		data := policyResp.Body.Data
+		if data.GroupSpec != nil {
+			entry.RegionId = getStringValue(data.GroupSpec.RegionId)
+		}
+
+		nd := data.NetworkData
+		if nd == nil || getStringValue(nd.OfficeSiteType) == "" {
+			entries = append(entries, entry)
+			continue
+		}
+		entry.NetworkType = strings.ToUpper(getStringValue(nd.OfficeSiteType))
+		entry.VpcId = getStringValue(nd.VpcId)
+		entry.VpcName = getStringValue(nd.VpcName)
+		entry.VSwitchId = getStringValue(nd.VSwitchId)
+		entry.SessionBandwidth = nd.SessionBandwidth
+		for _, dns := range strings.Split(getStringValue(nd.DnsAddress), ",") {
+			if dns = strings.TrimSpace(dns); dns != "" {
+				entry.DnsAddress = append(entry.DnsAddress, dns)
+			}
+		}
+
+		if entry.RegionId != "" {
+			key := entry.NetworkType + "|" + entry.RegionId + "|" + entry.VpcId
+			cached, ok := siteCache[key]
+			if !ok {
+				req := &client.DescribeOfficeSitesRequest{
+					OfficeSiteType: dara.String(entry.NetworkType),
+					RegionName:     dara.String(entry.RegionId),
+				}
+				if entry.VpcId != "" {
+					req.SetVpcId(entry.VpcId)
+				}
+				resp, serr := apiClient.DescribeOfficeSites(ctx, req)
+				// A failure reported in the body must not read as "no office site".
+				if serr == nil && resp != nil && resp.Body != nil {
+					code := getStringValue(resp.Body.Code)
+					if (resp.Body.Success != nil && !*resp.Body.Success) || (code != "" && !isSuccessCode(code)) {
+						serr = fmt.Errorf("API request failed: Code=%s, Message=%s", code, getStringValue(resp.Body.Message))
+					} else {
+						cached.site = resp.Body.GetData()
+					}
+				}
+				cached.err = serr
+				siteCache[key] = cached
+			}
+			if cached.err != nil {
+				entry.Error = fmt.Sprintf("DescribeOfficeSites: %v", cached.err)
+			} else if cached.site != nil {
+				entry.OfficeSiteId = getStringValue(cached.site.GetOfficeSiteId())
+				if len(entry.DnsAddress) == 0 {
+					entry.DnsAddress = append(entry.DnsAddress, cached.site.GetDnsAddress()...)
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// mockNetworkReportClient serves DescribeMcpPolicyData and DescribeOfficeSites for buildNetworkReport tests.
+// Other agentbay.Client methods are not used and panic through the nil embedded interface.
+type mockNetworkReportClient struct {
+	agentbay.Client
+	policies        map[string]*client.DescribeMcpPolicyDataResponseBodyData
+	officeSiteCalls int
+	// failingRegions answer DescribeOfficeSites with a failure in the body.
+	failingRegions map[string]bool
+}
+
+func (m *mockNetworkReportClient) DescribeMcpPolicyData(ctx context.Context, req *client.DescribeMcpPolicyDataRequest) (*client.DescribeMcpPolicyDataResponse, error) {
+	data, ok := m.policies[*req.ImageId]
+	if !ok {
+		return nil, fmt.Errorf("image not found")
+	}
+	return &client.DescribeMcpPolicyDataResponse{Body: &client.DescribeMcpPolicyDataResponseBody{Data: data}}, nil
+}
+
+func (m *mockNetworkReportClient) DescribeOfficeSites(ctx context.Context, req *client.DescribeOfficeSitesRequest) (*client.DescribeOfficeSitesResponse, error) {
+	m.officeSiteCalls++
+	if m.failingRegions[*req.RegionName] {
+		return &client.DescribeOfficeSitesResponse{Body: &client.DescribeOfficeSitesResponseBody{
+			Success: dara.Bool(false), Code: dara.String("Throttling"), Message: dara.String("Request was denied due to flow control."),
+		}}, nil
+	}
+	site := &client.DescribeOfficeSitesResponseBodyData{
+		OfficeSiteId: dara.String(*req.RegionName + "+dir-" + *req.OfficeSiteType),
+		DnsAddress:   []string{"100.100.2.136"},
+	}
+	return &client.DescribeOfficeSitesResponse{Body: &client.DescribeOfficeSitesResponseBody{Data: site}}, nil
+}
+
+func reportPolicy(siteType, vpcId, dns string) *client.DescribeMcpPolicyDataResponseBodyData {
+	data := &client.DescribeMcpPolicyDataResponseBodyData{
+		GroupSpec: &client.GroupSpec{RegionId: dara.String("cn-hangzhou")},
+	}
+	if siteType != "" {
+		data.NetworkData = &client.NetworkData{
+			OfficeSiteType: dara.String(siteType),
+			VpcId:          dara.String(vpcId),
+			VSwitchId:      dara.String("vsw-1"),
+			DnsAddress:     dara.String(dns),
+		}
+	}
+	return data
+}
+
+func reportImage(id string) *client.ListMcpImagesResponseBodyData {
+	return &client.ListMcpImagesResponseBodyData{ImageId: dara.String(id), ImageName: dara.String("name-" + id)}
+}
+
+func TestBuildNetworkReport(t *testing.T) {
+	mock := &mockNetworkReportClient{
+		policies: map[string]*client.DescribeMcpPolicyDataResponseBodyData{
+			"img-default":  reportPolicy("", "", ""),
+			"img-custom-1": reportPolicy("CUSTOMIZED", "vpc-1", "8.8.8.8, 1.1.1.1"),
+			"img-custom-2": reportPolicy("CUSTOMIZED", "vpc-1", ""),
+		},
+	}
+	images := []*client.ListMcpImagesResponseBodyData{
+		reportImage("img-default"),
+		reportImage("img-custom-1"),
+		reportImage("img-custom-2"),
+		reportImage("img-missing"),
+	}
+
+	entries := buildNetworkReport(context.Background(), mock, images)
+	require.Len(t, entries, 4)
+
+	t.Run("image without NetworkData is DEFAULT", func(t *testing.T) {
+		assert.Equal(t, "DEFAULT", entries[0].NetworkType)
+		assert.Empty(t, entries[0].OfficeSiteId)
+		assert.Empty(t, entries[0].Error)
+	})
+
+	t.Run("customized image resolves office site and keeps its own DNS", func(t *testing.T) {
+		assert.Equal(t, "CUSTOMIZED", entries[1].NetworkType)
+		assert.Equal(t, "cn-hangzhou+dir-CUSTOMIZED", entries[1].OfficeSiteId)
+		assert.Equal(t, "vpc-1", entries[1].VpcId)
+		assert.Equal(t, "vsw-1", entries[1].VSwitchId)
+		assert.Equal(t, []string{"8.8.8.8", "1.1.1.1"}, entries[1].DnsAddress)
+	})
+
+	t.Run("office site default DNS is used when NetworkData has none", func(t *testing.T) {
+		assert.Equal(t, []string{"100.100.2.136"}, entries[2].DnsAddress)
+	})
+
+	t.Run("office site lookups are cached per network", func(t *testing.T) {
+		assert.Equal(t, 1, mock.officeSiteCalls)
+	})
+
+	t.Run("office site failure in the body is recorded", func(t *testing.T) {
+		failing := reportPolicy("CUSTOMIZED", "vpc-2", "")
+		failing.GroupSpec.RegionId = dara.String("cn-shanghai")
+		mock := &mockNetworkReportClient{
+			policies:       map[string]*client.DescribeMcpPolicyDataResponseBodyData{"img-sh": failing},
+			failingRegions: map[string]bool{"cn-shanghai": true},
+		}
+		entries := buildNetworkReport(context.Background(), mock, []*client.ListMcpImagesResponseBodyData{reportImage("img-sh")})
+		require.Len(t, entries, 1)
+		assert.Empty(t, entries[0].OfficeSiteId)
+		assert.Contains(t, entries[0].Error, "Throttling")
+	})
+
+	t.Run("policy lookup failure is recorded, not fatal", func(t *testing.T) {
+		assert.Equal(t, "img-missing", entries[3].ImageId)
+		assert.Contains(t, entries[3].Error, "DescribeMcpPolicyData")
+	})
+}
@@ -0,0 +1,650 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// plugin.go turns executables named agentbay-<name> into "agentbay <name>" subcommands, the way git
+// and kubectl do. Plugins are looked up in the plugins directory of the CLI config directory (where
+// "agentbay plugin install" puts them) and then on PATH; the first match of a name wins, and a
+// plugin never replaces a built-in command. A plugin runs with the resolved endpoint, environment
+// and a fresh credential in AGENTBAY_PLUGIN_* variables, and can ask for them again at any time by
+// sending a JSON request to "agentbay plugin context".
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+const (
+	// pluginPrefix is the file name prefix that makes an executable a plugin.
+	pluginPrefix = "agentbay-"
+	// pluginGroupID is the help group plugin commands are listed under.
+	pluginGroupID = "plugins"
+	// pluginAnnotation marks a command registered for a plugin and holds the plugin path.
+	pluginAnnotation = "agentbay-plugin"
+	// pluginProtocolVersion is the version of the plugin context served by "plugin context".
+	pluginProtocolVersion = 1
+)
+
+// Environment variables set for a plugin. Access key credentials are passed through unchanged in
+// the AGENTBAY_ACCESS_KEY_* variables the CLI itself reads them from.
+const (
+	envPluginName           = "AGENTBAY_PLUGIN_NAME"
+	envPluginCLI            = "AGENTBAY_PLUGIN_CLI"
+	envPluginEndpoint       = "AGENTBAY_PLUGIN_ENDPOINT"
+	envPluginEnvironment    = "AGENTBAY_PLUGIN_ENVIRONMENT"
+	envPluginCredentialType = "AGENTBAY_PLUGIN_CREDENTIAL_TYPE"
+	envPluginBearerToken    = "AGENTBAY_PLUGIN_BEARER_TOKEN"
+	envPluginTokenExpiresAt = "AGENTBAY_PLUGIN_TOKEN_EXPIRES_AT"
+)
+
+// Credential types in the plugin context.
+const (
+	pluginCredentialNone        = "none"
+	pluginCredentialAccessKey   = "access_key"
+	pluginCredentialSTS         = "sts"
+	pluginCredentialBearerToken = "bearer_token"
+)
+
+// Plugin states reported by plugin list.
+const (
+	pluginActive   = "active"
+	pluginShadowed = "shadowed"
+	pluginBuiltin  = "builtin"
+)
+
+var pluginNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// PluginExitError carries the exit status of a plugin that failed, so the CLI exits with it.
+type PluginExitError struct {
+	Name string
+	Code int
+}
+
+func (e *PluginExitError) Error() string {
+	return fmt.Sprintf("plugin %s exited with status %d", e.Name, e.Code)
+}
+
+// pluginEntry is one plugin executable found on the search path.
+type pluginEntry struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Status     string `json:"status"`
+	ShadowedBy string `json:"shadowedBy,omitempty"`
+}
+
+// pluginContext is what a plugin learns about the CLI it was started from.
+type pluginContext struct {
+	Version     int              `json:"version"`
+	Endpoint    string           `json:"endpoint"`
+	Environment string           `json:"environment"`
+	Credential  pluginCredential `json:"credential"`
+}
+
+type pluginCredential struct {
+	Type            string `json:"type"`
+	AccessKeyId     string `json:"accessKeyId,omitempty"`
+	AccessKeySecret string `json:"accessKeySecret,omitempty"`
+	SecurityToken   string `json:"securityToken,omitempty"`
+	BearerToken     string `json:"bearerToken,omitempty"`
+	ExpiresAt       string `json:"expiresAt,omitempty"`
+}
+
+// pluginContextRequest is the JSON a plugin sends to "plugin context" on stdin.
+type pluginContextRequest struct {
+	Version int `json:"version"`
+}
+
+// PluginCmd manages plugins.
+var PluginCmd = &cobra.Command{
+	Use:     "plugin",
+	Short:   "Manage CLI plugins",
+	GroupID: "management",
+	Long: `Manage CLI plugins.
+
+Any executable named agentbay-<name> in the plugins directory or on PATH runs as
+"agentbay <name>". The plugins directory (plugins/ in the CLI config directory)
+is searched first; a plugin never replaces a built-in command.
+
+A plugin is started with these environment variables:
+  AGENTBAY_PLUGIN_NAME              Name the plugin was invoked as
+  AGENTBAY_PLUGIN_CLI               Path of the agentbay binary
+  AGENTBAY_PLUGIN_ENDPOINT          Resolved API endpoint
+  AGENTBAY_PLUGIN_ENVIRONMENT       Resolved environment (production, prerelease, ...)
+  AGENTBAY_PLUGIN_CREDENTIAL_TYPE   access_key, sts, bearer_token or none
+  AGENTBAY_PLUGIN_BEARER_TOKEN      OAuth access token (bearer_token only)
+  AGENTBAY_PLUGIN_TOKEN_EXPIRES_AT  Token expiry, RFC 3339 (bearer_token only)
+Access keys are passed in the AGENTBAY_ACCESS_KEY_* variables they were read from.
+
+Long-running plugins can fetch a fresh context as JSON with 'agentbay plugin context'.`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed plugins",
+	Long: `List the plugin executables found in the plugins directory and on PATH, in lookup
+order. A plugin whose name was already found earlier is shadowed, and one named
+after a built-in command is ignored.
+
+Examples:
+  agentbay plugin list
+  agentbay plugin list -o json`,
+	Args: cobra.NoArgs,
+	RunE: runPluginList,
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <path|url>",
+	Short: "Install a plugin into the plugins directory",
+	Long: `Copy a plugin executable into the plugins directory, from a local file or an
+https URL. The plugin name comes from the agentbay-<name> file name or --name.
+A URL requires --sha256, and the download must match it; plain http URLs are
+refused. --sha256 also verifies a local file.
+
+Examples:
+  agentbay plugin install ./bin/agentbay-audit
+  agentbay plugin install https://example.com/tools/agentbay-audit --sha256 <hex>
+  agentbay plugin install ./build/audit-tool --name audit --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginInstall,
+}
+
+var pluginContextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Print the plugin context as JSON (for plugins)",
+	Long: `Answer a plugin context request: read a JSON request such as {"version":1} on stdin
+and print the resolved endpoint, environment and a fresh credential as JSON.
+OAuth tokens are refreshed first when they are about to expire. Output is
+refused on a terminal, as it contains credentials.
+
+Response:
+  {"version":1,"endpoint":"...","environment":"production",
+   "credential":{"type":"bearer_token","bearerToken":"...","expiresAt":"..."}}
+
+Examples:
+  echo '{"version":1}' | "$AGENTBAY_PLUGIN_CLI" plugin context`,
+	Args: cobra.NoArgs,
+	RunE: runPluginContext,
+}
+
+func init() {
+	pluginListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+	addPluginInstallFlags(pluginInstallCmd)
+
+	PluginCmd.AddCommand(pluginListCmd)
+	PluginCmd.AddCommand(pluginInstallCmd)
+	PluginCmd.AddCommand(pluginContextCmd)
+}
+
+func addPluginInstallFlags(c *cobra.Command) {
+	c.Flags().String("name", "", "Plugin name (default: taken from the agentbay-<name> file name)")
+	c.Flags().String("sha256", "", "Expected SHA-256 of the plugin executable (hex); required for URLs")
+	c.Flags().Bool("force", false, "Replace an installed plugin of the same name")
+}
+
+// AddPluginCommands registers every active plugin as a subcommand of root. Call it after the
+// built-in commands are added.
+func AddPluginCommands(root *cobra.Command) {
+	grouped := false
+	for _, p := range findPlugins(pluginSearchDirs(), builtinCommandNames(root)) {
+		if p.Status != pluginActive {
+			continue
+		}
+		if !grouped {
+			root.AddGroup(&cobra.Group{ID: pluginGroupID, Title: "Plugin Commands"})
+			grouped = true
+		}
+		root.AddCommand(newPluginCommand(p))
+	}
+}
+
+func newPluginCommand(p pluginEntry) *cobra.Command {
+	return &cobra.Command{
+		Use:                p.Name,
+		Short:              "Plugin " + p.Path,
+		GroupID:            pluginGroupID,
+		Annotations:        map[string]string{pluginAnnotation: p.Path},
+		DisableFlagParsing: true,
+		SilenceUsage:       true,
+		ValidArgsFunction: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveDefault
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			err := runPlugin(p, args)
+			var exitErr *PluginExitError
+			if errors.As(err, &exitErr) {
+				// The plugin reported its own failure; only its exit status is passed on.
+				c.SilenceErrors = true
+			}
+			return err
+		},
+	}
+}
+
+// builtinCommandNames returns the names and aliases plugins may not take, including the help and
+// completion commands cobra adds on Execute.
+func builtinCommandNames(root *cobra.Command) map[string]bool {
+	names := map[string]bool{
+		"help":                          true,
+		"completion":                    true,
+		cobra.ShellCompRequestCmd:       true,
+		cobra.ShellCompNoDescRequestCmd: true,
+	}
+	for _, c := range root.Commands() {
+		if _, ok := c.Annotations[pluginAnnotation]; ok {
+			continue
+		}
+		names[c.Name()] = true
+		for _, a := range c.Aliases {
+			names[a] = true
+		}
+	}
+	return names
+}
+
+func pluginsDir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plugins"), nil
+}
+
+// pluginSearchDirs returns the plugins directory followed by the PATH entries, without duplicates.
+// Empty PATH entries are skipped so the working directory is never searched.
+func pluginSearchDirs() []string {
+	var dirs []string
+	if dir, err := pluginsDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+
+	seen := map[string]bool{}
+	out := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		if d == "" {
+			continue
+		}
+		d = filepath.Clean(d)
+		if seen[d] {
+			continue
+		}
+		seen[d] = true
+		out = append(out, d)
+	}
+	return out
+}
+
+// pluginExecutableSuffixes are the extensions that make a file executable on Windows.
+var pluginExecutableSuffixes = []string{".exe", ".bat", ".cmd"}
+
+// pluginName returns the plugin name of an agentbay-<name> file name.
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, pluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, pluginPrefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		matched := false
+		for _, s := range pluginExecutableSuffixes {
+			if ext == s {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if !pluginNamePattern.MatchString(name) {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutableFile(info os.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	// On Windows the extension, checked by pluginName, decides.
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0111 != 0
+}
+
+// findPlugins lists every plugin executable in dirs in lookup order. A name found again later is
+// shadowed by the first one, and a name of a built-in command is never active.
+func findPlugins(dirs []string, builtin map[string]bool) []pluginEntry {
+	first := map[string]string{}
+	var plugins []pluginEntry
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e.Name())
+			if !ok {
+				continue
+			}
+			p := filepath.Join(dir, e.Name())
+			info, err := os.Stat(p)
+			if err != nil || !isExecutableFile(info) {
+				continue
+			}
+			entry := pluginEntry{Name: name, Path: p, Status: pluginActive}
+			switch {
+			case builtin[name]:
+				entry.Status = pluginBuiltin
+			case first[name] != "":
+				entry.Status = pluginShadowed
+				entry.ShadowedBy = first[name]
+			default:
+				first[name] = p
+			}
+			plugins = append(plugins, entry)
+		}
+	}
+	return plugins
+}
+
+// runPlugin runs a plugin with the CLI's terminal and the plugin environment, and waits for it.
+func runPlugin(p pluginEntry, args []string) error {
+	pc, err := resolvePluginContext(freshPluginToken)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] Running plugin %s without a credential: %v\n", p.Name, err)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		self = ""
+	}
+
+	proc := exec.Command(p.Path, args...)
+	proc.Env = pluginEnviron(os.Environ(), p.Name, self, pc)
+	proc.Stdin = os.Stdin
+	proc.Stdout = os.Stdout
+	proc.Stderr = os.Stderr
+
+	// Ctrl-C reaches the plugin directly; the CLI keeps waiting so the plugin can clean up. A
+	// handled signal, unlike an ignored one, is reset to the default in the plugin.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	if err := proc.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			code := exitErr.ExitCode()
+			if code < 0 {
+				code = 1
+			}
+			return &PluginExitError{Name: p.Name, Code: code}
+		}
+		return fmt.Errorf("[ERROR] Failed to run plugin %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// freshPluginToken returns the OAuth token, refreshed when it is about to expire, or nil when not
+// logged in.
+func freshPluginToken() (*config.Token, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.AccessToken == "" {
+		return nil, nil
+	}
+	return agentbay.FreshToken(cfg)
+}
+
+// resolvePluginContext resolves the endpoint, environment and credential the way API calls do:
+// access keys from the environment take precedence over the OAuth login. On error the context is
+// still returned, without a credential.
+func resolvePluginContext(freshToken func() (*config.Token, error)) (*pluginContext, error) {
+	pc := &pluginContext{
+		Version:     pluginProtocolVersion,
+		Endpoint:    config.LoadAPIConfig(nil).Endpoint,
+		Environment: string(config.GetEnvironment()),
+		Credential:  pluginCredential{Type: pluginCredentialNone},
+	}
+	if ak, sk, session, ok := config.AccessKeyFromEnv(); ok {
+		pc.Credential = pluginCredential{Type: pluginCredentialAccessKey, AccessKeyId: ak, AccessKeySecret: sk}
+		if session != "" {
+			pc.Credential.Type = pluginCredentialSTS
+			pc.Credential.SecurityToken = session
+		}
+		return pc, nil
+	}
+	token, err := freshToken()
+	if err != nil {
+		return pc, err
+	}
+	if token != nil {
+		pc.Credential = pluginCredential{Type: pluginCredentialBearerToken, BearerToken: token.AccessToken}
+		if !token.ExpiresAt.IsZero() {
+			pc.Credential.ExpiresAt = token.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return pc, nil
+}
+
+// pluginEnviron returns base with the plugin variables set. Plugin variables inherited from an
+// outer plugin are dropped so a nested plugin never sees a stale token.
+func pluginEnviron(base []string, name, self string, pc *pluginContext) []string {
+	env := make([]string, 0, len(base)+7)
+	for _, kv := range base {
+		if !strings.HasPrefix(kv, "AGENTBAY_PLUGIN_") {
+			env = append(env, kv)
+		}
+	}
+	env = append(env,
+		envPluginName+"="+name,
+		envPluginCLI+"="+self,
+		envPluginEndpoint+"="+pc.Endpoint,
+		envPluginEnvironment+"="+pc.Environment,
+		envPluginCredentialType+"="+pc.Credential.Type,
+	)
+	if pc.Credential.Type == pluginCredentialBearerToken {
+		env = append(env, envPluginBearerToken+"="+pc.Credential.BearerToken)
+		if pc.Credential.ExpiresAt != "" {
+			env = append(env, envPluginTokenExpiresAt+"="+pc.Credential.ExpiresAt)
+		}
+	}
+	return env
+}
+
+func runPluginContext(cobraCmd *cobra.Command, args []string) error {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("[ERROR] plugin context prints credentials and is meant to be read by plugins; redirect its output")
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf(`[ERROR] plugin context expects a JSON request on stdin, e.g. echo '{"version":1}' | agentbay plugin context`)
+	}
+	return servePluginContext(os.Stdin, os.Stdout, freshPluginToken)
+}
+
+// servePluginContext answers one request. An empty request asks for the current version.
+func servePluginContext(in io.Reader, out io.Writer, freshToken func() (*config.Token, error)) error {
+	data, err := io.ReadAll(io.LimitReader(in, 64*1024))
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to read the plugin context request: %w", err)
+	}
+	req := pluginContextRequest{Version: pluginProtocolVersion}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &req); err != nil {
+			return fmt.Errorf("[ERROR] Invalid plugin context request: %w", err)
+		}
+	}
+	if req.Version < 1 || req.Version > pluginProtocolVersion {
+		return fmt.Errorf("[ERROR] Unsupported plugin protocol version %d (supported: %d)", req.Version, pluginProtocolVersion)
+	}
+	pc, err := resolvePluginContext(freshToken)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	return json.NewEncoder(out).Encode(pc)
+}
+
+func runPluginList(cobraCmd *cobra.Command, args []string) error {
+	format, _ := cobraCmd.Flags().GetString("output")
+	if format != "" && format != "json" {
+		return fmt.Errorf("[ERROR] Invalid --output %q: use json", format)
+	}
+	plugins := findPlugins(pluginSearchDirs(), builtinCommandNames(cobraCmd.Root()))
+
+	if format == "json" {
+		if plugins == nil {
+			plugins = []pluginEntry{}
+		}
+		b, err := json.MarshalIndent(plugins, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	if len(plugins) == 0 {
+		fmt.Println("No plugins found. Install one with 'agentbay plugin install' or put an agentbay-<name> executable on PATH.")
+		return nil
+	}
+	fmt.Printf("%-20s  %-10s  %s\n", "Name", "Status", "Path")
+	fmt.Printf("%-20s  %-10s  %s\n", "--------------------", "----------", "----")
+	for _, p := range plugins {
+		fmt.Printf("%-20s  %-10s  %s\n", p.Name, p.Status, p.Path)
+	}
+	for _, p := range plugins {
+		switch p.Status {
+		case pluginShadowed:
+			fmt.Printf("[WARN] %s is shadowed by %s\n", p.Path, p.ShadowedBy)
+		case pluginBuiltin:
+			fmt.Printf("[WARN] %s is ignored: %q is a built-in command\n", p.Path, p.Name)
+		}
+	}
+	return nil
+}
+
+func runPluginInstall(cobraCmd *cobra.Command, args []string) error {
+	source := args[0]
+	name, _ := cobraCmd.Flags().GetString("name")
+	expected, _ := cobraCmd.Flags().GetString("sha256")
+	force, _ := cobraCmd.Flags().GetBool("force")
+
+	if name == "" {
+		base := path.Base(filepath.ToSlash(source))
+		if i := strings.IndexAny(base, "?#"); i >= 0 {
+			base = base[:i]
+		}
+		if !strings.HasPrefix(base, pluginPrefix) {
+			return fmt.Errorf("[ERROR] %s is not named %s<name>; pass --name", source, pluginPrefix)
+		}
+		name = strings.TrimPrefix(base, pluginPrefix)
+		for _, s := range pluginExecutableSuffixes {
+			if strings.HasSuffix(strings.ToLower(name), s) {
+				name = name[:len(name)-len(s)]
+				break
+			}
+		}
+	}
+	if !pluginNamePattern.MatchString(name) {
+		return fmt.Errorf("[ERROR] Invalid plugin name %q: use letters, digits, '-' and '_'", name)
+	}
+	// A downloaded executable runs with the user's credentials: only over TLS, and only pinned
+	if strings.HasPrefix(source, "http://") {
+		return fmt.Errorf("[ERROR] Refusing to download a plugin over plain http: %s; use an https URL", source)
+	}
+	if strings.HasPrefix(source, "https://") && expected == "" {
+		return fmt.Errorf("[ERROR] --sha256 is required when installing a plugin from a URL")
+	}
+	if builtinCommandNames(cobraCmd.Root())[name] {
+		return fmt.Errorf("[ERROR] %q is a built-in command and cannot be used as a plugin name", name)
+	}
+
+	dir, err := pluginsDir()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to locate the plugins directory: %w", err)
+	}
+	dest := filepath.Join(dir, pluginPrefix+name)
+	if runtime.GOOS == "windows" {
+		dest += ".exe"
+	}
+	if _, err := os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("[ERROR] Plugin %s is already installed at %s; pass --force to replace it", name, dest)
+	}
+
+	data, err := readPluginSource(source)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if expected != "" {
+		sum := sha256.Sum256(data)
+		if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, strings.TrimPrefix(expected, "sha256:")) {
+			return fmt.Errorf("[ERROR] SHA-256 mismatch for %s: expected %s, got %s", source, expected, actual)
+		}
+	}
+	if err := writePluginFile(dest, data); err != nil {
+		return fmt.Errorf("[ERROR] Failed to install plugin: %w", err)
+	}
+	fmt.Printf("[OK] Plugin %s installed: %s\n", name, dest)
+	fmt.Printf("[INFO] Run it with: agentbay %s\n", name)
+	return nil
+}
+
+// readPluginSource reads a plugin executable from a local file or an https URL.
+func readPluginSource(source string) ([]byte, error) {
+	if strings.HasPrefix(source, "https://") {
+		httpClient := &http.Client{Timeout: 5 * time.Minute}
+		resp, err := httpClient.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", source, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to download %s: HTTP %d", source, resp.StatusCode)
+		}
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", source, err)
+		}
+		return data, nil
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", source)
+	}
+	return os.ReadFile(source)
+}
+
+// writePluginFile writes an executable through a temporary file, so a running plugin is never
+// replaced by a half-written one.
+func writePluginFile(dest string, data []byte) error {
+	if runtime.GOOS == "windows" {
+		_ = os.Remove(dest)
+	}
+	return config.WriteFileAtomic(dest, data, 0755)
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+func skipOnWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+}
+
+func writePlugin(t *testing.T, dir, file, script string, mode os.FileMode) string {
+	p := filepath.Join(dir, file)
+	require.NoError(t, os.WriteFile(p, []byte("#!/bin/sh\n"+script+"\n"), mode))
+	return p
+}
+
+func TestPluginName(t *testing.T) {
+	skipOnWindows(t)
+	name, ok := pluginName("agentbay-audit")
+	assert.True(t, ok)
+	assert.Equal(t, "audit", name)
+	_, ok = pluginName("agentbay-")
+	assert.False(t, ok)
+	_, ok = pluginName("agentbay-.hidden")
+	assert.False(t, ok)
+	_, ok = pluginName("kubectl-foo")
+	assert.False(t, ok)
+}
+
+func TestFindPlugins(t *testing.T) {
+	skipOnWindows(t)
+	first, second := t.TempDir(), t.TempDir()
+	audit := writePlugin(t, first, "agentbay-audit", "true", 0755)
+	shadowed := writePlugin(t, second, "agentbay-audit", "true", 0755)
+	image := writePlugin(t, second, "agentbay-image", "true", 0755)
+	writePlugin(t, second, "agentbay-notexec", "true", 0644)
+	require.NoError(t, os.Mkdir(filepath.Join(second, "agentbay-dir"), 0755))
+
+	plugins := findPlugins([]string{first, second, filepath.Join(first, "missing")}, map[string]bool{"image": true})
+	assert.Equal(t, []pluginEntry{
+		{Name: "audit", Path: audit, Status: pluginActive},
+		{Name: "audit", Path: shadowed, Status: pluginShadowed, ShadowedBy: audit},
+		{Name: "image", Path: image, Status: pluginBuiltin},
+	}, plugins)
+}
+
+func TestPluginSearchDirs(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", configDir)
+	bin := t.TempDir()
+	t.Setenv("PATH", strings.Join([]string{bin, "", bin + string(filepath.Separator)}, string(filepath.ListSeparator)))
+	assert.Equal(t, []string{filepath.Join(configDir, "plugins"), bin}, pluginSearchDirs(), "the plugins directory comes first, empty and repeated entries are dropped")
+}
+
+func TestAddPluginCommands(t *testing.T) {
+	skipOnWindows(t)
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	bin := t.TempDir()
+	writePlugin(t, bin, "agentbay-audit", "true", 0755)
+	writePlugin(t, bin, "agentbay-version", "true", 0755)
+	t.Setenv("PATH", bin)
+
+	root := &cobra.Command{Use: "agentbay"}
+	root.AddCommand(&cobra.Command{Use: "version"})
+	AddPluginCommands(root)
+
+	c, _, err := root.Find([]string{"audit"})
+	require.NoError(t, err)
+	assert.Equal(t, "audit", c.Name())
+	assert.Equal(t, pluginGroupID, c.GroupID)
+	assert.True(t, c.DisableFlagParsing, "flags are passed to the plugin")
+	assert.Equal(t, filepath.Join(bin, "agentbay-audit"), c.Annotations[pluginAnnotation])
+
+	c, _, err = root.Find([]string{"version"})
+	require.NoError(t, err)
+	assert.Empty(t, c.Annotations[pluginAnnotation], "built-in commands are not replaced")
+	assert.False(t, builtinCommandNames(root)["audit"], "registered plugins are not built-in commands")
+}
+
+func TestResolvePluginContext(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_ENDPOINT", "api.example.com")
+	t.Setenv("AGENTBAY_ENV", "prerelease")
+	t.Setenv(config.EnvAccessKeyID, "")
+	t.Setenv(config.EnvAccessKeySecret, "")
+	t.Setenv(config.EnvAccessKeySessionToken, "")
+	expires := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := func() (*config.Token, error) { return &config.Token{AccessToken: "tok", ExpiresAt: expires}, nil }
+
+	pc, err := resolvePluginContext(token)
+	require.NoError(t, err)
+	assert.Equal(t, &pluginContext{
+		Version:     pluginProtocolVersion,
+		Endpoint:    "api.example.com",
+		Environment: "prerelease",
+		Credential:  pluginCredential{Type: pluginCredentialBearerToken, BearerToken: "tok", ExpiresAt: "2025-06-01T12:00:00Z"},
+	}, pc)
+
+	pc, err = resolvePluginContext(func() (*config.Token, error) { return nil, nil })
+	require.NoError(t, err)
+	assert.Equal(t, pluginCredentialNone, pc.Credential.Type, "not logged in")
+
+	pc, err = resolvePluginContext(func() (*config.Token, error) { return nil, errors.New("refresh failed") })
+	assert.ErrorContains(t, err, "refresh failed")
+	assert.Equal(t, "api.example.com", pc.Endpoint, "the context is still usable without a credential")
+	assert.Equal(t, pluginCredentialNone, pc.Credential.Type)
+
+	t.Setenv(config.EnvAccessKeyID, "LTAI-test")
+	t.Setenv(config.EnvAccessKeySecret, "secret")
+	pc, err = resolvePluginContext(token)
+	require.NoError(t, err)
+	assert.Equal(t, pluginCredential{Type: pluginCredentialAccessKey, AccessKeyId: "LTAI-test", AccessKeySecret: "secret"}, pc.Credential, "access keys take precedence")
+
+	t.Setenv(config.EnvAccessKeySessionToken, "sts-token")
+	pc, err = resolvePluginContext(token)
+	require.NoError(t, err)
+	assert.Equal(t, pluginCredentialSTS, pc.Credential.Type)
+	assert.Equal(t, "sts-token", pc.Credential.SecurityToken)
+}
+
+func TestPluginEnviron(t *testing.T) {
+	pc := &pluginContext{
+		Endpoint:    "api.example.com",
+		Environment: "production",
+		Credential:  pluginCredential{Type: pluginCredentialBearerToken, BearerToken: "tok", ExpiresAt: "2025-06-01T12:00:00Z"},
+	}
+	env := pluginEnviron([]string{"HOME=/home/u", "AGENTBAY_PLUGIN_BEARER_TOKEN=stale"}, "audit", "/usr/bin/agentbay", pc)
+	assert.Equal(t, []string{
+		"HOME=/home/u",
+		"AGENTBAY_PLUGIN_NAME=audit",
+		"AGENTBAY_PLUGIN_CLI=/usr/bin/agentbay",
+		"AGENTBAY_PLUGIN_ENDPOINT=api.example.com",
+		"AGENTBAY_PLUGIN_ENVIRONMENT=production",
+		"AGENTBAY_PLUGIN_CREDENTIAL_TYPE=bearer_token",
+		"AGENTBAY_PLUGIN_BEARER_TOKEN=tok",
+		"AGENTBAY_PLUGIN_TOKEN_EXPIRES_AT=2025-06-01T12:00:00Z",
+	}, env)
+
+	pc.Credential = pluginCredential{Type: pluginCredentialNone}
+	env = pluginEnviron([]string{"AGENTBAY_PLUGIN_BEARER_TOKEN=stale"}, "audit", "", pc)
+	assert.NotContains(t, strings.Join(env, "\n"), "stale", "an outer plugin's token is not inherited")
+}
+
+func TestServePluginContext(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_ENDPOINT", "api.example.com")
+	t.Setenv(config.EnvAccessKeyID, "")
+	t.Setenv(config.EnvAccessKeySecret, "")
+	token := func() (*config.Token, error) { return &config.Token{AccessToken: "tok"}, nil }
+
+	for _, req := range []string{"", `{"version":1}`} {
+		var out bytes.Buffer
+		require.NoError(t, servePluginContext(strings.NewReader(req), &out, token))
+		var pc pluginContext
+		require.NoError(t, json.Unmarshal(out.Bytes(), &pc))
+		assert.Equal(t, 1, pc.Version)
+		assert.Equal(t, "api.example.com", pc.Endpoint)
+		assert.Equal(t, pluginCredential{Type: pluginCredentialBearerToken, BearerToken: "tok"}, pc.Credential)
+	}
+
+	var out bytes.Buffer
+	assert.ErrorContains(t, servePluginContext(strings.NewReader(`{"version":2}`), &out, token), "Unsupported plugin protocol version 2")
+	assert.ErrorContains(t, servePluginContext(strings.NewReader(`not json`), &out, token), "Invalid plugin context request")
+	assert.ErrorContains(t, servePluginContext(strings.NewReader(""), &out, func() (*config.Token, error) {
+		return nil, errors.New("refresh failed")
+	}), "refresh failed")
+	assert.Empty(t, out.String())
+}
+
+func TestRunPlugin(t *testing.T) {
+	skipOnWindows(t)
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("AGENTBAY_CLI_ENDPOINT", "api.example.com")
+	t.Setenv(config.EnvAccessKeyID, "LTAI-test")
+	t.Setenv(config.EnvAccessKeySecret, "secret")
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	p := writePlugin(t, dir, "agentbay-probe",
+		`echo "$AGENTBAY_PLUGIN_NAME $AGENTBAY_PLUGIN_ENDPOINT $AGENTBAY_PLUGIN_CREDENTIAL_TYPE $*" > "`+out+`"; exit 3`, 0755)
+
+	err := runPlugin(pluginEntry{Name: "probe", Path: p}, []string{"--flag", "arg"})
+	var exitErr *PluginExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, exitErr.Code)
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "probe api.example.com access_key --flag arg\n", string(data))
+
+	err = runPlugin(pluginEntry{Name: "gone", Path: filepath.Join(dir, "agentbay-gone")}, nil)
+	assert.ErrorContains(t, err, "Failed to run plugin gone")
+}
+
+func TestRunPluginInstall(t *testing.T) {
+	skipOnWindows(t)
+	configDir := t.TempDir()
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", configDir)
+	src := writePlugin(t, t.TempDir(), "agentbay-audit", "true", 0644)
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+
+	install := func(args ...string) error {
+		root := &cobra.Command{Use: "agentbay"}
+		root.AddCommand(&cobra.Command{Use: "image"})
+		c := &cobra.Command{Use: "install", Args: cobra.ExactArgs(1), RunE: runPluginInstall}
+		addPluginInstallFlags(c)
+		root.AddCommand(c)
+		root.SetArgs(append([]string{"install"}, args...))
+		root.SilenceErrors, root.SilenceUsage = true, true
+		return root.Execute()
+	}
+
+	require.NoError(t, install(src, "--sha256", hex.EncodeToString(sum[:])))
+	dest := filepath.Join(configDir, "plugins", "agentbay-audit")
+	info, err := os.Stat(dest)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm(), "installed plugins are executable")
+
+	assert.ErrorContains(t, install(src), "already installed")
+	require.NoError(t, install(src, "--force"))
+	assert.ErrorContains(t, install(src, "--name", "audit2", "--sha256", "00"), "SHA-256 mismatch")
+	assert.ErrorContains(t, install(src, "--name", "image"), "built-in command")
+	assert.ErrorContains(t, install(src, "--name", "../x"), "Invalid plugin name")
+	assert.ErrorContains(t, install("http://example.com/agentbay-web", "--sha256", hex.EncodeToString(sum[:])), "plain http")
+	assert.ErrorContains(t, install("https://example.com/agentbay-web"), "--sha256 is required")
+
+	other := writePlugin(t, t.TempDir(), "audit-tool", "true", 0755)
+	assert.ErrorContains(t, install(other), "pass --name")
+	require.NoError(t, install(other, "--name", "tool"))
+	_, err = os.Stat(filepath.Join(configDir, "plugins", "agentbay-tool"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// resource_cache.go wires the on-disk response cache of the API client into the list and describe
+// commands: --refresh and --offline select the cache mode, and a note after the output tells how
+// old the data is when any of it came from the cache.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// addCacheFlags registers --refresh and --offline on a command that reads through the cache.
+func addCacheFlags(c *cobra.Command) {
+	c.Flags().Bool("refresh", false, "Ignore cached responses and fetch fresh data from the API")
+	c.Flags().Bool("offline", false, "Only use cached responses, whatever their age, without calling the API")
+}
+
+func cacheModeFromFlags(c *cobra.Command) (agentbay.CacheMode, error) {
+	refresh, _ := c.Flags().GetBool("refresh")
+	offline, _ := c.Flags().GetBool("offline")
+	showSecret, _ := c.Flags().GetBool("show-secret")
+	switch {
+	case refresh && offline:
+		return agentbay.CacheDefault, fmt.Errorf("--refresh and --offline are mutually exclusive")
+	case refresh:
+		return agentbay.CacheRefresh, nil
+	case offline:
+		if showSecret {
+			return agentbay.CacheDefault, fmt.Errorf("--show-secret needs fresh data: cached API keys are masked")
+		}
+		return agentbay.CacheOffline, nil
+	case showSecret:
+		// Cached API keys are masked, so plaintext keys must come from the API.
+		return agentbay.CacheRefresh, nil
+	}
+	return agentbay.CacheDefault, nil
+}
+
+// newCachedClient builds an API client whose list and describe calls go through the cache in the
+// mode selected by the command's flags.
+func newCachedClient(c *cobra.Command, cfg *config.Config) (*agentbay.CachedClient, error) {
+	mode, err := cacheModeFromFlags(c)
+	if err != nil {
+		return nil, err
+	}
+	cc := agentbay.NewCachedClient(agentbay.NewClientFromConfig(cfg), mode, 0)
+	cc.Cacheable = cacheableResponse
+	return cc, nil
+}
+
+// cacheableResponse keeps image responses out of the cache while an image is changing state, so a
+// cached "Activating" is never shown after the activation finished. The status is read the way
+// image list and GetImageInfo read it.
+func cacheableResponse(response any) bool {
+	switch resp := response.(type) {
+	case *client.ListMcpImagesResponse:
+		if resp == nil || resp.Body == nil {
+			return false
+		}
+		for _, img := range resp.Body.Data {
+			if img == nil {
+				continue
+			}
+			if !imageStatusSettled(getStringValue(img.ImageResourceStatus)) {
+				return false
+			}
+		}
+	case *client.GetMcpImageInfoResponse:
+		if resp == nil || resp.Body == nil || resp.Body.Data == nil || !dara.BoolValue(resp.Body.Success) {
+			return false
+		}
+		status := getStringValue(resp.Body.Data.ImageResourceStatus)
+		if status == "" && resp.Body.Data.ImageInfo != nil {
+			status = getStringValue(resp.Body.Data.ImageInfo.Status)
+		}
+		return imageStatusSettled(status)
+	}
+	return true
+}
+
+func imageStatusSettled(status string) bool {
+	return status == "" || IsTerminalState(status)
+}
+
+// cacheNoteWriter is where the cache age note goes: stderr for JSON output, so it stays parseable.
+func cacheNoteWriter(format string) io.Writer {
+	if format != "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// printCacheAge tells how old the data shown is when any of it was served from the cache.
+func printCacheAge(w io.Writer, cc *agentbay.CachedClient, now time.Time) {
+	since, ok := cc.CachedSince()
+	if !ok {
+		return
+	}
+	fmt.Fprintf(w, "[INFO] Showing cached data from %s ago (use --refresh to update)\n", formatShortDuration(now.Sub(since)))
+}
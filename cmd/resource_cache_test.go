@@ -0,0 +1,96 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestCacheModeFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		c := &cobra.Command{}
+		addCacheFlags(c)
+		c.Flags().Bool("show-secret", false, "")
+		require.NoError(t, c.ParseFlags(args))
+		return c
+	}
+	mode, err := cacheModeFromFlags(newCmd())
+	require.NoError(t, err)
+	assert.Equal(t, agentbay.CacheDefault, mode)
+
+	mode, err = cacheModeFromFlags(newCmd("--refresh"))
+	require.NoError(t, err)
+	assert.Equal(t, agentbay.CacheRefresh, mode)
+
+	mode, err = cacheModeFromFlags(newCmd("--offline"))
+	require.NoError(t, err)
+	assert.Equal(t, agentbay.CacheOffline, mode)
+
+	_, err = cacheModeFromFlags(newCmd("--refresh", "--offline"))
+	assert.ErrorContains(t, err, "mutually exclusive")
+
+	mode, err = cacheModeFromFlags(newCmd("--show-secret"))
+	require.NoError(t, err)
+	assert.Equal(t, agentbay.CacheRefresh, mode, "cached API keys are masked")
+
+	_, err = cacheModeFromFlags(newCmd("--show-secret", "--offline"))
+	assert.ErrorContains(t, err, "masked")
+}
+
+func TestCacheableResponse(t *testing.T) {
+	list := func(statuses ...string) *client.ListMcpImagesResponse {
+		body := &client.ListMcpImagesResponseBody{}
+		for _, s := range statuses {
+			body.Data = append(body.Data, &client.ListMcpImagesResponseBodyData{ImageResourceStatus: dara.String(s)})
+		}
+		return &client.ListMcpImagesResponse{Body: body}
+	}
+	assert.True(t, cacheableResponse(list("RESOURCE_PUBLISHED", "IMAGE_AVAILABLE", "")))
+	assert.False(t, cacheableResponse(list("RESOURCE_PUBLISHED", "RESOURCE_DEPLOYING")), "a page with an activating image is not stored")
+	assert.False(t, cacheableResponse(list("IMAGE_CREATING")))
+
+	info := func(status string, success bool) *client.GetMcpImageInfoResponse {
+		return &client.GetMcpImageInfoResponse{Body: &client.GetMcpImageInfoResponseBody{
+			Success: dara.Bool(success),
+			Data:    &client.GetMcpImageInfoResponseBodyData{ImageResourceStatus: dara.String(status)},
+		}}
+	}
+	assert.True(t, cacheableResponse(info("RESOURCE_PUBLISHED", true)))
+	assert.False(t, cacheableResponse(info("RESOURCE_DELETING", true)))
+	assert.False(t, cacheableResponse(info("RESOURCE_PUBLISHED", false)), "failed lookups are not stored")
+
+	assert.True(t, cacheableResponse(&client.DescribeApiKeysResponse{}), "only image responses depend on state")
+}
+
+func TestPrintCacheAge(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	mock := &mockListKeysClient{pages: [][]*client.DescribeApiKeysResponseBodyDataApiKey{{}, {}}}
+	cc := agentbay.NewCachedClient(mock, agentbay.CacheDefault, 0)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	_, err := cc.DescribeApiKeys(ctx, &client.DescribeApiKeysRequest{})
+	require.NoError(t, err)
+	printCacheAge(&buf, cc, time.Now())
+	assert.Empty(t, buf.String(), "nothing is printed for fresh data")
+
+	_, err = cc.DescribeApiKeys(ctx, &client.DescribeApiKeysRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, mock.calls)
+	since, ok := cc.CachedSince()
+	require.True(t, ok)
+	printCacheAge(&buf, cc, since.Add(3*time.Minute))
+	assert.Equal(t, "[INFO] Showing cached data from 3m00s ago (use --refresh to update)\n", buf.String())
+}
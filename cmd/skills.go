@@ -14,7 +14,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -32,7 +34,7 @@ const skillDetailLabelW = 14
 var SkillsCmd = &cobra.Command{
 	Use:     "skills",
 	Short:   "Manage AgentBay skills",
-	Long:    "Push, update, and list skills; show details.",
+	Long:    "Push, update, and list skills; show details. Scaffold new skills with init and check them with validate.",
 	GroupID: "management",
 }
 
@@ -41,7 +43,8 @@ var skillsPushCmd = &cobra.Command{
 	Short: "Push a local skill directory or zip to the cloud",
 	Long: `Push a local skill to the cloud (upload zip, create/update skill).
 Accepts either a directory (with SKILL.md and frontmatter name/description) or a .zip file.
-Directory: packed to zip then uploaded. Zip: uploaded as-is.`,
+Directory: checked like "skills validate", packed to zip then uploaded; errors stop the push
+(use --no-validate to skip the checks). Zip: uploaded as-is.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSkillsPush,
 }
@@ -49,9 +52,17 @@ Directory: packed to zip then uploaded. Zip: uploaded as-is.`,
 var skillsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List cloud skills",
-	Long:  `List skills visible to you (yours and public), with optional filters for name and tags.`,
-	Args:  cobra.NoArgs,
-	RunE:  runSkillsList,
+	Long: `List skills visible to you (yours and public), with optional filters for name and tags.
+
+Use --page and --size for a single page, or --all / --limit to walk pages. With --all,
+-o json and -o ndjson stream results as pages arrive.
+
+Examples:
+  agentbay skills list
+  agentbay skills list --tag aliyun --page 2
+  agentbay skills list --all -o ndjson`,
+	Args: cobra.NoArgs,
+	RunE: runSkillsList,
 }
 
 var skillsShowCmd = &cobra.Command{
@@ -80,11 +91,15 @@ func init() {
 	skillsPushCmd.Flags().StringArray("tag", nil, "Tag name for the skill (can be specified multiple times, e.g. --tag \"tag1\" --tag \"tag2\")")
 	skillsPushCmd.Flags().String("icon", "https://img.alicdn.com/imgextra/i4/O1CN01syuoCy1qhsZxbwuBz_!!6000000005528-2-tps-100-100.png", "Icon for the skill (URL or identifier); uses the default AgentBay icon if not specified")
 
+	skillsPushCmd.Flags().Bool("no-validate", false, "Skip the \"skills validate\" checks on a skill directory before uploading")
+
 	skillsListCmd.Flags().Int("page", 1, "Page number (default: 1)")
 	skillsListCmd.Flags().Int("size", 10, "Page size (default: 10)")
 	skillsListCmd.Flags().String("name", "", "Filter by skill name (optional)")
 	skillsListCmd.Flags().StringArray("tag", nil, "Filter by tag name (can be specified multiple times, e.g. --tag test --tag aliyun)")
-	skillsListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts)`)
+	skillsListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output (e.g. for AI/scripts), or "ndjson" for one object per line`)
+	addListPagingFlags(skillsListCmd)
+	addCacheFlags(skillsListCmd)
 
 	skillsUpdateCmd.Flags().String("skill-id", "", "Skill ID to update (required)")
 	_ = skillsUpdateCmd.MarkFlagRequired("skill-id")
@@ -93,9 +108,17 @@ func init() {
 	skillsUpdateCmd.Flags().StringArray("tag", nil, `Tag name for the skill (can be specified multiple times, e.g. --tag "tag1" --tag "tag2")`)
 	skillsUpdateCmd.Flags().String("icon", "", "Icon for the skill (e.g. URL or identifier)")
 	skillsUpdateCmd.Flags().Bool("clear-tags", false, "Remove all tags from the skill")
+	skillsUpdateCmd.Flags().Bool("no-validate", false, "Skip the \"skills validate\" checks on a skill directory before uploading")
 
 	skillsDeleteCmd.Flags().String("skill-id", "", "Skill ID to delete")
 	skillsDeleteCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt and skill detail lookup (for non-interactive use)")
+
+	skillsShowCmd.ValidArgsFunction = completeOnlyArg(completionSkills, nil)
+
+	addCacheFlags(skillsShowCmd)
+	skillsDeleteCmd.ValidArgsFunction = completeOnlyArg(completionSkills, nil)
+	registerFlagCompletion(skillsUpdateCmd, "skill-id", completeFlag(completionSkills, nil))
+	registerFlagCompletion(skillsDeleteCmd, "skill-id", completeFlag(completionSkills, nil))
 }
 
 // parseSkillFrontmatter parses --- name: x description: y --- from SKILL.md content.
@@ -130,7 +153,8 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 	}
 
 	var skillZipName string
-	var zipPath string // path to zip file to upload (temp file for dir, or pathInput for .zip)
+	var zipPath string // path to zip file to upload as-is (.zip input only)
+	var pkgData []byte // packed skill, kept in the local history after a successful push
 
 	if info.IsDir() {
 		skillDir := pathInput
@@ -157,8 +181,17 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 				"      ---",
 			)
 		}
+		if noValidate, _ := cmd.Flags().GetBool("no-validate"); !noValidate {
+			if err := checkSkillDirBeforeUpload(skillDir); err != nil {
+				return err
+			}
+		}
 		skillZipName = skillDirToZipFileName(skillDir)
-		// zipPath will be set after we pack to temp file below
+		zipBuf, err := zipSkillDir(skillDir)
+		if err != nil {
+			return fmt.Errorf("pack skill: %w", err)
+		}
+		pkgData = zipBuf.Bytes()
 	} else {
 		// Regular file: must be .zip
 		if !strings.HasSuffix(strings.ToLower(pathInput), ".zip") {
@@ -170,7 +203,18 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 		}
 		skillZipName = filepath.Base(pathInput)
 		zipPath = pathInput
+		if pkgData, err = os.ReadFile(zipPath); err != nil {
+			return fmt.Errorf("read zip: %w", err)
+		}
+	}
+
+	skillName, version := skillPackageVersion(pkgData)
+	if version != "" {
+		if _, err := parseSkillSemver(version); err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
 	}
+	printSkillUploadVersion(version)
 
 	// Parse tags flag
 	tagsFlag, _ := cmd.Flags().GetStringArray("tag")
@@ -204,86 +248,15 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 		fmt.Printf("[STEP %d/%d] Processing tags...\n", stepIdx, totalSteps)
 		stepIdx++
 
-		listResp, err := apiClient.ListTag(ctx)
-		if err != nil {
-			printRequestIDFromErrIfVerbose(cmd, err)
-			return fmt.Errorf("[ERROR] Failed to list tags: %w", err)
-		}
-		if listResp.Body != nil && listResp.Body.GetRequestId() != "" {
-			fmt.Printf("[INFO] ListTag RequestId: %s\n", listResp.Body.GetRequestId())
-		}
-
-		existingTagNames := map[string]bool{}
-		if listResp.Body != nil && listResp.Body.Data != nil {
-			for _, item := range listResp.Body.Data {
-				if item.TagName != nil {
-					existingTagNames[*item.TagName] = true
-				}
-			}
-		}
-
-		var missingTags []string
-		for _, tagName := range tags {
-			if !existingTagNames[tagName] {
-				missingTags = append(missingTags, tagName)
-			}
-		}
-
-		if len(missingTags) > 0 {
-			fmt.Printf("[INFO] Tags not found: %s, creating...\n", strings.Join(missingTags, ", "))
-			createReq := &client.CreateTagRequest{TagList: missingTags}
-			createTagResp, err := apiClient.CreateTag(ctx, createReq)
-			if err != nil {
-				printRequestIDFromErrIfVerbose(cmd, err)
-				return fmt.Errorf("[ERROR] Failed to create tags: %w", err)
-			}
-			if createTagResp.Body != nil && createTagResp.Body.GetRequestId() != "" {
-				fmt.Printf("[INFO] CreateTag RequestId: %s\n", createTagResp.Body.GetRequestId())
-			}
-			fmt.Printf("[INFO] Tags created successfully.\n")
-		} else {
-			fmt.Printf("[INFO] All tags already exist.\n")
+		if err := ensureSkillTags(ctx, cmd, apiClient, tags); err != nil {
+			return err
 		}
 	}
 
 	// Next step: Get upload credential
 	fmt.Printf("[STEP %d/%d] Getting upload credential...\n", stepIdx, totalSteps)
 	stepIdx++
-	credReq := &client.GetMarketSkillCredentialRequest{FileName: &skillZipName}
-	var credResp *client.GetMarketSkillCredentialResponse
-	err = withTransientRetry(ctx, client.DefaultRetryConfig(), "GetMarketSkillCredential", func() error {
-		var e error
-		credResp, e = apiClient.GetMarketSkillCredential(ctx, credReq)
-		return e
-	})
-	if err != nil {
-		printRequestIDFromErrIfVerbose(cmd, err)
-		return fmt.Errorf("[ERROR] Failed to get upload credential: %w", err)
-	}
-	if credResp.Body == nil || credResp.Body.Data == nil {
-		return fmt.Errorf("invalid response: missing credential data")
-	}
-	if credResp.Body != nil && credResp.Body.RequestId != nil && *credResp.Body.RequestId != "" {
-		fmt.Printf("[INFO] GetMarketSkillCredential RequestId: %s\n", *credResp.Body.RequestId)
-	}
-	uploadURLStr := ""
-	if u := credResp.Body.Data.GetOssUrl(); u != nil && *u != "" {
-		uploadURLStr = *u
-	}
-	if uploadURLStr == "" {
-		if u := credResp.Body.Data.GetUrl(); u != nil && *u != "" {
-			uploadURLStr = *u
-		}
-	}
-	if uploadURLStr == "" {
-		return fmt.Errorf("invalid response: missing OSS upload URL")
-	}
-	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
-		fmt.Fprintf(os.Stderr, "[DEBUG] OSS upload URL: %s\n", uploadURLStr)
-	}
-
-	// For step 3: prefer credential response OssBucket/OssFilePath (backend format); fallback to parsing upload URL.
-	createBucket, createOssFilePath, err := parseBucketAndPathForCreate(credResp.Body.Data, uploadURLStr)
+	uploadURLStr, createBucket, createOssPath, err := requestSkillUpload(ctx, cmd, apiClient, skillZipName)
 	if err != nil {
 		return err
 	}
@@ -302,39 +275,13 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 	} else {
 		// Directory: pack then upload
 		fmt.Printf("[STEP %d/%d] Packing and uploading skill...\n", stepIdx, totalSteps)
-		zipBuf, err := zipSkillDir(pathInput)
-		if err != nil {
-			return fmt.Errorf("pack skill: %w", err)
-		}
-		tmpFile, err := os.CreateTemp("", "agentbay-skill-*.zip")
-		if err != nil {
-			return fmt.Errorf("create temp zip: %w", err)
-		}
-		tmpPath := tmpFile.Name()
-		defer os.Remove(tmpPath)
-		if _, err := tmpFile.Write(zipBuf.Bytes()); err != nil {
-			_ = tmpFile.Close()
-			return fmt.Errorf("write temp zip: %w", err)
-		}
-		if err := tmpFile.Close(); err != nil {
-			return fmt.Errorf("close temp zip: %w", err)
-		}
-		if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Upload size: %d bytes, temp file: %s\n", zipBuf.Len(), tmpPath)
-		}
-		if err := uploadFileToOSS(tmpPath, uploadURLStr); err != nil {
-			return fmt.Errorf("[ERROR] Failed to upload: %w", err)
+		if err := uploadSkillZip(cmd, pkgData, uploadURLStr); err != nil {
+			return err
 		}
 	}
 	stepIdx++
 
 	fmt.Printf("[STEP %d/%d] Creating skill...\n", stepIdx, totalSteps)
-	// Pre-release credential URL path is often "null/<id>"; some backends reject "null" in OssFilePath.
-	// Pass only the suffix for CreateMarketSkill when path is exactly "null/<suffix>" so backend receives a valid path.
-	createOssPath := createOssFilePath
-	if strings.HasPrefix(createOssPath, "null/") && len(createOssPath) > 5 {
-		createOssPath = createOssPath[5:] // len("null/")
-	}
 	createReq := &client.CreateMarketSkillRequest{
 		OssBucket:   &createBucket,
 		OssFilePath: &createOssPath,
@@ -367,10 +314,16 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 	}
 	if skillId == "" {
 		skillId = "<unknown>"
+	} else {
+		changelog, _ := cmd.Flags().GetString("changelog")
+		noteSkillRelease(skillId, skillName, skillRelease{Version: version, Source: "push", Changelog: changelog}, pkgData)
 	}
 	fmt.Println()
 	fmt.Printf("[SUCCESS] ✅ Skill created successfully!\n")
 	fmt.Printf("[RESULT] Skill ID: %s\n", skillId)
+	if version != "" {
+		fmt.Printf("[RESULT] Version: %s\n", version)
+	}
 	return nil
 }
 
@@ -390,10 +343,18 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Validate: --tag and --clear-tags are mutually exclusive
+	addTagsFlag, _ := cmd.Flags().GetStringArray("add-tag")
+	removeTagsFlag, _ := cmd.Flags().GetStringArray("remove-tag")
+	addTags, removeTags := trimTagArgs(addTagsFlag), trimTagArgs(removeTagsFlag)
+	editTags := len(addTags) > 0 || len(removeTags) > 0
+
+	// Validate: --tag, --clear-tags and --add-tag/--remove-tag are mutually exclusive
 	if clearTags && len(tags) > 0 {
 		return fmt.Errorf("[ERROR] --clear-tags and --tag cannot be used together")
 	}
+	if editTags && (clearTags || len(tags) > 0) {
+		return fmt.Errorf("[ERROR] --add-tag/--remove-tag cannot be used together with --tag or --clear-tags")
+	}
 
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -406,7 +367,7 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 	hasFile := fileInput != ""
 	stepIdx := 1
 	totalSteps := 0
-	if len(tags) > 0 {
+	if len(tags) > 0 || editTags {
 		totalSteps++ // ListTag/CreateTag step
 	}
 	if hasFile {
@@ -414,58 +375,11 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 	}
 	totalSteps++ // UpdateMarketSkill call
 
-	// Step: Process tags (if any)
-	if len(tags) > 0 {
-		fmt.Printf("[STEP %d/%d] Processing tags...\n", stepIdx, totalSteps)
-		stepIdx++
-
-		listResp, err := apiClient.ListTag(ctx)
-		if err != nil {
-			printRequestIDFromErrIfVerbose(cmd, err)
-			return fmt.Errorf("[ERROR] Failed to list tags: %w", err)
-		}
-		if listResp.Body != nil && listResp.Body.GetRequestId() != "" {
-			fmt.Printf("[INFO] ListTag RequestId: %s\n", listResp.Body.GetRequestId())
-		}
-
-		existingTagNames := map[string]bool{}
-		if listResp.Body != nil && listResp.Body.Data != nil {
-			for _, item := range listResp.Body.Data {
-				if item.TagName != nil {
-					existingTagNames[*item.TagName] = true
-				}
-			}
-		}
-
-		var missingTags []string
-		for _, tagName := range tags {
-			if !existingTagNames[tagName] {
-				missingTags = append(missingTags, tagName)
-			}
-		}
-
-		if len(missingTags) > 0 {
-			fmt.Printf("[INFO] Tags not found: %s, creating...\n", strings.Join(missingTags, ", "))
-			createReq := &client.CreateTagRequest{TagList: missingTags}
-			createTagResp, err := apiClient.CreateTag(ctx, createReq)
-			if err != nil {
-				printRequestIDFromErrIfVerbose(cmd, err)
-				return fmt.Errorf("[ERROR] Failed to create tags: %w", err)
-			}
-			if createTagResp.Body != nil && createTagResp.Body.GetRequestId() != "" {
-				fmt.Printf("[INFO] CreateTag RequestId: %s\n", createTagResp.Body.GetRequestId())
-			}
-			fmt.Printf("[INFO] Tags created successfully.\n")
-		} else {
-			fmt.Printf("[INFO] All tags already exist.\n")
-		}
-	}
-
-	var ossBucket, ossFilePath string
-
-	// Steps: Get credential + upload (if --file provided)
+	// Resolve, check and pack --file before any API call.
+	var pathInput, skillZipName, zipPath, skillName, version string
+	var pkgData []byte // packed skill, kept in the local history after a successful update
 	if hasFile {
-		pathInput := filepath.Clean(fileInput)
+		pathInput = filepath.Clean(fileInput)
 		info, err := os.Stat(pathInput)
 		if err != nil {
 			if os.IsNotExist(err) {
@@ -478,9 +392,6 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("path: %w", err)
 		}
 
-		var skillZipName string
-		var zipPath string
-
 		if info.IsDir() {
 			skillDir := pathInput
 			skillMdPath := filepath.Join(skillDir, skillFileName)
@@ -506,7 +417,17 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 					"      ---",
 				)
 			}
+			if noValidate, _ := cmd.Flags().GetBool("no-validate"); !noValidate {
+				if err := checkSkillDirBeforeUpload(skillDir); err != nil {
+					return err
+				}
+			}
 			skillZipName = skillDirToZipFileName(skillDir)
+			zipBuf, err := zipSkillDir(skillDir)
+			if err != nil {
+				return fmt.Errorf("pack skill: %w", err)
+			}
+			pkgData = zipBuf.Bytes()
 		} else {
 			if !strings.HasSuffix(strings.ToLower(pathInput), ".zip") {
 				return printErrorMessage(
@@ -517,45 +438,72 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 			}
 			skillZipName = filepath.Base(pathInput)
 			zipPath = pathInput
+			if pkgData, err = os.ReadFile(zipPath); err != nil {
+				return fmt.Errorf("read zip: %w", err)
+			}
 		}
 
-		// Get upload credential
-		fmt.Printf("[STEP %d/%d] Getting upload credential...\n", stepIdx, totalSteps)
+		skillName, version = skillPackageVersion(pkgData)
+		if skipCheck, _ := cmd.Flags().GetBool("skip-version-check"); !skipCheck {
+			if err := checkSkillUploadVersion(ctx, apiClient, skillId, version); err != nil {
+				return printErrorMessage(
+					fmt.Sprintf("[ERROR] %s", err.Error()),
+					"",
+					fmt.Sprintf("[TIP] Raise version in %s, or pass --skip-version-check to publish anyway", skillFileName),
+				)
+			}
+		}
+		printSkillUploadVersion(version)
+	}
+
+	// Step: Process tags (if any)
+	if editTags {
+		// Incremental edit: start from the skill's current tags.
+		fmt.Printf("[STEP %d/%d] Processing tags...\n", stepIdx, totalSteps)
 		stepIdx++
-		credReq := &client.GetMarketSkillCredentialRequest{FileName: &skillZipName}
-		var credResp *client.GetMarketSkillCredentialResponse
-		err = withTransientRetry(ctx, client.DefaultRetryConfig(), "GetMarketSkillCredential", func() error {
-			var e error
-			credResp, e = apiClient.GetMarketSkillCredential(ctx, credReq)
-			return e
-		})
+
+		detail, err := apiClient.DescribeMarketSkillDetail(ctx, &client.DescribeMarketSkillDetailRequest{SkillId: &skillId})
 		if err != nil {
 			printRequestIDFromErrIfVerbose(cmd, err)
-			return fmt.Errorf("[ERROR] Failed to get upload credential: %w", err)
-		}
-		if credResp.Body == nil || credResp.Body.Data == nil {
-			return fmt.Errorf("invalid response: missing credential data")
+			return fmt.Errorf("[ERROR] Failed to get skill details: %w", err)
 		}
-		if credResp.Body != nil && credResp.Body.RequestId != nil && *credResp.Body.RequestId != "" {
-			fmt.Printf("[INFO] GetMarketSkillCredential RequestId: %s\n", *credResp.Body.RequestId)
+		if detail.Body == nil || detail.Body.Data == nil {
+			return fmt.Errorf("[ERROR] Skill %s not found", skillId)
 		}
-		uploadURLStr := ""
-		if u := credResp.Body.Data.GetOssUrl(); u != nil && *u != "" {
-			uploadURLStr = *u
+		current := trimTagArgs(detail.Body.Data.TenantTags)
+		tags = editSkillTags(current, addTags, removeTags)
+		fmt.Printf("[INFO] Tags: [%s] -> [%s]\n", strings.Join(current, ", "), strings.Join(tags, ", "))
+		if len(tags) == 0 {
+			clearTags = true
 		}
-		if uploadURLStr == "" {
-			if u := credResp.Body.Data.GetUrl(); u != nil && *u != "" {
-				uploadURLStr = *u
+		var added []string
+		for _, t := range addTags {
+			if !slices.Contains(current, t) {
+				added = append(added, t)
 			}
 		}
-		if uploadURLStr == "" {
-			return fmt.Errorf("invalid response: missing OSS upload URL")
+		if len(added) > 0 {
+			if err := ensureSkillTags(ctx, cmd, apiClient, added); err != nil {
+				return err
+			}
 		}
-		if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
-			fmt.Fprintf(os.Stderr, "[DEBUG] OSS upload URL: %s\n", uploadURLStr)
+	} else if len(tags) > 0 {
+		fmt.Printf("[STEP %d/%d] Processing tags...\n", stepIdx, totalSteps)
+		stepIdx++
+
+		if err := ensureSkillTags(ctx, cmd, apiClient, tags); err != nil {
+			return err
 		}
+	}
+
+	var ossBucket, ossFilePath string
 
-		createBucket, createOssFilePath, err := parseBucketAndPathForCreate(credResp.Body.Data, uploadURLStr)
+	// Steps: Get credential + upload (if --file provided)
+	if hasFile {
+		// Get upload credential
+		fmt.Printf("[STEP %d/%d] Getting upload credential...\n", stepIdx, totalSteps)
+		stepIdx++
+		uploadURLStr, createBucket, createOssPath, err := requestSkillUpload(ctx, cmd, apiClient, skillZipName)
 		if err != nil {
 			return err
 		}
@@ -573,39 +521,15 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 			}
 		} else {
 			fmt.Printf("[STEP %d/%d] Packing and uploading skill...\n", stepIdx, totalSteps)
-			zipBuf, err := zipSkillDir(pathInput)
-			if err != nil {
-				return fmt.Errorf("pack skill: %w", err)
-			}
-			tmpFile, err := os.CreateTemp("", "agentbay-skill-*.zip")
-			if err != nil {
-				return fmt.Errorf("create temp zip: %w", err)
-			}
-			tmpPath := tmpFile.Name()
-			defer os.Remove(tmpPath)
-			if _, err := tmpFile.Write(zipBuf.Bytes()); err != nil {
-				_ = tmpFile.Close()
-				return fmt.Errorf("write temp zip: %w", err)
-			}
-			if err := tmpFile.Close(); err != nil {
-				return fmt.Errorf("close temp zip: %w", err)
-			}
-			if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
-				fmt.Fprintf(os.Stderr, "[DEBUG] Upload size: %d bytes, temp file: %s\n", zipBuf.Len(), tmpPath)
-			}
-			if err := uploadFileToOSS(tmpPath, uploadURLStr); err != nil {
-				return fmt.Errorf("[ERROR] Failed to upload: %w", err)
+			if err := uploadSkillZip(cmd, pkgData, uploadURLStr); err != nil {
+				return err
 			}
 		}
 		stepIdx++
 
 		// Prepare OSS fields for UpdateMarketSkill
 		ossBucket = createBucket
-		ossFilePath = createOssFilePath
-		// Pre-release credential URL path is often "null/<id>"; some backends reject "null" in OssFilePath.
-		if strings.HasPrefix(ossFilePath, "null/") && len(ossFilePath) > 5 {
-			ossFilePath = ossFilePath[5:]
-		}
+		ossFilePath = createOssPath
 	}
 
 	// Final step: Call UpdateMarketSkill
@@ -643,9 +567,144 @@ func runSkillsUpdate(cmd *cobra.Command, args []string) error {
 	if updateResp.Body != nil && updateResp.Body.RequestId != nil && *updateResp.Body.RequestId != "" {
 		fmt.Printf("[INFO] UpdateMarketSkill RequestId: %s\n", *updateResp.Body.RequestId)
 	}
+	if hasFile {
+		changelog, _ := cmd.Flags().GetString("changelog")
+		noteSkillRelease(skillId, skillName, skillRelease{Version: version, Source: "update", Changelog: changelog}, pkgData)
+	}
 	fmt.Println()
 	fmt.Printf("[SUCCESS] ✅ Skill updated successfully!\n")
 	fmt.Printf("[RESULT] Skill ID: %s\n", skillId)
+	if version != "" {
+		fmt.Printf("[RESULT] Version: %s\n", version)
+	}
+	return nil
+}
+
+// listSkillTags returns the tags defined for the account, reporting the request ID to progress.
+func listSkillTags(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, progress io.Writer) ([]client.ListTagResponseBodyDataItem, error) {
+	listResp, err := apiClient.ListTag(ctx)
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return nil, fmt.Errorf("[ERROR] Failed to list tags: %w", err)
+	}
+	if listResp.Body == nil {
+		return nil, nil
+	}
+	if listResp.Body.GetRequestId() != "" {
+		fmt.Fprintf(progress, "[INFO] ListTag RequestId: %s\n", listResp.Body.GetRequestId())
+	}
+	return listResp.Body.Data, nil
+}
+
+// ensureSkillTags creates the tags in tags that do not exist yet.
+func ensureSkillTags(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, tags []string) error {
+	existing, err := listSkillTags(ctx, cmd, apiClient, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	existingTagNames := map[string]bool{}
+	for _, item := range existing {
+		if item.TagName != nil {
+			existingTagNames[*item.TagName] = true
+		}
+	}
+
+	var missingTags []string
+	for _, tagName := range tags {
+		if !existingTagNames[tagName] {
+			missingTags = append(missingTags, tagName)
+		}
+	}
+
+	if len(missingTags) > 0 {
+		fmt.Printf("[INFO] Tags not found: %s, creating...\n", strings.Join(missingTags, ", "))
+		createReq := &client.CreateTagRequest{TagList: missingTags}
+		createTagResp, err := apiClient.CreateTag(ctx, createReq)
+		if err != nil {
+			printRequestIDFromErrIfVerbose(cmd, err)
+			return fmt.Errorf("[ERROR] Failed to create tags: %w", err)
+		}
+		if createTagResp.Body != nil && createTagResp.Body.GetRequestId() != "" {
+			fmt.Printf("[INFO] CreateTag RequestId: %s\n", createTagResp.Body.GetRequestId())
+		}
+		fmt.Printf("[INFO] Tags created successfully.\n")
+	} else {
+		fmt.Printf("[INFO] All tags already exist.\n")
+	}
+	return nil
+}
+
+// requestSkillUpload gets an upload credential for zipName. It returns the pre-signed upload URL
+// and the bucket and object path to pass to CreateMarketSkill or UpdateMarketSkill.
+func requestSkillUpload(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, skillZipName string) (uploadURL, bucket, ossPath string, err error) {
+	credReq := &client.GetMarketSkillCredentialRequest{FileName: &skillZipName}
+	var credResp *client.GetMarketSkillCredentialResponse
+	err = withTransientRetry(ctx, client.DefaultRetryConfig(), "GetMarketSkillCredential", func() error {
+		var e error
+		credResp, e = apiClient.GetMarketSkillCredential(ctx, credReq)
+		return e
+	})
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return "", "", "", fmt.Errorf("[ERROR] Failed to get upload credential: %w", err)
+	}
+	if credResp.Body == nil || credResp.Body.Data == nil {
+		return "", "", "", fmt.Errorf("invalid response: missing credential data")
+	}
+	if credResp.Body != nil && credResp.Body.RequestId != nil && *credResp.Body.RequestId != "" {
+		fmt.Printf("[INFO] GetMarketSkillCredential RequestId: %s\n", *credResp.Body.RequestId)
+	}
+	uploadURLStr := ""
+	if u := credResp.Body.Data.GetOssUrl(); u != nil && *u != "" {
+		uploadURLStr = *u
+	}
+	if uploadURLStr == "" {
+		if u := credResp.Body.Data.GetUrl(); u != nil && *u != "" {
+			uploadURLStr = *u
+		}
+	}
+	if uploadURLStr == "" {
+		return "", "", "", fmt.Errorf("invalid response: missing OSS upload URL")
+	}
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] OSS upload URL: %s\n", uploadURLStr)
+	}
+
+	// Prefer credential response OssBucket/OssFilePath (backend format); fallback to parsing upload URL.
+	bucket, ossPath, err = parseBucketAndPathForCreate(credResp.Body.Data, uploadURLStr)
+	if err != nil {
+		return "", "", "", err
+	}
+	// Pre-release credential URL path is often "null/<id>"; some backends reject "null" in OssFilePath.
+	// Pass only the suffix when path is exactly "null/<suffix>" so backend receives a valid path.
+	if strings.HasPrefix(ossPath, "null/") && len(ossPath) > 5 {
+		ossPath = ossPath[5:] // len("null/")
+	}
+	return uploadURLStr, bucket, ossPath, nil
+}
+
+// uploadSkillZip uploads a packed skill to uploadURLStr through a temp file.
+func uploadSkillZip(cmd *cobra.Command, data []byte, uploadURLStr string) error {
+	tmpFile, err := os.CreateTemp("", "agentbay-skill-*.zip")
+	if err != nil {
+		return fmt.Errorf("create temp zip: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("write temp zip: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("close temp zip: %w", err)
+	}
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Upload size: %d bytes, temp file: %s\n", len(data), tmpPath)
+	}
+	if err := uploadFileToOSS(tmpPath, uploadURLStr); err != nil {
+		return fmt.Errorf("[ERROR] Failed to upload: %w", err)
+	}
 	return nil
 }
 
@@ -753,6 +812,11 @@ func runSkillsList(cmd *cobra.Command, args []string) error {
 	name, _ := cmd.Flags().GetString("name")
 	tags, _ := cmd.Flags().GetStringArray("tag")
 	outputFmt, _ := cmd.Flags().GetString("output")
+	paging, err := listPagingFromFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	format := listOutputFormat(outputFmt)
 
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -761,9 +825,21 @@ func runSkillsList(cmd *cobra.Command, args []string) error {
 	if !cfg.IsAuthenticated() {
 		return fmt.Errorf("[ERROR] Not authenticated. Run 'agentbay login' or set AGENTBAY_ACCESS_KEY_ID/AGENTBAY_ACCESS_KEY_SECRET")
 	}
-	apiClient := agentbay.NewClientFromConfig(cfg)
+	apiClient, err := newCachedClient(cmd, cfg)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	defer func() { printCacheAge(cacheNoteWriter(format), apiClient, time.Now()) }()
 	ctx := context.Background()
 
+	if paging.walks() || format == "ndjson" {
+		if page < 1 {
+			page = 1
+		}
+		fetch := skillPages(apiClient, name, tags, size, listProgress(true, format))
+		return listSkillsPaged(ctx, fetch, paging, pageCursor{PageNo: page}, format)
+	}
+
 	req := &client.ListMarketSkillByPageRequest{}
 	if page > 0 {
 		pageNo := int32(page)
@@ -817,23 +893,13 @@ func runSkillsList(cmd *cobra.Command, args []string) error {
 	}
 
 	// JSON output mode
-	if strings.EqualFold(outputFmt, "json") {
-		type skillJSON struct {
-			SkillId     string   `json:"skillId"`
-			SkillName   string   `json:"skillName"`
-			Description string   `json:"description"`
-			Status      string   `json:"status"`
-			Tags        []string `json:"tags"`
-			Icon        string   `json:"icon"`
-			GmtModified string   `json:"gmtModified"`
-			GmtCreate   string   `json:"gmtCreate"`
-		}
+	if format == "json" {
 		type pageJSON struct {
-			TotalCount int32       `json:"totalCount"`
-			TotalPage  int32       `json:"totalPage"`
-			PageSize   int32       `json:"pageSize"`
-			PageNumber int32       `json:"pageNumber"`
-			Result     []skillJSON `json:"result"`
+			TotalCount int32           `json:"totalCount"`
+			TotalPage  int32           `json:"totalPage"`
+			PageSize   int32           `json:"pageSize"`
+			PageNumber int32           `json:"pageNumber"`
+			Result     []skillListItem `json:"result"`
 		}
 		var pg pageJSON
 		if data.TotalCount != nil {
@@ -849,37 +915,10 @@ func runSkillsList(cmd *cobra.Command, args []string) error {
 			pg.PageNumber = *data.PageNumber
 		}
 		for _, item := range data.GetResult() {
-			s := skillJSON{
-				Tags: item.TenantTags,
-			}
-			if item.SkillId != nil {
-				s.SkillId = *item.SkillId
-			}
-			if item.SkillName != nil {
-				s.SkillName = *item.SkillName
-			}
-			if item.Description != nil {
-				s.Description = *item.Description
-			}
-			if item.SkillStatus != nil {
-				s.Status = *item.SkillStatus
-			}
-			if item.Icon != nil {
-				s.Icon = *item.Icon
-			}
-			if item.GmtModified != nil {
-				s.GmtModified = *item.GmtModified
-			}
-			if item.GmtCreate != nil {
-				s.GmtCreate = *item.GmtCreate
-			}
-			if s.Tags == nil {
-				s.Tags = []string{}
-			}
-			pg.Result = append(pg.Result, s)
+			pg.Result = append(pg.Result, newSkillListItem(item))
 		}
 		if pg.Result == nil {
-			pg.Result = []skillJSON{}
+			pg.Result = []skillListItem{}
 		}
 		out, jerr := json.MarshalIndent(pg, "", "  ")
 		if jerr != nil {
@@ -914,6 +953,18 @@ func runSkillsList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	printSkillsTable(results)
+
+	// Show next page tip if there are more pages
+	if pageNum < totalPage {
+		fmt.Printf("\n[TIP] Use --page %d to view the next page.\n", pageNum+1)
+	}
+
+	return nil
+}
+
+// printSkillsTable prints skills with columns sized to the terminal width.
+func printSkillsTable(results []*client.ListMarketSkillByPageResponseBodyDataResult) {
 	// Compute dynamic column widths based on terminal width.
 	// Priority: SKILL NAME > SKILL ID > STATUS > TAGS > MODIFIED
 	termWidth := 120
@@ -1024,12 +1075,150 @@ func runSkillsList(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Println(row)
 	}
+}
 
-	// Show next page tip if there are more pages
-	if pageNum < totalPage {
-		fmt.Printf("\n[TIP] Use --page %d to view the next page.\n", pageNum+1)
+// skillListItem is one skill in skills list -o json / ndjson output.
+type skillListItem struct {
+	SkillId     string   `json:"skillId"`
+	SkillName   string   `json:"skillName"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+	Icon        string   `json:"icon"`
+	GmtModified string   `json:"gmtModified"`
+	GmtCreate   string   `json:"gmtCreate"`
+}
+
+func newSkillListItem(item *client.ListMarketSkillByPageResponseBodyDataResult) skillListItem {
+	s := skillListItem{
+		Tags: item.TenantTags,
+	}
+	if item.SkillId != nil {
+		s.SkillId = *item.SkillId
+	}
+	if item.SkillName != nil {
+		s.SkillName = *item.SkillName
+	}
+	if item.Description != nil {
+		s.Description = *item.Description
+	}
+	if item.SkillStatus != nil {
+		s.Status = *item.SkillStatus
+	}
+	if item.Icon != nil {
+		s.Icon = *item.Icon
+	}
+	if item.GmtModified != nil {
+		s.GmtModified = *item.GmtModified
+	}
+	if item.GmtCreate != nil {
+		s.GmtCreate = *item.GmtCreate
+	}
+	if s.Tags == nil {
+		s.Tags = []string{}
 	}
+	return s
+}
 
+// skillPages fetches ListMarketSkillByPage pages, reporting each request ID to progress.
+func skillPages(apiClient agentbay.Client, name string, tags []string, size int, progress io.Writer) pageFetcher[*client.ListMarketSkillByPageResponseBodyDataResult] {
+	return func(ctx context.Context, at pageCursor) (*listPage[*client.ListMarketSkillByPageResponseBodyDataResult], error) {
+		req := &client.ListMarketSkillByPageRequest{}
+		pageNo := int32(at.PageNo)
+		req.PageNo = &pageNo
+		if size > 0 {
+			pageSize := int32(size)
+			req.PageSize = &pageSize
+		}
+		if name != "" {
+			req.SkillName = &name
+		}
+		if len(tags) > 0 {
+			req.TagList = tags
+		}
+		resp, err := apiClient.ListMarketSkillByPage(ctx, req)
+		if err != nil {
+			if reqID := extractRequestIDFromErr(err); reqID != "" {
+				fmt.Fprintf(progress, "[INFO] ListMarketSkillByPage Request ID: %s\n", reqID)
+			}
+			return nil, fmt.Errorf("failed to list skills: %w", err)
+		}
+		if resp == nil || resp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing body")
+		}
+		if reqId := resp.Body.GetRequestId(); reqId != nil && *reqId != "" {
+			fmt.Fprintf(progress, "[INFO] ListMarketSkillByPage Request ID: %s\n", *reqId)
+		}
+		code := ""
+		if resp.Body.Code != nil {
+			code = *resp.Body.Code
+		}
+		successPtr := resp.Body.Success
+		if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
+			msg := ""
+			if resp.Body.Message != nil {
+				msg = *resp.Body.Message
+			}
+			return nil, fmt.Errorf("failed to list skills: Code=%s, Message=%s", code, msg)
+		}
+		page := &listPage[*client.ListMarketSkillByPageResponseBodyDataResult]{}
+		data := resp.Body.GetData()
+		if data == nil {
+			return page, nil
+		}
+		page.Items = data.GetResult()
+		if data.TotalCount != nil {
+			total := int(*data.TotalCount)
+			page.Total = &total
+		}
+		if len(page.Items) > 0 && data.TotalPage != nil {
+			if at.PageNo < int(*data.TotalPage) {
+				page.Next = &pageCursor{PageNo: at.PageNo + 1}
+			}
+		} else {
+			total := -1
+			if data.TotalCount != nil {
+				total = int(*data.TotalCount)
+			}
+			page.Next = nextPageNo(at.PageNo, size, len(page.Items), total)
+		}
+		return page, nil
+	}
+}
+
+// listSkillsPaged is skills list with --all, --limit or -o ndjson.
+func listSkillsPaged(ctx context.Context, fetch pageFetcher[*client.ListMarketSkillByPageResponseBodyDataResult], paging listPaging, start pageCursor, format string) error {
+	if format != "" {
+		stream := newListStream(os.Stdout, format, "result")
+		walk, err := walkPages(ctx, paging, start, fetch, func(item *client.ListMarketSkillByPageResponseBodyDataResult) error {
+			if item == nil {
+				return nil
+			}
+			return stream.write(newSkillListItem(item))
+		})
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", stream.fail(err))
+		}
+		return stream.close(listStreamField{"totalCount", walk.totalCount()})
+	}
+
+	var results []*client.ListMarketSkillByPageResponseBodyDataResult
+	walk, err := walkPages(ctx, paging, start, fetch, func(item *client.ListMarketSkillByPageResponseBodyDataResult) error {
+		if item != nil {
+			results = append(results, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if len(results) == 0 {
+		fmt.Println("[INFO] No skills found.")
+		return nil
+	}
+	fmt.Printf("[OK] Found %d skill(s) in %d page(s)\n\n", len(results), walk.Pages)
+	printSkillsTable(results)
+	printMorePagesHint(os.Stdout, walk, "--page")
 	return nil
 }
 
@@ -1051,7 +1240,11 @@ func runSkillsShow(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
-	apiClient := agentbay.NewClientFromConfig(cfg)
+	apiClient, err := newCachedClient(cmd, cfg)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	defer func() { printCacheAge(os.Stdout, apiClient, time.Now()) }()
 	ctx := context.Background()
 
 	req := &client.DescribeMarketSkillDetailRequest{SkillId: &skillId}
@@ -0,0 +1,316 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// skillDiffMaxCells bounds the line diff table; larger files are reported as changed only.
+const skillDiffMaxCells = 4_000_000
+
+// skillFileChange is one entry of a skill diff. Status is "A" (local only), "D" (published only)
+// or "M" (content differs).
+type skillFileChange struct {
+	Status string   `json:"status"`
+	Path   string   `json:"path"`
+	Binary bool     `json:"binary,omitempty"`
+	Diff   []string `json:"diff,omitempty"`
+}
+
+// readLocalSkillFiles reads the files zipSkillDir would pack from dir, leaving out version control
+// directories, which "skills validate" refuses to upload.
+func readLocalSkillFiles(dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", ".svn", ".hg":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = b
+		return nil
+	})
+	return files, err
+}
+
+// diffSkillFiles compares the published package with local files. context is the number of
+// unchanged lines around each hunk; a negative value skips line diffs.
+func diffSkillFiles(published *skillPackage, local map[string][]byte, context int) []skillFileChange {
+	seen := map[string]bool{}
+	var names []string
+	for name := range published.Files {
+		seen[name] = true
+		names = append(names, name)
+	}
+	for name := range local {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []skillFileChange
+	for _, name := range names {
+		remote, inRemote := published.Files[name]
+		data, inLocal := local[name]
+		var c skillFileChange
+		switch {
+		case !inLocal:
+			c = skillFileChange{Status: "D", Path: name}
+		case !inRemote:
+			c = skillFileChange{Status: "A", Path: name}
+		case bytes.Equal(remote.Data, data):
+			continue
+		default:
+			c = skillFileChange{Status: "M", Path: name}
+		}
+		c.Binary = isBinaryContent(remote.Data) || isBinaryContent(data)
+		if context >= 0 && !c.Binary {
+			c.Diff = unifiedLineDiff(splitLines(remote.Data), splitLines(data), context)
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+func isBinaryContent(b []byte) bool {
+	return bytes.IndexByte(b, 0) >= 0 || !utf8.Valid(b)
+}
+
+func splitLines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+	s := strings.TrimSuffix(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n")
+	return strings.Split(s, "\n")
+}
+
+// unifiedLineDiff returns the hunks of a unified diff from a to b, without file headers. It returns
+// nil when the inputs are too large to diff.
+func unifiedLineDiff(a, b []string, context int) []string {
+	if len(a)*len(b) > skillDiffMaxCells {
+		return nil
+	}
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type op struct {
+		kind byte // ' ', '-', '+'
+		text string
+		ai   int // line index in a (for ' ' and '-')
+		bi   int // line index in b (for ' ' and '+')
+	}
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{' ', a[i], i, j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, op{'+', b[j], i, j})
+			j++
+		default:
+			ops = append(ops, op{'-', a[i], i, j})
+			i++
+		}
+	}
+
+	var out []string
+	for k := 0; k < len(ops); {
+		if ops[k].kind == ' ' {
+			k++
+			continue
+		}
+		start := k - context
+		if start < 0 {
+			start = 0
+		}
+		// Extend the hunk while the next change is within 2*context unchanged lines.
+		end := k
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end += min(context, run-end)
+				break
+			}
+			end = run
+		}
+		aStart, bStart, aLen, bLen := ops[start].ai, ops[start].bi, 0, 0
+		var body []string
+		for _, o := range ops[start:end] {
+			body = append(body, string(o.kind)+o.text)
+			if o.kind != '+' {
+				aLen++
+			}
+			if o.kind != '-' {
+				bLen++
+			}
+		}
+		out = append(out, fmt.Sprintf("@@ -%s +%s @@", hunkRange(aStart, aLen), hunkRange(bStart, bLen)))
+		out = append(out, body...)
+		k = end
+	}
+	return out
+}
+
+// hunkRange formats a unified diff range; an empty range names the line before it.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+func printSkillDiff(changes []skillFileChange, skillId, dir string, nameOnly bool) {
+	for _, c := range changes {
+		fmt.Printf("%s  %s\n", c.Status, c.Path)
+	}
+	if !nameOnly {
+		for _, c := range changes {
+			from, to := "a/"+c.Path, "b/"+c.Path
+			switch c.Status {
+			case "A":
+				from = "/dev/null"
+			case "D":
+				to = "/dev/null"
+			}
+			fmt.Printf("\n--- %s (published)\n+++ %s (local)\n", from, to)
+			switch {
+			case c.Binary:
+				fmt.Println("Binary files differ")
+			case c.Diff == nil:
+				fmt.Println("Files differ (too large to show a line diff)")
+			default:
+				fmt.Println(strings.Join(c.Diff, "\n"))
+			}
+		}
+	}
+	counts := map[string]int{}
+	for _, c := range changes {
+		counts[c.Status]++
+	}
+	fmt.Printf("\n[INFO] %d file(s) changed between skill %s and %s: %d modified, %d added, %d deleted.\n",
+		len(changes), skillId, dir, counts["M"], counts["A"], counts["D"])
+}
+
+func runSkillsDiff(cmd *cobra.Command, args []string) error {
+	skillId := args[0]
+	dir := filepath.Clean(args[1])
+	nameOnly, _ := cmd.Flags().GetBool("name-only")
+	exitCode, _ := cmd.Flags().GetBool("exit-code")
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Not a skill directory: %s", dir),
+			"",
+			"[TIP] Usage: agentbay skills diff <skill-id> <skill-dir>",
+		)
+	}
+	local, err := readLocalSkillFiles(dir)
+	if err != nil {
+		return fmt.Errorf("[ERROR] read %s: %w", dir, err)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	skill, err := fetchPublishedSkill(context.Background(), apiClient, skillId)
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return err
+	}
+
+	contextLines := 3
+	if nameOnly {
+		contextLines = -1
+	}
+	changes := diffSkillFiles(skill.Package, local, contextLines)
+
+	if strings.EqualFold(outputFmt, "json") {
+		if changes == nil {
+			changes = []skillFileChange{}
+		}
+		b, err := json.MarshalIndent(map[string]interface{}{
+			"skillId":   skill.SkillId,
+			"dir":       dir,
+			"published": map[string]interface{}{"size": skill.Package.Size, "sha256": skill.Package.SHA256, "files": len(skill.Package.Files)},
+			"changes":   changes,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		fmt.Println(string(b))
+	} else {
+		if skill.RequestId != "" {
+			fmt.Printf("[INFO] DescribeMarketSkillDetail RequestId: %s\n", skill.RequestId)
+		}
+		if len(changes) == 0 {
+			fmt.Printf("[OK] No differences between skill %s and %s.\n", skill.SkillId, dir)
+		} else {
+			printSkillDiff(changes, skill.SkillId, dir, nameOnly)
+		}
+	}
+	if exitCode && len(changes) > 0 {
+		return fmt.Errorf("[ERROR] %d file(s) differ from the published skill", len(changes))
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var skillInitCmd = &cobra.Command{
+	Use:   "init <name>",
+	Short: "Scaffold a new skill directory",
+	Long: `Create a skill directory with a SKILL.md carrying the full YAML frontmatter and example
+resources that SKILL.md links to:
+
+  <name>/SKILL.md
+  <name>/scripts/example.sh
+  <name>/references/REFERENCE.md
+  <name>/assets/template.md
+
+The result passes "skills validate" and can be pushed as-is once the placeholders are filled in.
+
+Examples:
+  agentbay skills init pdf-report
+  agentbay skills init pdf-report --description "Build PDF reports from CSV files" --dir ./skills`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsInit,
+}
+
+func init() {
+	skillInitCmd.Flags().String("dir", ".", "Parent directory in which to create the skill directory")
+	skillInitCmd.Flags().String("description", "", "Description for the frontmatter (default: a placeholder to edit)")
+	skillInitCmd.Flags().String("author", "", "Author for the frontmatter (optional)")
+	skillInitCmd.Flags().Bool("force", false, "Write into an existing non-empty directory, overwriting scaffold files")
+
+	SkillsCmd.AddCommand(skillInitCmd)
+}
+
+// skillScaffold returns the files of a new skill, keyed by slash-separated relative path. The
+// frontmatter lists every supported field so authors can see what is available.
+func skillScaffold(name, description, author string) (map[string]string, error) {
+	quote := func(v string) (string, error) {
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("yaml marshal: %w", err)
+		}
+		return strings.TrimSuffix(string(b), "\n"), nil
+	}
+	desc, err := quote(description)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := quote(author)
+	if err != nil {
+		return nil, err
+	}
+	front := "name: " + name + "\n" +
+		"description: " + desc + "\n" +
+		"version: 0.1.0\n" +
+		"license: Apache-2.0\n" +
+		"author: " + auth + "\n" +
+		"tags: []\n" +
+		"allowed-tools: []\n" +
+		"metadata: {}\n"
+	title := strings.ReplaceAll(name, "-", " ")
+	skillMd := "---\n" + front + "---\n\n" +
+		"# " + title + "\n\n" +
+		"Describe what this skill does and when it should be used. Keep this file short and\n" +
+		"move long material into the referenced files below.\n\n" +
+		"## Instructions\n\n" +
+		"1. Explain the first step.\n" +
+		"2. Run [the example script](scripts/example.sh) when a helper is needed.\n" +
+		"3. Fill in [the template](assets/template.md) to produce the result.\n\n" +
+		"## Reference\n\n" +
+		"See [REFERENCE.md](references/REFERENCE.md) for details loaded only when needed.\n"
+	return map[string]string{
+		skillFileName: skillMd,
+		"scripts/example.sh": "#!/usr/bin/env bash\n" +
+			"# Example helper script for the " + name + " skill.\n" +
+			"set -euo pipefail\n\n" +
+			"echo \"Hello from " + name + "\"\n",
+		"references/REFERENCE.md": "# " + title + " reference\n\n" +
+			"Put detailed documentation, API notes or examples here.\n",
+		"assets/template.md": "# Title\n\n" +
+			"Template content the skill fills in.\n",
+	}, nil
+}
+
+func runSkillsInit(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	parent, _ := cmd.Flags().GetString("dir")
+	description, _ := cmd.Flags().GetString("description")
+	author, _ := cmd.Flags().GetString("author")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if !skillNameRe.MatchString(name) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid skill name: %s", name),
+			"",
+			"[TIP] Use 1-64 lowercase letters, digits or hyphens, e.g. pdf-report",
+		)
+	}
+	if description == "" {
+		description = "TODO: describe what " + name + " does and when to use it."
+	}
+	files, err := skillScaffold(name, description, author)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(parent, name)
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 && !force {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Directory %s already exists and is not empty", dir),
+			"",
+			"[TIP] Choose another name or --dir, or pass --force to overwrite the scaffold files",
+		)
+	}
+
+	paths := []string{skillFileName, "scripts/example.sh", "references/REFERENCE.md", "assets/template.md"}
+	for _, rel := range paths {
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return fmt.Errorf("[ERROR] create directory: %w", err)
+		}
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(rel, ".sh") {
+			mode = 0755
+		}
+		if err := os.WriteFile(p, []byte(files[rel]), mode); err != nil {
+			return fmt.Errorf("[ERROR] write %s: %w", rel, err)
+		}
+	}
+
+	fmt.Printf("[OK] Created skill %s in %s\n", name, dir)
+	for _, rel := range paths {
+		fmt.Printf("  %s\n", filepath.Join(dir, filepath.FromSlash(rel)))
+	}
+	fmt.Printf("\n[TIP] Edit %s, then run: agentbay skills validate %s && agentbay skills push %s\n",
+		filepath.Join(dir, skillFileName), dir, dir)
+	return nil
+}
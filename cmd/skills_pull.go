@@ -0,0 +1,470 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc64"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// skillMaxPackageSize caps the downloaded zip; a package within the upload limits compresses below it.
+const skillMaxPackageSize = 2 * skillMaxTotalSize
+
+var skillsPullCmd = &cobra.Command{
+	Use:   "pull <skill-id>",
+	Short: "Download a published skill into a local directory",
+	Long: `Download the package of a published skill, check its integrity and unpack it into a
+directory laid out the way "skills push" packs one (SKILL.md at the top level).
+
+Integrity checks: the download must be complete and match the Content-MD5 and CRC64 checksums
+returned by storage when present; every zip entry must pass its CRC32 check, stay inside the
+directory, and the package must contain SKILL.md and fit the "skills validate" size limits.
+
+The target directory defaults to ./<skill-name>. An existing non-empty directory is only
+updated with --force, and only when it already holds a skill (a SKILL.md at the top level):
+the files of the package are overwritten and other files in the directory are kept. Edit the files, then publish them with:
+
+  agentbay skills update --skill-id <skill-id> --file <dir>
+
+Examples:
+  agentbay skills pull 35U2Ver2
+  agentbay skills pull 35U2Ver2 --dir ./skills/pdf-report --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsPull,
+}
+
+var skillsDiffCmd = &cobra.Command{
+	Use:   "diff <skill-id> <skill-dir>",
+	Short: "Show local changes against the published skill",
+	Long: `Compare a local skill directory with the published package of a skill. Files are listed
+as A (only local), D (only published) or M (content differs), followed by a unified diff of
+each changed text file.
+
+Examples:
+  agentbay skills diff 35U2Ver2 ./pdf-report
+  agentbay skills diff 35U2Ver2 ./pdf-report --name-only
+  agentbay skills diff 35U2Ver2 ./pdf-report --exit-code -o json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSkillsDiff,
+}
+
+func init() {
+	skillsPullCmd.Flags().String("dir", "", "Directory to unpack into (default: ./<skill-name>)")
+	skillsPullCmd.Flags().Bool("force", false, "Overwrite the package files in an existing skill directory")
+
+	skillsDiffCmd.Flags().Bool("name-only", false, "List changed files without line diffs")
+	skillsDiffCmd.Flags().Bool("exit-code", false, "Exit with an error when there are differences")
+	skillsDiffCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+
+	skillsPullCmd.ValidArgsFunction = completeOnlyArg(completionSkills, nil)
+	skillsDiffCmd.ValidArgsFunction = completeFirstArg(completionSkills, nil)
+
+	SkillsCmd.AddCommand(skillsPullCmd)
+	SkillsCmd.AddCommand(skillsDiffCmd)
+}
+
+// skillFile is one file of a skill package; Mode keeps the executable bit.
+type skillFile struct {
+	Data []byte
+	Mode os.FileMode
+}
+
+// skillPackage is a downloaded and checked skill zip.
+type skillPackage struct {
+	Size   int64
+	SHA256 string
+	Files  map[string]skillFile
+}
+
+// publishedSkill is a skill's detail together with its downloaded package.
+type publishedSkill struct {
+	SkillId   string
+	Name      string
+	RequestId string
+	Package   *skillPackage
+}
+
+// fetchPublishedSkill looks up the skill's FileUrl and downloads its package.
+func fetchPublishedSkill(ctx context.Context, apiClient agentbay.Client, skillId string) (*publishedSkill, error) {
+	resp, err := apiClient.DescribeMarketSkillDetail(ctx, &client.DescribeMarketSkillDetailRequest{SkillId: &skillId})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Failed to get skill details: %w", err)
+	}
+	if resp.Body == nil || resp.Body.Data == nil {
+		return nil, fmt.Errorf("[ERROR] Skill %s not found", skillId)
+	}
+	d := resp.Body.Data
+	out := &publishedSkill{SkillId: strPtr(d.GetSkillId()), Name: strPtr(d.GetName()), RequestId: strPtr(resp.Body.GetRequestId())}
+	if out.SkillId == "" {
+		out.SkillId = skillId
+	}
+	fileUrl := strPtr(d.GetFileUrl())
+	if fileUrl == "" {
+		return nil, fmt.Errorf("[ERROR] Skill %s has no downloadable package (empty FileUrl)", skillId)
+	}
+	data, err := downloadSkillPackage(ctx, fileUrl)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Failed to download skill %s: %w", skillId, err)
+	}
+	pkg, err := readSkillPackage(data)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Skill %s package is invalid: %w", skillId, err)
+	}
+	out.Package = pkg
+	return out, nil
+}
+
+// downloadSkillPackage fetches fileUrl with transient retries and checks the body against the
+// length and checksum headers storage returns.
+func downloadSkillPackage(ctx context.Context, fileUrl string) ([]byte, error) {
+	var data []byte
+	err := withTransientRetry(ctx, client.DefaultRetryConfig(), "skill download", func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileUrl, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("User-Agent", "AgentBay-CLI/1.0")
+		httpClient := &http.Client{Timeout: 5 * time.Minute}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		if resp.ContentLength > skillMaxPackageSize {
+			return fmt.Errorf("package size %s exceeds the limit of %s", formatBytes(resp.ContentLength), formatBytes(skillMaxPackageSize))
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, skillMaxPackageSize+1))
+		if err != nil {
+			return err
+		}
+		if len(body) > skillMaxPackageSize {
+			return fmt.Errorf("package exceeds the limit of %s", formatBytes(skillMaxPackageSize))
+		}
+		if err := verifySkillDownload(resp.Header, resp.ContentLength, body); err != nil {
+			return err
+		}
+		data = body
+		return nil
+	})
+	return data, err
+}
+
+// verifySkillDownload checks body against Content-Length, Content-MD5 and OSS's
+// x-oss-hash-crc64ecma header, skipping whichever is absent.
+func verifySkillDownload(h http.Header, contentLength int64, body []byte) error {
+	if contentLength >= 0 && int64(len(body)) != contentLength {
+		return fmt.Errorf("incomplete download: got %d of %d bytes", len(body), contentLength)
+	}
+	if want := h.Get("Content-MD5"); want != "" {
+		sum := md5.Sum(body)
+		if got := base64.StdEncoding.EncodeToString(sum[:]); got != want {
+			return fmt.Errorf("MD5 mismatch: got %s, want %s", got, want)
+		}
+	}
+	if want := h.Get("X-Oss-Hash-Crc64ecma"); want != "" {
+		got := strconv.FormatUint(crc64.Checksum(body, crc64.MakeTable(crc64.ECMA)), 10)
+		if got != want {
+			return fmt.Errorf("CRC64 mismatch: got %s, want %s", got, want)
+		}
+	}
+	return nil
+}
+
+// readSkillPackage unzips data in memory. Entries are CRC-checked as they are read; a single
+// top-level directory wrapping SKILL.md is stripped so the layout matches zipSkillDir.
+func readSkillPackage(data []byte) (*skillPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a zip archive: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	pkg := &skillPackage{Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:]), Files: map[string]skillFile{}}
+
+	var total int64
+	for _, f := range zr.File {
+		name := strings.ReplaceAll(f.Name, "\\", "/")
+		if strings.HasSuffix(name, "/") || f.FileInfo().IsDir() || strings.HasPrefix(name, "__MACOSX/") {
+			continue
+		}
+		clean := path.Clean(name)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || strings.Contains(clean, ":") {
+			return nil, fmt.Errorf("entry %q points outside the skill directory", f.Name)
+		}
+		if f.Mode()&os.ModeSymlink != 0 {
+			return nil, fmt.Errorf("entry %q is a symlink", f.Name)
+		}
+		if _, dup := pkg.Files[clean]; dup {
+			return nil, fmt.Errorf("duplicate entry %q", f.Name)
+		}
+		if len(pkg.Files) >= skillMaxFiles {
+			return nil, fmt.Errorf("more than %d files", skillMaxFiles)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", f.Name, err)
+		}
+		// Read through a limit so a forged header cannot inflate past the size limits.
+		b, err := io.ReadAll(io.LimitReader(rc, skillMaxFileSize+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", f.Name, err)
+		}
+		if len(b) > skillMaxFileSize {
+			return nil, fmt.Errorf("entry %q exceeds the file size limit of %s", f.Name, formatBytes(skillMaxFileSize))
+		}
+		total += int64(len(b))
+		if total > skillMaxTotalSize {
+			return nil, fmt.Errorf("unpacked size exceeds the limit of %s", formatBytes(skillMaxTotalSize))
+		}
+		mode := os.FileMode(0644)
+		if f.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		pkg.Files[clean] = skillFile{Data: b, Mode: mode}
+	}
+
+	if _, ok := pkg.Files[skillFileName]; !ok {
+		prefix := ""
+		for name := range pkg.Files {
+			if strings.Count(name, "/") == 1 && path.Base(name) == skillFileName {
+				prefix = path.Dir(name) + "/"
+				break
+			}
+		}
+		if prefix == "" {
+			return nil, fmt.Errorf("%s not found in package", skillFileName)
+		}
+		stripped := make(map[string]skillFile, len(pkg.Files))
+		for name, f := range pkg.Files {
+			if !strings.HasPrefix(name, prefix) {
+				return nil, fmt.Errorf("%s is under %s but %q is not", skillFileName, prefix, name)
+			}
+			stripped[strings.TrimPrefix(name, prefix)] = f
+		}
+		pkg.Files = stripped
+	}
+	return pkg, nil
+}
+
+// names returns the package's file paths in sorted order.
+func (p *skillPackage) names() []string {
+	names := make([]string, 0, len(p.Files))
+	for name := range p.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Errors of checkSkillPullDir, matched by runSkillsPull to print the right tip.
+var (
+	errSkillDirNotEmpty = errors.New("already exists and is not empty")
+	errSkillDirNotSkill = errors.New("is not empty and does not contain a " + skillFileName + "; --force only replaces a skill directory")
+)
+
+// checkSkillPullDir reports whether dir exists and is not empty, and refuses it unless force is
+// set and dir already holds a skill.
+func checkSkillPullDir(dir string, force bool) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+	if !force {
+		return true, fmt.Errorf("directory %s %w", dir, errSkillDirNotEmpty)
+	}
+	if info, err := os.Lstat(filepath.Join(dir, skillFileName)); err != nil || !info.Mode().IsRegular() {
+		return true, fmt.Errorf("directory %s %w", dir, errSkillDirNotSkill)
+	}
+	return true, nil
+}
+
+// unpackSkillPackage writes pkg into dir. A missing or empty dir is filled from a temporary
+// sibling directory, so a failed write never leaves it half written. With force, a dir that
+// already holds a skill (a SKILL.md at the top level) is updated in place: each file of the
+// package replaces its counterpart atomically, and nothing else in dir is removed. Any other
+// non-empty dir is refused, force or not.
+func unpackSkillPackage(pkg *skillPackage, dir string, force bool) error {
+	occupied, err := checkSkillPullDir(dir, force)
+	if err != nil {
+		return err
+	}
+	if occupied {
+		return replaceSkillFiles(pkg, dir)
+	}
+
+	parent := filepath.Dir(dir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(parent, ".skill-pull-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	for _, name := range pkg.names() {
+		f := pkg.Files[name]
+		p := filepath.Join(tmp, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, f.Data, f.Mode); err != nil {
+			return err
+		}
+	}
+	if err := os.Chmod(tmp, 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Rename(tmp, dir)
+}
+
+// replaceSkillFiles overwrites the files of pkg in the existing skill directory dir. Paths are
+// resolved inside dir, so a symlink in dir cannot redirect a write outside it, and every target is
+// checked before the first file is written.
+func replaceSkillFiles(pkg *skillPackage, dir string) error {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	names := pkg.names()
+	for _, name := range names {
+		p := filepath.FromSlash(name)
+		if info, err := root.Lstat(p); err == nil && info.IsDir() {
+			return fmt.Errorf("cannot replace directory %s with a file", filepath.Join(dir, p))
+		}
+		for d := filepath.Dir(p); d != "."; d = filepath.Dir(d) {
+			if info, err := root.Lstat(d); err == nil && !info.IsDir() {
+				return fmt.Errorf("cannot write %s: %s is not a directory", filepath.Join(dir, p), filepath.Join(dir, d))
+			}
+		}
+	}
+	for _, name := range names {
+		f := pkg.Files[name]
+		p := filepath.FromSlash(name)
+		if d := filepath.Dir(p); d != "." {
+			if err := root.MkdirAll(d, 0755); err != nil {
+				return err
+			}
+		}
+		tmp := filepath.Join(filepath.Dir(p), ".skill-pull-"+filepath.Base(p))
+		if err := root.WriteFile(tmp, f.Data, f.Mode); err != nil {
+			return err
+		}
+		// WriteFile keeps the mode of a leftover file; the package's mode wins
+		if err := root.Chmod(tmp, f.Mode); err != nil {
+			_ = root.Remove(tmp)
+			return err
+		}
+		if err := root.Rename(tmp, p); err != nil {
+			_ = root.Remove(tmp)
+			return err
+		}
+	}
+	return nil
+}
+
+func runSkillsPull(cmd *cobra.Command, args []string) error {
+	skillId := args[0]
+	dir, _ := cmd.Flags().GetString("dir")
+	force, _ := cmd.Flags().GetBool("force")
+
+	// Fail before downloading when the target is known and cannot be used.
+	if dir != "" {
+		if _, err := checkSkillPullDir(dir, force); err != nil {
+			return skillPullDirError(err)
+		}
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx := context.Background()
+
+	fmt.Printf("[STEP 1/2] Downloading skill %s...\n", skillId)
+	skill, err := fetchPublishedSkill(ctx, apiClient, skillId)
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return err
+	}
+	if skill.RequestId != "" {
+		fmt.Printf("[INFO] DescribeMarketSkillDetail RequestId: %s\n", skill.RequestId)
+	}
+	pkg := skill.Package
+	fmt.Printf("[INFO] Package: %s, %d file(s), sha256 %s\n", formatBytes(pkg.Size), len(pkg.Files), pkg.SHA256)
+
+	if dir == "" {
+		dir = skill.Name
+		if !skillNameRe.MatchString(dir) {
+			if m, _, _, err := parseSkillManifest(pkg.Files[skillFileName].Data); err == nil && skillNameRe.MatchString(m.Name) {
+				dir = m.Name
+			} else {
+				dir = skill.SkillId
+			}
+		}
+	}
+	dir = filepath.Clean(dir)
+
+	fmt.Printf("[STEP 2/2] Unpacking into %s...\n", dir)
+	if err := unpackSkillPackage(pkg, dir, force); err != nil {
+		return skillPullDirError(err)
+	}
+	fmt.Printf("[SUCCESS] Pulled skill %s into %s\n", skill.SkillId, dir)
+
+	if v, err := validateSkillDir(dir); err == nil {
+		if errs, warnings := v.counts(); errs+warnings > 0 {
+			fmt.Printf("[WARN] The published package has %d validation error(s) and %d warning(s); run: agentbay skills validate %s\n", errs, warnings, dir)
+		}
+	}
+	fmt.Printf("[TIP] After editing, publish with: agentbay skills update --skill-id %s --file %s\n", skill.SkillId, dir)
+	return nil
+}
+
+// skillPullDirError prints a refused target directory with a tip and wraps any other unpack error.
+func skillPullDirError(err error) error {
+	switch {
+	case errors.Is(err, errSkillDirNotEmpty):
+		return printErrorMessage(fmt.Sprintf("[ERROR] %s", err), "",
+			"[TIP] Choose another --dir, or pass --force to overwrite the skill files in it")
+	case errors.Is(err, errSkillDirNotSkill):
+		return printErrorMessage(fmt.Sprintf("[ERROR] %s", err), "",
+			"[TIP] Choose another --dir; --force never replaces a directory without a "+skillFileName)
+	}
+	return fmt.Errorf("[ERROR] unpack skill: %w", err)
+}
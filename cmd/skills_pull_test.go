@@ -0,0 +1,194 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"hash/crc64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+type mockSkillDetailClient struct {
+	agentbay.Client
+	fileUrl string
+}
+
+func (m *mockSkillDetailClient) DescribeMarketSkillDetail(ctx context.Context, req *client.DescribeMarketSkillDetailRequest) (*client.DescribeMarketSkillDetailResponse, error) {
+	return &client.DescribeMarketSkillDetailResponse{Body: &client.DescribeMarketSkillDetailResponseBody{
+		RequestId: dara.String("R1"),
+		Data: &client.DescribeMarketSkillDetailResponseBodyData{
+			SkillId: req.SkillId,
+			Name:    dara.String("pdf-report"),
+			FileUrl: dara.String(m.fileUrl),
+		},
+	}}, nil
+}
+
+func zipEntries(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range entries {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func serveSkillZip(t *testing.T, data []byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sum := md5.Sum(data)
+		w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+		w.Header().Set("X-Oss-Hash-Crc64ecma", strconv.FormatUint(crc64.Checksum(data, crc64.MakeTable(crc64.ECMA)), 10))
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestReadSkillPackage(t *testing.T) {
+	pkg, err := readSkillPackage(zipEntries(t, map[string]string{
+		"pdf-report/SKILL.md":       "---\nname: pdf-report\ndescription: d\n---\n",
+		"pdf-report/scripts/run.sh": "echo\n",
+		"__MACOSX/pdf-report/._x":   "junk",
+		"pdf-report/references/":    "",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SKILL.md", "scripts/run.sh"}, pkg.names(), "a single wrapping directory is stripped")
+	assert.Len(t, pkg.SHA256, 64)
+
+	_, err = readSkillPackage(zipEntries(t, map[string]string{"SKILL.md": "x", "../evil": "x"}))
+	assert.ErrorContains(t, err, "outside the skill directory")
+	_, err = readSkillPackage(zipEntries(t, map[string]string{"README.md": "x"}))
+	assert.ErrorContains(t, err, "SKILL.md not found")
+	_, err = readSkillPackage([]byte("not a zip"))
+	assert.ErrorContains(t, err, "not a zip")
+}
+
+func TestVerifySkillDownload(t *testing.T) {
+	body := []byte("package")
+	h := http.Header{}
+	assert.NoError(t, verifySkillDownload(h, -1, body))
+	assert.ErrorContains(t, verifySkillDownload(h, 10, body), "incomplete download")
+
+	h.Set("Content-MD5", "AAAAAAAAAAAAAAAAAAAAAA==")
+	assert.ErrorContains(t, verifySkillDownload(h, int64(len(body)), body), "MD5 mismatch")
+
+	h = http.Header{}
+	h.Set("X-Oss-Hash-Crc64ecma", "1")
+	assert.ErrorContains(t, verifySkillDownload(h, -1, body), "CRC64 mismatch")
+}
+
+func TestFetchAndUnpackSkill(t *testing.T) {
+	src := t.TempDir()
+	files, err := skillScaffold("pdf-report", "d", "")
+	require.NoError(t, err)
+	writeSkillFiles(t, src, files)
+	require.NoError(t, os.Chmod(filepath.Join(src, "scripts", "example.sh"), 0755))
+	zipBuf, err := zipSkillDir(src)
+	require.NoError(t, err)
+	srv := serveSkillZip(t, zipBuf.Bytes())
+
+	skill, err := fetchPublishedSkill(context.Background(), &mockSkillDetailClient{fileUrl: srv.URL}, "sk-1")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-1", skill.SkillId)
+	assert.Equal(t, "R1", skill.RequestId)
+
+	dir := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, unpackSkillPackage(skill.Package, dir, false))
+	local, err := readLocalSkillFiles(dir)
+	require.NoError(t, err)
+	assert.Empty(t, diffSkillFiles(skill.Package, local, 3), "a fresh pull matches the published package")
+	info, err := os.Stat(filepath.Join(dir, "scripts", "example.sh"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "executable bit survives the round trip")
+
+	assert.ErrorContains(t, unpackSkillPackage(skill.Package, dir, false), "not empty")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stale.txt"), []byte("x"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, skillFileName), []byte("edited"), 0600))
+	require.NoError(t, unpackSkillPackage(skill.Package, dir, true))
+	_, err = os.Stat(filepath.Join(dir, "stale.txt"))
+	assert.NoError(t, err, "--force keeps files outside the package")
+	data, err := os.ReadFile(filepath.Join(dir, skillFileName))
+	require.NoError(t, err)
+	assert.Equal(t, skill.Package.Files[skillFileName].Data, data, "--force overwrites the package files")
+	info, err = os.Stat(filepath.Join(dir, skillFileName))
+	require.NoError(t, err)
+	assert.Equal(t, skill.Package.Files[skillFileName].Mode, info.Mode().Perm())
+}
+
+func TestUnpackSkillPackageForceRefusesNonSkillDir(t *testing.T) {
+	pkg := &skillPackage{Files: map[string]skillFile{skillFileName: {Data: []byte("x"), Mode: 0644}}}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("mine"), 0644))
+
+	err := unpackSkillPackage(pkg, dir, true)
+	assert.ErrorIs(t, err, errSkillDirNotSkill)
+	_, err = os.Stat(filepath.Join(dir, "notes.txt"))
+	assert.NoError(t, err, "a refused directory is left alone")
+	_, err = os.Stat(filepath.Join(dir, skillFileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUnpackSkillPackageForceStaysInsideDir(t *testing.T) {
+	outside := t.TempDir()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, skillFileName), []byte("old"), 0644))
+	require.NoError(t, os.Symlink(outside, filepath.Join(dir, "scripts")))
+	pkg := &skillPackage{Files: map[string]skillFile{
+		skillFileName:        {Data: []byte("new"), Mode: 0644},
+		"scripts/example.sh": {Data: []byte("echo"), Mode: 0755},
+	}}
+
+	assert.Error(t, unpackSkillPackage(pkg, dir, true))
+	_, err := os.Stat(filepath.Join(outside, "example.sh"))
+	assert.True(t, os.IsNotExist(err), "a symlink cannot redirect a write outside the directory")
+	data, err := os.ReadFile(filepath.Join(dir, skillFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data), "targets are checked before the first write")
+}
+
+func TestDiffSkillFiles(t *testing.T) {
+	published := &skillPackage{Files: map[string]skillFile{
+		"SKILL.md":       {Data: []byte("a\nb\nc\nd\ne\nf\ng\nh\n")},
+		"assets/old.png": {Data: []byte("\x89PNG\x00")},
+		"same.txt":       {Data: []byte("same\n")},
+	}}
+	local := map[string][]byte{
+		"SKILL.md":       []byte("a\nb\nC\nd\ne\nf\ng\nh\ni\n"),
+		"same.txt":       []byte("same\n"),
+		"scripts/new.sh": []byte("echo\n"),
+	}
+	changes := diffSkillFiles(published, local, 1)
+	require.Len(t, changes, 3)
+	assert.Equal(t, skillFileChange{Status: "M", Path: "SKILL.md", Diff: []string{
+		"@@ -2,3 +2,3 @@", " b", "-c", "+C", " d",
+		"@@ -8 +8,2 @@", " h", "+i",
+	}}, changes[0])
+	assert.Equal(t, "D", changes[1].Status)
+	assert.True(t, changes[1].Binary)
+	assert.Equal(t, skillFileChange{Status: "A", Path: "scripts/new.sh", Diff: []string{"@@ -0,0 +1 @@", "+echo"}}, changes[2])
+
+	assert.Nil(t, diffSkillFiles(published, local, -1)[0].Diff, "--name-only skips line diffs")
+}
@@ -0,0 +1,549 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// skillLockFileName is the default lock file written at the root of a synced skills repository.
+const skillLockFileName = "agentbay-skills.lock"
+
+var skillsSyncCmd = &cobra.Command{
+	Use:   "sync <root-dir>",
+	Short: "Publish every skill directory under a root directory",
+	Long: `Find every directory with a SKILL.md under <root-dir>, match each to a published skill and
+create, update or (with --prune) delete skills so the cloud matches the repository.
+
+Matching: a skill recorded in the lock file is matched by its skill ID; otherwise by the
+frontmatter name against ListMarketSkillByPage. Two published skills with the same name are
+reported as an error until one is pinned in the lock file.
+
+Comparison: the content hash of the local directory is compared with the one recorded in the
+lock file; when the published skill changed since the last sync, or was never synced, its
+package is downloaded and hashed instead.
+
+Pruning: --prune only deletes skills recorded in the lock file whose directory is gone, never
+skills this repository did not publish. Deletions ask for confirmation unless --yes is given.
+
+The plan is printed before anything changes; --dry-run stops there. Directories that fail
+"skills validate" are reported and skipped. The lock file (default <root-dir>/` + skillLockFileName + `)
+records the name, path, skill ID and content hash of every synced skill; commit it with the
+repository.
+
+Examples:
+  agentbay skills sync ./skills --dry-run
+  agentbay skills sync ./skills
+  agentbay skills sync ./skills --prune --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsSync,
+}
+
+func init() {
+	skillsSyncCmd.Flags().Bool("dry-run", false, "Print the plan without changing anything")
+	skillsSyncCmd.Flags().Bool("prune", false, "Delete skills recorded in the lock file whose directory no longer exists")
+	skillsSyncCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt for deletions")
+	skillsSyncCmd.Flags().String("lock-file", "", "Lock file path (default: <root-dir>/"+skillLockFileName+")")
+	skillsSyncCmd.Flags().Bool("no-validate", false, "Skip the \"skills validate\" checks before uploading")
+
+	SkillsCmd.AddCommand(skillsSyncCmd)
+}
+
+// skillLock is the lock file of a synced skills repository, keyed by skill name.
+type skillLock struct {
+	Version int                       `json:"version"`
+	Skills  map[string]skillLockEntry `json:"skills"`
+}
+
+type skillLockEntry struct {
+	Path        string `json:"path"`
+	SkillId     string `json:"skillId"`
+	ContentHash string `json:"contentHash"`
+	// PublishedAt is the GmtModified the skill had when its hash was last confirmed; empty when
+	// the CLI itself just uploaded it.
+	PublishedAt string `json:"publishedAt,omitempty"`
+	SyncedAt    string `json:"syncedAt"`
+}
+
+func loadSkillLock(path string) (*skillLock, error) {
+	lock := &skillLock{Version: 1, Skills: map[string]skillLockEntry{}}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, lock); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if lock.Skills == nil {
+		lock.Skills = map[string]skillLockEntry{}
+	}
+	return lock, nil
+}
+
+// saveSkillLock replaces the lock file through a temporary file, so an interrupted sync never
+// leaves a truncated lock behind. It is meant to be committed, hence 0644.
+func saveSkillLock(path string, lock *skillLock) error {
+	b, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	return config.WriteFileAtomic(path, append(b, '\n'), 0644)
+}
+
+// skillContentHash hashes a skill's files by path and content, so the same tree gives the same
+// hash however it was zipped.
+func skillContentHash(files map[string][]byte) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		sum := sha256.Sum256(files[name])
+		fmt.Fprintf(h, "%s\x00%s\n", name, hex.EncodeToString(sum[:]))
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+func (p *skillPackage) contentHash() string {
+	files := make(map[string][]byte, len(p.Files))
+	for name, f := range p.Files {
+		files[name] = f.Data
+	}
+	return skillContentHash(files)
+}
+
+// localSkill is a skill directory found under the sync root.
+type localSkill struct {
+	Name  string
+	Path  string // slash-separated, relative to the root
+	Dir   string
+	Tags  []string
+	Hash  string
+	Error string
+}
+
+// findLocalSkills returns every directory under root that holds a SKILL.md, without descending
+// into skill directories, hidden directories or node_modules.
+func findLocalSkills(root string, validate bool) ([]localSkill, error) {
+	var skills []localSkill
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(p, skillFileName)); err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		skills = append(skills, readLocalSkill(p, filepath.ToSlash(rel), validate))
+		return filepath.SkipDir
+	})
+	return skills, err
+}
+
+func readLocalSkill(dir, rel string, validate bool) localSkill {
+	s := localSkill{Path: rel, Dir: dir, Name: filepath.Base(dir)}
+	content, err := os.ReadFile(filepath.Join(dir, skillFileName))
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	if m, _, _, err := parseSkillManifest(content); err == nil && m.Name != "" {
+		s.Name, s.Tags = m.Name, m.Tags
+	} else if name, _, err := parseSkillFrontmatter(content); err == nil {
+		s.Name = name
+	} else {
+		s.Error = err.Error()
+		return s
+	}
+	if validate {
+		v, err := validateSkillDir(dir)
+		if err != nil {
+			s.Error = err.Error()
+			return s
+		}
+		if errs, _ := v.counts(); errs > 0 {
+			for _, f := range v.Findings {
+				if f.Severity == "error" {
+					s.Error = fmt.Sprintf("%d validation error(s), first: %s: %s", errs, f.Path, f.Message)
+					break
+				}
+			}
+			return s
+		}
+	}
+	files, err := readLocalSkillFiles(dir)
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	s.Hash = skillContentHash(files)
+	return s
+}
+
+// skillSyncAction is one step of a sync plan. Action is "create", "update", "unchanged",
+// "delete" or "error".
+type skillSyncAction struct {
+	Action      string
+	Name        string
+	Path        string
+	SkillId     string
+	Hash        string
+	PublishedAt string
+	Reason      string
+	Local       *localSkill
+}
+
+// planSkillSync decides what to do for every local skill and, with prune, for every locked skill
+// whose directory is gone. fetch downloads a published package when hashes must be compared.
+func planSkillSync(locals []localSkill, remote []*client.ListMarketSkillByPageResponseBodyDataResult, lock *skillLock,
+	prune bool, fetch func(skillId string) (*skillPackage, error)) []skillSyncAction {
+	byId := map[string]*client.ListMarketSkillByPageResponseBodyDataResult{}
+	byName := map[string][]*client.ListMarketSkillByPageResponseBodyDataResult{}
+	for _, r := range remote {
+		byId[strPtr(r.SkillId)] = r
+		byName[strPtr(r.SkillName)] = append(byName[strPtr(r.SkillName)], r)
+	}
+
+	var plan []skillSyncAction
+	seen := map[string]string{}
+	for i := range locals {
+		l := &locals[i]
+		a := skillSyncAction{Name: l.Name, Path: l.Path, Hash: l.Hash, Local: l}
+		if prev, dup := seen[l.Name]; dup {
+			a.Action, a.Reason = "error", fmt.Sprintf("name also used by %s", prev)
+			plan = append(plan, a)
+			continue
+		}
+		seen[l.Name] = l.Path
+		if l.Error != "" {
+			a.Action, a.Reason = "error", l.Error
+			plan = append(plan, a)
+			continue
+		}
+
+		entry, locked := lock.Skills[l.Name]
+		var match *client.ListMarketSkillByPageResponseBodyDataResult
+		if locked {
+			match = byId[entry.SkillId]
+		}
+		if match == nil {
+			switch candidates := byName[l.Name]; len(candidates) {
+			case 0:
+			case 1:
+				match = candidates[0]
+			default:
+				a.Action, a.Reason = "error", fmt.Sprintf("%d published skills are named %q; record the right skill ID in the lock file", len(candidates), l.Name)
+				plan = append(plan, a)
+				continue
+			}
+		}
+		if match == nil {
+			a.Action, a.Reason = "create", "not published"
+			plan = append(plan, a)
+			continue
+		}
+		a.SkillId, a.PublishedAt = strPtr(match.SkillId), strPtr(match.GmtModified)
+
+		if locked && entry.SkillId == a.SkillId && entry.PublishedAt != "" && entry.PublishedAt == a.PublishedAt {
+			if entry.ContentHash == l.Hash {
+				a.Action = "unchanged"
+			} else {
+				a.Action, a.Reason = "update", "local content changed"
+			}
+			plan = append(plan, a)
+			continue
+		}
+		pkg, err := fetch(a.SkillId)
+		if err != nil {
+			a.Action, a.Reason = "error", err.Error()
+		} else if pkg.contentHash() == l.Hash {
+			a.Action = "unchanged"
+		} else {
+			a.Action, a.Reason = "update", "content differs from the published package"
+		}
+		plan = append(plan, a)
+	}
+
+	if prune {
+		var names []string
+		for name := range lock.Skills {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			entry := lock.Skills[name]
+			if _, ok := seen[name]; ok || byId[entry.SkillId] == nil {
+				continue
+			}
+			plan = append(plan, skillSyncAction{Action: "delete", Name: name, Path: entry.Path, SkillId: entry.SkillId, Reason: "directory removed"})
+		}
+	}
+	return plan
+}
+
+func printSkillSyncPlan(plan []skillSyncAction) map[string]int {
+	nameW, pathW := len("NAME"), len("PATH")
+	for _, a := range plan {
+		nameW = max(nameW, len(a.Name))
+		pathW = max(pathW, len(a.Path))
+	}
+	counts := map[string]int{}
+	fmt.Printf("%-10s %-*s %-*s %-10s %s\n", "ACTION", nameW, "NAME", pathW, "PATH", "SKILL ID", "REASON")
+	for _, a := range plan {
+		counts[a.Action]++
+		id := a.SkillId
+		if id == "" {
+			id = "-"
+		}
+		fmt.Printf("%-10s %-*s %-*s %-10s %s\n", a.Action, nameW, a.Name, pathW, a.Path, id, a.Reason)
+	}
+	fmt.Printf("\n[INFO] Plan: %d to create, %d to update, %d to delete, %d unchanged, %d error(s).\n",
+		counts["create"], counts["update"], counts["delete"], counts["unchanged"], counts["error"])
+	return counts
+}
+
+// publishSkillDir uploads dir and creates the skill, or updates skillId when it is set. It
+// returns the skill ID. An update must raise the version like "skills update" does.
+func publishSkillDir(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, dir, skillId string, tags []string) (string, error) {
+	zipBuf, err := zipSkillDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("pack skill: %w", err)
+	}
+	pkgData := zipBuf.Bytes()
+	name, version := skillPackageVersion(pkgData)
+	if err := checkSkillUploadVersion(ctx, apiClient, skillId, version); err != nil {
+		return "", fmt.Errorf("[ERROR] %w", err)
+	}
+	if len(tags) > 0 {
+		if err := ensureSkillTags(ctx, cmd, apiClient, tags); err != nil {
+			return "", err
+		}
+	}
+	uploadURL, bucket, ossPath, err := requestSkillUpload(ctx, cmd, apiClient, skillDirToZipFileName(dir))
+	if err != nil {
+		return "", err
+	}
+	if err := uploadSkillZip(cmd, pkgData, uploadURL); err != nil {
+		return "", err
+	}
+
+	var resp *client.CreateMarketSkillResponse
+	action := "CreateMarketSkill"
+	if skillId != "" {
+		action = "UpdateMarketSkill"
+	}
+	err = withTransientRetry(ctx, client.DefaultRetryConfig(), action, func() error {
+		var e error
+		if skillId == "" {
+			resp, e = apiClient.CreateMarketSkill(ctx, &client.CreateMarketSkillRequest{OssBucket: &bucket, OssFilePath: &ossPath, TagList: tags})
+		} else {
+			resp, e = apiClient.UpdateMarketSkill(ctx, &client.UpdateMarketSkillRequest{SkillId: &skillId, OssBucket: &bucket, OssFilePath: &ossPath, TagList: tags})
+		}
+		return e
+	})
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return "", fmt.Errorf("[ERROR] %s failed: %w", action, err)
+	}
+	if resp.Body != nil && resp.Body.RequestId != nil && *resp.Body.RequestId != "" {
+		fmt.Printf("[INFO] %s RequestId: %s\n", action, *resp.Body.RequestId)
+	}
+	if skillId == "" && resp.Body != nil && resp.Body.Data != nil {
+		skillId = strPtr(resp.Body.Data.SkillId)
+	}
+	if skillId == "" {
+		return "", fmt.Errorf("[ERROR] %s returned no skill ID", action)
+	}
+	noteSkillRelease(skillId, name, skillRelease{Version: version, Source: "sync"}, pkgData)
+	return skillId, nil
+}
+
+func deletePublishedSkill(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, skillId string) error {
+	resp, err := apiClient.DeleteMarketSkill(ctx, &client.DeleteMarketSkillRequest{SkillId: &skillId})
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] Failed to delete skill: %w", err)
+	}
+	if resp.Body == nil {
+		return fmt.Errorf("[ERROR] Invalid response: missing body")
+	}
+	if reqID := resp.Body.GetRequestId(); reqID != "" {
+		fmt.Printf("[INFO] DeleteMarketSkill Request ID: %s\n", reqID)
+	}
+	code := resp.Body.GetCode()
+	if (resp.Body.Success != nil && !*resp.Body.Success) || (code != "" && !strings.EqualFold(code, "ok")) {
+		return fmt.Errorf("[ERROR] Failed to delete skill: Code=%s, Message=%s", code, resp.Body.GetMessage())
+	}
+	return nil
+}
+
+// applySkillSync carries out plan and updates lock as each step succeeds. It returns counts of
+// "created", "updated", "deleted", "unchanged" and "failed" steps.
+func applySkillSync(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, plan []skillSyncAction, lock *skillLock) map[string]int {
+	done := map[string]int{}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, a := range plan {
+		switch a.Action {
+		case "unchanged":
+			lock.Skills[a.Name] = skillLockEntry{Path: a.Path, SkillId: a.SkillId, ContentHash: a.Hash, PublishedAt: a.PublishedAt, SyncedAt: now}
+			done["unchanged"]++
+		case "create", "update":
+			fmt.Printf("\n[SYNC] %s %s (%s)...\n", a.Action, a.Name, a.Path)
+			skillId, err := publishSkillDir(ctx, cmd, apiClient, a.Local.Dir, a.SkillId, a.Local.Tags)
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				done["failed"]++
+				continue
+			}
+			lock.Skills[a.Name] = skillLockEntry{Path: a.Path, SkillId: skillId, ContentHash: a.Hash, SyncedAt: now}
+			past := map[string]string{"create": "created", "update": "updated"}[a.Action]
+			fmt.Printf("[OK] %s %s: %s\n", past, a.Name, skillId)
+			done[past]++
+		case "delete":
+			fmt.Printf("\n[SYNC] delete %s (%s)...\n", a.Name, a.SkillId)
+			if err := deletePublishedSkill(ctx, cmd, apiClient, a.SkillId); err != nil {
+				fmt.Printf("%v\n", err)
+				done["failed"]++
+				continue
+			}
+			delete(lock.Skills, a.Name)
+			fmt.Printf("[OK] deleted %s: %s\n", a.Name, a.SkillId)
+			done["deleted"]++
+		}
+	}
+	return done
+}
+
+func runSkillsSync(cmd *cobra.Command, args []string) error {
+	root := filepath.Clean(args[0])
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	prune, _ := cmd.Flags().GetBool("prune")
+	autoYes, _ := cmd.Flags().GetBool("yes")
+	lockPath, _ := cmd.Flags().GetString("lock-file")
+	noValidate, _ := cmd.Flags().GetBool("no-validate")
+	if lockPath == "" {
+		lockPath = filepath.Join(root, skillLockFileName)
+	}
+
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Not a directory: %s", root),
+			"",
+			"[TIP] Usage: agentbay skills sync <root-dir>",
+		)
+	}
+	if _, err := os.Stat(filepath.Join(root, skillFileName)); err == nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %s is itself a skill directory", root),
+			"",
+			"[TIP] Pass the directory that contains your skill folders, or use skills push / skills update for a single skill",
+		)
+	}
+	lock, err := loadSkillLock(lockPath)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+
+	fmt.Printf("[STEP 1/3] Scanning %s...\n", root)
+	locals, err := findLocalSkills(root, !noValidate)
+	if err != nil {
+		return fmt.Errorf("[ERROR] scan %s: %w", root, err)
+	}
+	fmt.Printf("[INFO] Found %d skill director(ies).\n", len(locals))
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx := context.Background()
+
+	fmt.Printf("[STEP 2/3] Listing published skills...\n")
+	var remote []*client.ListMarketSkillByPageResponseBodyDataResult
+	_, err = walkPages(ctx, listPaging{All: true}, pageCursor{PageNo: 1}, skillPages(apiClient, "", nil, 100, io.Discard),
+		func(r *client.ListMarketSkillByPageResponseBodyDataResult) error {
+			remote = append(remote, r)
+			return nil
+		})
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	fmt.Printf("[INFO] %d published skill(s) visible.\n", len(remote))
+
+	fmt.Printf("[STEP 3/3] Comparing content...\n\n")
+	plan := planSkillSync(locals, remote, lock, prune, func(skillId string) (*skillPackage, error) {
+		skill, err := fetchPublishedSkill(ctx, apiClient, skillId)
+		if err != nil {
+			return nil, err
+		}
+		return skill.Package, nil
+	})
+	if len(plan) == 0 {
+		fmt.Printf("[EMPTY] No skill directories found under %s.\n", root)
+		return nil
+	}
+	counts := printSkillSyncPlan(plan)
+
+	if dryRun {
+		fmt.Printf("[INFO] Dry run; nothing was changed.\n")
+		return nil
+	}
+	if counts["delete"] > 0 && !autoYes {
+		confirmed, err := ConfirmPrompt(fmt.Sprintf("Permanently delete %d skill(s) listed above? [y/N]: ", counts["delete"]), autoYes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		if !confirmed {
+			fmt.Printf("[INFO] Operation cancelled.\n")
+			return nil
+		}
+	}
+
+	done := applySkillSync(ctx, cmd, apiClient, plan, lock)
+	if err := saveSkillLock(lockPath, lock); err != nil {
+		return fmt.Errorf("[ERROR] write lock file: %w", err)
+	}
+	fmt.Println()
+	fmt.Printf("[INFO] Lock file: %s\n", lockPath)
+	failed := done["failed"] + counts["error"]
+	summary := fmt.Sprintf("%d created, %d updated, %d deleted, %d unchanged, %d failed",
+		done["created"], done["updated"], done["deleted"], done["unchanged"], failed)
+	if failed > 0 {
+		return fmt.Errorf("[ERROR] Sync finished with failures: %s", summary)
+	}
+	fmt.Printf("[SUCCESS] Sync finished: %s.\n", summary)
+	return nil
+}
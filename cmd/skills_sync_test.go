@@ -0,0 +1,232 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func listedSkill(id, name, modified string) *client.ListMarketSkillByPageResponseBodyDataResult {
+	return &client.ListMarketSkillByPageResponseBodyDataResult{SkillId: dara.String(id), SkillName: dara.String(name), GmtModified: dara.String(modified)}
+}
+
+func skillPackageOf(files map[string]string) *skillPackage {
+	pkg := &skillPackage{Files: map[string]skillFile{}}
+	for name, data := range files {
+		pkg.Files[name] = skillFile{Data: []byte(data)}
+	}
+	return pkg
+}
+
+func TestSkillContentHash(t *testing.T) {
+	a := skillContentHash(map[string][]byte{"SKILL.md": []byte("x"), "b.txt": []byte("y")})
+	assert.Equal(t, a, skillPackageOf(map[string]string{"b.txt": "y", "SKILL.md": "x"}).contentHash())
+	assert.NotEqual(t, a, skillContentHash(map[string][]byte{"SKILL.md": []byte("x"), "c.txt": []byte("y")}), "paths are part of the hash")
+}
+
+func TestFindLocalSkills(t *testing.T) {
+	root := t.TempDir()
+	writeSkillFiles(t, root, map[string]string{
+		"pdf/SKILL.md":              "---\nname: pdf-report\ndescription: d\ntags: [docs]\n---\n",
+		"pdf/nested/SKILL.md":       "---\nname: nested\ndescription: d\n---\n",
+		"group/xlsx/SKILL.md":       "---\nname: xlsx\ndescription: d\n---\n",
+		"bad/SKILL.md":              "---\nname: bad\ndescription: d\n---\n",
+		"bad/.env":                  "TOKEN=x\n",
+		".hidden/SKILL.md":          "---\nname: hidden\ndescription: d\n---\n",
+		"node_modules/x/SKILL.md":   "---\nname: dep\ndescription: d\n---\n",
+		"notes/README.md":           "not a skill\n",
+		"legacy/SKILL.md":           "name: legacy\ndescription: old style\n",
+		skillLockFileName:           "{}",
+		"group/xlsx/scripts/run.sh": "echo\n",
+	})
+
+	skills, err := findLocalSkills(root, true)
+	require.NoError(t, err)
+	byPath := map[string]localSkill{}
+	for _, s := range skills {
+		byPath[s.Path] = s
+	}
+	assert.Len(t, skills, 4, "nested, hidden and node_modules directories are skipped")
+	assert.Equal(t, "pdf-report", byPath["pdf"].Name)
+	assert.Equal(t, []string{"docs"}, byPath["pdf"].Tags)
+	assert.NotEmpty(t, byPath["group/xlsx"].Hash)
+	assert.Contains(t, byPath["bad"].Error, "validation error")
+	assert.Equal(t, "legacy", byPath["legacy"].Name, "old-style frontmatter still yields a name")
+
+	skills, err = findLocalSkills(root, false)
+	require.NoError(t, err)
+	for _, s := range skills {
+		if s.Path == "bad" {
+			assert.Empty(t, s.Error, "--no-validate skips the checks")
+		}
+	}
+}
+
+func TestPlanSkillSync(t *testing.T) {
+	same := map[string]string{"SKILL.md": "same"}
+	sameHash := skillPackageOf(same).contentHash()
+	locals := []localSkill{
+		{Name: "new", Path: "new", Hash: "sha256:new"},
+		{Name: "locked-same", Path: "locked-same", Hash: sameHash},
+		{Name: "locked-changed", Path: "locked-changed", Hash: "sha256:changed"},
+		{Name: "remote-same", Path: "remote-same", Hash: sameHash},
+		{Name: "remote-diff", Path: "remote-diff", Hash: "sha256:local"},
+		{Name: "dup", Path: "dup", Hash: "sha256:x"},
+		{Name: "remote-same", Path: "copy", Hash: sameHash},
+		{Name: "broken", Path: "broken", Error: "SKILL.md: bad"},
+	}
+	remote := []*client.ListMarketSkillByPageResponseBodyDataResult{
+		listedSkill("sk-1", "locked-same", "t1"),
+		listedSkill("sk-2", "renamed-remotely", "t2"),
+		listedSkill("sk-3", "remote-same", "t3"),
+		listedSkill("sk-4", "remote-diff", "t4"),
+		listedSkill("sk-5", "dup", "t5"),
+		listedSkill("sk-6", "dup", "t6"),
+		listedSkill("sk-7", "gone", "t7"),
+	}
+	lock := &skillLock{Skills: map[string]skillLockEntry{
+		"locked-same":    {SkillId: "sk-1", ContentHash: sameHash, PublishedAt: "t1"},
+		"locked-changed": {SkillId: "sk-2", ContentHash: "sha256:old", PublishedAt: "t2"},
+		"gone":           {SkillId: "sk-7", Path: "gone"},
+		"gone-remote":    {SkillId: "sk-99"},
+	}}
+	var fetched []string
+	fetch := func(skillId string) (*skillPackage, error) {
+		fetched = append(fetched, skillId)
+		if skillId == "sk-3" {
+			return skillPackageOf(same), nil
+		}
+		return skillPackageOf(map[string]string{"SKILL.md": "remote"}), nil
+	}
+
+	plan := planSkillSync(locals, remote, lock, true, fetch)
+	got := map[string]string{}
+	for _, a := range plan {
+		got[a.Path] = a.Action + " " + a.SkillId
+	}
+	assert.Equal(t, map[string]string{
+		"new":            "create ",
+		"locked-same":    "unchanged sk-1",
+		"locked-changed": "update sk-2",
+		"remote-same":    "unchanged sk-3",
+		"remote-diff":    "update sk-4",
+		"dup":            "error ",
+		"copy":           "error ",
+		"broken":         "error ",
+		"gone":           "delete sk-7",
+	}, got)
+	assert.Equal(t, []string{"sk-3", "sk-4"}, fetched, "locked skills unchanged since the last sync are not downloaded")
+
+	plan = planSkillSync(nil, remote, lock, false, fetch)
+	assert.Empty(t, plan, "without --prune nothing is deleted")
+}
+
+type mockSkillSyncClient struct {
+	agentbay.Client
+	uploadURL string
+	// fileUrl serves the published package of every skill.
+	fileUrl string
+	created []string
+	updated []string
+	deleted []string
+}
+
+func (m *mockSkillSyncClient) GetMarketSkillCredential(ctx context.Context, req *client.GetMarketSkillCredentialRequest) (*client.GetMarketSkillCredentialResponse, error) {
+	return &client.GetMarketSkillCredentialResponse{Body: &client.GetMarketSkillCredentialResponseBody{Data: &client.GetMarketSkillCredentialResponseBodyData{
+		OssUrl: dara.String(m.uploadURL), OssBucket: dara.String("bucket"), OssFilePath: dara.String("null/" + *req.FileName),
+	}}}, nil
+}
+
+func (m *mockSkillSyncClient) CreateMarketSkill(ctx context.Context, req *client.CreateMarketSkillRequest) (*client.CreateMarketSkillResponse, error) {
+	m.created = append(m.created, *req.OssFilePath)
+	id := fmt.Sprintf("sk-new-%d", len(m.created))
+	return &client.CreateMarketSkillResponse{Body: &client.CreateMarketSkillResponseBody{Data: &client.CreateMarketSkillResponseBodyData{SkillId: &id}}}, nil
+}
+
+func (m *mockSkillSyncClient) UpdateMarketSkill(ctx context.Context, req *client.UpdateMarketSkillRequest) (*client.CreateMarketSkillResponse, error) {
+	m.updated = append(m.updated, *req.SkillId)
+	return &client.CreateMarketSkillResponse{Body: &client.CreateMarketSkillResponseBody{}}, nil
+}
+
+func (m *mockSkillSyncClient) DescribeMarketSkillDetail(ctx context.Context, req *client.DescribeMarketSkillDetailRequest) (*client.DescribeMarketSkillDetailResponse, error) {
+	return (&mockSkillDetailClient{fileUrl: m.fileUrl}).DescribeMarketSkillDetail(ctx, req)
+}
+
+func (m *mockSkillSyncClient) DeleteMarketSkill(ctx context.Context, req *client.DeleteMarketSkillRequest) (*client.DeleteMarketSkillResponse, error) {
+	m.deleted = append(m.deleted, *req.SkillId)
+	return &client.DeleteMarketSkillResponse{Body: &client.DeleteMarketSkillResponseBody{Code: dara.String("ok")}}, nil
+}
+
+func TestApplySkillSync(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	var uploads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		uploads++
+	}))
+	t.Cleanup(srv.Close)
+
+	root := t.TempDir()
+	writeSkillFiles(t, root, map[string]string{
+		"pdf/SKILL.md":  "---\nname: pdf-report\ndescription: d\n---\n",
+		"xlsx/SKILL.md": "---\nname: xlsx\ndescription: d\n---\n",
+	})
+	locals, err := findLocalSkills(root, true)
+	require.NoError(t, err)
+	plan := []skillSyncAction{
+		{Action: "create", Name: "pdf-report", Path: "pdf", Hash: locals[0].Hash, Local: &locals[0]},
+		{Action: "update", Name: "xlsx", Path: "xlsx", SkillId: "sk-2", Hash: locals[1].Hash, Local: &locals[1]},
+		{Action: "delete", Name: "old", SkillId: "sk-9"},
+		{Action: "error", Name: "bad", Reason: "x"},
+	}
+	lock := &skillLock{Version: 1, Skills: map[string]skillLockEntry{"old": {SkillId: "sk-9"}}}
+	published := serveSkillZip(t, zipEntries(t, map[string]string{"SKILL.md": "---\nname: xlsx\ndescription: d\n---\n"}))
+	mock := &mockSkillSyncClient{uploadURL: srv.URL + "/upload", fileUrl: published.URL}
+
+	var done map[string]int
+	captureImageCreateFromTemplateStdout(t, func() {
+		done = applySkillSync(context.Background(), skillsSyncCmd, mock, plan, lock)
+	})
+	assert.Equal(t, map[string]int{"created": 1, "updated": 1, "deleted": 1}, done)
+	assert.Equal(t, 2, uploads)
+	assert.Equal(t, []string{"pdf.zip"}, mock.created, `the "null/" path prefix is stripped`)
+	assert.Equal(t, []string{"sk-2"}, mock.updated)
+	assert.Equal(t, []string{"sk-9"}, mock.deleted)
+	assert.Equal(t, "sk-new-1", lock.Skills["pdf-report"].SkillId)
+	assert.Equal(t, locals[1].Hash, lock.Skills["xlsx"].ContentHash)
+	assert.NotContains(t, lock.Skills, "old")
+	history, err := loadSkillHistory()
+	require.NoError(t, err)
+	assert.Equal(t, "sync", history.get("sk-2").Releases[0].Source, "published packages are kept in the local history")
+
+	lockPath := filepath.Join(root, skillLockFileName)
+	require.NoError(t, os.WriteFile(lockPath, []byte("{}"), 0600))
+	require.NoError(t, saveSkillLock(lockPath, lock))
+	info, err := os.Stat(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm(), "the lock file is replaced, not rewritten in place")
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "no temporary file is left next to the lock")
+	loaded, err := loadSkillLock(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, lock, loaded)
+	empty, err := loadSkillLock(filepath.Join(root, "missing.lock"))
+	require.NoError(t, err)
+	assert.Empty(t, empty.Skills)
+}
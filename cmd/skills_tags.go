@@ -0,0 +1,352 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var skillsTagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Manage skill tags",
+	Long: `List, create and delete the tags that can be attached to skills.
+
+push and update create missing tags implicitly; these commands manage them directly.`,
+}
+
+var skillsTagsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tags with the number of skills using each",
+	Long: `List the tags of your account. The SKILLS column counts the visible skills that carry
+each tag; use --unused to find tags that no skill uses any more.`,
+	Args: cobra.NoArgs,
+	RunE: runSkillsTagsList,
+}
+
+var skillsTagsCreateCmd = &cobra.Command{
+	Use:   "create <tag>...",
+	Short: "Create one or more tags",
+	Long:  `Create tags that do not exist yet. Existing tags are reported and left unchanged.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runSkillsTagsCreate,
+}
+
+var skillsTagsDeleteCmd = &cobra.Command{
+	Use:   "delete <tag>...",
+	Short: "Remove tags from every skill that uses them",
+	Long: `Remove tags from every skill that carries them, keeping the other tags of each skill.
+
+The AgentBay OpenAPI has no action to delete a tag definition, so the tag itself stays in
+"skills tags list" with a count of 0.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSkillsTagsDelete,
+}
+
+func init() {
+	skillsTagsListCmd.Flags().Bool("unused", false, "Only show tags that no skill uses")
+	skillsTagsListCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+	skillsTagsDeleteCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+
+	skillsUpdateCmd.Flags().StringArray("add-tag", nil, "Add a tag, keeping the existing ones (can be specified multiple times)")
+	skillsUpdateCmd.Flags().StringArray("remove-tag", nil, "Remove a tag, keeping the others (can be specified multiple times)")
+
+	skillsTagsCmd.AddCommand(skillsTagsListCmd)
+	skillsTagsCmd.AddCommand(skillsTagsCreateCmd)
+	skillsTagsCmd.AddCommand(skillsTagsDeleteCmd)
+	SkillsCmd.AddCommand(skillsTagsCmd)
+}
+
+// skillTagItem is one row of "skills tags list".
+type skillTagItem struct {
+	TagName    string `json:"tagName"`
+	TagId      string `json:"tagId"`
+	SkillCount int    `json:"skillCount"`
+}
+
+// trimTagArgs trims tag names and drops empty and repeated ones, keeping the order.
+func trimTagArgs(values []string) []string {
+	var out []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// editSkillTags applies --add-tag and --remove-tag to current. Existing tags keep their order
+// and added tags go at the end.
+func editSkillTags(current, add, remove []string) []string {
+	out := []string{}
+	for _, t := range append(slices.Clone(current), add...) {
+		if !slices.Contains(remove, t) && !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// listAllSkills walks every page of ListMarketSkillByPage.
+func listAllSkills(ctx context.Context, apiClient agentbay.Client, progress io.Writer) ([]*client.ListMarketSkillByPageResponseBodyDataResult, error) {
+	var skills []*client.ListMarketSkillByPageResponseBodyDataResult
+	_, err := walkPages(ctx, listPaging{All: true}, pageCursor{PageNo: 1}, skillPages(apiClient, "", nil, 100, progress),
+		func(r *client.ListMarketSkillByPageResponseBodyDataResult) error {
+			if r != nil {
+				skills = append(skills, r)
+			}
+			return nil
+		})
+	return skills, err
+}
+
+// countSkillTags joins the tag list with the tags carried by skills. Tags that skills carry but
+// ListTag does not return are added with an empty ID.
+func countSkillTags(tags []client.ListTagResponseBodyDataItem, skills []*client.ListMarketSkillByPageResponseBodyDataResult) []skillTagItem {
+	counts := map[string]int{}
+	for _, s := range skills {
+		for _, t := range trimTagArgs(s.TenantTags) {
+			counts[t]++
+		}
+	}
+	var items []skillTagItem
+	seen := map[string]bool{}
+	for _, t := range tags {
+		name := strPtr(t.TagName)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		items = append(items, skillTagItem{TagName: name, TagId: strPtr(t.TagId), SkillCount: counts[name]})
+	}
+	for name, n := range counts {
+		if !seen[name] {
+			items = append(items, skillTagItem{TagName: name, SkillCount: n})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].TagName < items[j].TagName })
+	return items
+}
+
+func runSkillsTagsList(cmd *cobra.Command, args []string) error {
+	unused, _ := cmd.Flags().GetBool("unused")
+	outputFmt, _ := cmd.Flags().GetString("output")
+	progress := io.Writer(os.Stdout)
+	if outputFmt == "json" {
+		progress = os.Stderr
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx := context.Background()
+
+	tags, err := listSkillTags(ctx, cmd, apiClient, progress)
+	if err != nil {
+		return err
+	}
+	skills, err := listAllSkills(ctx, apiClient, io.Discard)
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	items := countSkillTags(tags, skills)
+	if unused {
+		items = slices.DeleteFunc(items, func(it skillTagItem) bool { return it.SkillCount > 0 })
+	}
+
+	if outputFmt == "json" {
+		if items == nil {
+			items = []skillTagItem{}
+		}
+		data, err := json.MarshalIndent(map[string]any{"tags": items}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	if len(items) == 0 {
+		if unused {
+			fmt.Printf("[EMPTY] Every tag is used by at least one skill.\n")
+		} else {
+			fmt.Printf("[EMPTY] No tags found.\n")
+		}
+		return nil
+	}
+	nameW, idW := len("TAG"), len("TAG ID")
+	for _, it := range items {
+		nameW = max(nameW, len(it.TagName))
+		idW = max(idW, len(it.TagId))
+	}
+	fmt.Printf("%-*s  %-*s  %s\n", nameW, "TAG", idW, "TAG ID", "SKILLS")
+	for _, it := range items {
+		id := it.TagId
+		if id == "" {
+			id = "-"
+		}
+		fmt.Printf("%-*s  %-*s  %d\n", nameW, it.TagName, idW, id, it.SkillCount)
+	}
+	fmt.Printf("\n[INFO] %d tag(s), counted over %d visible skill(s).\n", len(items), len(skills))
+	return nil
+}
+
+func runSkillsTagsCreate(cmd *cobra.Command, args []string) error {
+	names := trimTagArgs(args)
+	if len(names) == 0 {
+		return fmt.Errorf("[ERROR] Tag names must not be empty")
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx := context.Background()
+
+	existing, err := listSkillTags(ctx, cmd, apiClient, os.Stdout)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, name := range names {
+		if slices.ContainsFunc(existing, func(t client.ListTagResponseBodyDataItem) bool { return strPtr(t.TagName) == name }) {
+			fmt.Printf("[INFO] Tag %s already exists.\n", name)
+			continue
+		}
+		missing = append(missing, name)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	resp, err := apiClient.CreateTag(ctx, &client.CreateTagRequest{TagList: missing})
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] Failed to create tags: %w", err)
+	}
+	if resp.Body != nil && resp.Body.GetRequestId() != "" {
+		fmt.Printf("[INFO] CreateTag RequestId: %s\n", resp.Body.GetRequestId())
+	}
+	fmt.Printf("[SUCCESS] ✅ Created %d tag(s): %s\n", len(missing), strings.Join(missing, ", "))
+	return nil
+}
+
+func runSkillsTagsDelete(cmd *cobra.Command, args []string) error {
+	names := trimTagArgs(args)
+	autoYes, _ := cmd.Flags().GetBool("yes")
+	if len(names) == 0 {
+		return fmt.Errorf("[ERROR] Tag names must not be empty")
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx := context.Background()
+
+	fmt.Printf("[STEP 1/3] Checking tags...\n")
+	existing, err := listSkillTags(ctx, cmd, apiClient, os.Stdout)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(existing, func(t client.ListTagResponseBodyDataItem) bool { return strPtr(t.TagName) == name }) {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Tag not found: %s", name),
+				"",
+				"[TIP] List tags with: agentbay skills tags list",
+			)
+		}
+	}
+
+	fmt.Printf("[STEP 2/3] Finding skills that use the tag(s)...\n")
+	skills, err := listAllSkills(ctx, apiClient, io.Discard)
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	var affected []*client.ListMarketSkillByPageResponseBodyDataResult
+	for _, s := range skills {
+		if slices.ContainsFunc(s.TenantTags, func(t string) bool { return slices.Contains(names, strings.TrimSpace(t)) }) {
+			affected = append(affected, s)
+		}
+	}
+	if len(affected) == 0 {
+		fmt.Printf("[INFO] No skill uses %s.\n", strings.Join(names, ", "))
+		printTagDefinitionKept()
+		return nil
+	}
+	for _, s := range affected {
+		fmt.Printf("  %s  %s  [%s]\n", strPtr(s.SkillId), strPtr(s.SkillName), strings.Join(s.TenantTags, ", "))
+	}
+	fmt.Println()
+	confirmed, err := ConfirmPrompt(fmt.Sprintf("Remove %s from %d skill(s) listed above? [y/N]: ", strings.Join(names, ", "), len(affected)), autoYes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if !confirmed {
+		fmt.Printf("[INFO] Operation cancelled.\n")
+		return nil
+	}
+
+	fmt.Printf("[STEP 3/3] Removing tags...\n")
+	failed := 0
+	for _, s := range affected {
+		skillId := strPtr(s.SkillId)
+		if err := setSkillTags(ctx, cmd, apiClient, skillId, editSkillTags(trimTagArgs(s.TenantTags), nil, names)); err != nil {
+			fmt.Printf("[WARN] %s (%s): %v\n", skillId, strPtr(s.SkillName), err)
+			failed++
+			continue
+		}
+		fmt.Printf("[OK] %s (%s)\n", skillId, strPtr(s.SkillName))
+	}
+	printTagDefinitionKept()
+	if failed > 0 {
+		return fmt.Errorf("[ERROR] Failed to update %d of %d skill(s)", failed, len(affected))
+	}
+	fmt.Printf("[SUCCESS] ✅ Removed %s from %d skill(s).\n", strings.Join(names, ", "), len(affected))
+	return nil
+}
+
+func printTagDefinitionKept() {
+	fmt.Printf("[INFO] Tag definitions are kept: the AgentBay OpenAPI has no action to delete them.\n")
+}
+
+// setSkillTags replaces the tags of skillId; an empty list clears them.
+func setSkillTags(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, skillId string, tags []string) error {
+	req := &client.UpdateMarketSkillRequest{SkillId: &skillId, TagList: tags}
+	if req.TagList == nil {
+		req.TagList = []string{}
+	}
+	var resp *client.CreateMarketSkillResponse
+	err := withTransientRetry(ctx, client.DefaultRetryConfig(), "UpdateMarketSkill", func() error {
+		var e error
+		resp, e = apiClient.UpdateMarketSkill(ctx, req)
+		return e
+	})
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return err
+	}
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose && resp.Body != nil && resp.Body.RequestId != nil {
+		fmt.Fprintf(os.Stderr, "[DEBUG] UpdateMarketSkill RequestId: %s\n", *resp.Body.RequestId)
+	}
+	return nil
+}
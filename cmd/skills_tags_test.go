@@ -0,0 +1,59 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestEditSkillTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "c", "d"}, editSkillTags([]string{"a", "b", "c"}, []string{"c", "d"}, []string{"b"}))
+	assert.Equal(t, []string{}, editSkillTags([]string{"a"}, nil, []string{"a"}), "removing the last tag yields an empty list")
+	assert.Equal(t, []string{"x"}, editSkillTags(nil, []string{"x", "x"}, nil))
+	assert.Equal(t, []string{"a", "b"}, trimTagArgs([]string{" a ", "", "b", "a"}))
+}
+
+func TestCountSkillTags(t *testing.T) {
+	tags := []client.ListTagResponseBodyDataItem{
+		{TagName: dara.String("docs"), TagId: dara.String("t-1")},
+		{TagName: dara.String("unused"), TagId: dara.String("t-2")},
+	}
+	skills := []*client.ListMarketSkillByPageResponseBodyDataResult{
+		{SkillId: dara.String("sk-1"), TenantTags: []string{"docs", "public"}},
+		{SkillId: dara.String("sk-2"), TenantTags: []string{"docs"}},
+	}
+	assert.Equal(t, []skillTagItem{
+		{TagName: "docs", TagId: "t-1", SkillCount: 2},
+		{TagName: "public", SkillCount: 1},
+		{TagName: "unused", TagId: "t-2", SkillCount: 0},
+	}, countSkillTags(tags, skills))
+}
+
+type mockSkillTagClient struct {
+	agentbay.Client
+	tagLists [][]string
+}
+
+func (m *mockSkillTagClient) UpdateMarketSkill(ctx context.Context, req *client.UpdateMarketSkillRequest) (*client.CreateMarketSkillResponse, error) {
+	m.tagLists = append(m.tagLists, req.TagList)
+	return &client.CreateMarketSkillResponse{Body: &client.CreateMarketSkillResponseBody{}}, nil
+}
+
+func TestSetSkillTags(t *testing.T) {
+	mock := &mockSkillTagClient{}
+	require.NoError(t, setSkillTags(context.Background(), skillsTagsDeleteCmd, mock, "sk-1", []string{"a"}))
+	require.NoError(t, setSkillTags(context.Background(), skillsTagsDeleteCmd, mock, "sk-1", nil))
+	require.Len(t, mock.tagLists, 2)
+	assert.Equal(t, []string{"a"}, mock.tagLists[0])
+	assert.NotNil(t, mock.tagLists[1], "an empty list is sent so the API clears the tags")
+	assert.Empty(t, mock.tagLists[1])
+}
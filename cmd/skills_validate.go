@@ -0,0 +1,484 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Package limits enforced by "skills validate" and before "skills push/update".
+const (
+	skillMaxFileSize    = 10_000_000
+	skillMaxTotalSize   = 50_000_000
+	skillMaxFiles       = 1000
+	skillMaxDescription = 1024
+	skillMaxBodyLines   = 500
+)
+
+var (
+	skillNameRe    = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,62}[a-z0-9])?$`)
+	skillVersionRe = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+	// Markdown links and images: [text](target) / ![alt](target "title").
+	skillLinkRe = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+)
+
+// skillAssetExts are binary file types a skill may carry as resources.
+var skillAssetExts = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".ico": true, ".bmp": true,
+	".pdf": true, ".woff": true, ".woff2": true, ".ttf": true, ".otf": true,
+	".docx": true, ".xlsx": true, ".pptx": true,
+}
+
+var skillValidateCmd = &cobra.Command{
+	Use:   "validate <skill-dir>",
+	Short: "Check a skill directory before pushing it",
+	Long: `Check a skill directory the way "skills push" and "skills update" do before uploading:
+
+  SKILL.md     present, valid UTF-8, YAML frontmatter between "---" lines
+  frontmatter  name (lowercase letters, digits and hyphens, at most 64 characters) and
+               description (at most 1024 characters) are required; version must be
+               semantic (1.2.3); license, author, tags, allowed-tools and metadata are
+               optional; unknown fields are warned about
+  references   relative links in SKILL.md must point at files inside the directory
+  limits       at most 1000 files, 10 MB per file and 50 MB in total
+  forbidden    .env files, .git directories, private keys, symlinks and executables or
+               other binaries (images, fonts, PDF and Office documents are allowed)
+  encoding     text files must be UTF-8
+
+Errors fail the command; warnings fail it only with --strict.
+
+Examples:
+  agentbay skills validate ./my-skill
+  agentbay skills validate ./my-skill --strict -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsValidate,
+}
+
+func init() {
+	skillValidateCmd.Flags().Bool("strict", false, "Fail on warnings too")
+	skillValidateCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+
+	SkillsCmd.AddCommand(skillValidateCmd)
+}
+
+// skillManifest is the YAML frontmatter of SKILL.md.
+type skillManifest struct {
+	Name         string                 `yaml:"name" json:"name"`
+	Description  string                 `yaml:"description" json:"description"`
+	Version      string                 `yaml:"version,omitempty" json:"version,omitempty"`
+	License      string                 `yaml:"license,omitempty" json:"license,omitempty"`
+	Author       string                 `yaml:"author,omitempty" json:"author,omitempty"`
+	Tags         []string               `yaml:"tags,omitempty" json:"tags,omitempty"`
+	AllowedTools []string               `yaml:"allowed-tools,omitempty" json:"allowedTools,omitempty"`
+	Metadata     map[string]interface{} `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+var skillManifestFields = map[string]bool{
+	"name": true, "description": true, "version": true, "license": true,
+	"author": true, "tags": true, "allowed-tools": true, "metadata": true,
+}
+
+// splitSkillFrontmatter returns the YAML between the leading "---" lines of SKILL.md and the
+// Markdown body after them.
+func splitSkillFrontmatter(content []byte) (front, body []byte, err error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, nil, fmt.Errorf("%s must start with a YAML frontmatter block opened by a \"---\" line", skillFileName)
+	}
+	rest := text[len("---\n"):]
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n---") {
+			return []byte(rest[:len(rest)-len("\n---")]), nil, nil
+		}
+		return nil, nil, fmt.Errorf("%s frontmatter is not closed by a \"---\" line", skillFileName)
+	}
+	return []byte(rest[:end]), []byte(rest[end+len("\n---\n"):]), nil
+}
+
+// parseSkillManifest parses the frontmatter of SKILL.md as YAML. It returns the manifest, the
+// Markdown body and the names of fields it does not know.
+func parseSkillManifest(content []byte) (*skillManifest, []byte, []string, error) {
+	front, body, err := splitSkillFrontmatter(content)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(front, &raw); err != nil {
+		return nil, nil, nil, fmt.Errorf("%s frontmatter is not valid YAML: %w", skillFileName, err)
+	}
+	var unknown []string
+	for k := range raw {
+		if !skillManifestFields[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+
+	m := &skillManifest{}
+	// allowed-tools may be a list or a space/comma separated string.
+	if n, ok := raw["allowed-tools"]; ok && n.Kind == yaml.ScalarNode {
+		m.AllowedTools = strings.FieldsFunc(n.Value, func(r rune) bool { return r == ',' || r == ' ' })
+		delete(raw, "allowed-tools")
+		front, _ = yaml.Marshal(raw)
+	}
+	if err := yaml.Unmarshal(front, m); err != nil {
+		return nil, nil, nil, fmt.Errorf("%s frontmatter: %w", skillFileName, err)
+	}
+	return m, body, unknown, nil
+}
+
+// skillFinding is one problem found in a skill package.
+type skillFinding struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path,omitempty"`
+	Message  string `json:"message"`
+}
+
+// skillValidation is the result of validateSkillDir.
+type skillValidation struct {
+	Dir      string         `json:"dir"`
+	Manifest *skillManifest `json:"manifest,omitempty"`
+	Files    int            `json:"files"`
+	Size     int64          `json:"size"`
+	Findings []skillFinding `json:"findings"`
+}
+
+func (v *skillValidation) add(severity, path, format string, a ...interface{}) {
+	v.Findings = append(v.Findings, skillFinding{Severity: severity, Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+func (v *skillValidation) counts() (errs, warnings int) {
+	for _, f := range v.Findings {
+		if f.Severity == "error" {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	return errs, warnings
+}
+
+// validateSkillDir checks a skill directory; it returns an error only when dir cannot be read.
+func validateSkillDir(dir string) (*skillValidation, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	v := &skillValidation{Dir: dir}
+
+	files := map[string]bool{}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		base := d.Name()
+		if d.IsDir() {
+			switch base {
+			case ".git", ".svn", ".hg":
+				v.add("error", rel+"/", "version control directory must not be packaged")
+				return filepath.SkipDir
+			case "node_modules", "__pycache__", ".venv", "venv":
+				v.add("warning", rel+"/", "dependency or cache directory is usually not meant to be packaged")
+			}
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			v.add("error", rel, "symlinks are not supported; copy the file into the skill instead")
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[rel] = true
+		v.Files++
+		v.Size += fi.Size()
+		checkSkillFile(v, p, rel, base, fi)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if v.Files > skillMaxFiles {
+		v.add("error", "", "%d files exceed the limit of %d", v.Files, skillMaxFiles)
+	}
+	if v.Size > skillMaxTotalSize {
+		v.add("error", "", "total size %s exceeds the limit of %s", formatBytes(v.Size), formatBytes(skillMaxTotalSize))
+	}
+
+	checkSkillManifest(v, dir, files)
+	sort.SliceStable(v.Findings, func(i, j int) bool {
+		if v.Findings[i].Severity != v.Findings[j].Severity {
+			return v.Findings[i].Severity == "error"
+		}
+		return v.Findings[i].Path < v.Findings[j].Path
+	})
+	return v, nil
+}
+
+func checkSkillFile(v *skillValidation, p, rel, base string, fi fs.FileInfo) {
+	lower := strings.ToLower(base)
+	switch {
+	case lower == ".env" || strings.HasPrefix(lower, ".env."):
+		if lower != ".env.example" && lower != ".env.sample" {
+			v.add("error", rel, "environment files may hold secrets and must not be packaged")
+			return
+		}
+	case lower == "id_rsa" || lower == "id_ed25519" || lower == "id_ecdsa" ||
+		strings.HasSuffix(lower, ".pem") || strings.HasSuffix(lower, ".key") || strings.HasSuffix(lower, ".p12") || strings.HasSuffix(lower, ".pfx"):
+		v.add("error", rel, "private keys and certificates must not be packaged")
+		return
+	case lower == ".ds_store" || lower == "thumbs.db":
+		v.add("warning", rel, "operating system metadata file")
+		return
+	}
+	if fi.Size() > skillMaxFileSize {
+		v.add("error", rel, "file size %s exceeds the limit of %s", formatBytes(fi.Size()), formatBytes(skillMaxFileSize))
+		return
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		v.add("error", rel, "cannot read file: %v", err)
+		return
+	}
+	defer f.Close()
+	head := make([]byte, 8192)
+	n, _ := f.Read(head)
+	head = head[:n]
+
+	if isExecutableBinary(head) {
+		v.add("error", rel, "executable binaries must not be packaged; ship source or scripts instead")
+		return
+	}
+	if bytes.IndexByte(head, 0) >= 0 {
+		if !skillAssetExts[strings.ToLower(filepath.Ext(base))] {
+			v.add("error", rel, "binary file type is not allowed (allowed: images, fonts, PDF and Office documents)")
+		}
+		return
+	}
+	if skillAssetExts[strings.ToLower(filepath.Ext(base))] {
+		return
+	}
+	content, err := os.ReadFile(p)
+	if err != nil {
+		v.add("error", rel, "cannot read file: %v", err)
+		return
+	}
+	if bytes.HasPrefix(content, []byte("\xef\xbb\xbf")) {
+		v.add("warning", rel, "file starts with a UTF-8 byte order mark")
+	}
+	if !utf8.Valid(content) {
+		v.add("error", rel, "text file is not valid UTF-8")
+	}
+}
+
+// isExecutableBinary reports whether head starts like an ELF, PE or Mach-O executable.
+func isExecutableBinary(head []byte) bool {
+	for _, magic := range [][]byte{
+		[]byte("\x7fELF"), []byte("MZ"),
+		{0xfe, 0xed, 0xfa, 0xce}, {0xfe, 0xed, 0xfa, 0xcf}, {0xce, 0xfa, 0xed, 0xfe}, {0xcf, 0xfa, 0xed, 0xfe}, {0xca, 0xfe, 0xba, 0xbe},
+	} {
+		if bytes.HasPrefix(head, magic) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkSkillManifest(v *skillValidation, dir string, files map[string]bool) {
+	if !files[skillFileName] {
+		v.add("error", skillFileName, "file not found")
+		return
+	}
+	content, err := os.ReadFile(filepath.Join(dir, skillFileName))
+	if err != nil {
+		v.add("error", skillFileName, "cannot read file: %v", err)
+		return
+	}
+	m, body, unknown, err := parseSkillManifest(content)
+	if err != nil {
+		v.add("error", skillFileName, "%v", err)
+		return
+	}
+	v.Manifest = m
+
+	switch {
+	case m.Name == "":
+		v.add("error", skillFileName, "frontmatter field \"name\" is required")
+	case !skillNameRe.MatchString(m.Name):
+		v.add("error", skillFileName, "name %q must be 1-64 lowercase letters, digits or hyphens, not starting or ending with a hyphen", m.Name)
+	}
+	switch desc := strings.TrimSpace(m.Description); {
+	case desc == "":
+		v.add("error", skillFileName, "frontmatter field \"description\" is required")
+	case utf8.RuneCountInString(desc) > skillMaxDescription:
+		v.add("error", skillFileName, "description is %d characters, the limit is %d", utf8.RuneCountInString(desc), skillMaxDescription)
+	}
+	if m.Version != "" && !skillVersionRe.MatchString(m.Version) {
+		v.add("error", skillFileName, "version %q is not a semantic version such as 1.2.0", m.Version)
+	}
+	for _, tag := range m.Tags {
+		if strings.TrimSpace(tag) == "" {
+			v.add("error", skillFileName, "tags must not be empty")
+			break
+		}
+	}
+	for _, k := range unknown {
+		v.add("warning", skillFileName, "unknown frontmatter field %q", k)
+	}
+	if lines := bytes.Count(body, []byte("\n")); lines > skillMaxBodyLines {
+		v.add("warning", skillFileName, "body has %d lines; keep it under %d and move details into referenced files", lines, skillMaxBodyLines)
+	}
+
+	for _, target := range skillReferences(body) {
+		ref := path.Clean(target)
+		if path.IsAbs(ref) || ref == ".." || strings.HasPrefix(ref, "../") {
+			v.add("error", skillFileName, "reference %q points outside the skill directory", target)
+			continue
+		}
+		if !files[ref] && !skillDirExists(dir, ref) {
+			v.add("error", skillFileName, "referenced file %q does not exist", target)
+		}
+	}
+}
+
+// skillReferences returns the relative link targets in a SKILL.md body, without anchors or queries.
+func skillReferences(body []byte) []string {
+	var refs []string
+	seen := map[string]bool{}
+	inFence := false
+	for _, line := range strings.Split(string(body), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, m := range skillLinkRe.FindAllStringSubmatch(line, -1) {
+			target := m[1]
+			if strings.HasPrefix(target, "#") || strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:") {
+				continue
+			}
+			if i := strings.IndexAny(target, "#?"); i >= 0 {
+				target = target[:i]
+			}
+			if unescaped, err := url.PathUnescape(target); err == nil {
+				target = unescaped
+			}
+			if target != "" && !seen[target] {
+				seen[target] = true
+				refs = append(refs, target)
+			}
+		}
+	}
+	return refs
+}
+
+func skillDirExists(dir, rel string) bool {
+	fi, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel)))
+	return err == nil && fi.IsDir()
+}
+
+// printSkillValidation prints the findings of v and a one-line summary.
+func printSkillValidation(v *skillValidation) {
+	for _, f := range v.Findings {
+		tag := "[ERROR]"
+		if f.Severity != "error" {
+			tag = "[WARN]"
+		}
+		if f.Path != "" {
+			fmt.Printf("%s %s: %s\n", tag, f.Path, f.Message)
+		} else {
+			fmt.Printf("%s %s\n", tag, f.Message)
+		}
+	}
+	errs, warnings := v.counts()
+	name := "(unnamed)"
+	if v.Manifest != nil && v.Manifest.Name != "" {
+		name = v.Manifest.Name
+	}
+	if errs == 0 && warnings == 0 {
+		fmt.Printf("[OK] Skill %s is valid: %d file(s), %s.\n", name, v.Files, formatBytes(v.Size))
+		return
+	}
+	fmt.Printf("[INFO] Skill %s: %d file(s), %s, %d error(s), %d warning(s).\n", name, v.Files, formatBytes(v.Size), errs, warnings)
+}
+
+// checkSkillDirBeforeUpload validates dir for skills push/update and returns an error when the
+// package has errors.
+func checkSkillDirBeforeUpload(dir string) error {
+	v, err := validateSkillDir(dir)
+	if err != nil {
+		return fmt.Errorf("[ERROR] validate skill: %w", err)
+	}
+	errs, warnings := v.counts()
+	if errs == 0 && warnings == 0 {
+		return nil
+	}
+	printSkillValidation(v)
+	if errs > 0 {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Skill package %s has %d error(s); nothing was uploaded", dir, errs),
+			"",
+			"[TIP] Fix the errors above, or pass --no-validate to upload anyway",
+		)
+	}
+	return nil
+}
+
+func runSkillsValidate(cmd *cobra.Command, args []string) error {
+	strict, _ := cmd.Flags().GetBool("strict")
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	v, err := validateSkillDir(filepath.Clean(args[0]))
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	errs, warnings := v.counts()
+	if strings.EqualFold(outputFmt, "json") {
+		if v.Findings == nil {
+			v.Findings = []skillFinding{}
+		}
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		fmt.Println(string(b))
+	} else {
+		printSkillValidation(v)
+	}
+	if errs > 0 || (strict && warnings > 0) {
+		return fmt.Errorf("[ERROR] skill validation failed: %d error(s), %d warning(s)", errs, warnings)
+	}
+	return nil
+}
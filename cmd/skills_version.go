@@ -0,0 +1,542 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// skills_version.go adds versioning on top of UpdateMarketSkill, which overwrites a skill in
+// place. push, update and sync read "version" from the SKILL.md frontmatter and refuse to
+// replace a skill with a version that is not higher than every version published for it before,
+// as recorded locally or in the package the skill currently serves.
+// Each published zip is kept with its SHA-256 in the skill_history directory of the CLI config
+// directory, so "skills rollback" can upload an earlier package again and "skills history" can
+// list what was published.
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+const skillHistoryDirName = "skill_history"
+
+var skillsRollbackCmd = &cobra.Command{
+	Use:   "rollback <skill-id>",
+	Short: "Re-publish an earlier version of a skill from local history",
+	Long: `Replace a skill with a package published earlier from this machine.
+
+push, update and sync keep every uploaded zip in the local skill history. rollback checks the
+stored package against its recorded SHA-256 and uploads it again through the same credential
+and OSS upload path as "skills update". Tags and icon are left unchanged.
+
+Examples:
+  agentbay skills history 35U2Ver2
+  agentbay skills rollback 35U2Ver2 --to 1.2.0`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsRollback,
+}
+
+var skillsHistoryCmd = &cobra.Command{
+	Use:   "history <skill-id>",
+	Short: "List the versions of a skill published from this machine",
+	Long: `List the releases of a skill recorded in the local skill history, oldest first.
+The last row is the package currently published, as far as this machine knows.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsHistory,
+}
+
+func init() {
+	skillsPushCmd.Flags().String("changelog", "", "Changelog note recorded with this version in the local skill history")
+	skillsUpdateCmd.Flags().String("changelog", "", "Changelog note recorded with this version in the local skill history")
+	skillsUpdateCmd.Flags().Bool("skip-version-check", false, "Allow a version that is not higher than the versions published before")
+
+	skillsRollbackCmd.Flags().String("to", "", "Version to restore (required)")
+	_ = skillsRollbackCmd.MarkFlagRequired("to")
+	skillsRollbackCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+
+	skillsHistoryCmd.Flags().StringP("output", "o", "", `Output format. Use "json" for machine-readable output`)
+
+	skillsRollbackCmd.ValidArgsFunction = completeOnlyArg(completionSkills, nil)
+	skillsHistoryCmd.ValidArgsFunction = completeOnlyArg(completionSkills, nil)
+
+	SkillsCmd.AddCommand(skillsRollbackCmd)
+	SkillsCmd.AddCommand(skillsHistoryCmd)
+}
+
+// skillSemver is a parsed semantic version; build metadata is dropped as it has no precedence.
+type skillSemver struct {
+	major, minor, patch int
+	pre                 []string
+}
+
+func parseSkillSemver(s string) (skillSemver, error) {
+	var v skillSemver
+	if !skillVersionRe.MatchString(s) {
+		return v, fmt.Errorf("version %q is not a semantic version such as 1.2.0", s)
+	}
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, _ := strings.Cut(s, "-")
+	parts := strings.Split(core, ".")
+	nums := []*int{&v.major, &v.minor, &v.patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return v, fmt.Errorf("version %q: %w", s, err)
+		}
+		*nums[i] = n
+	}
+	if pre != "" {
+		v.pre = strings.Split(pre, ".")
+	}
+	return v, nil
+}
+
+// compare orders versions by semver precedence: a pre-release sorts before its release, and
+// pre-release identifiers compare numerically when both are numbers.
+func (a skillSemver) compare(b skillSemver) int {
+	if c := cmp.Compare(a.major, b.major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.minor, b.minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.patch, b.patch); c != 0 {
+		return c
+	}
+	switch {
+	case len(a.pre) == 0 && len(b.pre) == 0:
+		return 0
+	case len(a.pre) == 0:
+		return 1
+	case len(b.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		x, y := a.pre[i], b.pre[i]
+		xn, xerr := strconv.Atoi(x)
+		yn, yerr := strconv.Atoi(y)
+		switch {
+		case xerr == nil && yerr == nil:
+			if xn != yn {
+				return cmp.Compare(xn, yn)
+			}
+		case xerr == nil:
+			return -1
+		case yerr == nil:
+			return 1
+		default:
+			if c := strings.Compare(x, y); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(a.pre), len(b.pre))
+}
+
+// skillHistoryStore is the on-disk structure of skill_history/index.json, keyed by skill ID.
+type skillHistoryStore struct {
+	Skills map[string]*skillHistory `json:"skills"`
+}
+
+type skillHistory struct {
+	Name     string         `json:"name,omitempty"`
+	Releases []skillRelease `json:"releases"`
+}
+
+// skillRelease is one upload of a skill package.
+type skillRelease struct {
+	Version string `json:"version,omitempty"`
+	SHA256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+	// File is the stored zip, relative to the skill_history directory.
+	File string `json:"file"`
+	// Source is the command that published the package: push, update, sync or rollback.
+	Source      string `json:"source"`
+	Changelog   string `json:"changelog,omitempty"`
+	PublishedAt string `json:"published_at"`
+}
+
+func skillHistoryDir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, skillHistoryDirName), nil
+}
+
+func skillHistoryFile() (*config.StateFile, error) {
+	dir, err := skillHistoryDir()
+	if err != nil {
+		return nil, err
+	}
+	return &config.StateFile{Path: filepath.Join(dir, "index.json")}, nil
+}
+
+func loadSkillHistory() (*skillHistoryStore, error) {
+	store := &skillHistoryStore{}
+	f, err := skillHistoryFile()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Load(store); err != nil {
+		return nil, err
+	}
+	if store.Skills == nil {
+		store.Skills = map[string]*skillHistory{}
+	}
+	return store, nil
+}
+
+// get returns the history of skillId, or nil.
+func (s *skillHistoryStore) get(skillId string) *skillHistory {
+	if s == nil {
+		return nil
+	}
+	return s.Skills[skillId]
+}
+
+// highest returns the highest version ever published for the skill, or "" when no release has
+// a valid version. Rollbacks do not lower it, so the next update still has to go above it.
+func (h *skillHistory) highest() string {
+	if h == nil {
+		return ""
+	}
+	var best string
+	var bestV skillSemver
+	for _, r := range h.Releases {
+		v, err := parseSkillSemver(r.Version)
+		if err != nil {
+			continue
+		}
+		if best == "" || v.compare(bestV) > 0 {
+			best, bestV = r.Version, v
+		}
+	}
+	return best
+}
+
+// release returns the most recent release of version ("v1.2.0" and "1.2.0" are the same), or nil.
+func (h *skillHistory) release(version string) *skillRelease {
+	if h == nil {
+		return nil
+	}
+	want, err := parseSkillSemver(version)
+	for i := len(h.Releases) - 1; i >= 0; i-- {
+		r := &h.Releases[i]
+		if r.Version == version {
+			return r
+		}
+		if v, verr := parseSkillSemver(r.Version); err == nil && verr == nil && v.compare(want) == 0 {
+			return r
+		}
+	}
+	return nil
+}
+
+// checkSkillVersionBump returns an error unless version is higher than every version in h and
+// than published, the version of the package the skill currently serves. Once the skill has a
+// versioned release, a package without a version is refused too.
+func checkSkillVersionBump(h *skillHistory, published, version string) error {
+	prev := h.highest()
+	if p, err := parseSkillSemver(published); err == nil {
+		if pv, err := parseSkillSemver(prev); err != nil || p.compare(pv) > 0 {
+			prev = published
+		}
+	}
+	if version == "" {
+		if prev != "" {
+			return fmt.Errorf("%s has no version, but version %s is already published for this skill", skillFileName, prev)
+		}
+		return nil
+	}
+	v, err := parseSkillSemver(version)
+	if err != nil {
+		return err
+	}
+	if prev == "" {
+		return nil
+	}
+	p, _ := parseSkillSemver(prev)
+	if v.compare(p) <= 0 {
+		return fmt.Errorf("version %s is not higher than %s, the highest version published for this skill", version, prev)
+	}
+	return nil
+}
+
+// skillPackageVersion returns the name and version in the SKILL.md of a zipped package. Both are
+// empty when the package cannot be read; the upload itself decides whether that is fatal.
+func skillPackageVersion(data []byte) (name, version string) {
+	pkg, err := readSkillPackage(data)
+	if err != nil {
+		return "", ""
+	}
+	return pkg.version()
+}
+
+// version returns the name and version in the SKILL.md of the package; version is empty when
+// the frontmatter has none or is not a valid manifest.
+func (p *skillPackage) version() (name, version string) {
+	content := p.Files[skillFileName].Data
+	if m, _, _, err := parseSkillManifest(content); err == nil {
+		return m.Name, m.Version
+	}
+	name, _, _ = parseSkillFrontmatter(content)
+	return name, ""
+}
+
+// checkSkillUploadVersion checks the version of a package about to replace skillId against the
+// local history and the package currently published; skillId is empty for a new skill, where
+// only the version format is checked.
+func checkSkillUploadVersion(ctx context.Context, apiClient agentbay.Client, skillId, version string) error {
+	if version != "" {
+		if _, err := parseSkillSemver(version); err != nil {
+			return err
+		}
+	}
+	if skillId == "" {
+		return nil
+	}
+	store, err := loadSkillHistory()
+	if err != nil {
+		return err
+	}
+	skill, err := fetchPublishedSkill(ctx, apiClient, skillId)
+	if err != nil {
+		return fmt.Errorf("cannot compare with the published version: %s", strings.TrimPrefix(err.Error(), "[ERROR] "))
+	}
+	_, published := skill.Package.version()
+	return checkSkillVersionBump(store.get(skillId), published, version)
+}
+
+// recordSkillRelease stores data as a release of skillId and appends rel to its history.
+// Identical packages share one stored file.
+func recordSkillRelease(skillId, name string, rel skillRelease, data []byte) error {
+	dir, err := skillHistoryDir()
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	rel.SHA256 = hex.EncodeToString(sum[:])
+	rel.Size = int64(len(data))
+	rel.File = skillId + "/" + rel.SHA256[:16] + ".zip"
+	if rel.PublishedAt == "" {
+		rel.PublishedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	p := filepath.Join(dir, filepath.FromSlash(rel.File))
+	if _, err := os.Stat(p); os.IsNotExist(err) {
+		// Written atomically, so that a stored package is either complete or missing.
+		if err := config.WriteFileAtomic(p, data, 0600); err != nil {
+			return err
+		}
+	}
+
+	f, err := skillHistoryFile()
+	if err != nil {
+		return err
+	}
+	store := &skillHistoryStore{}
+	return f.Update(store, func() error {
+		if store.Skills == nil {
+			store.Skills = map[string]*skillHistory{}
+		}
+		h := store.Skills[skillId]
+		if h == nil {
+			h = &skillHistory{}
+			store.Skills[skillId] = h
+		}
+		if name != "" {
+			h.Name = name
+		}
+		h.Releases = append(h.Releases, rel)
+		return nil
+	})
+}
+
+// readSkillRelease returns the stored package of rel after checking its SHA-256.
+func readSkillRelease(rel *skillRelease) ([]byte, error) {
+	dir, err := skillHistoryDir()
+	if err != nil {
+		return nil, err
+	}
+	p := filepath.Join(dir, filepath.FromSlash(rel.File))
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("stored package for version %s: %w", rel.Version, err)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != rel.SHA256 {
+		return nil, fmt.Errorf("stored package %s is corrupted: sha256 %s, recorded %s", p, got, rel.SHA256)
+	}
+	return data, nil
+}
+
+// noteSkillRelease records a release and only warns on failure: the skill is already published.
+func noteSkillRelease(skillId, name string, rel skillRelease, data []byte) {
+	if err := recordSkillRelease(skillId, name, rel, data); err != nil {
+		fmt.Printf("[WARN] Failed to record the release in the local skill history: %v\n", err)
+	}
+}
+
+func runSkillsRollback(cmd *cobra.Command, args []string) error {
+	skillId := args[0]
+	to, _ := cmd.Flags().GetString("to")
+	autoYes, _ := cmd.Flags().GetBool("yes")
+
+	fmt.Printf("[STEP 1/3] Reading local skill history...\n")
+	store, err := loadSkillHistory()
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	h := store.get(skillId)
+	if h == nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] No local history for skill %s", skillId),
+			"",
+			"[TIP] Only versions published from this machine with push, update or sync can be restored",
+		)
+	}
+	rel := h.release(to)
+	if rel == nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Version %s of skill %s is not in the local history", to, skillId),
+			"",
+			fmt.Sprintf("[TIP] List the recorded versions: agentbay skills history %s", skillId),
+		)
+	}
+	data, err := readSkillRelease(rel)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	current := h.Releases[len(h.Releases)-1]
+	fmt.Printf("[INFO] Restoring version %s (published %s, %s, sha256 %s)\n", rel.Version, rel.PublishedAt, formatBytes(rel.Size), rel.SHA256)
+	if current.SHA256 == rel.SHA256 {
+		fmt.Printf("[WARN] The last recorded release already has this content.\n")
+	}
+	fmt.Println()
+
+	confirmed, err := ConfirmPrompt(fmt.Sprintf("Replace skill %s with version %s? [y/N]: ", skillId, rel.Version), autoYes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	if !confirmed {
+		fmt.Printf("[INFO] Operation cancelled.\n")
+		return nil
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx := context.Background()
+	if err := rollbackSkill(ctx, cmd, apiClient, skillId, h.Name, rel, data); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Printf("[SUCCESS] ✅ Skill rolled back to version %s!\n", rel.Version)
+	fmt.Printf("[RESULT] Skill ID: %s\n", skillId)
+	return nil
+}
+
+// rollbackSkill uploads the stored package data of rel and points skillId at it.
+func rollbackSkill(ctx context.Context, cmd *cobra.Command, apiClient agentbay.Client, skillId, name string, rel *skillRelease, data []byte) error {
+	zipName := "skill.zip"
+	if name != "" {
+		zipName = name + ".zip"
+	}
+	fmt.Printf("[STEP 2/3] Getting upload credential...\n")
+	uploadURL, bucket, ossPath, err := requestSkillUpload(ctx, cmd, apiClient, zipName)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[STEP 3/3] Uploading package and updating skill...\n")
+	if err := uploadSkillZip(cmd, data, uploadURL); err != nil {
+		return err
+	}
+	var resp *client.CreateMarketSkillResponse
+	err = withTransientRetry(ctx, client.DefaultRetryConfig(), "UpdateMarketSkill", func() error {
+		var e error
+		resp, e = apiClient.UpdateMarketSkill(ctx, &client.UpdateMarketSkillRequest{SkillId: &skillId, OssBucket: &bucket, OssFilePath: &ossPath})
+		return e
+	})
+	if err != nil {
+		printRequestIDFromErrIfVerbose(cmd, err)
+		return fmt.Errorf("[ERROR] Failed to update skill: %w", err)
+	}
+	if resp.Body != nil && resp.Body.RequestId != nil && *resp.Body.RequestId != "" {
+		fmt.Printf("[INFO] UpdateMarketSkill RequestId: %s\n", *resp.Body.RequestId)
+	}
+	noteSkillRelease(skillId, name, skillRelease{
+		Version:   rel.Version,
+		Source:    "rollback",
+		Changelog: fmt.Sprintf("rollback to %s", rel.Version),
+	}, data)
+	return nil
+}
+
+func runSkillsHistory(cmd *cobra.Command, args []string) error {
+	skillId := args[0]
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	store, err := loadSkillHistory()
+	if err != nil {
+		return fmt.Errorf("[ERROR] %w", err)
+	}
+	h := store.get(skillId)
+	if outputFmt == "json" {
+		if h == nil {
+			h = &skillHistory{Releases: []skillRelease{}}
+		}
+		data, err := json.MarshalIndent(struct {
+			SkillId string `json:"skill_id"`
+			*skillHistory
+		}{skillId, h}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("[ERROR] %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	if h == nil || len(h.Releases) == 0 {
+		fmt.Printf("[EMPTY] No local history for skill %s.\n", skillId)
+		return nil
+	}
+	fmt.Printf("[INFO] Skill %s (%s), highest version %s\n\n", skillId, cmp.Or(h.Name, "-"), cmp.Or(h.highest(), "-"))
+	verW := len("VERSION")
+	for _, r := range h.Releases {
+		verW = max(verW, len(r.Version))
+	}
+	fmt.Printf("  %-*s  %-20s  %-8s  %-10s  %-16s  %s\n", verW, "VERSION", "PUBLISHED", "SOURCE", "SIZE", "SHA256", "CHANGELOG")
+	for i, r := range h.Releases {
+		mark := " "
+		if i == len(h.Releases)-1 {
+			mark = "*"
+		}
+		fmt.Printf("%s %-*s  %-20s  %-8s  %-10s  %-16s  %s\n", mark, verW, cmp.Or(r.Version, "-"), r.PublishedAt, r.Source, formatBytes(r.Size), r.SHA256[:min(16, len(r.SHA256))], r.Changelog)
+	}
+	fmt.Printf("\n[INFO] * marks the release currently published from this machine.\n")
+	return nil
+}
+
+// printSkillUploadVersion reports the version about to be published.
+func printSkillUploadVersion(version string) {
+	if version == "" {
+		fmt.Printf("[WARN] %s has no version; add \"version: 1.0.0\" to its frontmatter to enable version checks and rollback.\n", skillFileName)
+		return
+	}
+	fmt.Printf("[INFO] Version: %s\n", version)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillSemverCompare(t *testing.T) {
+	ordered := []string{"0.9.9", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "v1.0.1", "1.2.0", "1.10.0", "2.0.0"}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, err := parseSkillSemver(ordered[i])
+		require.NoError(t, err)
+		b, err := parseSkillSemver(ordered[i+1])
+		require.NoError(t, err)
+		assert.Equal(t, -1, a.compare(b), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, b.compare(a), "%s > %s", ordered[i+1], ordered[i])
+	}
+	a, _ := parseSkillSemver("1.2.0+build.5")
+	b, _ := parseSkillSemver("v1.2.0")
+	assert.Zero(t, a.compare(b), "build metadata and the v prefix do not matter")
+
+	_, err := parseSkillSemver("1.2")
+	assert.ErrorContains(t, err, "not a semantic version")
+}
+
+func TestCheckSkillVersionBump(t *testing.T) {
+	h := &skillHistory{Releases: []skillRelease{
+		{Version: "1.0.0"}, {Version: "1.2.0"}, {Version: "1.0.0", Source: "rollback"}, {Version: ""},
+	}}
+	assert.Equal(t, "1.2.0", h.highest(), "a rollback does not lower the highest version")
+	assert.NoError(t, checkSkillVersionBump(h, "", "1.2.1"))
+	assert.ErrorContains(t, checkSkillVersionBump(h, "", ""), "has no version, but version 1.2.0 is already published",
+		"a versioned skill cannot go back to an unversioned package")
+	assert.ErrorContains(t, checkSkillVersionBump(h, "", "1.2.0"), "not higher than 1.2.0")
+	assert.ErrorContains(t, checkSkillVersionBump(h, "", "1.1.0"), "not higher than 1.2.0")
+	assert.ErrorContains(t, checkSkillVersionBump(h, "", "1.3"), "not a semantic version")
+	assert.NoError(t, checkSkillVersionBump(nil, "", "0.1.0"), "no history, nothing to compare with")
+	assert.NoError(t, checkSkillVersionBump(nil, "", ""), "unversioned skills are not checked")
+
+	assert.ErrorContains(t, checkSkillVersionBump(h, "2.0.0", "1.3.0"), "not higher than 2.0.0",
+		"a version published from another machine counts too")
+	assert.ErrorContains(t, checkSkillVersionBump(nil, "v1.0.0", "1.0.0"), "not higher than v1.0.0")
+	assert.ErrorContains(t, checkSkillVersionBump(nil, "1.0.0", ""), "has no version")
+	assert.NoError(t, checkSkillVersionBump(h, "1.0.0", "1.2.1"), "the local history can be ahead of the published package")
+
+	assert.Equal(t, "rollback", h.release("v1.0.0").Source, "the most recent release of a version wins")
+	assert.Nil(t, h.release("9.9.9"))
+}
+
+func TestSkillHistoryRecordAndRollback(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	v1 := zipEntries(t, map[string]string{"SKILL.md": "---\nname: pdf-report\ndescription: d\nversion: 1.0.0\n---\n"})
+	v2 := zipEntries(t, map[string]string{"SKILL.md": "---\nname: pdf-report\ndescription: d\nversion: 1.1.0\n---\n"})
+
+	name, version := skillPackageVersion(v1)
+	assert.Equal(t, "pdf-report", name)
+	assert.Equal(t, "1.0.0", version)
+	_, version = skillPackageVersion([]byte("not a zip"))
+	assert.Empty(t, version)
+
+	require.NoError(t, recordSkillRelease("sk-1", name, skillRelease{Version: "1.0.0", Source: "push", Changelog: "first"}, v1))
+	require.NoError(t, recordSkillRelease("sk-1", name, skillRelease{Version: "1.1.0", Source: "update"}, v2))
+	published := serveSkillZip(t, v2)
+	detail := &mockSkillDetailClient{fileUrl: published.URL}
+	ctx := context.Background()
+	assert.ErrorContains(t, checkSkillUploadVersion(ctx, detail, "sk-1", "1.1.0"), "not higher than 1.1.0")
+	assert.NoError(t, checkSkillUploadVersion(ctx, detail, "sk-1", "1.1.1"))
+	assert.NoError(t, checkSkillUploadVersion(ctx, nil, "", "1.0.0"), "a new skill has no history")
+	assert.ErrorContains(t, checkSkillUploadVersion(ctx, detail, "sk-2", "1.1.0"), "not higher than 1.1.0",
+		"the published package is checked without local history")
+	assert.ErrorContains(t, checkSkillUploadVersion(ctx, &mockSkillDetailClient{}, "sk-1", "1.1.1"), "cannot compare with the published version")
+
+	var uploaded []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploaded, _ = io.ReadAll(r.Body)
+	}))
+	t.Cleanup(srv.Close)
+	mock := &mockSkillSyncClient{uploadURL: srv.URL + "/upload"}
+
+	store, err := loadSkillHistory()
+	require.NoError(t, err)
+	h := store.get("sk-1")
+	rel := h.release("1.0.0")
+	data, err := readSkillRelease(rel)
+	require.NoError(t, err)
+	captureImageCreateFromTemplateStdout(t, func() {
+		require.NoError(t, rollbackSkill(context.Background(), skillsRollbackCmd, mock, "sk-1", h.Name, rel, data))
+	})
+	assert.Equal(t, v1, uploaded, "the stored package is uploaded unchanged")
+	assert.Equal(t, []string{"sk-1"}, mock.updated)
+
+	store, err = loadSkillHistory()
+	require.NoError(t, err)
+	h = store.get("sk-1")
+	require.Len(t, h.Releases, 3)
+	last := h.Releases[2]
+	assert.Equal(t, "rollback", last.Source)
+	assert.Equal(t, h.Releases[0].File, last.File, "identical packages share one stored file")
+	assert.Equal(t, "1.1.0", h.highest())
+
+	dir, err := skillHistoryDir()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, filepath.FromSlash(last.File)), []byte("tampered"), 0600))
+	_, err = readSkillRelease(&last)
+	assert.ErrorContains(t, err, "corrupted")
+}
@@ -0,0 +1,609 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var UICmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Browse images, skills, API keys and shares in a terminal UI",
+	Long: `Open an interactive terminal UI with one tab each for images, skills, API keys and
+Docker repo shares. Every tab loads all pages, so IDs can be found with a fuzzy search
+instead of paging through the list commands.
+
+Keys:
+  ←/→, Tab, 1-4   Switch tabs
+  ↑/↓, j/k        Move the selection (PgUp/PgDn, g/G to jump)
+  /               Fuzzy search the current tab (Enter keeps the filter, Esc clears it)
+  Enter           Load image status, policy data and pre-open values into the detail pane
+  r               Reload the current tab
+  q, Ctrl-C       Quit
+
+Actions (each asks for confirmation, then runs the same code as the command shown):
+  Images    a activate, d deactivate, x delete   (agentbay image activate|deactivate|delete)
+  Skills    x delete                             (agentbay skills delete)
+  API keys  e enable, d disable, x delete        (agentbay apikey enable|disable|delete)
+  Shares    x unshare (outgoing shares)          (agentbay docker unshare)
+
+Actions run with default flags. For activation with specific CPU/memory, network or
+lifecycle settings, use 'agentbay image activate' directly.
+
+Examples:
+  # Open the UI on the images tab
+  agentbay ui
+
+  # Start on the API keys tab
+  agentbay ui --tab apikeys`,
+	GroupID: "core",
+	Args:    cobra.NoArgs,
+	RunE:    runUI,
+}
+
+// uiTabNames are the --tab values, in tab order.
+var uiTabNames = []string{"images", "skills", "apikeys", "shares"}
+
+func init() {
+	UICmd.Flags().String("tab", "images", "Tab to open first: images, skills, apikeys or shares")
+}
+
+func runUI(cmd *cobra.Command, args []string) error {
+	tabName, _ := cmd.Flags().GetString("tab")
+	start := -1
+	for i, name := range uiTabNames {
+		if strings.EqualFold(tabName, name) {
+			start = i
+		}
+	}
+	if start < 0 {
+		return fmt.Errorf("[ERROR] Invalid --tab %q: must be one of %s", tabName, strings.Join(uiTabNames, ", "))
+	}
+
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return fmt.Errorf("[ERROR] agentbay ui needs an interactive terminal; use the list commands (e.g. 'agentbay image list --all -o json') in scripts")
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to load configuration: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return config.ErrNotAuthenticated()
+	}
+	apiClient := agentbay.NewClientFromConfig(cfg)
+
+	m := &uiModel{tabs: newUITabs()}
+	m.active = start
+	tty := &uiTerminal{fd: inFd, in: os.Stdin, out: os.Stdout}
+	if err := tty.enter(); err != nil {
+		return fmt.Errorf("[ERROR] Failed to set up the terminal: %w", err)
+	}
+	defer tty.leave()
+
+	effect := uiEffectLoad
+	buf := make([]byte, 64)
+	for {
+		switch effect {
+		case uiEffectQuit:
+			return nil
+		case uiEffectLoad:
+			uiLoadTab(tty, m, apiClient)
+		case uiEffectDescribe:
+			uiDescribeRow(tty, m, apiClient)
+		case uiEffectRun:
+			if err := uiRunAction(tty, m); err != nil {
+				return err
+			}
+			uiLoadTab(tty, m, apiClient)
+		}
+		tty.draw(m)
+
+		n, err := tty.in.Read(buf)
+		if err != nil {
+			return nil
+		}
+		effect = uiEffectNone
+		for _, k := range parseUIKeys(buf[:n]) {
+			if e := m.handleKey(k); e != uiEffectNone {
+				effect = e
+				break
+			}
+		}
+	}
+}
+
+// uiLoadTab (re)loads the rows of the active tab, showing "Loading..." while it runs.
+func uiLoadTab(tty *uiTerminal, m *uiModel, apiClient agentbay.Client) {
+	t := m.tab()
+	m.message = fmt.Sprintf("Loading %s...", strings.ToLower(t.Name))
+	tty.draw(m)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	rows, err := t.load(ctx, apiClient)
+	if err != nil {
+		t.loaded, t.err = true, fmt.Sprintf("[ERROR] Failed to load %s: %v", strings.ToLower(t.Name), err)
+		m.message = ""
+		return
+	}
+	t.setRows(rows)
+	m.message = ""
+}
+
+// uiDescribeRow fetches the extra detail of the selected row into the detail pane.
+func uiDescribeRow(tty *uiTerminal, m *uiModel, apiClient agentbay.Client) {
+	t := m.tab()
+	r := t.selected()
+	if r == nil || t.describe == nil {
+		return
+	}
+	m.message = fmt.Sprintf("Loading details of %s...", r.ID)
+	tty.draw(m)
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	if t.extra == nil {
+		t.extra = map[string][]uiField{}
+	}
+	t.extra[r.ID] = t.describe(ctx, apiClient, *r)
+	m.message = ""
+}
+
+// uiRunAction leaves the UI, runs the confirmed action with its normal command output and waits
+// for Enter before returning to the UI.
+func uiRunAction(tty *uiTerminal, m *uiModel) error {
+	a, r := m.pending, m.tab().selected()
+	m.pending = nil
+	if a == nil || r == nil {
+		return nil
+	}
+	tty.leave()
+	fmt.Printf("$ %s\n\n", a.commandLine(*r))
+	if err := a.run(*r); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		m.message = fmt.Sprintf("[ERROR] %s %s failed, see the output above it in your scrollback", a.Verb, r.ID)
+	} else {
+		m.message = fmt.Sprintf("[OK] %s %s finished", a.Verb, r.ID)
+	}
+	fmt.Print("\nPress Enter to return to agentbay ui...")
+	b := make([]byte, 1)
+	for {
+		if n, err := tty.in.Read(b); err != nil || (n == 1 && b[0] == '\n') {
+			break
+		}
+	}
+	return tty.enter()
+}
+
+// runUICommand runs an existing command's RunE with flags set as if given on the command line,
+// then resets them so later runs start from the defaults again.
+func runUICommand(c *cobra.Command, args []string, flags map[string]string) error {
+	defer func() {
+		for name := range flags {
+			if f := c.Flags().Lookup(name); f != nil {
+				_ = f.Value.Set(f.DefValue)
+				f.Changed = false
+			}
+		}
+	}()
+	for name, value := range flags {
+		if err := c.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("[ERROR] %s: %w", c.CommandPath(), err)
+		}
+	}
+	return c.RunE(c, args)
+}
+
+// ---------------------------------------------------------------------------
+// Terminal
+// ---------------------------------------------------------------------------
+
+// uiTerminal switches the terminal into raw mode on the alternate screen and draws the model.
+type uiTerminal struct {
+	fd    int
+	state *term.State
+	in    io.Reader
+	out   io.Writer
+}
+
+func (t *uiTerminal) enter() error {
+	state, err := term.MakeRaw(t.fd)
+	if err != nil {
+		return err
+	}
+	t.state = state
+	fmt.Fprint(t.out, "\x1b[?1049h\x1b[?25l")
+	return nil
+}
+
+func (t *uiTerminal) leave() {
+	if t.state == nil {
+		return
+	}
+	fmt.Fprint(t.out, "\x1b[?25h\x1b[?1049l")
+	_ = term.Restore(t.fd, t.state)
+	t.state = nil
+}
+
+func (t *uiTerminal) draw(m *uiModel) {
+	width, height, err := term.GetSize(t.fd)
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 100, 30
+	}
+	// Leave the last column free so a full-width line does not wrap and scroll the screen.
+	width--
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range m.view(width, height) {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString("\x1b[K")
+	}
+	fmt.Fprint(t.out, b.String())
+}
+
+// ---------------------------------------------------------------------------
+// Tabs
+// ---------------------------------------------------------------------------
+
+func newUITabs() []*uiTab {
+	return []*uiTab{
+		{
+			Name:         "Images",
+			Columns:      []uiColumn{{"IMAGE ID", 25}, {"IMAGE NAME", 30}, {"TYPE", 7}, {"STATUS", 15}, {"OS", 18}},
+			load:         loadUIImages,
+			describe:     describeUIImage,
+			describeHint: "Press Enter to load status, policy data and pre-open values.",
+			Actions: []uiAction{
+				{Key: 'a', Verb: "Activate", Command: "agentbay image activate %s", allowed: uiImageCan("activate"),
+					run: func(r uiRow) error { return runUICommand(imageActivateCmd, []string{r.ID}, nil) }},
+				{Key: 'd', Verb: "Deactivate", Command: "agentbay image deactivate %s", allowed: uiImageCan("deactivate"),
+					run: func(r uiRow) error { return runUICommand(imageDeactivateCmd, []string{r.ID}, nil) }},
+				{Key: 'x', Verb: "Delete", Command: "agentbay image delete %s --yes", allowed: uiImageCan("delete"),
+					run: func(r uiRow) error {
+						return runUICommand(imageDeleteCmd, []string{r.ID}, map[string]string{"yes": "true"})
+					}},
+			},
+		},
+		{
+			Name:    "Skills",
+			Columns: []uiColumn{{"SKILL ID", 24}, {"NAME", 30}, {"STATUS", 10}, {"TAGS", 30}},
+			load:    loadUISkills,
+			Actions: []uiAction{
+				{Key: 'x', Verb: "Delete", Command: "agentbay skills delete %s --yes", allowed: func(uiRow) string { return "" },
+					run: func(r uiRow) error {
+						return runUICommand(skillsDeleteCmd, []string{r.ID}, map[string]string{"yes": "true"})
+					}},
+			},
+		},
+		{
+			Name:    "API Keys",
+			Columns: []uiColumn{{"NAME", 24}, {"KEY ID", 24}, {"STATUS", 10}, {"LAST USED", 20}},
+			load:    loadUIApiKeys,
+			Actions: []uiAction{
+				{Key: 'e', Verb: "Enable", Command: "agentbay apikey enable --api-key-id %s", allowed: uiApiKeyCan("ENABLED"),
+					run: func(r uiRow) error {
+						return runUICommand(apikeyEnableCmd, nil, map[string]string{"api-key-id": r.ID})
+					}},
+				{Key: 'd', Verb: "Disable", Command: "agentbay apikey disable --api-key-id %s", allowed: uiApiKeyCan("DISABLED"),
+					run: func(r uiRow) error {
+						return runUICommand(apikeyDisableCmd, nil, map[string]string{"api-key-id": r.ID})
+					}},
+				{Key: 'x', Verb: "Delete", Command: "agentbay apikey delete --api-key-id %s --yes", allowed: func(uiRow) string { return "" },
+					run: func(r uiRow) error {
+						return runUICommand(apikeyDeleteCmd, nil, map[string]string{"api-key-id": r.ID, "yes": "true"})
+					}},
+			},
+		},
+		{
+			Name:    "Shares",
+			Columns: []uiColumn{{"DIRECTION", 10}, {"PEER UID", 20}, {"STATUS", 12}, {"EXPIRES", 25}},
+			load:    loadUIShares,
+			Actions: []uiAction{
+				{Key: 'x', Verb: "Unshare", Command: "agentbay docker unshare %s",
+					allowed: func(r uiRow) string {
+						if r.Kind != "Outgoing" {
+							return "only repos you shared (Outgoing) can be unshared"
+						}
+						return ""
+					},
+					run: func(r uiRow) error { return runUICommand(dockerUnshareCmd, []string{r.ID}, nil) }},
+			},
+		},
+	}
+}
+
+// uiImageCan returns the allowed check of an image action, mirroring the checks the image
+// commands make before calling the API.
+func uiImageCan(action string) func(r uiRow) string {
+	return func(r uiRow) string {
+		if !IsUserImage(r.Kind) {
+			return "system images cannot be activated, deactivated or deleted"
+		}
+		switch {
+		case action == "activate" && !IsDeactivated(r.Status) && !IsFailed(r.Status):
+			return fmt.Sprintf("the image is %s", formatImageStatus(r.Status))
+		case action == "deactivate" && !IsActivated(r.Status) && !IsFailed(r.Status):
+			return fmt.Sprintf("the image is %s", formatImageStatus(r.Status))
+		case action == "delete" && !IsDeletable(r.Status):
+			return fmt.Sprintf("a %s image cannot be deleted", formatImageStatus(r.Status))
+		}
+		return ""
+	}
+}
+
+// uiApiKeyCan returns the allowed check for changing a key to target status.
+func uiApiKeyCan(target string) func(r uiRow) string {
+	return func(r uiRow) string {
+		if strings.EqualFold(r.Status, target) {
+			return fmt.Sprintf("the key is already %s", strings.ToLower(target))
+		}
+		return ""
+	}
+}
+
+func loadUIImages(ctx context.Context, apiClient agentbay.Client) ([]uiRow, error) {
+	var rows []uiRow
+	for _, imageType := range []string{"User", "System"} {
+		_, err := walkPages(ctx, listPaging{All: true}, pageCursor{PageNo: 1}, imagePages(apiClient, imageType, "", 50, io.Discard),
+			func(img *client.ListMcpImagesResponseBodyData) error {
+				if img != nil {
+					rows = append(rows, newUIImageRow(img, imageType))
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+func newUIImageRow(img *client.ListMcpImagesResponseBodyData, imageType string) uiRow {
+	item := newImageItemJSON(img)
+	r := uiRow{
+		ID:     item.ImageId,
+		Cells:  []string{item.ImageId, item.ImageName, imageType, item.StatusDisplay, item.OsDisplay},
+		Kind:   imageType,
+		Status: item.Status,
+		Detail: []uiField{
+			{"Image", fmt.Sprintf("%s (%s)", item.ImageId, item.ImageName)},
+			{"Type", strings.TrimSpace(imageType + " " + item.Type)},
+			{"Status", fmt.Sprintf("%s (%s)", item.StatusDisplay, item.Status)},
+			{"OS", item.OsDisplay},
+		},
+	}
+	if item.ApplyScene != "" {
+		r.Detail = append(r.Detail, uiField{"Apply scene", item.ApplyScene})
+	}
+	return r
+}
+
+// describeUIImage loads the live status, policy data and pre-open values of an image. A failed
+// lookup is shown in place of its section instead of hiding the others.
+func describeUIImage(ctx context.Context, apiClient agentbay.Client, r uiRow) []uiField {
+	var fields []uiField
+	if info, err := GetImageInfo(ctx, apiClient, r.ID); err != nil {
+		fields = append(fields, uiField{"Deployment", fmt.Sprintf("[ERROR] %v", err)})
+	} else {
+		fields = append(fields, uiField{"Deployment", fmt.Sprintf("%s (resource group ready: %t)", summarizeDeploymentState(info.ResourceStatus), info.ResourceGroupReady)})
+	}
+
+	policyResp, err := apiClient.DescribeMcpPolicyData(ctx, &client.DescribeMcpPolicyDataRequest{ImageId: &r.ID})
+	switch {
+	case err != nil:
+		fields = append(fields, uiField{"Policy", fmt.Sprintf("[ERROR] %v", err)})
+	case policyResp == nil || policyResp.Body == nil || policyResp.Body.Data == nil:
+		fields = append(fields, uiField{"Policy", "-"})
+	default:
+		fields = append(fields, uiPolicyFields(policyResp.Body.Data)...)
+	}
+
+	page, err := preOpenPages(apiClient, []string{r.ID}, 10, io.Discard)(ctx, pageCursor{})
+	if err != nil {
+		return append(fields, uiField{"Pre-open", fmt.Sprintf("[ERROR] %v", err)})
+	}
+	return append(fields, uiPreOpenFields(r.ID, page.Items)...)
+}
+
+// uiPolicyFields summarises DescribeMcpPolicyData for the detail pane.
+func uiPolicyFields(d *client.DescribeMcpPolicyDataResponseBodyData) []uiField {
+	policy := strPtr(d.PolicyId)
+	if policy == "" {
+		policy = "-"
+	}
+	if d.IsDefaultData != nil && *d.IsDefaultData {
+		policy += " (default)"
+	}
+	fields := []uiField{{"Policy", policy}}
+	if g := d.GroupSpec; g != nil {
+		spec := strPtr(g.AppInstanceType)
+		if g.Cpu != nil && g.Memory != nil {
+			spec = strings.TrimSpace(fmt.Sprintf("%s %d vCPU / %d GiB", spec, *g.Cpu, *g.Memory))
+		}
+		if region := strPtr(g.RegionId); region != "" {
+			spec = strings.TrimSpace(spec + " in " + region)
+		}
+		if spec != "" {
+			fields = append(fields, uiField{"Spec", spec})
+		}
+	}
+	if lc := d.SandboxLifeCycle; lc != nil {
+		var parts []string
+		if mode := strPtr(lc.Mode); mode != "" {
+			parts = append(parts, "mode "+mode)
+		}
+		if lc.DesktopMaxRuntime != nil {
+			parts = append(parts, fmt.Sprintf("max runtime %gm", *lc.DesktopMaxRuntime))
+		}
+		if lc.UserIdleTimeout != nil {
+			parts = append(parts, fmt.Sprintf("idle timeout %gm", *lc.UserIdleTimeout))
+		}
+		if lc.HibernateTimeout != nil {
+			parts = append(parts, fmt.Sprintf("hibernate %gh", *lc.HibernateTimeout))
+		}
+		if len(parts) > 0 {
+			fields = append(fields, uiField{"Lifecycle", strings.Join(parts, ", ")})
+		}
+	}
+	if d.NetworkConfig != nil && d.NetworkConfig.Enabled != nil {
+		fields = append(fields, uiField{"Network", fmt.Sprintf("enabled: %t", *d.NetworkConfig.Enabled)})
+	}
+	return fields
+}
+
+// uiPreOpenFields lists the pre-open (reserved) sandboxes of each resource group of imageId.
+func uiPreOpenFields(imageId string, images []*client.DescribeImageReserveMinAmountImage) []uiField {
+	var fields []uiField
+	for _, img := range images {
+		if img == nil || img.GetImageId() != imageId {
+			continue
+		}
+		for _, rg := range img.GetResourceGroups() {
+			if rg == nil {
+				continue
+			}
+			reserve := "-"
+			if rg.ReserveMinAmount != nil {
+				reserve = strconv.Itoa(int(rg.GetReserveMinAmount()))
+			}
+			fields = append(fields, uiField{"Pre-open", fmt.Sprintf("%s reserve %s / max %d (%s, %s)",
+				rg.GetResourceGroupType(), reserve, rg.GetMaxAmount(), rg.GetResourceGroupId(), rg.GetStatus())})
+		}
+	}
+	if len(fields) == 0 {
+		fields = append(fields, uiField{"Pre-open", "-"})
+	}
+	return fields
+}
+
+func loadUISkills(ctx context.Context, apiClient agentbay.Client) ([]uiRow, error) {
+	skills, err := listAllSkills(ctx, apiClient, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]uiRow, 0, len(skills))
+	for _, s := range skills {
+		rows = append(rows, newUISkillRow(s))
+	}
+	return rows, nil
+}
+
+func newUISkillRow(s *client.ListMarketSkillByPageResponseBodyDataResult) uiRow {
+	item := newSkillListItem(s)
+	tags := strings.Join(s.TenantTags, ", ")
+	return uiRow{
+		ID:     strPtr(s.SkillId),
+		Cells:  []string{strPtr(s.SkillId), strPtr(s.SkillName), strPtr(s.SkillStatus), tags},
+		Status: strPtr(s.SkillStatus),
+		Detail: []uiField{
+			{"Skill", fmt.Sprintf("%s (%s)", strPtr(s.SkillId), strPtr(s.SkillName))},
+			{"Status", strPtr(s.SkillStatus)},
+			{"Tags", tags},
+			{"Created", item.GmtCreate},
+			{"Modified", item.GmtModified},
+			{"Description", strPtr(s.Description)},
+		},
+	}
+}
+
+func loadUIApiKeys(ctx context.Context, apiClient agentbay.Client) ([]uiRow, error) {
+	meta, err := loadApikeyMetadata()
+	if err != nil {
+		meta = nil
+	}
+	var rows []uiRow
+	_, err = walkPages(ctx, listPaging{All: true}, pageCursor{}, apiKeyPages(apiClient, 50, "", io.Discard),
+		func(key *client.DescribeApiKeysResponseBodyDataApiKey) error {
+			if key != nil {
+				rows = append(rows, newUIApiKeyRow(key, meta))
+			}
+			return nil
+		})
+	return rows, err
+}
+
+func newUIApiKeyRow(key *client.DescribeApiKeysResponseBodyDataApiKey, meta *apikeyMetadataStore) uiRow {
+	d := newApikeyDetails(key, meta, nil)
+	concurrency := "-"
+	if d.Concurrency != nil {
+		concurrency = strconv.Itoa(int(*d.Concurrency))
+	}
+	r := uiRow{
+		ID:     d.KeyId,
+		Cells:  []string{d.Name, d.KeyId, d.Status, truncateDateOffset(d.LastUseDate)},
+		Status: d.Status,
+		Detail: []uiField{
+			{"API key", fmt.Sprintf("%s (%s)", d.Name, d.KeyId)},
+			{"Key", d.ApiKey},
+			{"Status", d.Status},
+			{"Concurrency", concurrency},
+			{"Created", truncateDateOffset(d.GmtCreate)},
+			{"Last used", truncateDateOffset(d.LastUseDate)},
+		},
+	}
+	if d.BoundPolicy != "" {
+		r.Detail = append(r.Detail, uiField{"Policy", d.BoundPolicy})
+	}
+	if d.ExpiresAt != "" {
+		r.Detail = append(r.Detail, uiField{"Expires", d.ExpiresAt})
+	}
+	if labels := meta.get(d.KeyId).labelString(); labels != "" {
+		r.Detail = append(r.Detail, uiField{"Labels", labels})
+	}
+	return r
+}
+
+func loadUIShares(ctx context.Context, apiClient agentbay.Client) ([]uiRow, error) {
+	var rows []uiRow
+	for _, direction := range []string{"Outgoing", "Incoming"} {
+		expiries := shareGrantExpiries(direction)
+		_, err := walkPages(ctx, listPaging{All: true}, pageCursor{PageNo: 1}, sharedRepoPages(apiClient, direction, 0, 50, io.Discard),
+			func(item *client.ListSharedDockerReposResponseBodyDataItem) error {
+				if item != nil {
+					rows = append(rows, newUIShareRow(newShareListItem(item, expiries), direction))
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+func newUIShareRow(s shareListItem, direction string) uiRow {
+	uid := strconv.FormatInt(s.PeerAliUid, 10)
+	expires := s.ExpiresAt
+	if expires == "" {
+		expires = "-"
+	}
+	return uiRow{
+		ID:     uid,
+		Cells:  []string{direction, uid, s.Status, expires},
+		Kind:   direction,
+		Status: s.Status,
+		Detail: []uiField{
+			{"Peer UID", uid},
+			{"Direction", direction},
+			{"Status", s.Status},
+			{"Expires", expires},
+		},
+	}
+}
@@ -0,0 +1,553 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// ui_model.go holds the state of "agentbay ui" without any terminal or API access: decoding
+// keypresses, fuzzy filtering, key handling and rendering. ui.go feeds it rows and performs the
+// effects that handleKey asks for.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+)
+
+// ---------------------------------------------------------------------------
+// Keys
+// ---------------------------------------------------------------------------
+
+type uiKeyCode int
+
+const (
+	uiKeyRune uiKeyCode = iota
+	uiKeyEnter
+	uiKeyEsc
+	uiKeyBackspace
+	uiKeyTab
+	uiKeyBackTab
+	uiKeyUp
+	uiKeyDown
+	uiKeyLeft
+	uiKeyRight
+	uiKeyPgUp
+	uiKeyPgDown
+	uiKeyHome
+	uiKeyEnd
+	uiKeyCtrlC
+)
+
+// uiKey is one decoded keypress; Rune is set for uiKeyRune.
+type uiKey struct {
+	Code uiKeyCode
+	Rune rune
+}
+
+// uiEscapeKeys maps the CSI/SS3 sequences sent by common terminals (after ESC) to keys.
+var uiEscapeKeys = map[string]uiKeyCode{
+	"[A": uiKeyUp, "[B": uiKeyDown, "[C": uiKeyRight, "[D": uiKeyLeft,
+	"OA": uiKeyUp, "OB": uiKeyDown, "OC": uiKeyRight, "OD": uiKeyLeft,
+	"[Z": uiKeyBackTab, "[5~": uiKeyPgUp, "[6~": uiKeyPgDown,
+	"[H": uiKeyHome, "[F": uiKeyEnd, "OH": uiKeyHome, "OF": uiKeyEnd,
+	"[1~": uiKeyHome, "[4~": uiKeyEnd, "[7~": uiKeyHome, "[8~": uiKeyEnd,
+}
+
+// parseUIKeys decodes the bytes of one terminal read. A lone ESC is the Escape key; unknown
+// escape sequences are dropped.
+func parseUIKeys(b []byte) []uiKey {
+	var keys []uiKey
+	s := string(b)
+	for len(s) > 0 {
+		switch c := s[0]; {
+		case c == 0x1b:
+			if len(s) == 1 || (s[1] != '[' && s[1] != 'O') {
+				keys = append(keys, uiKey{Code: uiKeyEsc})
+				s = s[1:]
+				continue
+			}
+			// A sequence ends at the first byte in 0x40..0x7e after its introducer.
+			end := 2
+			for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+				end++
+			}
+			if end == len(s) {
+				return keys
+			}
+			if code, ok := uiEscapeKeys[s[1:end+1]]; ok {
+				keys = append(keys, uiKey{Code: code})
+			}
+			s = s[end+1:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, uiKey{Code: uiKeyEnter})
+			s = s[1:]
+		case c == '\t':
+			keys = append(keys, uiKey{Code: uiKeyTab})
+			s = s[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, uiKey{Code: uiKeyBackspace})
+			s = s[1:]
+		case c == 0x03:
+			keys = append(keys, uiKey{Code: uiKeyCtrlC})
+			s = s[1:]
+		case c < 0x20:
+			s = s[1:]
+		default:
+			r := []rune(s)[0]
+			keys = append(keys, uiKey{Code: uiKeyRune, Rune: r})
+			s = s[len(string(r)):]
+		}
+	}
+	return keys
+}
+
+// ---------------------------------------------------------------------------
+// Tabs, rows and actions
+// ---------------------------------------------------------------------------
+
+// uiField is one "Label: value" line of the detail pane.
+type uiField struct {
+	Label string
+	Value string
+}
+
+// uiRow is one item of a tab. Kind is the item's sub-type (image type, share direction) and
+// Status its raw status; both drive which actions apply.
+type uiRow struct {
+	ID     string
+	Cells  []string
+	Kind   string
+	Status string
+	Detail []uiField
+}
+
+type uiColumn struct {
+	Title string
+	Width int
+}
+
+// uiAction is a key binding that runs an existing command on the selected row after the user
+// confirms. allowed returns why the action does not apply to a row, or "" when it does.
+type uiAction struct {
+	Key     rune
+	Verb    string
+	Command string
+	allowed func(r uiRow) string
+	run     func(r uiRow) error
+}
+
+// commandLine is the equivalent CLI invocation, shown before the action runs.
+func (a *uiAction) commandLine(r uiRow) string {
+	return fmt.Sprintf(a.Command, r.ID)
+}
+
+type uiTab struct {
+	Name    string
+	Columns []uiColumn
+	Actions []uiAction
+	// load fetches every row of the tab; describe, when set, fetches extra detail for one row.
+	load     func(ctx context.Context, apiClient agentbay.Client) ([]uiRow, error)
+	describe func(ctx context.Context, apiClient agentbay.Client, r uiRow) []uiField
+	// describeHint is shown in the detail pane until describe has run for the row.
+	describeHint string
+
+	rows   []uiRow
+	loaded bool
+	err    string
+	filter string
+	view   []int
+	cursor int
+	offset int
+	extra  map[string][]uiField
+}
+
+// setRows replaces the rows after a (re)load, keeping the filter and, where possible, the
+// selected item.
+func (t *uiTab) setRows(rows []uiRow) {
+	selected := ""
+	if r := t.selected(); r != nil {
+		selected = r.ID
+	}
+	t.rows, t.loaded, t.err = rows, true, ""
+	t.extra = nil
+	t.applyFilter()
+	for i, idx := range t.view {
+		if t.rows[idx].ID == selected {
+			t.cursor = i
+		}
+	}
+}
+
+// applyFilter recomputes the visible rows: all rows in order without a filter, otherwise the
+// fuzzy matches, best first.
+func (t *uiTab) applyFilter() {
+	t.view = t.view[:0]
+	type scored struct{ idx, score int }
+	var matches []scored
+	for i, r := range t.rows {
+		if t.filter == "" {
+			t.view = append(t.view, i)
+			continue
+		}
+		if score, ok := fuzzyScore(t.filter, r.ID+" "+strings.Join(r.Cells, " ")); ok {
+			matches = append(matches, scored{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	for _, m := range matches {
+		t.view = append(t.view, m.idx)
+	}
+	t.cursor, t.offset = 0, 0
+}
+
+func (t *uiTab) selected() *uiRow {
+	if t.cursor < 0 || t.cursor >= len(t.view) {
+		return nil
+	}
+	return &t.rows[t.view[t.cursor]]
+}
+
+func (t *uiTab) move(delta int) {
+	t.cursor = max(0, min(t.cursor+delta, len(t.view)-1))
+}
+
+func (t *uiTab) action(key rune) *uiAction {
+	for i := range t.Actions {
+		if t.Actions[i].Key == key {
+			return &t.Actions[i]
+		}
+	}
+	return nil
+}
+
+// fuzzyScore reports whether the runes of pattern appear in text in order, ignoring case, and
+// scores the match: runes that follow the previous match or start a word score higher.
+func fuzzyScore(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	score, pi, prevMatch := 0, 0, -2
+	prev := ' '
+	for i, r := range []rune(strings.ToLower(text)) {
+		if pi < len(p) && r == p[pi] {
+			score++
+			if prevMatch == i-1 {
+				score += 3
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 2
+			}
+			prevMatch = i
+			pi++
+		}
+		prev = r
+	}
+	return score, pi == len(p)
+}
+
+// ---------------------------------------------------------------------------
+// Model
+// ---------------------------------------------------------------------------
+
+type uiMode int
+
+const (
+	uiModeBrowse uiMode = iota
+	uiModeFilter
+	uiModeConfirm
+)
+
+// uiEffect is what the terminal loop has to do after a key.
+type uiEffect int
+
+const (
+	uiEffectNone uiEffect = iota
+	uiEffectQuit
+	uiEffectLoad
+	uiEffectDescribe
+	uiEffectRun
+)
+
+type uiModel struct {
+	tabs    []*uiTab
+	active  int
+	mode    uiMode
+	pending *uiAction
+	message string
+}
+
+func (m *uiModel) tab() *uiTab {
+	return m.tabs[m.active]
+}
+
+// switchTab activates tab i and asks for a load the first time it is shown.
+func (m *uiModel) switchTab(i int) uiEffect {
+	m.active = (i + len(m.tabs)) % len(m.tabs)
+	m.message = ""
+	if !m.tab().loaded {
+		return uiEffectLoad
+	}
+	return uiEffectNone
+}
+
+// handleKey applies one keypress and returns the effect the caller has to perform.
+func (m *uiModel) handleKey(k uiKey) uiEffect {
+	if k.Code == uiKeyCtrlC {
+		return uiEffectQuit
+	}
+	t := m.tab()
+	switch m.mode {
+	case uiModeConfirm:
+		m.mode = uiModeBrowse
+		if k.Code == uiKeyRune && (k.Rune == 'y' || k.Rune == 'Y') {
+			return uiEffectRun
+		}
+		m.pending = nil
+		m.message = "Cancelled."
+		return uiEffectNone
+	case uiModeFilter:
+		switch k.Code {
+		case uiKeyEnter:
+			m.mode = uiModeBrowse
+		case uiKeyEsc:
+			m.mode = uiModeBrowse
+			t.filter = ""
+			t.applyFilter()
+		case uiKeyBackspace:
+			if r := []rune(t.filter); len(r) > 0 {
+				t.filter = string(r[:len(r)-1])
+				t.applyFilter()
+			}
+		case uiKeyRune:
+			t.filter += string(k.Rune)
+			t.applyFilter()
+		case uiKeyUp:
+			t.move(-1)
+		case uiKeyDown:
+			t.move(1)
+		}
+		return uiEffectNone
+	}
+
+	switch k.Code {
+	case uiKeyTab, uiKeyRight:
+		return m.switchTab(m.active + 1)
+	case uiKeyBackTab, uiKeyLeft:
+		return m.switchTab(m.active - 1)
+	case uiKeyUp:
+		t.move(-1)
+	case uiKeyDown:
+		t.move(1)
+	case uiKeyPgUp:
+		t.move(-10)
+	case uiKeyPgDown:
+		t.move(10)
+	case uiKeyHome:
+		t.move(-len(t.view))
+	case uiKeyEnd:
+		t.move(len(t.view))
+	case uiKeyEsc:
+		if t.filter != "" {
+			t.filter = ""
+			t.applyFilter()
+		}
+	case uiKeyEnter:
+		if r := t.selected(); r != nil && t.describe != nil {
+			return uiEffectDescribe
+		}
+	case uiKeyRune:
+		return m.handleRune(k.Rune)
+	}
+	return uiEffectNone
+}
+
+func (m *uiModel) handleRune(r rune) uiEffect {
+	t := m.tab()
+	switch {
+	case r == 'q':
+		return uiEffectQuit
+	case r >= '1' && r <= '9' && int(r-'1') < len(m.tabs):
+		return m.switchTab(int(r - '1'))
+	case r == 'j':
+		t.move(1)
+	case r == 'k':
+		t.move(-1)
+	case r == 'g':
+		t.move(-len(t.view))
+	case r == 'G':
+		t.move(len(t.view))
+	case r == '/':
+		m.mode = uiModeFilter
+		m.message = ""
+	case r == 'r':
+		return uiEffectLoad
+	default:
+		a := t.action(r)
+		row := t.selected()
+		if a == nil || row == nil {
+			return uiEffectNone
+		}
+		if reason := a.allowed(*row); reason != "" {
+			m.message = fmt.Sprintf("[WARN] %s: %s", a.Verb, reason)
+			return uiEffectNone
+		}
+		m.pending = a
+		m.mode = uiModeConfirm
+	}
+	return uiEffectNone
+}
+
+// ---------------------------------------------------------------------------
+// Rendering
+// ---------------------------------------------------------------------------
+
+const (
+	uiReverse = "\x1b[7m"
+	uiBold    = "\x1b[1m"
+	uiDim     = "\x1b[2m"
+	uiReset   = "\x1b[0m"
+)
+
+// uiFit truncates or pads s to exactly width display columns.
+func uiFit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return padString(truncateString(s, width), width)
+}
+
+// view renders the screen as width x height lines (without line terminators). It also scrolls
+// the list so the cursor stays visible.
+func (m *uiModel) view(width, height int) []string {
+	t := m.tab()
+	lines := make([]string, 0, height)
+
+	// Tab bar
+	var bar strings.Builder
+	plain := 0
+	for i, tab := range m.tabs {
+		label := fmt.Sprintf(" %d %s ", i+1, tab.Name)
+		if tab.loaded {
+			label = fmt.Sprintf(" %d %s (%d) ", i+1, tab.Name, len(tab.rows))
+		}
+		if plain+displayWidth(label) > width {
+			break
+		}
+		plain += displayWidth(label)
+		if i == m.active {
+			bar.WriteString(uiReverse + label + uiReset)
+		} else {
+			bar.WriteString(label)
+		}
+	}
+	lines = append(lines, bar.String())
+
+	// Filter line
+	switch {
+	case m.mode == uiModeFilter:
+		lines = append(lines, uiFit("/"+t.filter+"_", width))
+	case t.filter != "":
+		lines = append(lines, uiFit(fmt.Sprintf("/%s  (%d of %d, Esc to clear)", t.filter, len(t.view), len(t.rows)), width))
+	default:
+		lines = append(lines, uiDim+uiFit("/ to search", width)+uiReset)
+	}
+
+	detailHeight := min(12, max(4, height/3))
+	listHeight := max(1, height-len(lines)-1-detailHeight-2)
+
+	// Column header and rows
+	var header []string
+	for _, c := range t.Columns {
+		header = append(header, padString(c.Title, c.Width))
+	}
+	lines = append(lines, uiBold+uiFit(strings.Join(header, " "), width)+uiReset)
+
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+listHeight {
+		t.offset = t.cursor - listHeight + 1
+	}
+	for i := 0; i < listHeight; i++ {
+		idx := t.offset + i
+		switch {
+		case idx < len(t.view):
+			r := t.rows[t.view[idx]]
+			var cells []string
+			for ci, c := range t.Columns {
+				cell := ""
+				if ci < len(r.Cells) {
+					cell = r.Cells[ci]
+				}
+				cells = append(cells, uiFit(cell, c.Width))
+			}
+			line := uiFit(strings.Join(cells, " "), width)
+			if idx == t.cursor {
+				line = uiReverse + line + uiReset
+			}
+			lines = append(lines, line)
+		case i == 0 && t.err != "":
+			lines = append(lines, uiFit(t.err, width))
+		case i == 0 && !t.loaded:
+			lines = append(lines, uiFit("Loading...", width))
+		case i == 0:
+			lines = append(lines, uiFit("[EMPTY] Nothing to show.", width))
+		default:
+			lines = append(lines, "")
+		}
+	}
+
+	// Detail pane
+	lines = append(lines, uiDim+strings.Repeat("─", width)+uiReset)
+	var detail []uiField
+	if r := t.selected(); r != nil {
+		detail = append(detail, r.Detail...)
+		if extra, ok := t.extra[r.ID]; ok {
+			detail = append(detail, extra...)
+		} else if t.describeHint != "" {
+			detail = append(detail, uiField{Value: t.describeHint})
+		}
+	}
+	labelWidth := 0
+	for _, f := range detail {
+		labelWidth = max(labelWidth, displayWidth(f.Label))
+	}
+	for i := 0; i < detailHeight; i++ {
+		if i >= len(detail) {
+			lines = append(lines, "")
+			continue
+		}
+		f := detail[i]
+		if f.Label == "" {
+			lines = append(lines, uiFit(f.Value, width))
+			continue
+		}
+		lines = append(lines, uiFit(padString(f.Label+":", labelWidth+1)+" "+f.Value, width))
+	}
+
+	// Status line
+	lines = append(lines, m.statusLine(width))
+	return lines
+}
+
+func (m *uiModel) statusLine(width int) string {
+	t := m.tab()
+	if m.mode == uiModeConfirm && m.pending != nil {
+		if r := t.selected(); r != nil {
+			return uiBold + uiFit(fmt.Sprintf("%s %s? Runs: %s  [y/N]", m.pending.Verb, r.ID, m.pending.commandLine(*r)), width) + uiReset
+		}
+	}
+	if m.message != "" {
+		return uiFit(m.message, width)
+	}
+	help := []string{"↑/↓ move", "←/→ tab", "/ search"}
+	if t.describe != nil {
+		help = append(help, "enter details")
+	}
+	for _, a := range t.Actions {
+		help = append(help, fmt.Sprintf("%c %s", a.Key, strings.ToLower(a.Verb)))
+	}
+	help = append(help, "r reload", "q quit")
+	return uiDim + uiFit(strings.Join(help, "  "), width) + uiReset
+}
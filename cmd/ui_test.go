@@ -0,0 +1,183 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestParseUIKeys(t *testing.T) {
+	keys := parseUIKeys([]byte("a\x1b[A\x1b[B\r\x1b\x7f\t\x1b[Z\x1b[5~\x1bOC\x03é\x1b[99~"))
+	assert.Equal(t, []uiKey{
+		{Code: uiKeyRune, Rune: 'a'}, {Code: uiKeyUp}, {Code: uiKeyDown}, {Code: uiKeyEnter},
+		{Code: uiKeyEsc}, {Code: uiKeyBackspace}, {Code: uiKeyTab}, {Code: uiKeyBackTab},
+		{Code: uiKeyPgUp}, {Code: uiKeyRight}, {Code: uiKeyCtrlC}, {Code: uiKeyRune, Rune: 'é'},
+	}, keys, "unknown sequences are dropped")
+	assert.Equal(t, []uiKey{{Code: uiKeyEsc}}, parseUIKeys([]byte{0x1b}))
+}
+
+func TestFuzzyScore(t *testing.T) {
+	_, ok := fuzzyScore("imgcprod", "imgc-07abc prod-browser")
+	assert.True(t, ok)
+	_, ok = fuzzyScore("xyz", "imgc-07abc prod-browser")
+	assert.False(t, ok)
+	exact, _ := fuzzyScore("prod", "imgc-1 prod-browser")
+	scattered, _ := fuzzyScore("prod", "imgc-1 p-r-o-d")
+	assert.Greater(t, exact, scattered, "consecutive matches rank higher")
+}
+
+func newTestUIModel(ran *[]string) *uiModel {
+	images := &uiTab{
+		Name:    "Images",
+		Columns: []uiColumn{{"IMAGE ID", 12}, {"NAME", 12}},
+		Actions: []uiAction{
+			{Key: 'a', Verb: "Activate", Command: "agentbay image activate %s", allowed: uiImageCan("activate"),
+				run: func(r uiRow) error { *ran = append(*ran, "activate "+r.ID); return nil }},
+			{Key: 'd', Verb: "Deactivate", Command: "agentbay image deactivate %s", allowed: uiImageCan("deactivate"),
+				run: func(r uiRow) error { *ran = append(*ran, "deactivate "+r.ID); return nil }},
+		},
+	}
+	images.setRows([]uiRow{
+		{ID: "imgc-aaa", Cells: []string{"imgc-aaa", "web-browser"}, Kind: "User", Status: "IMAGE_AVAILABLE"},
+		{ID: "imgc-bbb", Cells: []string{"imgc-bbb", "data-agent"}, Kind: "User", Status: "RESOURCE_PUBLISHED"},
+		{ID: "linux_latest", Cells: []string{"linux_latest", "Linux"}, Kind: "System", Status: "IMAGE_AVAILABLE"},
+	})
+	skills := &uiTab{Name: "Skills"}
+	return &uiModel{tabs: []*uiTab{images, skills}}
+}
+
+func TestUIModelFilterAndNavigate(t *testing.T) {
+	var ran []string
+	m := newTestUIModel(&ran)
+	tab := m.tab()
+
+	assert.Equal(t, uiEffectNone, m.handleKey(uiKey{Code: uiKeyRune, Rune: '/'}))
+	for _, r := range "agent" {
+		m.handleKey(uiKey{Code: uiKeyRune, Rune: r})
+	}
+	require.Len(t, tab.view, 1)
+	assert.Equal(t, "imgc-bbb", tab.selected().ID)
+	m.handleKey(uiKey{Code: uiKeyEnter})
+	assert.Equal(t, uiModeBrowse, m.mode)
+	assert.Equal(t, "agent", tab.filter, "Enter keeps the filter")
+
+	m.handleKey(uiKey{Code: uiKeyEsc})
+	assert.Len(t, tab.view, 3, "Esc clears the filter")
+	m.handleKey(uiKey{Code: uiKeyEnd})
+	assert.Equal(t, "linux_latest", tab.selected().ID)
+	m.handleKey(uiKey{Code: uiKeyDown})
+	assert.Equal(t, "linux_latest", tab.selected().ID, "the cursor stops at the last row")
+
+	assert.Equal(t, uiEffectLoad, m.handleKey(uiKey{Code: uiKeyTab}), "an unloaded tab is loaded on first show")
+	assert.Equal(t, 1, m.active)
+	assert.Equal(t, uiEffectNone, m.handleKey(uiKey{Code: uiKeyRune, Rune: '1'}))
+	assert.Equal(t, 0, m.active)
+	assert.Equal(t, uiEffectQuit, m.handleKey(uiKey{Code: uiKeyRune, Rune: 'q'}))
+}
+
+func TestUIModelActionsNeedConfirmation(t *testing.T) {
+	var ran []string
+	m := newTestUIModel(&ran)
+	tab := m.tab()
+
+	// imgc-aaa is not activated, so deactivate does not apply.
+	assert.Equal(t, uiEffectNone, m.handleKey(uiKey{Code: uiKeyRune, Rune: 'd'}))
+	assert.Equal(t, uiModeBrowse, m.mode)
+	assert.Contains(t, m.message, "Deactivate")
+
+	m.handleKey(uiKey{Code: uiKeyRune, Rune: 'a'})
+	assert.Equal(t, uiModeConfirm, m.mode)
+	assert.Contains(t, strings.Join(m.view(120, 24), "\n"), "agentbay image activate imgc-aaa")
+	assert.Equal(t, uiEffectNone, m.handleKey(uiKey{Code: uiKeyRune, Rune: 'n'}), "anything but y cancels")
+	assert.Nil(t, m.pending)
+
+	m.handleKey(uiKey{Code: uiKeyRune, Rune: 'a'})
+	assert.Equal(t, uiEffectRun, m.handleKey(uiKey{Code: uiKeyRune, Rune: 'y'}))
+	require.NoError(t, m.pending.run(*tab.selected()))
+	assert.Equal(t, []string{"activate imgc-aaa"}, ran)
+
+	m.handleKey(uiKey{Code: uiKeyEnd})
+	m.handleKey(uiKey{Code: uiKeyRune, Rune: 'a'})
+	assert.Contains(t, m.message, "system images", "system images have no actions")
+}
+
+func TestUIModelView(t *testing.T) {
+	var ran []string
+	m := newTestUIModel(&ran)
+	m.tab().extra = map[string][]uiField{"imgc-aaa": {{"Status", "Available"}}}
+	lines := m.view(60, 20)
+	assert.Len(t, lines, 20)
+	assert.Contains(t, lines[0], "1 Images (3)")
+	for _, line := range lines {
+		plain := strings.NewReplacer(uiReverse, "", uiBold, "", uiDim, "", uiReset, "").Replace(line)
+		assert.LessOrEqual(t, displayWidth(plain), 60)
+	}
+	assert.Contains(t, strings.Join(lines, "\n"), "Status: Available")
+
+	m.tabs[1].loaded, m.tabs[1].err = true, "[ERROR] Failed to load skills: boom"
+	m.active = 1
+	assert.Contains(t, strings.Join(m.view(60, 20), "\n"), "Failed to load skills")
+}
+
+func TestUIRowsAndDetail(t *testing.T) {
+	img := &client.ListMcpImagesResponseBodyData{ImageId: dara.String("imgc-1"), ImageName: dara.String("browser"), ImageResourceStatus: dara.String("RESOURCE_PUBLISHED")}
+	r := newUIImageRow(img, "User")
+	assert.Equal(t, "imgc-1", r.ID)
+	assert.Equal(t, "Activated", r.Cells[3])
+	assert.Empty(t, uiImageCan("deactivate")(r))
+	assert.NotEmpty(t, uiImageCan("delete")(r), "an activated image must be deactivated before deletion")
+
+	share := newUIShareRow(shareListItem{PeerAliUid: 42, Status: "Active"}, "Incoming")
+	assert.Equal(t, "42", share.ID)
+	assert.Equal(t, "-", share.Cells[3])
+
+	policy := uiPolicyFields(&client.DescribeMcpPolicyDataResponseBodyData{
+		PolicyId:         dara.String("pg-1"),
+		GroupSpec:        &client.GroupSpec{AppInstanceType: dara.String("eds.general.2c4g"), Cpu: dara.Int32(2), Memory: dara.Int32(4), RegionId: dara.String("cn-hangzhou")},
+		SandboxLifeCycle: &client.SandboxLifeCycle{Mode: dara.String("auto"), DesktopMaxRuntime: dara.Float64(60)},
+	})
+	assert.Equal(t, []uiField{
+		{"Policy", "pg-1"},
+		{"Spec", "eds.general.2c4g 2 vCPU / 4 GiB in cn-hangzhou"},
+		{"Lifecycle", "mode auto, max runtime 60m"},
+	}, policy)
+
+	preOpen := uiPreOpenFields("imgc-1", []*client.DescribeImageReserveMinAmountImage{{
+		ImageId: dara.String("imgc-1"),
+		ResourceGroups: []*client.DescribeImageReserveMinAmountResourceGroup{
+			{ResourceGroupType: dara.String("Default"), ReserveMinAmount: dara.Int32(2), MaxAmount: dara.Int32(10), ResourceGroupId: dara.String("rg-1"), Status: dara.String("Running")},
+		},
+	}})
+	assert.Equal(t, []uiField{{"Pre-open", "Default reserve 2 / max 10 (rg-1, Running)"}}, preOpen)
+	assert.Equal(t, []uiField{{"Pre-open", "-"}}, uiPreOpenFields("imgc-2", nil))
+}
+
+func TestRunUICommandResetsFlags(t *testing.T) {
+	var seen []string
+	c := &cobra.Command{Use: "probe", RunE: func(c *cobra.Command, args []string) error {
+		id, _ := c.Flags().GetString("id")
+		yes, _ := c.Flags().GetBool("yes")
+		seen = append(seen, id)
+		if !yes {
+			return errors.New("not confirmed")
+		}
+		return nil
+	}}
+	c.Flags().String("id", "", "")
+	c.Flags().Bool("yes", false, "")
+
+	require.NoError(t, runUICommand(c, nil, map[string]string{"id": "ak-1", "yes": "true"}))
+	assert.Error(t, runUICommand(c, nil, nil), "flags from the previous run are reset")
+	assert.Equal(t, []string{"ak-1", ""}, seen)
+	assert.False(t, c.Flags().Lookup("yes").Changed)
+}
@@ -0,0 +1,366 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// Response cache kinds. Each kind is stored in cache/<kind>.json in the CLI config directory, and a
+// write through the client removes the file of the kind it changes.
+const (
+	CacheImages  = "images"
+	CacheSkills  = "skills"
+	CacheApiKeys = "apikeys"
+	CacheShares  = "shares"
+)
+
+// DefaultCacheTTL is how long a cached list or describe response is served without --refresh.
+const DefaultCacheTTL = 5 * time.Minute
+
+// cacheRetention bounds how long an entry is kept for --offline use.
+const cacheRetention = 7 * 24 * time.Hour
+
+// CacheMode selects how a CachedClient uses the cache.
+type CacheMode int
+
+const (
+	// CacheDefault serves entries younger than the TTL and calls the API otherwise.
+	CacheDefault CacheMode = iota
+	// CacheRefresh always calls the API and stores the response.
+	CacheRefresh
+	// CacheOffline never calls the API and serves entries of any age.
+	CacheOffline
+)
+
+// ErrNotCached is returned in CacheOffline mode when no response is cached for a request.
+var ErrNotCached = errors.New("no cached response")
+
+// cacheFileVersion is bumped when the stored form changes; files of other versions are rebuilt.
+// Version 2 hashes the entry keys and masks API keys.
+const cacheFileVersion = 2
+
+type cacheFile struct {
+	Version int                    `json:"version"`
+	Entries map[string]*cacheEntry `json:"entries"`
+}
+
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Response  json.RawMessage `json:"response"`
+}
+
+var (
+	cacheMu          sync.Mutex
+	cacheInvalidated []func(kind string)
+)
+
+// OnCacheInvalidate registers fn to be called with the kind whenever the entries of a kind are
+// invalidated, so that derived local caches can be dropped as well.
+func OnCacheInvalidate(fn func(kind string)) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cacheInvalidated = append(cacheInvalidated, fn)
+}
+
+func cacheDir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+func cacheStateFile(kind string) (*config.StateFile, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &config.StateFile{Path: filepath.Join(dir, kind+".json")}, nil
+}
+
+// InvalidateCache removes the cached responses of the given kinds.
+func InvalidateCache(kinds ...string) {
+	cacheMu.Lock()
+	hooks := append([]func(string){}, cacheInvalidated...)
+	for _, kind := range kinds {
+		f, err := cacheStateFile(kind)
+		if err != nil {
+			continue
+		}
+		// Without the lock, a response fetched before the write could be saved back after it.
+		unlock, lockErr := f.Lock()
+		_ = os.Remove(f.Path)
+		if lockErr == nil {
+			unlock()
+		}
+	}
+	cacheMu.Unlock()
+	for _, kind := range kinds {
+		for _, fn := range hooks {
+			fn(kind)
+		}
+	}
+}
+
+// ClearCache removes every cached response, e.g. when the account changes on login or logout.
+func ClearCache() error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	InvalidateCache(CacheImages, CacheSkills, CacheApiKeys, CacheShares)
+	return nil
+}
+
+func loadCacheFile(kind string) *cacheFile {
+	cf := &cacheFile{}
+	f, err := cacheStateFile(kind)
+	// A corrupt or outdated file is simply rebuilt.
+	if err != nil || f.Load(cf) != nil || cf.Entries == nil || cf.Version != cacheFileVersion {
+		cf.Entries = map[string]*cacheEntry{}
+	}
+	return cf
+}
+
+// updateCacheFile applies fn to the file of kind under its lock and saves it with 0600
+// permissions: responses describe the account.
+func updateCacheFile(kind string, fn func(cf *cacheFile)) error {
+	f, err := cacheStateFile(kind)
+	if err != nil {
+		return err
+	}
+	unlock, err := f.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	cf := loadCacheFile(kind)
+	fn(cf)
+	cf.Version = cacheFileVersion
+	return f.Save(cf)
+}
+
+// cacheKey identifies a request: the endpoint and credential it is sent with, the action and the
+// request parameters. It is hashed, because the parameters can hold a user-visible API key.
+func cacheKey(action string, request any) (string, error) {
+	params, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	endpoint, account := CurrentAccount()
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s", endpoint, account, action, params)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CurrentAccount returns the endpoint requests are sent to and the account they are sent as: the
+// AccessKey ID from the environment, or "oauth" for the login session.
+func CurrentAccount() (endpoint, account string) {
+	account = "oauth"
+	if ak, _, _, ok := config.AccessKeyFromEnv(); ok {
+		account = ak
+	}
+	return config.LoadAPIConfig(nil).Endpoint, account
+}
+
+// CachedClient serves the list and describe calls of the wrapped Client from the on-disk cache
+// according to its mode. All other calls go to the wrapped Client unchanged.
+type CachedClient struct {
+	Client
+	// Cacheable reports whether a response may be stored, e.g. not while an image is still
+	// changing state. Nil stores every successful response. Responses whose body reports a
+	// failure are never stored.
+	Cacheable func(response any) bool
+
+	mode CacheMode
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu     sync.Mutex
+	oldest time.Time
+}
+
+// NewCachedClient wraps inner with the response cache. A ttl of 0 means DefaultCacheTTL.
+func NewCachedClient(inner Client, mode CacheMode, ttl time.Duration) *CachedClient {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &CachedClient{Client: inner, mode: mode, ttl: ttl, now: time.Now}
+}
+
+// CachedSince returns when the oldest response served from the cache was fetched, and false when
+// every response came from the API.
+func (c *CachedClient) CachedSince() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.oldest, !c.oldest.IsZero()
+}
+
+func (c *CachedClient) served(fetchedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.oldest.IsZero() || fetchedAt.Before(c.oldest) {
+		c.oldest = fetchedAt
+	}
+}
+
+// cachedCall answers request from the cache of kind when the mode allows it, and otherwise calls
+// the API and stores a successful response. A response counts as successful only when both the
+// call and its body succeeded, so throttling and other API errors are never replayed. When scrub
+// is set, it is applied to the stored copy of the response, never to the one returned.
+func cachedCall[Req any, Resp any](c *CachedClient, ctx context.Context, kind, action string, request Req, call func(context.Context, Req) (Resp, error), scrub func(Resp)) (Resp, error) {
+	key, err := cacheKey(action, request)
+	if err != nil {
+		return call(ctx, request)
+	}
+	now := c.now()
+	if c.mode != CacheRefresh {
+		cacheMu.Lock()
+		entry := loadCacheFile(kind).Entries[key]
+		cacheMu.Unlock()
+		if entry != nil && (c.mode == CacheOffline || (now.Sub(entry.FetchedAt) < c.ttl && !entry.FetchedAt.After(now))) {
+			var resp Resp
+			if json.Unmarshal(entry.Response, &resp) == nil {
+				c.served(entry.FetchedAt)
+				return resp, nil
+			}
+		}
+		if c.mode == CacheOffline {
+			var zero Resp
+			return zero, fmt.Errorf("%s: %w; run without --offline to fetch it", action, ErrNotCached)
+		}
+	}
+
+	resp, err := call(ctx, request)
+	if err != nil || !responseSucceeded(resp) || (c.Cacheable != nil && !c.Cacheable(resp)) {
+		return resp, err
+	}
+	data, mErr := json.Marshal(resp)
+	if mErr == nil && scrub != nil {
+		var stored Resp
+		if mErr = json.Unmarshal(data, &stored); mErr == nil {
+			scrub(stored)
+			data, mErr = json.Marshal(stored)
+		}
+	}
+	if mErr == nil {
+		cacheMu.Lock()
+		_ = updateCacheFile(kind, func(cf *cacheFile) {
+			for k, e := range cf.Entries {
+				if now.Sub(e.FetchedAt) > cacheRetention {
+					delete(cf.Entries, k)
+				}
+			}
+			cf.Entries[key] = &cacheEntry{FetchedAt: now.UTC(), Response: data}
+		})
+		cacheMu.Unlock()
+	}
+	return resp, nil
+}
+
+// ListMcpImages serves image list pages from the cache.
+func (c *CachedClient) ListMcpImages(ctx context.Context, request *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
+	return cachedCall(c, ctx, CacheImages, "ListMcpImages", request, c.Client.ListMcpImages, nil)
+}
+
+// GetMcpImageInfo serves image details from the cache.
+func (c *CachedClient) GetMcpImageInfo(ctx context.Context, request *client.GetMcpImageInfoRequest) (*client.GetMcpImageInfoResponse, error) {
+	return cachedCall(c, ctx, CacheImages, "GetMcpImageInfo", request, c.Client.GetMcpImageInfo, nil)
+}
+
+// ListMarketSkillByPage serves skill list pages from the cache.
+func (c *CachedClient) ListMarketSkillByPage(ctx context.Context, request *client.ListMarketSkillByPageRequest) (*client.ListMarketSkillByPageResponse, error) {
+	return cachedCall(c, ctx, CacheSkills, "ListMarketSkillByPage", request, c.Client.ListMarketSkillByPage, nil)
+}
+
+// DescribeMarketSkillDetail serves skill details from the cache.
+func (c *CachedClient) DescribeMarketSkillDetail(ctx context.Context, request *client.DescribeMarketSkillDetailRequest) (*client.DescribeMarketSkillDetailResponse, error) {
+	return cachedCall(c, ctx, CacheSkills, "DescribeMarketSkillDetail", request, c.Client.DescribeMarketSkillDetail, nil)
+}
+
+// DescribeApiKeys serves API key list pages from the cache.
+func (c *CachedClient) DescribeApiKeys(ctx context.Context, request *client.DescribeApiKeysRequest) (*client.DescribeApiKeysResponse, error) {
+	return cachedCall(c, ctx, CacheApiKeys, "DescribeApiKeys", request, c.Client.DescribeApiKeys, maskApiKeys)
+}
+
+// ListSharedDockerRepos serves Docker repo share list pages from the cache.
+func (c *CachedClient) ListSharedDockerRepos(ctx context.Context, request *client.ListSharedDockerReposRequest) (*client.ListSharedDockerReposResponse, error) {
+	return cachedCall(c, ctx, CacheShares, "ListSharedDockerRepos", request, c.Client.ListSharedDockerRepos, nil)
+}
+
+// DescribeMcpApiKey serves the lookup of a user-visible API key from the cache.
+func (c *CachedClient) DescribeMcpApiKey(ctx context.Context, request *client.DescribeMcpApiKeyRequest) (*client.DescribeMcpApiKeyResponse, error) {
+	return cachedCall(c, ctx, CacheApiKeys, "DescribeMcpApiKey", request, c.Client.DescribeMcpApiKey, nil)
+}
+
+// responseSucceeded reports whether the body of resp reports success: Success is true, or, for
+// APIs without Success, Code is empty, "ok" or an HTTP 2xx status. This is the rule the SDK's
+// typed errors use. A response without a body is
+// not a success.
+func responseSucceeded(resp any) bool {
+	v := reflect.ValueOf(resp)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	body := v.Elem().FieldByName("Body")
+	if !body.IsValid() || body.Kind() != reflect.Ptr || body.IsNil() || body.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	fields := body.Elem()
+	if f := fields.FieldByName("Success"); f.IsValid() && f.Kind() == reflect.Ptr && !f.IsNil() && f.Elem().Kind() == reflect.Bool {
+		return f.Elem().Bool()
+	}
+	code := fields.FieldByName("Code")
+	if code.IsValid() && code.Kind() == reflect.Ptr && !code.IsNil() {
+		code = code.Elem()
+	}
+	if code.IsValid() && code.Kind() == reflect.String && code.String() != "" {
+		c := code.String()
+		return strings.EqualFold(c, "ok") || (len(c) == 3 && c[0] == '2')
+	}
+	return true
+}
+
+// maskApiKeys masks the user-visible keys of a DescribeApiKeys response, so that no plaintext key
+// is written to the cache.
+func maskApiKeys(resp *client.DescribeApiKeysResponse) {
+	if resp == nil || resp.Body == nil || resp.Body.Data == nil {
+		return
+	}
+	for _, key := range resp.Body.Data.ApiKeys {
+		if key != nil && key.ApiKey != nil {
+			masked := MaskSecret(*key.ApiKey)
+			key.ApiKey = &masked
+		}
+	}
+}
+
+// MaskSecret keeps the akm- prefix and the last four characters of an API key.
+func MaskSecret(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	prefix := ""
+	if i := strings.Index(secret, "-"); i >= 0 && i < 5 {
+		prefix = secret[:i+1]
+	}
+	return prefix + "****" + secret[len(secret)-4:]
+}
@@ -6,9 +6,9 @@ package agentbay
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
-	openapiutil "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
 	"github.com/alibabacloud-go/tea/dara"
 
 	"github.com/agentbay/agentbay-cli/internal/auth"
@@ -70,11 +70,17 @@ type Client interface {
 	ListSharedDockerRepos(ctx context.Context, request *client.ListSharedDockerReposRequest) (*client.ListSharedDockerReposResponse, error)
 }
 
-// clientWrapper wraps the generated SDK client with additional functionality
+// clientWrapper wraps the generated SDK client with additional functionality. When the client
+// belongs to the CLI, methods that change images, skills, API keys or shares invalidate the
+// cached responses of that kind.
 type clientWrapper struct {
-	apiConfig *config.APIConfig
-	config    *config.Config
-	client    *client.Client
+	apiConfig   *config.APIConfig
+	config      *config.Config
+	credentials func(ctx context.Context) (Credentials, error)
+	httpClient  *http.Client
+	userAgent   string
+	client      *client.Client
+	cliCache    bool
 }
 
 // NewClient creates a new client wrapper with the given API configuration and config
@@ -82,6 +88,7 @@ func NewClient(apiConfig *config.APIConfig, cfg *config.Config) Client {
 	return &clientWrapper{
 		apiConfig: apiConfig,
 		config:    cfg,
+		cliCache:  true,
 	}
 }
 
@@ -91,24 +98,66 @@ func NewClientFromConfig(cfg *config.Config) Client {
 	return &clientWrapper{
 		apiConfig: &apiConfig,
 		config:    cfg,
+		cliCache:  true,
+	}
+}
+
+// NewClientWithOptions creates a client wrapper that takes its credentials, HTTP client and user
+// agent from opts instead of the CLI login. It is meant for callers other than the CLI and never
+// touches the CLI's response cache.
+func NewClientWithOptions(opts ClientOptions) Client {
+	apiConfig := opts.APIConfig
+	if apiConfig == nil {
+		loaded := config.LoadAPIConfig(nil)
+		apiConfig = &loaded
+	}
+	return &clientWrapper{
+		apiConfig:   apiConfig,
+		credentials: opts.Credentials,
+		httpClient:  opts.HTTPClient,
+		userAgent:   opts.UserAgent,
+	}
+}
+
+// invalidate drops the CLI's cached responses of kinds after a write made by the CLI.
+func (cw *clientWrapper) invalidate(kinds ...string) {
+	if cw.cliCache {
+		InvalidateCache(kinds...)
 	}
 }
 
 // getClient returns the underlying SDK client, creating it if necessary
-func (cw *clientWrapper) getClient() (*client.Client, error) {
+func (cw *clientWrapper) getClient(ctx context.Context) (*client.Client, error) {
+	if cw.credentials != nil {
+		creds, err := cw.credentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return cw.newSDKClient(ctx, creds)
+	}
+
 	if ak, sk, session, ok := config.AccessKeyFromEnv(); ok {
-		return newSDKClientWithAccessKeys(cw.apiConfig, ak, sk, session)
+		return cw.newSDKClient(ctx, Credentials{AccessKeyID: ak, AccessKeySecret: sk, SecurityToken: session})
 	}
 
-	// Refresh token if needed (checks expiry and refreshes automatically)
+	token, err := FreshToken(cw.config)
+	if err != nil {
+		return nil, err
+	}
+	return cw.newSDKClient(ctx, Credentials{BearerToken: token.AccessToken})
+}
+
+// FreshToken returns the OAuth token of cfg, refreshing it first when it has expired or is about
+// to expire.
+func FreshToken(cfg *config.Config) (*config.Token, error) {
 	// Create an adapter to bridge config.Config to auth.TokenConfig
 	tokenCfgAdapter := auth.NewConfigAdapter(
 		func() (string, string, time.Time, error) {
-			return cw.config.GetTokens()
+			return cfg.GetTokens()
 		},
-		cw.config.RefreshTokens,
-		cw.config.IsTokenExpired,
-		cw.config.ClearTokens,
+		cfg.RefreshTokens,
+		cfg.IsTokenExpired,
+		cfg.ClearTokens,
 	)
 
 	err := auth.RefreshTokenIfNeeded(tokenCfgAdapter, config.GetClientID())
@@ -116,27 +165,11 @@ func (cw *clientWrapper) getClient() (*client.Client, error) {
 		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
 	}
 
-	token, err := cw.config.GetToken()
+	token, err := cfg.GetToken()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get authentication token: %w", err)
 	}
-
-	endpoint := cw.apiConfig.Endpoint
-	openapiConfig := &openapiutil.Config{
-		// Use BearerToken for OAuth authentication (backend now supports BearerToken)
-		BearerToken:    dara.String(token.AccessToken),
-		Endpoint:       dara.String(endpoint),
-		ReadTimeout:    dara.Int(cw.apiConfig.TimeoutMs),
-		ConnectTimeout: dara.Int(cw.apiConfig.TimeoutMs),
-		UserAgent:      dara.String("AgentBay-CLI/1.0"),
-	}
-
-	sdkClient, err := client.NewClient(openapiConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create API client: %w", err)
-	}
-
-	return sdkClient, nil
+	return token, nil
 }
 
 // getRuntimeOptions returns default runtime options for SDK calls.
@@ -146,7 +179,7 @@ func (cw *clientWrapper) getRuntimeOptions() *dara.RuntimeOptions {
 
 // GetDockerFileStoreCredential wraps the SDK client method
 func (cw *clientWrapper) GetDockerFileStoreCredential(ctx context.Context, request *client.GetDockerFileStoreCredentialRequest) (*client.GetDockerFileStoreCredentialResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +188,7 @@ func (cw *clientWrapper) GetDockerFileStoreCredential(ctx context.Context, reque
 
 // GetMarketSkillCredential wraps the SDK client method
 func (cw *clientWrapper) GetMarketSkillCredential(ctx context.Context, request *client.GetMarketSkillCredentialRequest) (*client.GetMarketSkillCredentialResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +197,8 @@ func (cw *clientWrapper) GetMarketSkillCredential(ctx context.Context, request *
 
 // CreateMarketSkill wraps the SDK client method
 func (cw *clientWrapper) CreateMarketSkill(ctx context.Context, request *client.CreateMarketSkillRequest) (*client.CreateMarketSkillResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheSkills)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +207,8 @@ func (cw *clientWrapper) CreateMarketSkill(ctx context.Context, request *client.
 
 // UpdateMarketSkill wraps the SDK client method
 func (cw *clientWrapper) UpdateMarketSkill(ctx context.Context, request *client.UpdateMarketSkillRequest) (*client.CreateMarketSkillResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheSkills)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -192,7 +227,7 @@ func (cw *clientWrapper) UpdateMarketSkill(ctx context.Context, request *client.
 
 // DescribeMarketSkillDetail wraps the SDK client method
 func (cw *clientWrapper) DescribeMarketSkillDetail(ctx context.Context, request *client.DescribeMarketSkillDetailRequest) (*client.DescribeMarketSkillDetailResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +236,7 @@ func (cw *clientWrapper) DescribeMarketSkillDetail(ctx context.Context, request
 
 // ListTag wraps the SDK client method
 func (cw *clientWrapper) ListTag(ctx context.Context) (*client.ListTagResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -210,7 +245,7 @@ func (cw *clientWrapper) ListTag(ctx context.Context) (*client.ListTagResponse,
 
 // ListMarketSkillByPage wraps the SDK client method
 func (cw *clientWrapper) ListMarketSkillByPage(ctx context.Context, request *client.ListMarketSkillByPageRequest) (*client.ListMarketSkillByPageResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +254,8 @@ func (cw *clientWrapper) ListMarketSkillByPage(ctx context.Context, request *cli
 
 // DeleteMarketSkill wraps the SDK client method
 func (cw *clientWrapper) DeleteMarketSkill(ctx context.Context, request *client.DeleteMarketSkillRequest) (*client.DeleteMarketSkillResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheSkills)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -228,7 +264,7 @@ func (cw *clientWrapper) DeleteMarketSkill(ctx context.Context, request *client.
 
 // CreateTag wraps the SDK client method
 func (cw *clientWrapper) CreateTag(ctx context.Context, request *client.CreateTagRequest) (*client.CreateTagResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -237,7 +273,8 @@ func (cw *clientWrapper) CreateTag(ctx context.Context, request *client.CreateTa
 
 // CreateDockerImageTask wraps the SDK client method
 func (cw *clientWrapper) CreateDockerImageTask(ctx context.Context, request *client.CreateDockerImageTaskRequest) (*client.CreateDockerImageTaskResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheImages)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -246,7 +283,7 @@ func (cw *clientWrapper) CreateDockerImageTask(ctx context.Context, request *cli
 
 // GetDockerImageTask wraps the SDK client method
 func (cw *clientWrapper) GetDockerImageTask(ctx context.Context, request *client.GetDockerImageTaskRequest) (*client.GetDockerImageTaskResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -255,7 +292,7 @@ func (cw *clientWrapper) GetDockerImageTask(ctx context.Context, request *client
 
 // ListMcpImages wraps the SDK client method
 func (cw *clientWrapper) ListMcpImages(ctx context.Context, request *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -264,7 +301,8 @@ func (cw *clientWrapper) ListMcpImages(ctx context.Context, request *client.List
 
 // CreateResourceGroup wraps the SDK client method
 func (cw *clientWrapper) CreateResourceGroup(ctx context.Context, request *client.CreateResourceGroupRequest) (*client.CreateResourceGroupResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheImages)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -273,7 +311,8 @@ func (cw *clientWrapper) CreateResourceGroup(ctx context.Context, request *clien
 
 // DeleteResourceGroup wraps the SDK client method
 func (cw *clientWrapper) DeleteResourceGroup(ctx context.Context, request *client.DeleteResourceGroupRequest) (*client.DeleteResourceGroupResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheImages)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +321,8 @@ func (cw *clientWrapper) DeleteResourceGroup(ctx context.Context, request *clien
 
 // DeleteMcpImage wraps the SDK client method
 func (cw *clientWrapper) DeleteMcpImage(ctx context.Context, request *client.DeleteMcpImageRequest) (*client.DeleteMcpImageResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheImages)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +331,7 @@ func (cw *clientWrapper) DeleteMcpImage(ctx context.Context, request *client.Del
 
 // GetMcpImageInfo wraps the SDK client method
 func (cw *clientWrapper) GetMcpImageInfo(ctx context.Context, request *client.GetMcpImageInfoRequest) (*client.GetMcpImageInfoResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -300,7 +340,7 @@ func (cw *clientWrapper) GetMcpImageInfo(ctx context.Context, request *client.Ge
 
 // GetDockerfileTemplate wraps the SDK client method
 func (cw *clientWrapper) GetDockerfileTemplate(ctx context.Context, request *client.GetDockerfileTemplateRequest) (*client.GetDockerfileTemplateResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -309,7 +349,8 @@ func (cw *clientWrapper) GetDockerfileTemplate(ctx context.Context, request *cli
 
 // CreateApiKey wraps the SDK client method
 func (cw *clientWrapper) CreateApiKey(ctx context.Context, request *client.CreateApiKeyRequest) (*client.CreateApiKeyResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheApiKeys)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +359,8 @@ func (cw *clientWrapper) CreateApiKey(ctx context.Context, request *client.Creat
 
 // ModifyMcpApiKeyConfig wraps the SDK client method
 func (cw *clientWrapper) ModifyMcpApiKeyConfig(ctx context.Context, request *client.ModifyMcpApiKeyConfigRequest) (*client.ModifyMcpApiKeyConfigResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheApiKeys)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -327,7 +369,7 @@ func (cw *clientWrapper) ModifyMcpApiKeyConfig(ctx context.Context, request *cli
 
 // DescribeInstanceTypes wraps the SDK client method
 func (cw *clientWrapper) DescribeInstanceTypes(ctx context.Context, request *client.DescribeInstanceTypesRequest) (*client.DescribeInstanceTypesResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -336,7 +378,7 @@ func (cw *clientWrapper) DescribeInstanceTypes(ctx context.Context, request *cli
 
 // DescribeMcpPolicyData wraps the SDK client method
 func (cw *clientWrapper) DescribeMcpPolicyData(ctx context.Context, request *client.DescribeMcpPolicyDataRequest) (*client.DescribeMcpPolicyDataResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -345,7 +387,7 @@ func (cw *clientWrapper) DescribeMcpPolicyData(ctx context.Context, request *cli
 
 // SaveMcpPolicyData wraps the SDK client method
 func (cw *clientWrapper) SaveMcpPolicyData(ctx context.Context, request *client.SaveMcpPolicyDataRequest) (*client.SaveMcpPolicyDataResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +396,7 @@ func (cw *clientWrapper) SaveMcpPolicyData(ctx context.Context, request *client.
 
 // DescribeOfficeSites wraps the SDK client method
 func (cw *clientWrapper) DescribeOfficeSites(ctx context.Context, request *client.DescribeOfficeSitesRequest) (*client.DescribeOfficeSitesResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -363,7 +405,7 @@ func (cw *clientWrapper) DescribeOfficeSites(ctx context.Context, request *clien
 
 // CreateSimpleOfficeSite wraps the SDK client method
 func (cw *clientWrapper) CreateSimpleOfficeSite(ctx context.Context, request *client.CreateSimpleOfficeSiteRequest) (*client.CreateSimpleOfficeSiteResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +414,7 @@ func (cw *clientWrapper) CreateSimpleOfficeSite(ctx context.Context, request *cl
 
 // CreateMcpPolicyData wraps the SDK client method
 func (cw *clientWrapper) CreateMcpPolicyData(ctx context.Context, request *client.CreateModifyMcpPolicyDataRequest) (*client.CreateMcpPolicyDataResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -381,7 +423,7 @@ func (cw *clientWrapper) CreateMcpPolicyData(ctx context.Context, request *clien
 
 // ModifyMcpPolicyData wraps the SDK client method
 func (cw *clientWrapper) ModifyMcpPolicyData(ctx context.Context, request *client.CreateModifyMcpPolicyDataRequest) (*client.ModifyMcpPolicyDataResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -390,7 +432,7 @@ func (cw *clientWrapper) ModifyMcpPolicyData(ctx context.Context, request *clien
 
 // DescribeNetworkPackages wraps the SDK client method
 func (cw *clientWrapper) DescribeNetworkPackages(ctx context.Context, request *client.DescribeNetworkPackagesRequest) (*client.DescribeNetworkPackagesResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -399,7 +441,8 @@ func (cw *clientWrapper) DescribeNetworkPackages(ctx context.Context, request *c
 
 // BatchCreateHideResourceGroupsWithMaxSession wraps the SDK client method
 func (cw *clientWrapper) BatchCreateHideResourceGroupsWithMaxSession(ctx context.Context, request *client.BatchCreateHideResourceGroupsWithMaxSessionRequest) (*client.BatchCreateHideResourceGroupsWithMaxSessionResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheImages)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -408,7 +451,8 @@ func (cw *clientWrapper) BatchCreateHideResourceGroupsWithMaxSession(ctx context
 
 // UpdateImageReserveMinAmount wraps the SDK client method
 func (cw *clientWrapper) UpdateImageReserveMinAmount(ctx context.Context, request *client.UpdateImageReserveMinAmountRequest) (*client.UpdateImageReserveMinAmountResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheImages)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -417,7 +461,7 @@ func (cw *clientWrapper) UpdateImageReserveMinAmount(ctx context.Context, reques
 
 // DescribeMcpApiKey wraps the SDK client method
 func (cw *clientWrapper) DescribeMcpApiKey(ctx context.Context, request *client.DescribeMcpApiKeyRequest) (*client.DescribeMcpApiKeyResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -426,7 +470,8 @@ func (cw *clientWrapper) DescribeMcpApiKey(ctx context.Context, request *client.
 
 // ModifyApiKeyStatus wraps the SDK client method
 func (cw *clientWrapper) ModifyApiKeyStatus(ctx context.Context, request *client.ModifyApiKeyStatusRequest) (*client.ModifyApiKeyStatusResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheApiKeys)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +480,8 @@ func (cw *clientWrapper) ModifyApiKeyStatus(ctx context.Context, request *client
 
 // DeleteApiKey wraps the SDK client method
 func (cw *clientWrapper) DeleteApiKey(ctx context.Context, request *client.DeleteApiKeyRequest) (*client.DeleteApiKeyResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheApiKeys)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -444,7 +490,7 @@ func (cw *clientWrapper) DeleteApiKey(ctx context.Context, request *client.Delet
 
 // DescribeApiKeys wraps the SDK client method
 func (cw *clientWrapper) DescribeApiKeys(ctx context.Context, request *client.DescribeApiKeysRequest) (*client.DescribeApiKeysResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -453,7 +499,7 @@ func (cw *clientWrapper) DescribeApiKeys(ctx context.Context, request *client.De
 
 // DescribeKeyContent wraps the SDK client method
 func (cw *clientWrapper) DescribeKeyContent(ctx context.Context, request *client.DescribeKeyContentRequest) (*client.DescribeKeyContentResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -462,7 +508,7 @@ func (cw *clientWrapper) DescribeKeyContent(ctx context.Context, request *client
 
 // DescribeWarmUpStatusOpen wraps the SDK client method
 func (cw *clientWrapper) DescribeWarmUpStatusOpen(ctx context.Context, request *client.DescribeWarmUpStatusOpenRequest) (*client.DescribeWarmUpStatusOpenResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -471,7 +517,7 @@ func (cw *clientWrapper) DescribeWarmUpStatusOpen(ctx context.Context, request *
 
 // DescribeImageReserveMinAmount wraps the SDK client method
 func (cw *clientWrapper) DescribeImageReserveMinAmount(ctx context.Context, request *client.DescribeImageReserveMinAmountRequest) (*client.DescribeImageReserveMinAmountResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -480,7 +526,8 @@ func (cw *clientWrapper) DescribeImageReserveMinAmount(ctx context.Context, requ
 
 // ShareDockerRepo wraps the SDK client method
 func (cw *clientWrapper) ShareDockerRepo(ctx context.Context, request *client.ShareDockerRepoRequest) (*client.ShareDockerRepoResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheShares)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -489,7 +536,8 @@ func (cw *clientWrapper) ShareDockerRepo(ctx context.Context, request *client.Sh
 
 // UnshareDockerRepo wraps the SDK client method
 func (cw *clientWrapper) UnshareDockerRepo(ctx context.Context, request *client.UnshareDockerRepoRequest) (*client.UnshareDockerRepoResponse, error) {
-	sdkClient, err := cw.getClient()
+	defer cw.invalidate(CacheShares)
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -498,7 +546,7 @@ func (cw *clientWrapper) UnshareDockerRepo(ctx context.Context, request *client.
 
 // ListSharedDockerRepos wraps the SDK client method
 func (cw *clientWrapper) ListSharedDockerRepos(ctx context.Context, request *client.ListSharedDockerReposRequest) (*client.ListSharedDockerReposResponse, error) {
-	sdkClient, err := cw.getClient()
+	sdkClient, err := cw.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
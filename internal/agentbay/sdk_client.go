@@ -0,0 +1,93 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	openapiutil "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
+	"github.com/alibabacloud-go/tea/dara"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// DefaultUserAgent is sent with every API request unless ClientOptions.UserAgent is set.
+const DefaultUserAgent = "AgentBay-CLI/1.0"
+
+// Credentials authenticate API requests: either an access key pair, with SecurityToken for STS
+// credentials, or an OAuth BearerToken.
+type Credentials struct {
+	AccessKeyID     string
+	AccessKeySecret string
+	SecurityToken   string
+	BearerToken     string
+}
+
+// ClientOptions configures a client created with NewClientWithOptions.
+type ClientOptions struct {
+	// APIConfig holds the endpoint and timeout; nil loads it from the environment.
+	APIConfig *config.APIConfig
+	// Credentials is called before every request. Nil falls back to the CLI chain:
+	// AGENTBAY_ACCESS_KEY_* from the environment, then the login token.
+	Credentials func(ctx context.Context) (Credentials, error)
+	// HTTPClient sends the requests, bound to the context of each call. Nil uses the SDK's
+	// default transport.
+	HTTPClient *http.Client
+	// UserAgent replaces the default "AgentBay-CLI/1.0".
+	UserAgent string
+}
+
+// newSDKClient creates a generated SDK client authenticated with creds.
+func (cw *clientWrapper) newSDKClient(ctx context.Context, creds Credentials) (*client.Client, error) {
+	userAgent := cw.userAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	openapiConfig := &openapiutil.Config{
+		Endpoint:       dara.String(cw.apiConfig.Endpoint),
+		ReadTimeout:    dara.Int(cw.apiConfig.TimeoutMs),
+		ConnectTimeout: dara.Int(cw.apiConfig.TimeoutMs),
+		UserAgent:      dara.String(userAgent),
+	}
+
+	switch {
+	case creds.BearerToken != "":
+		// Use BearerToken for OAuth authentication (backend now supports BearerToken)
+		openapiConfig.BearerToken = dara.String(creds.BearerToken)
+	case creds.AccessKeyID != "" && creds.AccessKeySecret != "":
+		openapiConfig.AccessKeyId = dara.String(creds.AccessKeyID)
+		openapiConfig.AccessKeySecret = dara.String(creds.AccessKeySecret)
+		if creds.SecurityToken != "" {
+			openapiConfig.SecurityToken = dara.String(creds.SecurityToken)
+		}
+	default:
+		return nil, errors.New("no credentials: set an access key or a bearer token")
+	}
+
+	if cw.httpClient != nil {
+		openapiConfig.HttpClient = &contextHTTPClient{client: cw.httpClient, ctx: ctx}
+	}
+
+	sdkClient, err := client.NewClient(openapiConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+	return sdkClient, nil
+}
+
+// contextHTTPClient adapts an *http.Client to the SDK's HttpClient interface, so that cancelling
+// the context of a call aborts its request.
+type contextHTTPClient struct {
+	client *http.Client
+	ctx    context.Context
+}
+
+// Call sends req with the client, ignoring the transport the SDK built for its own client.
+func (c *contextHTTPClient) Call(req *http.Request, _ *http.Transport) (*http.Response, error) {
+	return c.client.Do(req.WithContext(c.ctx))
+}
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -24,6 +25,9 @@ func (client *Client) DescribeInstanceTypesWithOptions(request *DescribeInstance
 	if !dara.IsNil(request.ImageId) {
 		query["ImageId"] = request.ImageId
 	}
+	if !dara.IsNil(request.BizRegionId) {
+		query["BizRegionId"] = request.BizRegionId
+	}
 
 	req := &openapiutil.OpenApiRequest{
 		Query:   openapiutil.Query(query),
@@ -392,6 +396,9 @@ func (client *Client) DescribeOfficeSitesWithOptions(request *DescribeOfficeSite
 	if !dara.IsNil(request.RegionName) {
 		query["RegionName"] = request.RegionName
 	}
+	if !dara.IsNil(request.OfficeSiteId) {
+		query["OfficeSiteId"] = request.OfficeSiteId
+	}
 
 	req := &openapiutil.OpenApiRequest{
 		Query:   openapiutil.Query(query),
@@ -451,9 +458,30 @@ func parseDescribeOfficeSitesResponse(res map[string]interface{}) (*DescribeOffi
 	out.RawBody = bodyStr
 	parsed := &DescribeOfficeSitesResponseBody{}
 	if bodyStr != "" {
-		if err := json.Unmarshal([]byte(bodyStr), parsed); err != nil {
+		// Data is an object for single-site lookups and an array for listings.
+		var raw struct {
+			DescribeOfficeSitesResponseBody
+			Data json.RawMessage `json:"Data"`
+		}
+		if err := json.Unmarshal([]byte(bodyStr), &raw); err != nil {
 			return nil, &ErrWithRequestID{Err: err, RequestID: extractRequestIDFromResponse(res)}
 		}
+		*parsed = raw.DescribeOfficeSitesResponseBody
+		data := bytes.TrimSpace(raw.Data)
+		if len(data) > 0 && data[0] == '[' {
+			if err := json.Unmarshal(data, &parsed.OfficeSites); err != nil {
+				return nil, &ErrWithRequestID{Err: err, RequestID: extractRequestIDFromResponse(res)}
+			}
+			if len(parsed.OfficeSites) > 0 {
+				parsed.Data = parsed.OfficeSites[0]
+			}
+		} else if len(data) > 0 && data[0] == '{' {
+			parsed.Data = &DescribeOfficeSitesResponseBodyData{}
+			if err := json.Unmarshal(data, parsed.Data); err != nil {
+				return nil, &ErrWithRequestID{Err: err, RequestID: extractRequestIDFromResponse(res)}
+			}
+			parsed.OfficeSites = []*DescribeOfficeSitesResponseBodyData{parsed.Data}
+		}
 	}
 	out.Body = parsed
 	if h, ok := res["headers"].(map[string]*string); ok {
@@ -9,7 +9,8 @@ import (
 
 // DescribeInstanceTypesRequest - 查询实例规格请求
 type DescribeInstanceTypesRequest struct {
-	ImageId *string `json:"ImageId,omitempty" xml:"ImageId,omitempty"`
+	ImageId     *string `json:"ImageId,omitempty" xml:"ImageId,omitempty"`
+	BizRegionId *string `json:"BizRegionId,omitempty" xml:"BizRegionId,omitempty"`
 }
 
 func (s *DescribeInstanceTypesRequest) String() string {
@@ -29,16 +30,26 @@ func (s *DescribeInstanceTypesRequest) SetImageId(v string) *DescribeInstanceTyp
 	return s
 }
 
+func (s *DescribeInstanceTypesRequest) GetBizRegionId() *string {
+	return s.BizRegionId
+}
+
+func (s *DescribeInstanceTypesRequest) SetBizRegionId(v string) *DescribeInstanceTypesRequest {
+	s.BizRegionId = &v
+	return s
+}
+
 func (s *DescribeInstanceTypesRequest) Validate() error {
 	return dara.Validate(s)
 }
 
 // DescribeInstanceTypesResponseBodyDataInstanceType - 实例规格项
 type DescribeInstanceTypesResponseBodyDataInstanceType struct {
-	AppInstanceType *string `json:"AppInstanceType,omitempty" xml:"AppInstanceType,omitempty"`
-	Cpu             *int32  `json:"Cpu,omitempty" xml:"Cpu,omitempty"`
-	Memory          *int32  `json:"Memory,omitempty" xml:"Memory,omitempty"`
-	IsSelected      *bool   `json:"IsSelected,omitempty" xml:"IsSelected,omitempty"`
+	AppInstanceType *string  `json:"AppInstanceType,omitempty" xml:"AppInstanceType,omitempty"`
+	Cpu             *int32   `json:"Cpu,omitempty" xml:"Cpu,omitempty"`
+	Memory          *int32   `json:"Memory,omitempty" xml:"Memory,omitempty"`
+	IsSelected      *bool    `json:"IsSelected,omitempty" xml:"IsSelected,omitempty"`
+	RegionIds       []string `json:"RegionIds,omitempty" xml:"RegionIds,omitempty"`
 }
 
 func (s *DescribeInstanceTypesResponseBodyDataInstanceType) String() string {
@@ -61,6 +72,10 @@ func (s *DescribeInstanceTypesResponseBodyDataInstanceType) GetIsSelected() *boo
 	return s.IsSelected
 }
 
+func (s *DescribeInstanceTypesResponseBodyDataInstanceType) GetRegionIds() []string {
+	return s.RegionIds
+}
+
 // DescribeInstanceTypesResponseBody - 响应体
 // Note: Data is directly an array of instance types
 type DescribeInstanceTypesResponseBody struct {
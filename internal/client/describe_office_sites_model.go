@@ -12,6 +12,7 @@ type DescribeOfficeSitesRequest struct {
 	OfficeSiteType *string `json:"OfficeSiteType,omitempty" xml:"OfficeSiteType,omitempty"`
 	VpcId          *string `json:"VpcId,omitempty" xml:"VpcId,omitempty"`
 	RegionName     *string `json:"RegionName,omitempty" xml:"RegionName,omitempty"`
+	OfficeSiteId   *string `json:"OfficeSiteId,omitempty" xml:"OfficeSiteId,omitempty"`
 }
 
 func (s *DescribeOfficeSitesRequest) String() string {
@@ -49,14 +50,29 @@ func (s *DescribeOfficeSitesRequest) SetRegionName(v string) *DescribeOfficeSite
 	return s
 }
 
+func (s *DescribeOfficeSitesRequest) GetOfficeSiteId() *string {
+	return s.OfficeSiteId
+}
+
+func (s *DescribeOfficeSitesRequest) SetOfficeSiteId(v string) *DescribeOfficeSitesRequest {
+	s.OfficeSiteId = &v
+	return s
+}
+
 func (s *DescribeOfficeSitesRequest) Validate() error {
 	return dara.Validate(s)
 }
 
 // DescribeOfficeSitesResponseBodyData - 响应数据
 type DescribeOfficeSitesResponseBodyData struct {
-	OfficeSiteId *string  `json:"OfficeSiteId,omitempty" xml:"OfficeSiteId,omitempty"`
-	DnsAddress   []string `json:"DnsAddress,omitempty" xml:"DnsAddress,omitempty"`
+	OfficeSiteId   *string  `json:"OfficeSiteId,omitempty" xml:"OfficeSiteId,omitempty"`
+	DnsAddress     []string `json:"DnsAddress,omitempty" xml:"DnsAddress,omitempty"`
+	OfficeSiteName *string  `json:"OfficeSiteName,omitempty" xml:"OfficeSiteName,omitempty"`
+	OfficeSiteType *string  `json:"OfficeSiteType,omitempty" xml:"OfficeSiteType,omitempty"`
+	VpcId          *string  `json:"VpcId,omitempty" xml:"VpcId,omitempty"`
+	RegionId       *string  `json:"RegionId,omitempty" xml:"RegionId,omitempty"`
+	Status         *string  `json:"Status,omitempty" xml:"Status,omitempty"`
+	CreationTime   *string  `json:"CreationTime,omitempty" xml:"CreationTime,omitempty"`
 }
 
 func (s *DescribeOfficeSitesResponseBodyData) String() string {
@@ -71,13 +87,41 @@ func (s *DescribeOfficeSitesResponseBodyData) GetDnsAddress() []string {
 	return s.DnsAddress
 }
 
+func (s *DescribeOfficeSitesResponseBodyData) GetOfficeSiteName() *string {
+	return s.OfficeSiteName
+}
+
+func (s *DescribeOfficeSitesResponseBodyData) GetOfficeSiteType() *string {
+	return s.OfficeSiteType
+}
+
+func (s *DescribeOfficeSitesResponseBodyData) GetVpcId() *string {
+	return s.VpcId
+}
+
+func (s *DescribeOfficeSitesResponseBodyData) GetRegionId() *string {
+	return s.RegionId
+}
+
+func (s *DescribeOfficeSitesResponseBodyData) GetStatus() *string {
+	return s.Status
+}
+
+func (s *DescribeOfficeSitesResponseBodyData) GetCreationTime() *string {
+	return s.CreationTime
+}
+
 // DescribeOfficeSitesResponseBody - 响应体
+// Note: Data is a single office site when the query matches one site (e.g. by VpcId), and an
+// array when listing; OfficeSites always holds every returned site and Data the first one.
 type DescribeOfficeSitesResponseBody struct {
-	RequestId      string                              `json:"RequestId,omitempty" xml:"RequestId,omitempty"`
-	HttpStatusCode *int32                              `json:"HttpStatusCode,omitempty" xml:"HttpStatusCode,omitempty"`
-	Data           *DescribeOfficeSitesResponseBodyData `json:"Data,omitempty" xml:"Data,omitempty"`
-	Code           *string                             `json:"Code,omitempty" xml:"Code,omitempty"`
-	Success        *bool                               `json:"Success,omitempty" xml:"Success,omitempty"`
+	RequestId      string                                 `json:"RequestId,omitempty" xml:"RequestId,omitempty"`
+	HttpStatusCode *int32                                 `json:"HttpStatusCode,omitempty" xml:"HttpStatusCode,omitempty"`
+	Data           *DescribeOfficeSitesResponseBodyData   `json:"Data,omitempty" xml:"Data,omitempty"`
+	OfficeSites    []*DescribeOfficeSitesResponseBodyData `json:"-" xml:"-"`
+	Code           *string                                `json:"Code,omitempty" xml:"Code,omitempty"`
+	Message        *string                                `json:"Message,omitempty" xml:"Message,omitempty"`
+	Success        *bool                                  `json:"Success,omitempty" xml:"Success,omitempty"`
 }
 
 func (s *DescribeOfficeSitesResponseBody) String() string {
@@ -96,10 +140,18 @@ func (s *DescribeOfficeSitesResponseBody) GetData() *DescribeOfficeSitesResponse
 	return s.Data
 }
 
+func (s *DescribeOfficeSitesResponseBody) GetOfficeSites() []*DescribeOfficeSitesResponseBodyData {
+	return s.OfficeSites
+}
+
 func (s *DescribeOfficeSitesResponseBody) GetCode() *string {
 	return s.Code
 }
 
+func (s *DescribeOfficeSitesResponseBody) GetMessage() *string {
+	return s.Message
+}
+
 func (s *DescribeOfficeSitesResponseBody) GetSuccess() *bool {
 	return s.Success
 }
@@ -0,0 +1,50 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDescribeOfficeSitesResponse_DataObject(t *testing.T) {
+	t.Parallel()
+	body := `{"Code":"ok","RequestId":"R1","Success":true,"Data":{"OfficeSiteId":"cn-hangzhou+dir-001","DnsAddress":["100.100.2.136"]}}`
+	res := map[string]interface{}{"body": body}
+	out, err := parseDescribeOfficeSitesResponse(res)
+	require.NoError(t, err)
+	require.NotNil(t, out.Body)
+	require.Equal(t, "R1", *out.Body.GetRequestId())
+	require.NotNil(t, out.Body.Data)
+	require.Equal(t, "cn-hangzhou+dir-001", *out.Body.Data.GetOfficeSiteId())
+	require.Equal(t, []string{"100.100.2.136"}, out.Body.Data.GetDnsAddress())
+	require.Len(t, out.Body.GetOfficeSites(), 1)
+}
+
+func TestParseDescribeOfficeSitesResponse_DataArray(t *testing.T) {
+	t.Parallel()
+	body := `{"Code":"ok","RequestId":"R2","Success":true,"Data":[` +
+		`{"OfficeSiteId":"cn-hangzhou+dir-001","OfficeSiteType":"ADVANCED","Status":"REGISTERED"},` +
+		`{"OfficeSiteId":"cn-hangzhou+dir-002","OfficeSiteType":"CUSTOMIZED","VpcId":"vpc-1"}]}`
+	res := map[string]interface{}{"body": body}
+	out, err := parseDescribeOfficeSitesResponse(res)
+	require.NoError(t, err)
+	sites := out.Body.GetOfficeSites()
+	require.Len(t, sites, 2)
+	require.Equal(t, "CUSTOMIZED", *sites[1].GetOfficeSiteType())
+	require.Equal(t, "vpc-1", *sites[1].GetVpcId())
+	require.NotNil(t, out.Body.Data)
+	require.Equal(t, "cn-hangzhou+dir-001", *out.Body.Data.GetOfficeSiteId())
+}
+
+func TestParseDescribeOfficeSitesResponse_NoData(t *testing.T) {
+	t.Parallel()
+	body := `{"Code":"ok","RequestId":"R3","Success":true}`
+	res := map[string]interface{}{"body": body}
+	out, err := parseDescribeOfficeSitesResponse(res)
+	require.NoError(t, err)
+	require.Nil(t, out.Body.Data)
+	require.Empty(t, out.Body.GetOfficeSites())
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// stateLockTimeout bounds how long Lock waits for another process to release the file.
+	stateLockTimeout = 10 * time.Second
+	// stateLockStale is the age after which a lock is assumed to be left behind by a process that
+	// died, and is taken over. Locks are only held around a load-modify-save, never across API calls.
+	stateLockStale = 30 * time.Second
+	stateLockPoll  = 20 * time.Millisecond
+)
+
+// StateFile is a JSON file of local CLI state, such as share grants, rotations or cached
+// responses. Save replaces the file atomically, so a reader never sees a partly written file, and
+// Update holds a lock across load-modify-save, so that concurrent agentbay processes (e.g. a cron
+// job and an interactive command) do not lose each other's changes.
+type StateFile struct {
+	Path string
+}
+
+// NewStateFile returns the state file name in the CLI config directory.
+func NewStateFile(name string) (*StateFile, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &StateFile{Path: filepath.Join(dir, name)}, nil
+}
+
+// Load decodes the file into v. A missing file leaves v unchanged and is not an error.
+func (f *StateFile) Load(v any) error {
+	data, err := os.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", f.Path, err)
+	}
+	return nil
+}
+
+// Save replaces the file with v using WriteFileAtomic with 0600 permissions.
+func (f *StateFile) Save(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(f.Path, data, 0600)
+}
+
+// WriteFileAtomic replaces path with data: it is written to a temporary file created with 0600
+// permissions in the same directory, set to perm and renamed over the old file, creating the
+// directory if needed. Unlike os.WriteFile, a reader never sees a partly written file, perm applies
+// to an existing file too, and a symlink at path is replaced rather than followed.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	// The leading dot keeps the temporary file out of directory scans, e.g. plugin discovery.
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Update loads the file into v, calls fn and saves v, holding the lock of the file throughout. v
+// should be empty when passed in; it is saved only when fn returns nil.
+func (f *StateFile) Update(v any, fn func() error) error {
+	unlock, err := f.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := f.Load(v); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	return f.Save(v)
+}
+
+// Lock takes the lock of the file, a <file>.lock next to it created exclusively, and returns the
+// function that releases it.
+func (f *StateFile) Lock() (func(), error) {
+	lockPath := f.Path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(stateLockTimeout)
+	for {
+		lf, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			lf.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > stateLockStale {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s; remove it if no other agentbay command is running", lockPath)
+		}
+		time.Sleep(stateLockPoll)
+	}
+}
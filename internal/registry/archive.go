@@ -0,0 +1,555 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Media types handled by the client.
+const (
+	MediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeDockerConfig   = "application/vnd.docker.container.image.v1+json"
+	MediaTypeDockerLayer    = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// Annotations used by OCI layouts to name the images they contain.
+const (
+	annotationRefName        = "org.opencontainers.image.ref.name"
+	annotationContainerdName = "io.containerd.image.name"
+)
+
+// Descriptor references a blob or manifest by digest.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *Platform         `json:"platform,omitempty"`
+}
+
+// Platform describes the OS and architecture an image manifest is built for.
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// Manifest covers the fields of an image manifest and of an image index that the client needs.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        *Descriptor  `json:"config,omitempty"`
+	Layers        []Descriptor `json:"layers,omitempty"`
+	Manifests     []Descriptor `json:"manifests,omitempty"`
+}
+
+// IsIndex reports whether the manifest is an image index (manifest list).
+func (m *Manifest) IsIndex() bool {
+	return m.MediaType == MediaTypeOCIIndex || m.MediaType == MediaTypeDockerList || (m.Config == nil && len(m.Manifests) > 0)
+}
+
+// Image is a local image to push: a root manifest (image manifest or index) and access to every
+// blob and child manifest it references. Close releases temporary files.
+type Image struct {
+	MediaType string
+	Manifest  []byte
+	// Name is the image name recorded in the archive, if any.
+	Name string
+
+	openBlob func(digest string) (io.ReadCloser, error)
+	cleanup  []string
+}
+
+// OpenBlob opens a blob or child manifest referenced by the image.
+func (img *Image) OpenBlob(digest string) (io.ReadCloser, error) {
+	return img.openBlob(digest)
+}
+
+// Close removes temporary files created while loading the image.
+func (img *Image) Close() error {
+	for _, p := range img.cleanup {
+		os.Remove(p)
+	}
+	img.cleanup = nil
+	return nil
+}
+
+// LoadImage opens an image stored at p in one of these formats:
+//   - an OCI image layout directory (oci-layout + index.json + blobs/)
+//   - an OCI archive, i.e. a tar of an OCI layout ("podman save --format oci-archive", "ctr export",
+//     and "docker save" from Docker 25 onwards)
+//   - a "docker save" tarball (manifest.json + config + layer tars), or its extracted directory
+//
+// Tarballs may be gzip-compressed. When the archive holds more than one image, ref selects one by
+// name or tag; otherwise ref may be empty.
+func LoadImage(p, ref string) (img *Image, err error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	var src source
+	if info.IsDir() {
+		src = dirSource(p)
+	} else {
+		ts, err := newTarSource(p)
+		if err != nil {
+			return nil, err
+		}
+		if ts.tmp != "" {
+			// Blobs are read from the decompressed copy until the image is closed.
+			defer func() {
+				if img != nil {
+					img.cleanup = append(img.cleanup, ts.tmp)
+				} else {
+					os.Remove(ts.tmp)
+				}
+			}()
+		}
+		src = ts
+	}
+
+	switch {
+	case src.has("index.json"):
+		return loadOCILayout(src, ref)
+	case src.has("manifest.json"):
+		return loadDockerArchive(src, ref)
+	default:
+		return nil, fmt.Errorf("%s is not an OCI layout or docker save archive (no index.json or manifest.json found)", p)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Sources
+// ---------------------------------------------------------------------------
+
+type source interface {
+	has(name string) bool
+	open(name string) (io.ReadCloser, error)
+}
+
+// dirSource reads an extracted layout. Names come from the manifests inside it, so a name must not
+// lead outside the directory, through ".." or through a symlink.
+type dirSource string
+
+func (d dirSource) has(name string) bool {
+	rc, err := d.open(name)
+	if err != nil {
+		return false
+	}
+	rc.Close()
+	return true
+}
+
+func (d dirSource) open(name string) (io.ReadCloser, error) {
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("%s escapes the image directory %s", name, string(d))
+	}
+	return os.OpenInRoot(string(d), rel)
+}
+
+// tarEntry is where an entry's data lies in the (uncompressed) tarball.
+type tarEntry struct {
+	offset   int64
+	size     int64
+	typeflag byte
+	linkname string
+}
+
+// tarSource reads entries from a (optionally gzip-compressed) tarball. The archive is scanned once
+// to index the offset of each entry, and entries are then read in place. A gzip-compressed
+// tarball is first decompressed into the temporary file tmp, which the caller removes; data is
+// the file entries are read from.
+type tarSource struct {
+	path    string
+	data    string
+	tmp     string
+	entries map[string]tarEntry
+}
+
+func newTarSource(p string) (ts *tarSource, err error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	magic := make([]byte, 2)
+	_, _ = io.ReadFull(f, magic)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	ts = &tarSource{path: p, data: p, entries: make(map[string]tarEntry)}
+
+	if magic[0] == 0x1f && magic[1] == 0x8b {
+		tmp, err := decompressToTemp(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s: %w", p, err)
+		}
+		defer func() {
+			if err != nil {
+				os.Remove(tmp)
+			}
+		}()
+		ts.data, ts.tmp = tmp, tmp
+		f.Close()
+		if f, err = os.Open(tmp); err != nil {
+			return nil, err
+		}
+		defer f.Close()
+	}
+
+	// tar.Reader seeks over entry data on an *os.File, so after Next the file offset is where the
+	// entry's data starts.
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s as a tar archive: %w", p, err)
+		}
+		offset, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		ts.entries[cleanEntryName(hdr.Name)] = tarEntry{offset: offset, size: hdr.Size, typeflag: hdr.Typeflag, linkname: hdr.Linkname}
+	}
+	return ts, nil
+}
+
+func decompressToTemp(r io.Reader) (string, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+	f, err := os.CreateTemp("", "agentbay-image-*.tar")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, gz); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func (t *tarSource) has(name string) bool {
+	_, ok := t.entries[cleanEntryName(name)]
+	return ok
+}
+
+func (t *tarSource) open(name string) (io.ReadCloser, error) {
+	want := cleanEntryName(name)
+	// docker save stores repeated layers as symlinks to the first copy; links are followed a
+	// bounded number of times so that a cycle cannot loop forever.
+	for hops := 0; hops < 8; hops++ {
+		e, ok := t.entries[want]
+		if !ok {
+			return nil, fmt.Errorf("%s not found in %s", name, t.path)
+		}
+		if e.typeflag == tar.TypeSymlink {
+			want = cleanEntryName(path.Join(path.Dir(want), e.linkname))
+			continue
+		}
+		f, err := os.Open(t.data)
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.NewSectionReader(f, e.offset, e.size), f}, nil
+	}
+	return nil, fmt.Errorf("%s: too many levels of symlinks", name)
+}
+
+func cleanEntryName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+func readAll(src source, name string) ([]byte, error) {
+	rc, err := src.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func blobPath(digest string) (string, error) {
+	alg, hex, ok := strings.Cut(digest, ":")
+	if !ok || alg == "" || hex == "" || strings.ContainsAny(hex, "/\\.") {
+		return "", fmt.Errorf("invalid digest %q", digest)
+	}
+	return "blobs/" + alg + "/" + hex, nil
+}
+
+// ---------------------------------------------------------------------------
+// OCI layout
+// ---------------------------------------------------------------------------
+
+func loadOCILayout(src source, ref string) (*Image, error) {
+	data, err := readAll(src, "index.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index.json: %w", err)
+	}
+	var index Manifest
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index.json: %w", err)
+	}
+	if len(index.Manifests) == 0 {
+		return nil, fmt.Errorf("index.json lists no images")
+	}
+
+	desc, err := selectManifest(index.Manifests, ref)
+	if err != nil {
+		return nil, err
+	}
+	openBlob := func(digest string) (io.ReadCloser, error) {
+		p, err := blobPath(digest)
+		if err != nil {
+			return nil, err
+		}
+		return src.open(p)
+	}
+	rc, err := openBlob(desc.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", desc.Digest, err)
+	}
+	manifest, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType := desc.MediaType
+	if mediaType == "" {
+		var m Manifest
+		_ = json.Unmarshal(manifest, &m)
+		mediaType = m.MediaType
+	}
+	return &Image{
+		MediaType: mediaType,
+		Manifest:  manifest,
+		Name:      imageName(desc),
+		openBlob:  openBlob,
+	}, nil
+}
+
+func imageName(d Descriptor) string {
+	if n := d.Annotations[annotationContainerdName]; n != "" {
+		return n
+	}
+	return d.Annotations[annotationRefName]
+}
+
+// selectManifest picks the image from an OCI index. ref matches the full image name or its tag.
+func selectManifest(manifests []Descriptor, ref string) (Descriptor, error) {
+	if ref == "" {
+		if len(manifests) == 1 {
+			return manifests[0], nil
+		}
+		var names []string
+		for _, m := range manifests {
+			if n := imageName(m); n != "" {
+				names = append(names, n)
+			}
+		}
+		return Descriptor{}, fmt.Errorf("the archive contains %d images, select one by name (available: %s)", len(manifests), strings.Join(names, ", "))
+	}
+	for _, m := range manifests {
+		for _, n := range []string{m.Annotations[annotationContainerdName], m.Annotations[annotationRefName]} {
+			if n != "" && (n == ref || strings.HasSuffix(n, ":"+ref) || strings.HasSuffix(n, "/"+ref)) {
+				return m, nil
+			}
+		}
+	}
+	return Descriptor{}, fmt.Errorf("image %q not found in the archive", ref)
+}
+
+// ---------------------------------------------------------------------------
+// docker save
+// ---------------------------------------------------------------------------
+
+type dockerArchiveEntry struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+// loadDockerArchive converts a legacy "docker save" archive into a Docker v2 schema 2 manifest.
+// Uncompressed layer tars are gzip-compressed into temporary files so their digests are known
+// before upload.
+func loadDockerArchive(src source, ref string) (img *Image, err error) {
+	data, err := readAll(src, "manifest.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest.json: %w", err)
+	}
+	var entries []dockerArchiveEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest.json: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("manifest.json lists no images")
+	}
+
+	entry, err := selectDockerEntry(entries, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	img = &Image{MediaType: MediaTypeDockerManifest}
+	if len(entry.RepoTags) > 0 {
+		img.Name = entry.RepoTags[0]
+	}
+	// Error returns set img to nil, so keep the image whose temporary layers must be removed.
+	loaded := img
+	defer func() {
+		if err != nil {
+			loaded.Close()
+		}
+	}()
+
+	// digest -> how to open it
+	files := make(map[string]string)           // temp files
+	entriesByDigest := make(map[string]string) // archive entries pushed as-is
+
+	config, err := readAll(src, entry.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image config: %w", err)
+	}
+	configDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(config))
+
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeDockerManifest,
+		Config:        &Descriptor{MediaType: MediaTypeDockerConfig, Digest: configDigest, Size: int64(len(config))},
+	}
+	for _, layer := range entry.Layers {
+		desc, tmp, err := prepareDockerLayer(src, layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare layer %s: %w", layer, err)
+		}
+		if tmp != "" {
+			img.cleanup = append(img.cleanup, tmp)
+			files[desc.Digest] = tmp
+		} else {
+			entriesByDigest[desc.Digest] = layer
+		}
+		manifest.Layers = append(manifest.Layers, desc)
+	}
+
+	img.Manifest, err = json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	img.openBlob = func(digest string) (io.ReadCloser, error) {
+		if digest == configDigest {
+			return io.NopCloser(bytes.NewReader(config)), nil
+		}
+		if p, ok := files[digest]; ok {
+			return os.Open(p)
+		}
+		if name, ok := entriesByDigest[digest]; ok {
+			return src.open(name)
+		}
+		return nil, fmt.Errorf("blob %s not found in the archive", digest)
+	}
+	return img, nil
+}
+
+func selectDockerEntry(entries []dockerArchiveEntry, ref string) (dockerArchiveEntry, error) {
+	if ref == "" {
+		if len(entries) == 1 {
+			return entries[0], nil
+		}
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.RepoTags...)
+		}
+		return dockerArchiveEntry{}, fmt.Errorf("the archive contains %d images, select one by name (available: %s)", len(entries), strings.Join(names, ", "))
+	}
+	for _, e := range entries {
+		for _, t := range e.RepoTags {
+			if t == ref || strings.HasSuffix(t, ":"+ref) || strings.HasSuffix(t, "/"+ref) {
+				return e, nil
+			}
+		}
+	}
+	return dockerArchiveEntry{}, fmt.Errorf("image %q not found in the archive", ref)
+}
+
+// prepareDockerLayer returns the descriptor of a layer from a docker save archive. A layer that is
+// already gzip-compressed is pushed as-is (tmp is empty); otherwise it is compressed into tmp.
+func prepareDockerLayer(src source, name string) (desc Descriptor, tmp string, err error) {
+	rc, err := src.open(name)
+	if err != nil {
+		return Descriptor{}, "", err
+	}
+	defer rc.Close()
+	br := bufio.NewReader(rc)
+	magic, _ := br.Peek(2)
+
+	h := sha256.New()
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		n, err := io.Copy(h, br)
+		if err != nil {
+			return Descriptor{}, "", err
+		}
+		return Descriptor{MediaType: MediaTypeDockerLayer, Digest: fmt.Sprintf("sha256:%x", h.Sum(nil)), Size: n}, "", nil
+	}
+
+	f, err := os.CreateTemp("", "agentbay-layer-*.tar.gz")
+	if err != nil {
+		return Descriptor{}, "", err
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	counter := &countingWriter{w: io.MultiWriter(f, h)}
+	gz := gzip.NewWriter(counter)
+	if _, err = io.Copy(gz, br); err != nil {
+		return Descriptor{}, "", err
+	}
+	if err = gz.Close(); err != nil {
+		return Descriptor{}, "", err
+	}
+	return Descriptor{MediaType: MediaTypeDockerLayer, Digest: fmt.Sprintf("sha256:%x", h.Sum(nil)), Size: counter.n}, f.Name(), nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,423 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package registry is a minimal OCI Distribution (Docker Registry v2) client used to push images
+// to the AgentBay ACR registry without a local docker daemon and to list and inspect what is
+// stored there. It supports bearer-token and basic authentication, chunked blob uploads and
+// cross-repository blob mounts.
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultChunkSize is the size of each PATCH request in a chunked blob upload.
+const DefaultChunkSize int64 = 8 << 20
+
+// Client talks to a single registry host.
+type Client struct {
+	// Registry is the registry host, e.g. "xxx-registry.cn-hangzhou.cr.aliyuncs.com".
+	Registry string
+	Username string
+	Password string
+	// PlainHTTP uses http:// instead of https://. Only meant for local test registries.
+	PlainHTTP bool
+	// ChunkSize is the maximum body size of one upload PATCH; DefaultChunkSize when zero.
+	ChunkSize  int64
+	HTTPClient *http.Client
+
+	mu     sync.Mutex
+	tokens map[string]string // scope -> Authorization header value
+}
+
+// NewClient returns a client for registry authenticated with username and password.
+func NewClient(registry, username, password string) *Client {
+	return &Client{
+		Registry:   registry,
+		Username:   username,
+		Password:   password,
+		HTTPClient: &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// Error is an error response returned by the registry.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("registry returned HTTP %d: %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("registry returned HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+func (c *Client) baseURL() string {
+	scheme := "https"
+	if c.PlainHTTP {
+		scheme = "http"
+	}
+	return scheme + "://" + c.Registry
+}
+
+func (c *Client) chunkSize() int64 {
+	if c.ChunkSize > 0 {
+		return c.ChunkSize
+	}
+	return DefaultChunkSize
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// pushScope returns the token scopes needed to push to repo, plus pull access on each extra repo.
+func pushScope(repo string, pullFrom ...string) []string {
+	scopes := []string{fmt.Sprintf("repository:%s:pull,push", repo)}
+	for _, r := range pullFrom {
+		scopes = append(scopes, fmt.Sprintf("repository:%s:pull", r))
+	}
+	return scopes
+}
+
+// do sends the request built by newReq. On a 401 challenge it authenticates for scopes and retries
+// once, so newReq must be able to build the request (including its body) more than once.
+func (c *Client) do(ctx context.Context, scopes []string, newReq func() (*http.Request, error)) (*http.Response, error) {
+	key := strings.Join(scopes, " ")
+	for attempt := 0; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		c.mu.Lock()
+		auth := c.tokens[key]
+		c.mu.Unlock()
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+		challenge := resp.Header.Get("WWW-Authenticate")
+		drainAndClose(resp)
+		auth, err = c.authenticate(ctx, challenge, scopes)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.tokens == nil {
+			c.tokens = make(map[string]string)
+		}
+		c.tokens[key] = auth
+		c.mu.Unlock()
+	}
+}
+
+// authenticate answers a WWW-Authenticate challenge and returns the Authorization header to use.
+func (c *Client) authenticate(ctx context.Context, challenge string, scopes []string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		req, _ := http.NewRequest(http.MethodGet, "http://unused", nil)
+		req.SetBasicAuth(c.Username, c.Password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("registry requested unsupported authentication %q", challenge)
+	}
+
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry bearer challenge has no realm")
+	}
+	u, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", realm, err)
+	}
+	q := u.Query()
+	if service := params["service"]; service != "" {
+		q.Set("service", service)
+	}
+	for _, s := range scopes {
+		q.Add("scope", s)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed: %w", responseError(resp))
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	token := tok.Token
+	if token == "" {
+		token = tok.AccessToken
+	}
+	if token == "" {
+		return "", fmt.Errorf("token response did not contain a token")
+	}
+	return "Bearer " + token, nil
+}
+
+// parseChallenge splits `Bearer realm="...",service="...",scope="..."` into scheme and parameters.
+func parseChallenge(h string) (string, map[string]string) {
+	params := make(map[string]string)
+	h = strings.TrimSpace(h)
+	idx := strings.IndexByte(h, ' ')
+	if idx < 0 {
+		return h, params
+	}
+	scheme, rest := h[:idx], h[idx+1:]
+	for len(rest) > 0 {
+		rest = strings.TrimLeft(rest, " ,")
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+		var val string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				val, rest = rest[1:], ""
+			} else {
+				val, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				val, rest = rest, ""
+			} else {
+				val, rest = rest[:end], rest[end:]
+			}
+		}
+		params[key] = val
+	}
+	return scheme, params
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var envelope struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &envelope) == nil && len(envelope.Errors) > 0 {
+		return &Error{StatusCode: resp.StatusCode, Code: envelope.Errors[0].Code, Message: envelope.Errors[0].Message}
+	}
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	return &Error{StatusCode: resp.StatusCode, Message: msg}
+}
+
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
+
+// resolveLocation resolves an upload Location header, which may be relative, against the request URL.
+func resolveLocation(resp *http.Response) (*url.URL, error) {
+	loc := resp.Header.Get("Location")
+	if loc == "" {
+		return nil, fmt.Errorf("registry response has no Location header")
+	}
+	u, err := url.Parse(loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload location %q: %w", loc, err)
+	}
+	return resp.Request.URL.ResolveReference(u), nil
+}
+
+// BlobExists reports whether repo already contains the blob.
+func (c *Client) BlobExists(ctx context.Context, repo, digest string) (bool, error) {
+	u := fmt.Sprintf("%s/v2/%s/blobs/%s", c.baseURL(), repo, digest)
+	resp, err := c.do(ctx, pushScope(repo), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodHead, u, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	defer drainAndClose(resp)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+}
+
+// MountBlob asks the registry to link a blob from another repository on the same registry into repo.
+// It returns false, without error, when the registry declined the mount.
+func (c *Client) MountBlob(ctx context.Context, repo, fromRepo, digest string) (bool, error) {
+	u := fmt.Sprintf("%s/v2/%s/blobs/uploads/?mount=%s&from=%s", c.baseURL(), repo, url.QueryEscape(digest), url.QueryEscape(fromRepo))
+	resp, err := c.do(ctx, pushScope(repo, fromRepo), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, u, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	defer drainAndClose(resp)
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusAccepted:
+		// The registry opened a regular upload session instead; the caller uploads normally.
+		return false, nil
+	default:
+		return false, responseError(resp)
+	}
+}
+
+// UploadBlob uploads size bytes from r as a blob with the given digest, in ChunkSize pieces.
+// progress, when set, is called with the number of bytes sent after each chunk.
+func (c *Client) UploadBlob(ctx context.Context, repo, digest string, size int64, r io.Reader, progress func(sent int64)) error {
+	scopes := pushScope(repo)
+	start := fmt.Sprintf("%s/v2/%s/blobs/uploads/", c.baseURL(), repo)
+	resp, err := c.do(ctx, scopes, func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, start, nil)
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		defer resp.Body.Close()
+		return fmt.Errorf("failed to start upload: %w", responseError(resp))
+	}
+	location, err := resolveLocation(resp)
+	drainAndClose(resp)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, c.chunkSize())
+	var sent int64
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			chunk := buf[:n]
+			loc := location.String()
+			rangeHeader := fmt.Sprintf("%d-%d", sent, sent+int64(n)-1)
+			resp, err := c.do(ctx, scopes, func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodPatch, loc, bytes.NewReader(chunk))
+				if err != nil {
+					return nil, err
+				}
+				req.ContentLength = int64(len(chunk))
+				req.Header.Set("Content-Type", "application/octet-stream")
+				req.Header.Set("Content-Range", rangeHeader)
+				return req, nil
+			})
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+				defer resp.Body.Close()
+				return fmt.Errorf("failed to upload chunk %s: %w", rangeHeader, responseError(resp))
+			}
+			location, err = resolveLocation(resp)
+			drainAndClose(resp)
+			if err != nil {
+				return err
+			}
+			sent += int64(n)
+			if progress != nil {
+				progress(sent)
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read blob %s: %w", digest, readErr)
+		}
+	}
+	if size >= 0 && sent != size {
+		return fmt.Errorf("blob %s: read %d bytes, expected %d", digest, sent, size)
+	}
+
+	q := location.Query()
+	q.Set("digest", digest)
+	location.RawQuery = q.Encode()
+	final := location.String()
+	resp, err = c.do(ctx, scopes, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPut, final, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = 0
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp)
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to commit blob %s: %w", digest, responseError(resp))
+	}
+	return nil
+}
+
+// PutManifest uploads a manifest or index under reference (a tag or digest) and returns the
+// digest reported by the registry.
+func (c *Client) PutManifest(ctx context.Context, repo, reference, mediaType string, manifest []byte) (string, error) {
+	u := fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL(), repo, reference)
+	resp, err := c.do(ctx, pushScope(repo), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPut, u, bytes.NewReader(manifest))
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = int64(len(manifest))
+		req.Header.Set("Content-Type", mediaType)
+		return req, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	defer drainAndClose(resp)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to put manifest %s: %w", reference, responseError(resp))
+	}
+	return resp.Header.Get("Docker-Content-Digest"), nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxManifestSize bounds manifest and config reads; real ones are a few KB.
+const maxManifestSize = 4 << 20
+
+// tagPageSize is the page size requested when listing tags.
+var tagPageSize = 100
+
+// manifestAccept lists the manifest media types the client understands, most specific first.
+var manifestAccept = strings.Join([]string{
+	MediaTypeOCIManifest,
+	MediaTypeOCIIndex,
+	MediaTypeDockerManifest,
+	MediaTypeDockerList,
+}, ", ")
+
+// ImageConfig covers the fields of an image config blob shown by inspect.
+type ImageConfig struct {
+	Created      *time.Time `json:"created,omitempty"`
+	Author       string     `json:"author,omitempty"`
+	Architecture string     `json:"architecture"`
+	OS           string     `json:"os"`
+	Variant      string     `json:"variant,omitempty"`
+	Config       struct {
+		User         string              `json:"User,omitempty"`
+		Env          []string            `json:"Env,omitempty"`
+		Entrypoint   []string            `json:"Entrypoint,omitempty"`
+		Cmd          []string            `json:"Cmd,omitempty"`
+		WorkingDir   string              `json:"WorkingDir,omitempty"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+		Labels       map[string]string   `json:"Labels,omitempty"`
+	} `json:"config"`
+	History []struct {
+		Created    *time.Time `json:"created,omitempty"`
+		CreatedBy  string     `json:"created_by,omitempty"`
+		EmptyLayer bool       `json:"empty_layer,omitempty"`
+	} `json:"history,omitempty"`
+}
+
+// RemoteManifest is a manifest fetched from the registry.
+type RemoteManifest struct {
+	MediaType string
+	Digest    string
+	Data      []byte
+	// LastModified is the registry's Last-Modified header, zero when not sent.
+	LastModified time.Time
+}
+
+// Parse decodes the manifest body.
+func (m *RemoteManifest) Parse() (*Manifest, error) {
+	var out Manifest
+	if err := json.Unmarshal(m.Data, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", m.Digest, err)
+	}
+	if out.MediaType == "" {
+		out.MediaType = m.MediaType
+	}
+	return &out, nil
+}
+
+// IsNotFound reports whether err is a registry 404 (unknown repository, tag, manifest or blob).
+func IsNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// pullScope returns the token scope needed to read from repo.
+func pullScope(repo string) []string {
+	return []string{fmt.Sprintf("repository:%s:pull", repo)}
+}
+
+// ListTags returns every tag in repo, following Link pagination.
+func (c *Client) ListTags(ctx context.Context, repo string) ([]string, error) {
+	next := fmt.Sprintf("%s/v2/%s/tags/list?n=%d", c.baseURL(), repo, tagPageSize)
+	var tags []string
+	for next != "" {
+		u := next
+		resp, err := c.do(ctx, pullScope(repo), func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, u, nil)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := responseError(resp)
+			resp.Body.Close()
+			return nil, err
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&page)
+		next = nextLink(resp)
+		drainAndClose(resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tag list: %w", err)
+		}
+		tags = append(tags, page.Tags...)
+	}
+	return tags, nil
+}
+
+// nextLink returns the absolute URL of a `Link: <...>; rel="next"` header, or "".
+func nextLink(resp *http.Response) string {
+	for _, link := range resp.Header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			part = strings.TrimSpace(part)
+			if !strings.Contains(part, `rel="next"`) || !strings.HasPrefix(part, "<") {
+				continue
+			}
+			end := strings.IndexByte(part, '>')
+			if end < 0 {
+				continue
+			}
+			u, err := url.Parse(part[1:end])
+			if err != nil {
+				return ""
+			}
+			return resp.Request.URL.ResolveReference(u).String()
+		}
+	}
+	return ""
+}
+
+// GetManifest fetches the manifest or index that reference (a tag or digest) points to.
+func (c *Client) GetManifest(ctx context.Context, repo, reference string) (*RemoteManifest, error) {
+	u := fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL(), repo, reference)
+	resp, err := c.do(ctx, pullScope(repo), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", manifestAccept)
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, err
+	}
+	m := &RemoteManifest{
+		MediaType: strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0]),
+		Digest:    resp.Header.Get("Docker-Content-Digest"),
+		Data:      data,
+	}
+	if m.Digest == "" {
+		m.Digest = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	}
+	if lm := resp.Header.Get("Last-Modified"); lm != "" {
+		if t, err := http.ParseTime(lm); err == nil {
+			m.LastModified = t
+		}
+	}
+	return m, nil
+}
+
+// ManifestExists reports whether reference (a tag or digest) exists in repo.
+func (c *Client) ManifestExists(ctx context.Context, repo, reference string) (bool, error) {
+	u := fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL(), repo, reference)
+	resp, err := c.do(ctx, pullScope(repo), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodHead, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", manifestAccept)
+		return req, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	defer drainAndClose(resp)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+}
+
+// GetImageConfig fetches and decodes the config blob with the given digest.
+func (c *Client) GetImageConfig(ctx context.Context, repo, digest string) (*ImageConfig, error) {
+	u := fmt.Sprintf("%s/v2/%s/blobs/%s", c.baseURL(), repo, digest)
+	resp, err := c.do(ctx, pullScope(repo), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, u, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+	var cfg ImageConfig
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse image config %s: %w", digest, err)
+	}
+	return &cfg, nil
+}
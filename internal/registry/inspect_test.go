@@ -0,0 +1,84 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushFixture pushes the OCI layout image name to repo:tag on reg.
+func pushFixture(t *testing.T, reg *mockRegistry, repo, tag, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	ociLayout(t, dir, name)
+	img, err := LoadImage(dir, "")
+	require.NoError(t, err)
+	defer img.Close()
+	digest, err := reg.client().Push(context.Background(), repo, tag, img, PushOptions{})
+	require.NoError(t, err)
+	return digest
+}
+
+func TestListTagsPaginated(t *testing.T) {
+	old := tagPageSize
+	tagPageSize = 2
+	defer func() { tagPageSize = old }()
+
+	reg := newMockRegistry(t)
+	for i := 1; i <= 5; i++ {
+		pushFixture(t, reg, "ns/repo", fmt.Sprintf("v%d", i), "app")
+	}
+
+	tags, err := reg.client().ListTags(context.Background(), "ns/repo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1", "v2", "v3", "v4", "v5"}, tags)
+
+	_, err = reg.client().ListTags(context.Background(), "ns/missing")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestGetManifestAndConfig(t *testing.T) {
+	reg := newMockRegistry(t)
+	digest := pushFixture(t, reg, "ns/repo", "v1", "app")
+	c := reg.client()
+
+	m, err := c.GetManifest(context.Background(), "ns/repo", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, digest, m.Digest)
+	assert.Equal(t, MediaTypeOCIManifest, m.MediaType)
+	parsed, err := m.Parse()
+	require.NoError(t, err)
+	require.NotNil(t, parsed.Config)
+	assert.Len(t, parsed.Layers, 2)
+
+	cfg, err := c.GetImageConfig(context.Background(), "ns/repo", parsed.Config.Digest)
+	require.NoError(t, err)
+	assert.Equal(t, "amd64", cfg.Architecture)
+	assert.Equal(t, "linux", cfg.OS)
+
+	_, err = c.GetManifest(context.Background(), "ns/repo", "nope")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), "MANIFEST_UNKNOWN")
+}
+
+func TestManifestExists(t *testing.T) {
+	reg := newMockRegistry(t)
+	pushFixture(t, reg, "ns/repo", "v1", "app")
+	c := reg.client()
+
+	ok, err := c.ManifestExists(context.Background(), "ns/repo", "v1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = c.ManifestExists(context.Background(), "ns/repo", "v2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
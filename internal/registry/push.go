@@ -0,0 +1,184 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// Push actions reported through PushOptions.Progress.
+const (
+	ActionExists   = "exists"
+	ActionMounted  = "mounted"
+	ActionUploaded = "uploaded"
+	ActionManifest = "manifest"
+)
+
+// PushEvent reports that one blob or manifest has been handled.
+type PushEvent struct {
+	Action    string
+	Digest    string
+	MediaType string
+	Size      int64
+	// From is the repository a mounted blob was linked from.
+	From string
+}
+
+// PushOptions tunes Push.
+type PushOptions struct {
+	// MountFrom lists repositories on the same registry to try cross-repository mounts from before
+	// uploading a blob.
+	MountFrom []string
+	// Progress, when set, is called after each blob or manifest is handled.
+	Progress func(PushEvent)
+}
+
+// Push uploads img to repo and tags its root manifest as tag. Blobs already present in repo are
+// skipped and, when opts.MountFrom is set, mounted from other repositories where possible. Image
+// indexes are pushed together with every child manifest. It returns the root manifest digest.
+func (c *Client) Push(ctx context.Context, repo, tag string, img *Image, opts PushOptions) (string, error) {
+	p := &pusher{c: c, repo: repo, img: img, opts: opts, done: make(map[string]bool)}
+	if err := p.pushContent(ctx, img.Manifest); err != nil {
+		return "", err
+	}
+	digest, err := c.PutManifest(ctx, repo, tag, img.MediaType, img.Manifest)
+	if err != nil {
+		return "", err
+	}
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(img.Manifest))
+	}
+	p.report(PushEvent{Action: ActionManifest, Digest: digest, MediaType: img.MediaType, Size: int64(len(img.Manifest))})
+	return digest, nil
+}
+
+type pusher struct {
+	c    *Client
+	repo string
+	img  *Image
+	opts PushOptions
+	done map[string]bool
+}
+
+func (p *pusher) report(e PushEvent) {
+	if p.opts.Progress != nil {
+		p.opts.Progress(e)
+	}
+}
+
+// pushContent pushes everything a manifest references: child manifests for an index, config and
+// layers for an image manifest.
+func (p *pusher) pushContent(ctx context.Context, manifest []byte) error {
+	var m Manifest
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if m.IsIndex() {
+		for _, child := range m.Manifests {
+			if err := p.pushChildManifest(ctx, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if m.Config == nil {
+		return fmt.Errorf("manifest has no config")
+	}
+	for _, d := range append([]Descriptor{*m.Config}, m.Layers...) {
+		if err := p.pushBlob(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *pusher) pushChildManifest(ctx context.Context, d Descriptor) error {
+	if p.done[d.Digest] {
+		return nil
+	}
+	rc, err := p.img.OpenBlob(d.Digest)
+	if err != nil {
+		return fmt.Errorf("failed to read manifest %s: %w", d.Digest, err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return err
+	}
+	if got := fmt.Sprintf("sha256:%x", sha256.Sum256(data)); got != d.Digest {
+		return fmt.Errorf("manifest %s is corrupt (digest %s)", d.Digest, got)
+	}
+	if err := p.pushContent(ctx, data); err != nil {
+		return err
+	}
+	if _, err := p.c.PutManifest(ctx, p.repo, d.Digest, d.MediaType, data); err != nil {
+		return err
+	}
+	p.done[d.Digest] = true
+	p.report(PushEvent{Action: ActionManifest, Digest: d.Digest, MediaType: d.MediaType, Size: d.Size})
+	return nil
+}
+
+func (p *pusher) pushBlob(ctx context.Context, d Descriptor) error {
+	if p.done[d.Digest] {
+		return nil
+	}
+	exists, err := p.c.BlobExists(ctx, p.repo, d.Digest)
+	if err != nil {
+		return fmt.Errorf("failed to check blob %s: %w", d.Digest, err)
+	}
+	if exists {
+		p.done[d.Digest] = true
+		p.report(PushEvent{Action: ActionExists, Digest: d.Digest, MediaType: d.MediaType, Size: d.Size})
+		return nil
+	}
+
+	for _, from := range p.opts.MountFrom {
+		if from == p.repo {
+			continue
+		}
+		mounted, err := p.c.MountBlob(ctx, p.repo, from, d.Digest)
+		if err != nil || !mounted {
+			// A failed mount is not fatal; fall back to the next source or a regular upload.
+			continue
+		}
+		p.done[d.Digest] = true
+		p.report(PushEvent{Action: ActionMounted, Digest: d.Digest, MediaType: d.MediaType, Size: d.Size, From: from})
+		return nil
+	}
+
+	rc, err := p.img.OpenBlob(d.Digest)
+	if err != nil {
+		return fmt.Errorf("failed to read blob %s: %w", d.Digest, err)
+	}
+	defer rc.Close()
+	vr := &verifyingReader{r: rc, h: sha256.New()}
+	if err := p.c.UploadBlob(ctx, p.repo, d.Digest, d.Size, vr, nil); err != nil {
+		return fmt.Errorf("failed to upload blob %s: %w", d.Digest, err)
+	}
+	if got := fmt.Sprintf("sha256:%x", vr.h.Sum(nil)); got != d.Digest {
+		return fmt.Errorf("blob %s is corrupt (digest %s)", d.Digest, got)
+	}
+	p.done[d.Digest] = true
+	p.report(PushEvent{Action: ActionUploaded, Digest: d.Digest, MediaType: d.MediaType, Size: d.Size})
+	return nil
+}
+
+// verifyingReader hashes what is read so a corrupt local blob is detected. The registry rejects
+// the commit as well, but this gives a clearer error.
+type verifyingReader struct {
+	r io.Reader
+	h hash.Hash
+}
+
+func (v *verifyingReader) Read(b []byte) (int, error) {
+	n, err := v.r.Read(b)
+	v.h.Write(b[:n])
+	return n, err
+}
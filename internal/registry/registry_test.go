@@ -0,0 +1,575 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Mock registry
+// ---------------------------------------------------------------------------
+
+// mockRegistry is an in-memory Registry v2 server with bearer-token auth, chunked uploads and
+// cross-repository mounts.
+type mockRegistry struct {
+	t         *testing.T
+	srv       *httptest.Server
+	mu        sync.Mutex
+	blobs     map[string]map[string][]byte // repo -> digest -> content
+	manifests map[string]map[string][]byte // repo -> reference -> content
+	types     map[string]string            // repo/reference -> media type
+	uploads   map[string]*bytes.Buffer
+	patches   int
+	tokens    int
+}
+
+func newMockRegistry(t *testing.T) *mockRegistry {
+	m := &mockRegistry{
+		t:         t,
+		blobs:     map[string]map[string][]byte{},
+		manifests: map[string]map[string][]byte{},
+		types:     map[string]string{},
+		uploads:   map[string]*bytes.Buffer{},
+	}
+	m.srv = httptest.NewServer(http.HandlerFunc(m.serve))
+	t.Cleanup(m.srv.Close)
+	return m
+}
+
+func (m *mockRegistry) host() string {
+	return strings.TrimPrefix(m.srv.URL, "http://")
+}
+
+func (m *mockRegistry) client() *Client {
+	c := NewClient(m.host(), "user", "pass")
+	c.PlainHTTP = true
+	return c
+}
+
+func (m *mockRegistry) serve(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		u, p, ok := r.BasicAuth()
+		if !ok || u != "user" || p != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		m.tokens++
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "tok"})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer tok" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="mock"`, m.srv.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(p, "/blobs/uploads/"):
+		idx := strings.Index(p, "/blobs/uploads/")
+		repo, id := p[:idx], p[idx+len("/blobs/uploads/"):]
+		m.serveUpload(w, r, repo, id)
+	case strings.Contains(p, "/blobs/"):
+		idx := strings.Index(p, "/blobs/")
+		repo, digest := p[:idx], p[idx+len("/blobs/"):]
+		if content, ok := m.blobs[repo][digest]; ok {
+			w.WriteHeader(http.StatusOK)
+			if r.Method == http.MethodGet {
+				_, _ = w.Write(content)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case strings.HasSuffix(p, "/tags/list"):
+		m.serveTags(w, r, strings.TrimSuffix(p, "/tags/list"))
+	case strings.Contains(p, "/manifests/") && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+		idx := strings.Index(p, "/manifests/")
+		repo, ref := p[:idx], p[idx+len("/manifests/"):]
+		body, ok := m.manifests[repo][ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"MANIFEST_UNKNOWN","message":"manifest unknown"}]}`))
+			return
+		}
+		w.Header().Set("Content-Type", m.types[repo+"/"+ref])
+		w.Header().Set("Docker-Content-Digest", digestOf(body))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(body)
+		}
+	case strings.Contains(p, "/manifests/") && r.Method == http.MethodPut:
+		idx := strings.Index(p, "/manifests/")
+		repo, ref := p[:idx], p[idx+len("/manifests/"):]
+		body, _ := io.ReadAll(r.Body)
+		if m.manifests[repo] == nil {
+			m.manifests[repo] = map[string][]byte{}
+		}
+		m.manifests[repo][ref] = body
+		m.types[repo+"/"+ref] = r.Header.Get("Content-Type")
+		w.Header().Set("Docker-Content-Digest", fmt.Sprintf("sha256:%x", sha256.Sum256(body)))
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (m *mockRegistry) serveUpload(w http.ResponseWriter, r *http.Request, repo, id string) {
+	switch r.Method {
+	case http.MethodPost:
+		if digest, from := r.URL.Query().Get("mount"), r.URL.Query().Get("from"); digest != "" {
+			if content, ok := m.blobs[from][digest]; ok {
+				m.putBlob(repo, digest, content)
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+		}
+		id = strconv.Itoa(len(m.uploads) + 1)
+		m.uploads[id] = &bytes.Buffer{}
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", repo, id))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPatch:
+		buf := m.uploads[id]
+		var start, end int
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "%d-%d", &start, &end); err != nil || start != buf.Len() {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		n, _ := io.Copy(buf, r.Body)
+		if int(n) != end-start+1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.patches++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", repo, id))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		buf := m.uploads[id]
+		digest := r.URL.Query().Get("digest")
+		if got := fmt.Sprintf("sha256:%x", sha256.Sum256(buf.Bytes())); got != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"DIGEST_INVALID","message":"digest mismatch"}]}`))
+			return
+		}
+		m.putBlob(repo, digest, buf.Bytes())
+		delete(m.uploads, id)
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+// serveTags lists the non-digest references of repo, paginated by the n query parameter.
+func (m *mockRegistry) serveTags(w http.ResponseWriter, r *http.Request, repo string) {
+	if _, ok := m.manifests[repo]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"NAME_UNKNOWN","message":"repository name not known to registry"}]}`))
+		return
+	}
+	var tags []string
+	for ref := range m.manifests[repo] {
+		if !strings.HasPrefix(ref, "sha256:") {
+			tags = append(tags, ref)
+		}
+	}
+	sort.Strings(tags)
+	last := r.URL.Query().Get("last")
+	start := sort.SearchStrings(tags, last)
+	if last != "" && start < len(tags) && tags[start] == last {
+		start++
+	}
+	tags = tags[start:]
+	if n, _ := strconv.Atoi(r.URL.Query().Get("n")); n > 0 && len(tags) > n {
+		tags = tags[:n]
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=%d&last=%s>; rel="next"`, repo, n, tags[n-1]))
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": repo, "tags": tags})
+}
+
+func (m *mockRegistry) putBlob(repo, digest string, content []byte) {
+	if m.blobs[repo] == nil {
+		m.blobs[repo] = map[string][]byte{}
+	}
+	m.blobs[repo][digest] = content
+}
+
+// ---------------------------------------------------------------------------
+// Fixtures
+// ---------------------------------------------------------------------------
+
+func digestOf(b []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(b))
+}
+
+// noise returns n bytes that do not compress, derived from seed.
+func noise(seed string, n int) []byte {
+	var out []byte
+	sum := sha256.Sum256([]byte(seed))
+	for len(out) < n {
+		out = append(out, sum[:]...)
+		sum = sha256.Sum256(sum[:])
+	}
+	return out[:n]
+}
+
+func gzipBytes(t *testing.T, b []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// ociLayout builds an OCI layout in dir holding one image per name and returns the manifests.
+func ociLayout(t *testing.T, dir string, names ...string) map[string]Manifest {
+	t.Helper()
+	writeBlob := func(b []byte) Descriptor {
+		d := digestOf(b)
+		p := filepath.Join(dir, "blobs", "sha256", strings.TrimPrefix(d, "sha256:"))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, b, 0644))
+		return Descriptor{Digest: d, Size: int64(len(b))}
+	}
+	index := Manifest{SchemaVersion: 2, MediaType: MediaTypeOCIIndex}
+	out := map[string]Manifest{}
+	for _, name := range names {
+		cfg := writeBlob([]byte(`{"architecture":"amd64","os":"linux","name":"` + name + `"}`))
+		cfg.MediaType = "application/vnd.oci.image.config.v1+json"
+		layer := writeBlob(gzipBytes(t, noise(name, 8000)))
+		layer.MediaType = "application/vnd.oci.image.layer.v1.tar+gzip"
+		shared := writeBlob(gzipBytes(t, []byte("shared base layer")))
+		shared.MediaType = layer.MediaType
+		m := Manifest{SchemaVersion: 2, MediaType: MediaTypeOCIManifest, Config: &cfg, Layers: []Descriptor{shared, layer}}
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		md := writeBlob(data)
+		md.MediaType = MediaTypeOCIManifest
+		md.Annotations = map[string]string{annotationRefName: name}
+		index.Manifests = append(index.Manifests, md)
+		out[name] = m
+	}
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), data, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644))
+	return out
+}
+
+// tarDir packs dir into a tarball at dst.
+func tarDir(t *testing.T, dir, dst string, compress bool) {
+	t.Helper()
+	f, err := os.Create(dst)
+	require.NoError(t, err)
+	defer f.Close()
+	var w io.Writer = f
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(f)
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	require.NoError(t, filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: filepath.ToSlash(rel), Mode: 0644, Size: int64(len(data))}); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}))
+	require.NoError(t, tw.Close())
+	if gz != nil {
+		require.NoError(t, gz.Close())
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:ns/repo:pull,push"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, "https://auth.example.com/token", params["realm"])
+	assert.Equal(t, "registry.example.com", params["service"])
+	assert.Equal(t, "repository:ns/repo:pull,push", params["scope"])
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, "registry", params["realm"])
+}
+
+func TestPushOCILayoutChunked(t *testing.T) {
+	reg := newMockRegistry(t)
+	dir := t.TempDir()
+	manifests := ociLayout(t, dir, "app")
+
+	img, err := LoadImage(dir, "")
+	require.NoError(t, err)
+	defer img.Close()
+	assert.Equal(t, "app", img.Name)
+	assert.Equal(t, MediaTypeOCIManifest, img.MediaType)
+
+	c := reg.client()
+	c.ChunkSize = 1024
+	var events []PushEvent
+	digest, err := c.Push(context.Background(), "ns/repo", "v1", img, PushOptions{Progress: func(e PushEvent) { events = append(events, e) }})
+	require.NoError(t, err)
+	assert.Equal(t, digestOf(img.Manifest), digest)
+
+	m := manifests["app"]
+	assert.Contains(t, reg.blobs["ns/repo"], m.Config.Digest)
+	for _, l := range m.Layers {
+		assert.Equal(t, l.Size, int64(len(reg.blobs["ns/repo"][l.Digest])))
+	}
+	assert.Equal(t, img.Manifest, reg.manifests["ns/repo"]["v1"])
+	assert.Equal(t, MediaTypeOCIManifest, reg.types["ns/repo/v1"])
+	assert.Greater(t, reg.patches, 3, "large layer should be sent in several chunks")
+	assert.Equal(t, 1, reg.tokens, "token is cached per scope")
+	require.Len(t, events, 4)
+	assert.Equal(t, ActionUploaded, events[0].Action)
+	assert.Equal(t, ActionManifest, events[3].Action)
+
+	// Second push only re-tags.
+	events = nil
+	_, err = c.Push(context.Background(), "ns/repo", "v2", img, PushOptions{Progress: func(e PushEvent) { events = append(events, e) }})
+	require.NoError(t, err)
+	for _, e := range events[:3] {
+		assert.Equal(t, ActionExists, e.Action)
+	}
+}
+
+func TestPushCrossRepoMount(t *testing.T) {
+	reg := newMockRegistry(t)
+	dir := t.TempDir()
+	manifests := ociLayout(t, dir, "app")
+	shared := manifests["app"].Layers[0]
+	reg.putBlob("other/base", shared.Digest, []byte("ignored by the mock"))
+
+	img, err := LoadImage(dir, "")
+	require.NoError(t, err)
+	defer img.Close()
+
+	actions := map[string]PushEvent{}
+	_, err = reg.client().Push(context.Background(), "ns/repo", "v1", img, PushOptions{
+		MountFrom: []string{"missing/repo", "other/base"},
+		Progress:  func(e PushEvent) { actions[e.Digest] = e },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ActionMounted, actions[shared.Digest].Action)
+	assert.Equal(t, "other/base", actions[shared.Digest].From)
+	assert.Equal(t, ActionUploaded, actions[manifests["app"].Layers[1].Digest].Action)
+}
+
+func TestLoadOCIArchiveSelectsImage(t *testing.T) {
+	dir := t.TempDir()
+	ociLayout(t, dir, "docker.io/library/app:1.0", "docker.io/library/tool:2.0")
+	archive := filepath.Join(t.TempDir(), "images.tar.gz")
+	tarDir(t, dir, archive, true)
+
+	_, err := LoadImage(archive, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "docker.io/library/tool:2.0")
+
+	img, err := LoadImage(archive, "tool:2.0")
+	require.NoError(t, err)
+	defer img.Close()
+	assert.Equal(t, "docker.io/library/tool:2.0", img.Name)
+
+	reg := newMockRegistry(t)
+	_, err = reg.client().Push(context.Background(), "ns/repo", "tool", img, PushOptions{})
+	require.NoError(t, err)
+	assert.Len(t, reg.blobs["ns/repo"], 3)
+
+	_, err = LoadImage(archive, "missing")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestPushIndexWithChildManifests(t *testing.T) {
+	dir := t.TempDir()
+	manifests := ociLayout(t, dir, "amd64", "arm64")
+
+	// Wrap both manifests in a nested index, as multi-platform exports do.
+	var top Manifest
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &top))
+	nested, err := json.Marshal(Manifest{SchemaVersion: 2, MediaType: MediaTypeOCIIndex, Manifests: top.Manifests})
+	require.NoError(t, err)
+	nestedDigest := digestOf(nested)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blobs", "sha256", strings.TrimPrefix(nestedDigest, "sha256:")), nested, 0644))
+	top.Manifests = []Descriptor{{MediaType: MediaTypeOCIIndex, Digest: nestedDigest, Size: int64(len(nested))}}
+	data, err = json.Marshal(top)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), data, 0644))
+
+	img, err := LoadImage(dir, "")
+	require.NoError(t, err)
+	defer img.Close()
+	assert.Equal(t, MediaTypeOCIIndex, img.MediaType)
+
+	reg := newMockRegistry(t)
+	_, err = reg.client().Push(context.Background(), "ns/repo", "multi", img, PushOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, nested, reg.manifests["ns/repo"]["multi"])
+	// Both child manifests are pushed by digest and their blobs uploaded once.
+	assert.Len(t, reg.manifests["ns/repo"], 3)
+	assert.Len(t, reg.blobs["ns/repo"], 5)
+	assert.Contains(t, reg.blobs["ns/repo"], manifests["arm64"].Config.Digest)
+}
+
+func TestLoadDockerSaveArchive(t *testing.T) {
+	dir := t.TempDir()
+	config := []byte(`{"architecture":"amd64","os":"linux","rootfs":{"type":"layers"}}`)
+	layer := bytes.Repeat([]byte("uncompressed layer tar "), 1000)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "abc.json"), config, 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "l1"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "l1", "layer.tar"), layer, 0644))
+	manifest := `[{"Config":"abc.json","RepoTags":["myapp:latest"],"Layers":["l1/layer.tar","l2/layer.tar"]}]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(manifest), 0644))
+
+	// Build the tar by hand so l2/layer.tar is a symlink to l1, as docker save does for repeated layers.
+	archive := filepath.Join(t.TempDir(), "myapp.tar")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	tw := tar.NewWriter(f)
+	for _, e := range []struct {
+		name string
+		data []byte
+	}{{"manifest.json", []byte(manifest)}, {"abc.json", config}, {"l1/layer.tar", layer}} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.data))}))
+		_, err := tw.Write(e.data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "l2/layer.tar", Typeflag: tar.TypeSymlink, Linkname: "../l1/layer.tar"}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, f.Close())
+
+	img, err := LoadImage(archive, "")
+	require.NoError(t, err)
+	assert.Equal(t, "myapp:latest", img.Name)
+	assert.Equal(t, MediaTypeDockerManifest, img.MediaType)
+
+	var m Manifest
+	require.NoError(t, json.Unmarshal(img.Manifest, &m))
+	assert.Equal(t, digestOf(config), m.Config.Digest)
+	require.Len(t, m.Layers, 2)
+	assert.Equal(t, m.Layers[0].Digest, m.Layers[1].Digest)
+	assert.Equal(t, MediaTypeDockerLayer, m.Layers[0].MediaType)
+
+	reg := newMockRegistry(t)
+	_, err = reg.client().Push(context.Background(), "ns/repo", "v1", img, PushOptions{})
+	require.NoError(t, err)
+	uploaded := reg.blobs["ns/repo"][m.Layers[0].Digest]
+	gz, err := gzip.NewReader(bytes.NewReader(uploaded))
+	require.NoError(t, err)
+	plain, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, layer, plain)
+
+	require.NoError(t, img.Close())
+
+	// The extracted directory works too (the symlinked layer is absent there, so drop it).
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(strings.Replace(manifest, `,"l2/layer.tar"`, "", 1)), 0644))
+	dirImg, err := LoadImage(dir, "myapp:latest")
+	require.NoError(t, err)
+	defer dirImg.Close()
+	var dm Manifest
+	require.NoError(t, json.Unmarshal(dirImg.Manifest, &dm))
+	assert.Equal(t, m.Layers[0].Digest, dm.Layers[0].Digest)
+}
+
+func TestLoadDockerSaveDirRejectsEscapingNames(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "image")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.json"), []byte(`{"os":"linux"}`), 0644))
+	require.NoError(t, os.Symlink(filepath.Join(root, "secret.json"), filepath.Join(dir, "link.json")))
+
+	for _, config := range []string{"../secret.json", "l1/../../secret.json", filepath.Join(root, "secret.json"), "link.json"} {
+		manifest := fmt.Sprintf(`[{"Config":%q,"RepoTags":["myapp:latest"],"Layers":[]}]`, config)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(manifest), 0644))
+		_, err := LoadImage(dir, "")
+		assert.Error(t, err, "config %s is outside the image directory", config)
+	}
+}
+
+func TestTarSourceIndexesEntries(t *testing.T) {
+	dir := t.TempDir()
+	ociLayout(t, dir, "app:v1")
+	archive := filepath.Join(t.TempDir(), "app.tar.gz")
+	tarDir(t, dir, archive, true)
+
+	ts, err := newTarSource(archive)
+	require.NoError(t, err)
+	require.NotEmpty(t, ts.tmp, "a compressed archive is decompressed once")
+	for name := range ts.entries {
+		want, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		got, err := readAll(ts, name)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, name)
+	}
+	require.NoError(t, os.Remove(ts.tmp))
+
+	img, err := LoadImage(archive, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, img.cleanup)
+	tmp := img.cleanup[len(img.cleanup)-1]
+	require.NoError(t, img.Close())
+	_, err = os.Stat(tmp)
+	assert.True(t, os.IsNotExist(err), "Close removes the decompressed copy")
+
+	loop := &tarSource{path: "loop.tar", entries: map[string]tarEntry{
+		"a": {typeflag: tar.TypeSymlink, linkname: "b"},
+		"b": {typeflag: tar.TypeSymlink, linkname: "a"},
+	}}
+	_, err = loop.open("a")
+	assert.ErrorContains(t, err, "too many levels of symlinks")
+}
+
+func TestLoadImageRejectsUnknownFormat(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadImage(dir, "")
+	assert.ErrorContains(t, err, "not an OCI layout")
+
+	_, err = LoadImage(filepath.Join(dir, "missing.tar"), "")
+	assert.Error(t, err)
+}
+
+func TestUploadDigestMismatch(t *testing.T) {
+	reg := newMockRegistry(t)
+	err := reg.client().UploadBlob(context.Background(), "ns/repo", digestOf([]byte("other")), 4, strings.NewReader("data"), nil)
+	require.Error(t, err)
+	var regErr *Error
+	require.ErrorAs(t, err, &regErr)
+	assert.Equal(t, "DIGEST_INVALID", regErr.Code)
+}
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -40,10 +41,13 @@ func init() {
 	rootCmd.AddCommand(cmd.SkillsCmd)
 	rootCmd.AddCommand(cmd.ApiKeyCmd)
 	rootCmd.AddCommand(cmd.NetworkCmd)
+	rootCmd.AddCommand(cmd.InstanceTypesCmd)
 	rootCmd.AddCommand(cmd.DockerCmd)
+	rootCmd.AddCommand(cmd.UICmd)
+	rootCmd.AddCommand(cmd.PluginCmd)
 
 	// Global flags
-	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	rootCmd.SetCompletionCommandGroupID("core")
 	rootCmd.PersistentFlags().BoolP("help", "", false, "help for agentbay")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.Flags().BoolP("version", "", false, "Display the version of AgentBay CLI")
@@ -82,9 +86,23 @@ func main() {
 	// Load environment variables
 	_ = godotenv.Load()
 
+	// Invoked through the docker-credential-agentbay symlink: speak the credential helper protocol.
+	if cmd.IsCredentialHelperInvocation(os.Args[0]) {
+		os.Exit(cmd.RunCredentialHelper(os.Args[1:], os.Stdin, os.Stdout))
+	}
+
+	// Plugins: agentbay-<name> executables in the plugins directory or on PATH. Scanned only now,
+	// so the .env settings apply and credential helper calls never touch PATH.
+	cmd.AddPluginCommands(rootCmd)
+
 	// Execute root command
 	err := rootCmd.Execute()
 	if err != nil {
+		// A failed plugin already reported the error itself; keep its exit status.
+		var pluginErr *cmd.PluginExitError
+		if errors.As(err, &pluginErr) {
+			os.Exit(pluginErr.Code)
+		}
 		if isAuthError(err) {
 			fmt.Fprintln(os.Stderr, "[ERROR] Authentication required.")
 			fmt.Fprintln(os.Stderr, "")
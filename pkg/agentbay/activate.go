@@ -0,0 +1,713 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+// DefaultActivateCPU and DefaultActivateMemory are the resources of an activation that sets
+// neither CPU nor Memory.
+const (
+	DefaultActivateCPU    = 2
+	DefaultActivateMemory = 4
+)
+
+// Lifecycle overrides the sandbox lifecycle saved in the image's policy. Nil fields keep the
+// saved values.
+type Lifecycle struct {
+	Mode        *string  // "auto" or "manual"
+	MaxRuntime  *float64 // Maximum session runtime
+	Hibernate   *float64 // Idle time before a session hibernates
+	IdleTimeout *float64 // Idle time before a session is released; enables the idle timeout
+}
+
+// Merge returns existing with the fields set in l replaced. IdleTimeoutSwitch is on exactly when
+// the result has a UserIdleTimeout, including 0.
+func (l Lifecycle) Merge(existing *SandboxLifeCycle) *SandboxLifeCycle {
+	result := &SandboxLifeCycle{}
+
+	if existing != nil {
+		result.Mode = existing.Mode
+		result.HibernateTimeout = existing.HibernateTimeout
+		result.DesktopMaxRuntime = existing.DesktopMaxRuntime
+		result.UserIdleTimeout = existing.UserIdleTimeout
+		result.IdleTimeoutSwitch = existing.IdleTimeoutSwitch
+	}
+
+	if l.Mode != nil {
+		result.Mode = dara.String(*l.Mode)
+	}
+	if l.MaxRuntime != nil {
+		result.DesktopMaxRuntime = dara.Float64(*l.MaxRuntime)
+	}
+	if l.Hibernate != nil {
+		result.HibernateTimeout = dara.Float64(*l.Hibernate)
+	}
+	if l.IdleTimeout != nil {
+		result.UserIdleTimeout = dara.Float64(*l.IdleTimeout)
+	}
+
+	result.IdleTimeoutSwitch = dara.Bool(result.UserIdleTimeout != nil)
+	return result
+}
+
+// ActivateOptions configure ActivateImage and StartActivation.
+type ActivateOptions struct {
+	// CPU cores and Memory in GB; leave both 0 for DefaultActivateCPU and DefaultActivateMemory.
+	CPU    int
+	Memory int
+	// NetworkType defaults to NetworkDefault.
+	NetworkType NetworkType
+	// SessionBandwidth in Mbps, NetworkAdvanced only; 0 keeps the default.
+	SessionBandwidth int
+	// DNSAddresses for NetworkAdvanced and NetworkCustomized; empty uses the office network's.
+	DNSAddresses []string
+	// VpcId and VSwitchId are required for NetworkCustomized.
+	VpcId     string
+	VSwitchId string
+	// RegionId selects the region; empty uses the region of the image's policy.
+	RegionId string
+	// Lifecycle overrides the sandbox lifecycle.
+	Lifecycle Lifecycle
+	// Wait controls how ActivateImage waits for the activation to complete.
+	Wait WaitOptions
+	// OnProgress, when set, is called as each step starts and ends.
+	OnProgress func(ProgressEvent)
+}
+
+// normalize applies the defaults to o and validates the combination of options.
+func (o *ActivateOptions) normalize() error {
+	if o.NetworkType == "" {
+		o.NetworkType = NetworkDefault
+	}
+	switch o.NetworkType {
+	case NetworkDefault, NetworkAdvanced, NetworkCustomized:
+	default:
+		return fmt.Errorf("invalid network type: %s. Must be DEFAULT, ADVANCED or CUSTOMIZED", o.NetworkType)
+	}
+
+	if o.CPU == 0 && o.Memory == 0 {
+		o.CPU = DefaultActivateCPU
+		o.Memory = DefaultActivateMemory
+	}
+	if o.CPU <= 0 || o.Memory <= 0 {
+		return fmt.Errorf("CPU and memory must be positive and specified together")
+	}
+
+	if o.SessionBandwidth > 0 && o.NetworkType != NetworkAdvanced {
+		return fmt.Errorf("session bandwidth is only valid for ADVANCED network")
+	}
+	if len(o.DNSAddresses) > 0 && o.NetworkType == NetworkDefault {
+		return fmt.Errorf("DNS addresses are only valid for ADVANCED or CUSTOMIZED network")
+	}
+	if o.NetworkType == NetworkCustomized {
+		if o.VpcId == "" || o.VSwitchId == "" {
+			return fmt.Errorf("VPC ID and VSwitch ID are required for CUSTOMIZED network")
+		}
+	} else if o.VpcId != "" || o.VSwitchId != "" {
+		return fmt.Errorf("VPC ID and VSwitch ID are only valid for CUSTOMIZED network")
+	}
+	if o.Lifecycle.Mode != nil && *o.Lifecycle.Mode != "auto" && *o.Lifecycle.Mode != "manual" {
+		return fmt.Errorf("invalid lifecycle mode: %s. Must be auto or manual", *o.Lifecycle.Mode)
+	}
+	return nil
+}
+
+// StepState is the state a ProgressEvent reports.
+type StepState int
+
+const (
+	StepStarted StepState = iota
+	StepDone
+	StepFailed
+	StepSkipped
+	// StepNote carries an informational Message about the current step.
+	StepNote
+)
+
+// ProgressEvent reports the progress of an activation.
+type ProgressEvent struct {
+	// Step is the 1-based step number out of Steps; 0 for checks that are not numbered.
+	Step  int
+	Steps int
+	Title string // e.g. "Fetching policy data"
+	State StepState
+	// Action and RequestId identify the API call of a done or failed step, when known.
+	Action    string
+	RequestId string
+	// Message is the reason of a skipped step, or the text of a note.
+	Message string
+}
+
+// Activation describes the resource group created by StartActivation.
+type Activation struct {
+	ImageId         string
+	AppInstanceType string
+	RegionId        string
+	OfficeSiteId    string   // CUSTOMIZED only
+	DNSAddresses    []string // DNS addresses applied, including office network defaults
+	RequestId       string   // Request ID of CreateResourceGroup
+}
+
+// ErrNotActivatable is returned by ActivateImage for images in a state that cannot be activated,
+// such as an image that is still being built or deactivated.
+var ErrNotActivatable = errors.New("cannot activate image in current state")
+
+// ActivateImage activates a user image and waits until it is activated: it matches the
+// CPU/memory to an instance type, saves the network and lifecycle policy, creates the resource
+// group and polls the image status. An image that is already activating is only waited for; an
+// activated image or a system image is returned as is.
+func ActivateImage(ctx context.Context, c ActivationAPI, imageId string, opts ActivateOptions) (*ImageInfo, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	info, err := GetImageInfo(ctx, c, imageId)
+	if err != nil {
+		return nil, err
+	}
+	if info.ImageType == ImageTypeSystem || info.Status == StatusResourcePublished {
+		return info, nil
+	}
+	if info.ImageType != ImageTypeUser {
+		return nil, fmt.Errorf("unknown image type: %s (expected 'User' or 'System')", info.ImageType)
+	}
+
+	steps := 1
+	switch info.Status {
+	case StatusResourceDeploying:
+	case StatusImageAvailable:
+		if _, err := StartActivation(ctx, c, info, opts); err != nil {
+			return nil, err
+		}
+		steps = activationSteps(opts.NetworkType)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrNotActivatable, info.Status.Text())
+	}
+
+	p := &progress{report: opts.OnProgress, steps: steps, step: steps - 1}
+	p.begin("Waiting for activation")
+	info, err = WaitForStatus(ctx, c, imageId, StatusResourcePublished, opts.Wait)
+	if err != nil {
+		p.failed("", "")
+		return info, err
+	}
+	p.done("GetMcpImageInfo", info.RequestId)
+	return info, nil
+}
+
+// activationSteps returns the number of steps of an activation, including the final wait.
+func activationSteps(networkType NetworkType) int {
+	switch networkType {
+	case NetworkAdvanced:
+		return 7
+	case NetworkCustomized:
+		return 8
+	default:
+		return 6
+	}
+}
+
+// StartActivation runs the activation of a deactivated user image up to creating its resource
+// group, without waiting for the image to become activated. image must be the current
+// ImageInfo of the image, with status StatusImageAvailable.
+//
+// The network and lifecycle policy is saved before the resource group is created, because saving
+// it fails once the image is running.
+func StartActivation(ctx context.Context, c ActivationAPI, image *ImageInfo, opts ActivateOptions) (*Activation, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	a := &activation{
+		c:     c,
+		image: image,
+		opts:  opts,
+		p:     &progress{report: opts.OnProgress, steps: activationSteps(opts.NetworkType)},
+	}
+	return a.run(ctx)
+}
+
+// progress numbers the steps of an activation and reports them to a callback.
+type progress struct {
+	report func(ProgressEvent)
+	steps  int
+	step   int
+	cur    ProgressEvent
+}
+
+func (p *progress) emit(e ProgressEvent) {
+	if p.report != nil {
+		p.report(e)
+	}
+}
+
+// begin starts the next numbered step.
+func (p *progress) begin(title string) {
+	p.step++
+	p.cur = ProgressEvent{Step: p.step, Steps: p.steps, Title: title}
+	p.emit(p.cur)
+}
+
+// check starts an unnumbered check between steps.
+func (p *progress) check(title string) {
+	p.cur = ProgressEvent{Steps: p.steps, Title: title}
+	p.emit(p.cur)
+}
+
+func (p *progress) done(action, requestId string) {
+	e := p.cur
+	e.State, e.Action, e.RequestId = StepDone, action, requestId
+	p.emit(e)
+}
+
+func (p *progress) failed(action, requestId string) {
+	e := p.cur
+	e.State, e.Action, e.RequestId = StepFailed, action, requestId
+	p.emit(e)
+}
+
+func (p *progress) skipped(reason string) {
+	e := p.cur
+	e.State, e.Message = StepSkipped, reason
+	p.emit(e)
+}
+
+func (p *progress) note(format string, args ...any) {
+	e := p.cur
+	e.State, e.Message = StepNote, fmt.Sprintf(format, args...)
+	p.emit(e)
+}
+
+// activation holds the state of one StartActivation.
+type activation struct {
+	c     ActivationAPI
+	image *ImageInfo
+	opts  ActivateOptions
+	p     *progress
+
+	policy         *DescribeMcpPolicyDataResponseBodyData
+	lifecycle      *SandboxLifeCycle
+	edsPolicyId    string
+	serverRegionId string
+	regionName     string
+	result         Activation
+}
+
+func (a *activation) run(ctx context.Context) (*Activation, error) {
+	a.result.ImageId = a.image.ImageId
+
+	if err := a.matchInstanceType(ctx); err != nil {
+		return nil, err
+	}
+	if err := a.fetchPolicy(ctx); err != nil {
+		return nil, err
+	}
+
+	if a.opts.NetworkType == NetworkCustomized {
+		// Verify VPC/region against existing office sites before the first write call
+		a.p.check("Validating VPC and region")
+		if a.regionName == "" {
+			a.p.skipped("RegionName not available")
+		} else if problems := CheckCustomizedNetwork(ctx, a.c, a.opts.VpcId, a.regionName); len(problems) > 0 {
+			a.p.failed("", "")
+			return nil, &NetworkCheckError{Problems: problems}
+		} else {
+			a.p.done("", "")
+		}
+	}
+
+	if err := a.createOrModifyPolicy(ctx); err != nil {
+		return nil, err
+	}
+
+	switch a.opts.NetworkType {
+	case NetworkAdvanced:
+		if err := a.queryOfficeNetwork(ctx); err != nil {
+			return nil, err
+		}
+	case NetworkCustomized:
+		if err := a.queryOfficeNetwork(ctx); err != nil {
+			return nil, err
+		}
+		if err := a.ensureOfficeSite(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.savePolicy(ctx); err != nil {
+		return nil, err
+	}
+	if err := a.createResourceGroup(ctx); err != nil {
+		return nil, err
+	}
+	return &a.result, nil
+}
+
+// matchInstanceType queries DescribeInstanceTypes and picks the instance type with the requested
+// CPU and memory.
+func (a *activation) matchInstanceType(ctx context.Context) error {
+	a.p.begin("Querying instance types")
+	instanceTypes, requestId, err := ListInstanceTypes(ctx, a.c, a.image.ImageId, a.opts.RegionId)
+	if err != nil {
+		a.p.failed("DescribeInstanceTypes", requestId)
+		return fmt.Errorf("failed to query instance types: %w", err)
+	}
+	a.p.done("DescribeInstanceTypes", requestId)
+
+	matched, err := MatchInstanceType(a.opts.CPU, a.opts.Memory, instanceTypes)
+	if err != nil {
+		return err
+	}
+	if matched.AppInstanceType != nil {
+		a.result.AppInstanceType = *matched.AppInstanceType
+		a.p.note("Matched instance type: %s", a.result.AppInstanceType)
+	}
+	return nil
+}
+
+// fetchPolicy reads the image's policy and merges the requested lifecycle into it.
+func (a *activation) fetchPolicy(ctx context.Context) error {
+	a.p.begin("Fetching policy data")
+	resp, err := a.c.DescribeMcpPolicyData(ctx, &DescribeMcpPolicyDataRequest{
+		ImageId: dara.String(a.image.ImageId),
+	})
+	if err != nil {
+		a.p.failed("DescribeMcpPolicyData", requestIDOf(err))
+		return fmt.Errorf("failed to fetch policy data: %w", err)
+	}
+	var requestId string
+	if resp.Body != nil {
+		requestId = dara.StringValue(resp.Body.GetRequestId())
+		a.policy = resp.Body.Data
+	}
+	a.p.done("DescribeMcpPolicyData", requestId)
+
+	if a.policy != nil && a.policy.GroupSpec != nil && a.policy.GroupSpec.RegionId != nil {
+		a.serverRegionId = *a.policy.GroupSpec.RegionId
+	}
+	a.regionName = a.opts.RegionId
+	if a.regionName == "" {
+		a.regionName = a.serverRegionId
+	}
+
+	var existing *SandboxLifeCycle
+	if a.policy != nil {
+		existing = a.policy.SandboxLifeCycle
+	}
+	a.lifecycle = a.opts.Lifecycle.Merge(existing)
+	return nil
+}
+
+// createOrModifyPolicy calls CreateMcpPolicyData when the image still uses the default policy,
+// and ModifyMcpPolicyData otherwise. A created policy's ID is used when saving.
+func (a *activation) createOrModifyPolicy(ctx context.Context) error {
+	if a.policy == nil {
+		// Keep the numbers of the later steps
+		a.p.step++
+		return nil
+	}
+	isDefaultData := a.policy.IsDefaultData != nil && *a.policy.IsDefaultData
+
+	action := "ModifyMcpPolicyData"
+	if isDefaultData {
+		action = "CreateMcpPolicyData"
+	}
+	a.p.begin(action)
+
+	req := &CreateModifyMcpPolicyDataRequest{
+		ImageId:               dara.String(a.image.ImageId),
+		SandboxLifeCycle:      a.lifecycle,
+		NetworkConfig:         a.policy.NetworkConfig,
+		DisplayConfig:         a.policy.DisplayConfig,
+		BusinessType:          dara.Int32(1),
+		ResourceType:          dara.String("AIAgent"),
+		DisconnectKeepSession: dara.String("persistent"),
+		Name:                  dara.String(fmt.Sprintf("policy-agentbay-cli-%s", time.Now().Format("20060102-15:04:05"))),
+	}
+
+	// ScreenSettings flattened
+	if a.policy.ScreenSettings != nil {
+		req.Taskbar = a.policy.ScreenSettings.Taskbar
+		req.ScreenDisplayMode = a.policy.ScreenSettings.ScreenDisplayMode
+		req.ClientControlMenu = a.policy.ScreenSettings.ClientControlMenu
+	}
+
+	// RegionName from user-specified regionId or GroupSpec.RegionId
+	if a.opts.RegionId != "" {
+		req.RegionName = dara.String(a.opts.RegionId)
+	} else if a.policy.GroupSpec != nil {
+		req.RegionName = a.policy.GroupSpec.RegionId
+	}
+
+	// OsName-dependent fields
+	if a.image.OsName == "Android" {
+		req.InternetCommunicationProtocol = dara.String("rtc")
+		req.ResolutionWidth = dara.Int32(1080)
+		req.ResolutionHeight = dara.Int32(1920)
+	} else {
+		req.InternetCommunicationProtocol = dara.String("auto")
+	}
+
+	if isDefaultData {
+		resp, err := a.c.CreateMcpPolicyData(ctx, req)
+		if err != nil {
+			a.p.failed(action, requestIDOf(err))
+			return fmt.Errorf("failed to create policy data: %w", err)
+		}
+		if resp.Body != nil {
+			a.p.done(action, dara.StringValue(resp.Body.GetRequestId()))
+			a.edsPolicyId = dara.StringValue(resp.Body.GetPolicyId())
+		} else {
+			a.p.done(action, "")
+		}
+		return nil
+	}
+
+	resp, err := a.c.ModifyMcpPolicyData(ctx, req)
+	if err != nil {
+		a.p.failed(action, requestIDOf(err))
+		return fmt.Errorf("failed to modify policy data: %w", err)
+	}
+	var requestId string
+	if resp.Body != nil {
+		requestId = dara.StringValue(resp.Body.GetRequestId())
+	}
+	a.p.done(action, requestId)
+	return nil
+}
+
+// queryOfficeNetwork looks up the office network of the region for its default DNS addresses
+// and, for CUSTOMIZED, the office site already bound to the VPC.
+func (a *activation) queryOfficeNetwork(ctx context.Context) error {
+	title := "Querying office network"
+	req := &DescribeOfficeSitesRequest{
+		OfficeSiteType: dara.String(string(a.opts.NetworkType)),
+		RegionName:     dara.String(a.regionName),
+	}
+	if a.opts.NetworkType == NetworkCustomized {
+		title = "Querying customized office network"
+		req.VpcId = dara.String(a.opts.VpcId)
+	}
+
+	a.p.begin(title)
+	var defaultDnsAddresses []string
+	if a.regionName == "" {
+		a.p.skipped("RegionName not available")
+	} else {
+		resp, err := a.c.DescribeOfficeSites(ctx, req)
+		if err != nil {
+			a.p.failed("DescribeOfficeSites", requestIDOf(err))
+			if a.opts.NetworkType == NetworkCustomized {
+				return fmt.Errorf("failed to query customized office network: %w", err)
+			}
+			return fmt.Errorf("failed to query office network: %w", err)
+		}
+		var requestId string
+		if resp.Body != nil {
+			requestId = dara.StringValue(resp.Body.GetRequestId())
+		}
+		a.p.done("DescribeOfficeSites", requestId)
+
+		if resp.Body != nil && resp.Body.Data != nil {
+			defaultDnsAddresses = resp.Body.Data.DnsAddress
+			if a.opts.NetworkType == NetworkCustomized {
+				a.result.OfficeSiteId = dara.StringValue(resp.Body.Data.OfficeSiteId)
+				if a.result.OfficeSiteId != "" {
+					a.p.note("Found existing office site: %s", a.result.OfficeSiteId)
+				}
+			}
+			if len(defaultDnsAddresses) > 0 {
+				a.p.note("Office network default DNS: %s", strings.Join(defaultDnsAddresses, ", "))
+			}
+		}
+	}
+
+	// User-specified DNS addresses take priority over the office network defaults
+	a.result.DNSAddresses = a.opts.DNSAddresses
+	if len(a.result.DNSAddresses) == 0 && len(defaultDnsAddresses) > 0 {
+		a.result.DNSAddresses = defaultDnsAddresses
+		if a.opts.NetworkType == NetworkAdvanced {
+			a.p.note("Using default DNS addresses from office network: %s", strings.Join(defaultDnsAddresses, ", "))
+		}
+	}
+	return nil
+}
+
+// ensureOfficeSite creates a customized office site for the VPC unless one already exists.
+func (a *activation) ensureOfficeSite(ctx context.Context) error {
+	a.p.begin("Creating simple office site")
+	if a.result.OfficeSiteId != "" {
+		a.p.skipped(fmt.Sprintf("Office site already exists: %s", a.result.OfficeSiteId))
+	} else {
+		regionId := a.opts.RegionId
+		if regionId == "" {
+			regionId = a.serverRegionId
+		}
+		officeSiteId, requestId, err := CreateCustomizedOfficeSite(ctx, a.c, a.opts.VpcId, regionId, "")
+		if err != nil {
+			a.p.failed("CreateSimpleOfficeSite", requestId)
+			return fmt.Errorf("failed to create simple office site: %w", err)
+		}
+		a.p.done("CreateSimpleOfficeSite", requestId)
+		a.result.OfficeSiteId = officeSiteId
+		a.p.note("Created office site: %s", officeSiteId)
+	}
+
+	if len(a.opts.DNSAddresses) == 0 && len(a.result.DNSAddresses) > 0 {
+		a.p.note("Using default DNS addresses from office network: %s", strings.Join(a.result.DNSAddresses, ", "))
+	}
+	return nil
+}
+
+// savePolicy saves the policy with the instance type, region, lifecycle and network settings.
+func (a *activation) savePolicy(ctx context.Context) error {
+	a.p.begin("Saving policy configuration")
+	if a.policy == nil {
+		a.p.failed("", "")
+		return fmt.Errorf("invalid policy data response")
+	}
+	data := a.policy
+	regionId := a.opts.RegionId
+
+	saveReq := &SaveMcpPolicyDataRequest{
+		ImageId:          dara.String(a.image.ImageId),
+		PolicyId:         data.PolicyId,
+		SandboxLifeCycle: a.lifecycle,
+		ScreenSettings:   data.ScreenSettings,
+		NetworkConfig:    data.NetworkConfig,
+		DisplayConfig:    data.DisplayConfig,
+	}
+	if a.edsPolicyId != "" {
+		saveReq.PolicyId = dara.String(a.edsPolicyId)
+	}
+
+	// Copy GroupSpec and update with new values
+	if data.GroupSpec != nil {
+		saveReq.GroupSpec = &GroupSpec{
+			AppInstanceType: dara.String(a.result.AppInstanceType),
+			RegionName:      data.GroupSpec.RegionName,
+			Memory:          dara.Int32(int32(a.opts.Memory)),
+			Cpu:             dara.Int32(int32(a.opts.CPU)),
+			RegionId:        data.GroupSpec.RegionId,
+		}
+		if regionId != "" {
+			saveReq.GroupSpec.RegionName = dara.String(regionId)
+			saveReq.GroupSpec.RegionId = dara.String(regionId)
+		}
+	}
+	if regionId != "" {
+		saveReq.RegionId = dara.String(regionId)
+	} else if data.GroupSpec != nil && data.GroupSpec.RegionId != nil {
+		saveReq.RegionId = data.GroupSpec.RegionId
+	}
+
+	saveReq.NetworkData = &NetworkData{
+		OfficeSiteType: dara.String(string(a.opts.NetworkType)),
+	}
+	switch a.opts.NetworkType {
+	case NetworkDefault:
+		// The default network has no VPC or DNS of its own
+		saveReq.NetworkData.VpcId = dara.String("")
+		saveReq.NetworkData.DnsAddress = dara.String("")
+		saveReq.NetworkData.VpcName = dara.String("")
+	case NetworkAdvanced:
+		if a.opts.SessionBandwidth > 0 {
+			saveReq.NetworkData.SessionBandwidth = dara.Int32(int32(a.opts.SessionBandwidth))
+		}
+		if data.NetworkData != nil {
+			saveReq.NetworkData.VpcId = data.NetworkData.VpcId
+			saveReq.NetworkData.VpcName = data.NetworkData.VpcName
+		}
+	case NetworkCustomized:
+		saveReq.NetworkData.VpcId = dara.String(a.opts.VpcId)
+		saveReq.NetworkData.VSwitchId = dara.String(a.opts.VSwitchId)
+		if data.NetworkData != nil {
+			saveReq.NetworkData.VpcName = data.NetworkData.VpcName
+		}
+	}
+	if a.opts.NetworkType != NetworkDefault && len(a.result.DNSAddresses) > 0 {
+		saveReq.NetworkData.DnsAddress = dara.String(strings.Join(a.result.DNSAddresses, ","))
+	}
+
+	resp, err := a.c.SaveMcpPolicyData(ctx, saveReq)
+	if err != nil {
+		a.p.failed("SaveMcpPolicyData", requestIDOf(err))
+		return fmt.Errorf("failed to save policy data: %w", err)
+	}
+	var requestId string
+	if resp.Body != nil {
+		requestId = dara.StringValue(resp.Body.GetRequestId())
+	}
+	a.p.done("SaveMcpPolicyData", requestId)
+	return nil
+}
+
+// createResourceGroup creates the resource group that runs the image, which starts the activation.
+func (a *activation) createResourceGroup(ctx context.Context) error {
+	a.p.begin("Creating resource group")
+	req := &CreateResourceGroupRequest{
+		ImageId:        dara.String(a.image.ImageId),
+		Cpu:            dara.Int32(int32(a.opts.CPU)),
+		Memory:         dara.Int32(int32(a.opts.Memory)),
+		OfficeSiteType: dara.String(string(a.opts.NetworkType)),
+	}
+	if a.result.AppInstanceType != "" {
+		req.SetAppInstanceType(a.result.AppInstanceType)
+	}
+	switch a.opts.NetworkType {
+	case NetworkAdvanced:
+		if a.opts.SessionBandwidth > 0 {
+			req.SetSessionBandwidth(int32(a.opts.SessionBandwidth))
+		}
+	case NetworkCustomized:
+		req.SetVpcId(a.opts.VpcId)
+		req.SetVSwitchId(a.opts.VSwitchId)
+		if a.result.OfficeSiteId != "" {
+			req.SetOfficeSiteId(a.result.OfficeSiteId)
+		}
+	}
+	if a.opts.NetworkType != NetworkDefault && len(a.result.DNSAddresses) > 0 {
+		req.SetDnsAddress(a.result.DNSAddresses)
+	}
+
+	// A user-specified region takes priority over the region of the policy
+	a.result.RegionId = a.opts.RegionId
+	if a.result.RegionId == "" {
+		a.result.RegionId = a.serverRegionId
+	}
+	if a.result.RegionId != "" {
+		req.SetBizRegionId(a.result.RegionId)
+	}
+
+	resp, err := a.c.CreateResourceGroup(ctx, req)
+	if err != nil {
+		a.p.failed("CreateResourceGroup", requestIDOf(err))
+		return fmt.Errorf("failed to create resource group: %w", err)
+	}
+	if resp.Body == nil {
+		a.p.failed("CreateResourceGroup", "")
+		return fmt.Errorf("invalid response from server")
+	}
+	requestId := dara.StringValue(resp.Body.GetRequestId())
+	if !dara.BoolValue(resp.Body.GetSuccess()) {
+		a.p.failed("CreateResourceGroup", requestId)
+		return &Error{
+			Action:    "CreateResourceGroup",
+			Code:      dara.StringValue(resp.Body.GetCode()),
+			Message:   dara.StringValue(resp.Body.GetMessage()),
+			RequestId: requestId,
+		}
+	}
+
+	a.result.RequestId = requestId
+	a.p.done("CreateResourceGroup", requestId)
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// activationClient serves the calls of an activation and records the write requests.
+type activationClient struct {
+	statusClient
+	isDefaultData bool
+	officeDNS     []string
+
+	created  *CreateModifyMcpPolicyDataRequest
+	modified *CreateModifyMcpPolicyDataRequest
+	saved    *SaveMcpPolicyDataRequest
+	group    *CreateResourceGroupRequest
+}
+
+func (a *activationClient) DescribeInstanceTypes(ctx context.Context, req *DescribeInstanceTypesRequest) (*DescribeInstanceTypesResponse, error) {
+	return &DescribeInstanceTypesResponse{Body: &DescribeInstanceTypesResponseBody{
+		Success:   dara.Bool(true),
+		RequestId: "req-types",
+		Data: []*DescribeInstanceTypesResponseBodyDataInstanceType{
+			{AppInstanceType: dara.String("eds.large"), Cpu: dara.Int32(4), Memory: dara.Int32(8)},
+			{AppInstanceType: dara.String("eds.medium"), Cpu: dara.Int32(2), Memory: dara.Int32(4)},
+		},
+	}}, nil
+}
+
+func (a *activationClient) DescribeMcpPolicyData(ctx context.Context, req *DescribeMcpPolicyDataRequest) (*DescribeMcpPolicyDataResponse, error) {
+	return &DescribeMcpPolicyDataResponse{Body: &DescribeMcpPolicyDataResponseBody{
+		RequestId: "req-policy",
+		Data: &DescribeMcpPolicyDataResponseBodyData{
+			PolicyId:      dara.String("pol-1"),
+			IsDefaultData: dara.Bool(a.isDefaultData),
+			GroupSpec:     &GroupSpec{RegionId: dara.String("cn-hangzhou"), RegionName: dara.String("cn-hangzhou")},
+			SandboxLifeCycle: &SandboxLifeCycle{
+				Mode:             dara.String("auto"),
+				HibernateTimeout: dara.Float64(60),
+			},
+		},
+	}}, nil
+}
+
+func (a *activationClient) CreateMcpPolicyData(ctx context.Context, req *CreateModifyMcpPolicyDataRequest) (*CreateMcpPolicyDataResponse, error) {
+	a.created = req
+	return &CreateMcpPolicyDataResponse{Body: &CreateMcpPolicyDataResponseBody{PolicyId: dara.String("eds-pol-2")}}, nil
+}
+
+func (a *activationClient) ModifyMcpPolicyData(ctx context.Context, req *CreateModifyMcpPolicyDataRequest) (*ModifyMcpPolicyDataResponse, error) {
+	a.modified = req
+	return &ModifyMcpPolicyDataResponse{Body: &ModifyMcpPolicyDataResponseBody{}}, nil
+}
+
+func (a *activationClient) DescribeOfficeSites(ctx context.Context, req *DescribeOfficeSitesRequest) (*DescribeOfficeSitesResponse, error) {
+	return &DescribeOfficeSitesResponse{Body: &DescribeOfficeSitesResponseBody{
+		RequestId: "req-sites",
+		Data:      &DescribeOfficeSitesResponseBodyData{DnsAddress: a.officeDNS},
+	}}, nil
+}
+
+func (a *activationClient) SaveMcpPolicyData(ctx context.Context, req *SaveMcpPolicyDataRequest) (*SaveMcpPolicyDataResponse, error) {
+	a.saved = req
+	return &SaveMcpPolicyDataResponse{Body: &SaveMcpPolicyDataResponseBody{}}, nil
+}
+
+func (a *activationClient) CreateResourceGroup(ctx context.Context, req *CreateResourceGroupRequest) (*CreateResourceGroupResponse, error) {
+	a.group = req
+	return &CreateResourceGroupResponse{Body: &CreateResourceGroupResponseBody{
+		Success:   dara.Bool(true),
+		RequestId: dara.String("req-group"),
+	}}, nil
+}
+
+func TestActivateImageDefaultNetwork(t *testing.T) {
+	c := &activationClient{statusClient: statusClient{statuses: []ImageStatus{StatusImageAvailable, StatusResourceDeploying, StatusResourcePublished}}}
+	var events []ProgressEvent
+
+	info, err := ActivateImage(context.Background(), c, "imgc-1", ActivateOptions{
+		Lifecycle:  Lifecycle{IdleTimeout: dara.Float64(0)},
+		Wait:       fastWait,
+		OnProgress: func(e ProgressEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusResourcePublished, info.Status)
+
+	require.NotNil(t, c.modified, "a customized policy is modified")
+	assert.Nil(t, c.created)
+	assert.Equal(t, "cn-hangzhou", dara.StringValue(c.modified.RegionName))
+	assert.Equal(t, "auto", dara.StringValue(c.modified.InternetCommunicationProtocol))
+
+	require.NotNil(t, c.saved)
+	assert.Equal(t, "pol-1", dara.StringValue(c.saved.PolicyId))
+	assert.Equal(t, "eds.medium", dara.StringValue(c.saved.GroupSpec.AppInstanceType))
+	assert.Equal(t, "DEFAULT", dara.StringValue(c.saved.NetworkData.OfficeSiteType))
+	assert.Equal(t, 60.0, dara.Float64Value(c.saved.SandboxLifeCycle.HibernateTimeout), "saved lifecycle values are kept")
+	assert.True(t, dara.BoolValue(c.saved.SandboxLifeCycle.IdleTimeoutSwitch))
+
+	require.NotNil(t, c.group)
+	assert.Equal(t, int32(DefaultActivateCPU), dara.Int32Value(c.group.Cpu))
+	assert.Equal(t, int32(DefaultActivateMemory), dara.Int32Value(c.group.Memory))
+	assert.Equal(t, "eds.medium", dara.StringValue(c.group.AppInstanceType))
+	assert.Equal(t, "cn-hangzhou", dara.StringValue(c.group.BizRegionId))
+	assert.Nil(t, c.group.DnsAddress)
+
+	var titles []string
+	for _, e := range events {
+		if e.State == StepStarted {
+			titles = append(titles, e.Title)
+			assert.Equal(t, 6, e.Steps)
+		}
+	}
+	assert.Equal(t, []string{
+		"Querying instance types",
+		"Fetching policy data",
+		"ModifyMcpPolicyData",
+		"Saving policy configuration",
+		"Creating resource group",
+		"Waiting for activation",
+	}, titles)
+	last := events[len(events)-1]
+	assert.Equal(t, StepDone, last.State)
+	assert.Equal(t, 6, last.Step)
+}
+
+func TestStartActivationAdvancedNetwork(t *testing.T) {
+	c := &activationClient{isDefaultData: true, officeDNS: []string{"10.0.0.2"}}
+	image := &ImageInfo{ImageId: "imgc-1", Status: StatusImageAvailable, ImageType: ImageTypeUser, OsName: "Android"}
+
+	activation, err := StartActivation(context.Background(), c, image, ActivateOptions{
+		CPU:              4,
+		Memory:           8,
+		NetworkType:      NetworkAdvanced,
+		SessionBandwidth: 20,
+		RegionId:         "cn-shanghai",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "eds.large", activation.AppInstanceType)
+	assert.Equal(t, "cn-shanghai", activation.RegionId)
+	assert.Equal(t, []string{"10.0.0.2"}, activation.DNSAddresses)
+	assert.Equal(t, "req-group", activation.RequestId)
+
+	require.NotNil(t, c.created, "the default policy is replaced by a new one")
+	assert.Equal(t, "rtc", dara.StringValue(c.created.InternetCommunicationProtocol))
+	assert.Equal(t, "eds-pol-2", dara.StringValue(c.saved.PolicyId))
+	assert.Equal(t, "10.0.0.2", dara.StringValue(c.saved.NetworkData.DnsAddress))
+	assert.Equal(t, int32(20), dara.Int32Value(c.saved.NetworkData.SessionBandwidth))
+	assert.Equal(t, "cn-shanghai", dara.StringValue(c.saved.GroupSpec.RegionId))
+	assert.Equal(t, []string{"10.0.0.2"}, c.group.DnsAddress)
+	assert.Equal(t, int32(20), dara.Int32Value(c.group.SessionBandwidth))
+}
+
+func TestStartActivationRejectsUnknownInstanceType(t *testing.T) {
+	c := &activationClient{}
+	image := &ImageInfo{ImageId: "imgc-1", Status: StatusImageAvailable, ImageType: ImageTypeUser}
+
+	_, err := StartActivation(context.Background(), c, image, ActivateOptions{CPU: 3, Memory: 6})
+	var typeErr *InstanceTypeError
+	require.ErrorAs(t, err, &typeErr)
+	assert.Equal(t, []string{"2c4g", "4c8g"}, typeErr.Available)
+	assert.Nil(t, c.saved, "nothing is written when no instance type matches")
+}
+
+func TestActivateImageSkipsSystemAndActivatedImages(t *testing.T) {
+	c := &activationClient{statusClient: statusClient{statuses: []ImageStatus{StatusResourcePublished}}}
+
+	info, err := ActivateImage(context.Background(), c, "imgc-1", ActivateOptions{Wait: fastWait})
+	require.NoError(t, err)
+	assert.Equal(t, StatusResourcePublished, info.Status)
+
+	info, err = ActivateImage(context.Background(), c, "aliyun-mcp-ubuntu", ActivateOptions{Wait: fastWait})
+	require.NoError(t, err)
+	assert.Equal(t, ImageTypeSystem, info.ImageType)
+	assert.Nil(t, c.group)
+}
+
+func TestActivateImageValidatesOptions(t *testing.T) {
+	c := &activationClient{statusClient: statusClient{statuses: []ImageStatus{StatusImageAvailable}}}
+
+	for name, opts := range map[string]ActivateOptions{
+		"network type":         {NetworkType: "PRIVATE"},
+		"memory without CPU":   {Memory: 4},
+		"bandwidth":            {SessionBandwidth: 10},
+		"VPC for DEFAULT":      {VpcId: "vpc-1"},
+		"CUSTOMIZED no VPC":    {NetworkType: NetworkCustomized},
+		"lifecycle mode":       {Lifecycle: Lifecycle{Mode: dara.String("sometimes")}},
+		"DNS for DEFAULT":      {DNSAddresses: []string{"8.8.8.8"}},
+		"VSwitch for ADVANCED": {NetworkType: NetworkAdvanced, VSwitchId: "vsw-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ActivateImage(context.Background(), c, "imgc-1", opts)
+			assert.Error(t, err)
+		})
+	}
+	assert.Zero(t, c.calls, "options are validated before any call")
+}
+
+func TestLifecycleMerge(t *testing.T) {
+	existing := &SandboxLifeCycle{
+		Mode:              dara.String("auto"),
+		DesktopMaxRuntime: dara.Float64(3600),
+		UserIdleTimeout:   dara.Float64(300),
+		IdleTimeoutSwitch: dara.Bool(true),
+	}
+
+	merged := Lifecycle{Mode: dara.String("manual")}.Merge(existing)
+	assert.Equal(t, "manual", dara.StringValue(merged.Mode))
+	assert.Equal(t, 3600.0, dara.Float64Value(merged.DesktopMaxRuntime))
+	assert.True(t, dara.BoolValue(merged.IdleTimeoutSwitch))
+	assert.Equal(t, "auto", dara.StringValue(existing.Mode), "existing is not modified")
+
+	merged = Lifecycle{Hibernate: dara.Float64(float64(time.Minute / time.Second))}.Merge(nil)
+	assert.Equal(t, 60.0, dara.Float64Value(merged.HibernateTimeout))
+	assert.False(t, dara.BoolValue(merged.IdleTimeoutSwitch))
+}
+
+func TestLifecycleMergeOverridesAndIdleTimeoutSwitch(t *testing.T) {
+	existing := &SandboxLifeCycle{
+		Mode:              dara.String("manual"),
+		DesktopMaxRuntime: dara.Float64(7200),
+		HibernateTimeout:  dara.Float64(3600),
+		UserIdleTimeout:   dara.Float64(900),
+		IdleTimeoutSwitch: dara.Bool(true),
+	}
+
+	t.Run("no overrides keep existing", func(t *testing.T) {
+		merged := Lifecycle{}.Merge(existing)
+		assert.Equal(t, existing, merged)
+	})
+
+	t.Run("all overrides on nil", func(t *testing.T) {
+		merged := Lifecycle{
+			Mode:        dara.String("auto"),
+			MaxRuntime:  dara.Float64(3600),
+			Hibernate:   dara.Float64(1800),
+			IdleTimeout: dara.Float64(600),
+		}.Merge(nil)
+		assert.Equal(t, "auto", dara.StringValue(merged.Mode))
+		assert.Equal(t, 3600.0, dara.Float64Value(merged.DesktopMaxRuntime))
+		assert.Equal(t, 1800.0, dara.Float64Value(merged.HibernateTimeout))
+		assert.Equal(t, 600.0, dara.Float64Value(merged.UserIdleTimeout))
+		assert.True(t, dara.BoolValue(merged.IdleTimeoutSwitch))
+	})
+
+	t.Run("nothing set on nil", func(t *testing.T) {
+		merged := Lifecycle{}.Merge(nil)
+		require.NotNil(t, merged.IdleTimeoutSwitch)
+		assert.False(t, *merged.IdleTimeoutSwitch)
+		assert.Nil(t, merged.Mode)
+		assert.Nil(t, merged.UserIdleTimeout)
+	})
+
+	t.Run("zero idle timeout turns the switch on", func(t *testing.T) {
+		merged := Lifecycle{IdleTimeout: dara.Float64(0)}.Merge(nil)
+		require.NotNil(t, merged.UserIdleTimeout)
+		assert.Equal(t, 0.0, *merged.UserIdleTimeout)
+		assert.True(t, dara.BoolValue(merged.IdleTimeoutSwitch))
+	})
+
+	t.Run("existing without idle timeout keeps the switch off", func(t *testing.T) {
+		merged := Lifecycle{}.Merge(&SandboxLifeCycle{Mode: dara.String("auto")})
+		assert.Nil(t, merged.UserIdleTimeout)
+		assert.False(t, dara.BoolValue(merged.IdleTimeoutSwitch))
+	})
+}
@@ -0,0 +1,400 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	internal "github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// Client is the stable interface of the package: its methods and the types they take and return
+// are owned by this package and keep their signatures across releases. Operations added later go
+// on API, not on Client, so an implementation of Client written today keeps compiling.
+//
+// Failures are returned as *Error, including responses that report a failure in their body.
+type Client interface {
+	// ListImages returns one page of images of one type.
+	ListImages(ctx context.Context, opts ListImagesOptions) (*ImageList, error)
+	// GetImage returns the status and type of an image.
+	GetImage(ctx context.Context, imageId string) (*ImageInfo, error)
+	// DeleteImage deletes a user image. Activated images must be deactivated first.
+	DeleteImage(ctx context.Context, imageId string) error
+	// ActivateImage activates a user image and waits until it is activated; see the
+	// package-level ActivateImage.
+	ActivateImage(ctx context.Context, imageId string, opts ActivateOptions) (*ImageInfo, error)
+	// WaitForStatus polls the image until it reaches want; see the package-level WaitForStatus.
+	WaitForStatus(ctx context.Context, imageId string, want ImageStatus, opts WaitOptions) (*ImageInfo, error)
+	// API returns the per-action API the client is built on, with the same retries and errors.
+	API() API
+}
+
+// ImageAPI is the part of the AgentBay API that GetImageInfo, WaitForStatus and
+// WaitForResourceGroup call.
+type ImageAPI interface {
+	GetMcpImageInfo(ctx context.Context, request *GetMcpImageInfoRequest) (*GetMcpImageInfoResponse, error)
+}
+
+// NetworkAPI is the part of the AgentBay API that CheckCustomizedNetwork and
+// CreateCustomizedOfficeSite call.
+type NetworkAPI interface {
+	DescribeOfficeSites(ctx context.Context, request *DescribeOfficeSitesRequest) (*DescribeOfficeSitesResponse, error)
+	CreateSimpleOfficeSite(ctx context.Context, request *CreateSimpleOfficeSiteRequest) (*CreateSimpleOfficeSiteResponse, error)
+}
+
+// InstanceTypeAPI is the part of the AgentBay API that ListInstanceTypes calls.
+type InstanceTypeAPI interface {
+	DescribeInstanceTypes(ctx context.Context, request *DescribeInstanceTypesRequest) (*DescribeInstanceTypesResponse, error)
+}
+
+// ActivationAPI is the part of the AgentBay API that ActivateImage and StartActivation call.
+type ActivationAPI interface {
+	ImageAPI
+	NetworkAPI
+	InstanceTypeAPI
+	DescribeMcpPolicyData(ctx context.Context, request *DescribeMcpPolicyDataRequest) (*DescribeMcpPolicyDataResponse, error)
+	CreateMcpPolicyData(ctx context.Context, request *CreateModifyMcpPolicyDataRequest) (*CreateMcpPolicyDataResponse, error)
+	ModifyMcpPolicyData(ctx context.Context, request *CreateModifyMcpPolicyDataRequest) (*ModifyMcpPolicyDataResponse, error)
+	SaveMcpPolicyData(ctx context.Context, request *SaveMcpPolicyDataRequest) (*SaveMcpPolicyDataResponse, error)
+	CreateResourceGroup(ctx context.Context, request *CreateResourceGroupRequest) (*CreateResourceGroupResponse, error)
+}
+
+// API calls the AgentBay API action by action. Every method takes the request model of the action
+// of the same name and returns its response model. The models are generated from the API
+// definition, and API gains a method whenever the CLI calls a new action, so API is not covered by
+// the compatibility promise of Client: fakes should embed API rather than implement it in full.
+type API interface {
+	ActivationAPI
+	GetDockerFileStoreCredential(ctx context.Context, request *GetDockerFileStoreCredentialRequest) (*GetDockerFileStoreCredentialResponse, error)
+	CreateDockerImageTask(ctx context.Context, request *CreateDockerImageTaskRequest) (*CreateDockerImageTaskResponse, error)
+	GetDockerImageTask(ctx context.Context, request *GetDockerImageTaskRequest) (*GetDockerImageTaskResponse, error)
+	ListMcpImages(ctx context.Context, request *ListMcpImagesRequest) (*ListMcpImagesResponse, error)
+	DeleteResourceGroup(ctx context.Context, request *DeleteResourceGroupRequest) (*DeleteResourceGroupResponse, error)
+	DeleteMcpImage(ctx context.Context, request *DeleteMcpImageRequest) (*DeleteMcpImageResponse, error)
+	GetDockerfileTemplate(ctx context.Context, request *GetDockerfileTemplateRequest) (*GetDockerfileTemplateResponse, error)
+	// Market Skill
+	GetMarketSkillCredential(ctx context.Context, request *GetMarketSkillCredentialRequest) (*GetMarketSkillCredentialResponse, error)
+	CreateMarketSkill(ctx context.Context, request *CreateMarketSkillRequest) (*CreateMarketSkillResponse, error)
+	UpdateMarketSkill(ctx context.Context, request *UpdateMarketSkillRequest) (*UpdateMarketSkillResponse, error)
+	DescribeMarketSkillDetail(ctx context.Context, request *DescribeMarketSkillDetailRequest) (*DescribeMarketSkillDetailResponse, error)
+	ListMarketSkillByPage(ctx context.Context, request *ListMarketSkillByPageRequest) (*ListMarketSkillByPageResponse, error)
+	DeleteMarketSkill(ctx context.Context, request *DeleteMarketSkillRequest) (*DeleteMarketSkillResponse, error)
+	// Tags
+	ListTag(ctx context.Context, request *ListTagRequest) (*ListTagResponse, error)
+	CreateTag(ctx context.Context, request *CreateTagRequest) (*CreateTagResponse, error)
+	// API Key
+	CreateApiKey(ctx context.Context, request *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	ModifyMcpApiKeyConfig(ctx context.Context, request *ModifyMcpApiKeyConfigRequest) (*ModifyMcpApiKeyConfigResponse, error)
+	DescribeMcpApiKey(ctx context.Context, request *DescribeMcpApiKeyRequest) (*DescribeMcpApiKeyResponse, error)
+	ModifyApiKeyStatus(ctx context.Context, request *ModifyApiKeyStatusRequest) (*ModifyApiKeyStatusResponse, error)
+	DeleteApiKey(ctx context.Context, request *DeleteApiKeyRequest) (*DeleteApiKeyResponse, error)
+	DescribeApiKeys(ctx context.Context, request *DescribeApiKeysRequest) (*DescribeApiKeysResponse, error)
+	DescribeKeyContent(ctx context.Context, request *DescribeKeyContentRequest) (*DescribeKeyContentResponse, error)
+	// Network Packages
+	DescribeNetworkPackages(ctx context.Context, request *DescribeNetworkPackagesRequest) (*DescribeNetworkPackagesResponse, error)
+	// Resource Group Max Session
+	BatchCreateHideResourceGroupsWithMaxSession(ctx context.Context, request *BatchCreateHideResourceGroupsWithMaxSessionRequest) (*BatchCreateHideResourceGroupsWithMaxSessionResponse, error)
+	// Image Reserve Min Amount (Pre-Open)
+	UpdateImageReserveMinAmount(ctx context.Context, request *UpdateImageReserveMinAmountRequest) (*UpdateImageReserveMinAmountResponse, error)
+	// WarmUp Status
+	DescribeWarmUpStatusOpen(ctx context.Context, request *DescribeWarmUpStatusOpenRequest) (*DescribeWarmUpStatusOpenResponse, error)
+	// Image Pre-Open Query
+	DescribeImageReserveMinAmount(ctx context.Context, request *DescribeImageReserveMinAmountRequest) (*DescribeImageReserveMinAmountResponse, error)
+	// Docker Repo Sharing
+	ShareDockerRepo(ctx context.Context, request *ShareDockerRepoRequest) (*ShareDockerRepoResponse, error)
+	UnshareDockerRepo(ctx context.Context, request *UnshareDockerRepoRequest) (*UnshareDockerRepoResponse, error)
+	ListSharedDockerRepos(ctx context.Context, request *ListSharedDockerReposRequest) (*ListSharedDockerReposResponse, error)
+}
+
+// The CLI's client must work with the workflow helpers, and this package's client must implement
+// both interfaces.
+var (
+	_ ActivationAPI = internal.Client(nil)
+	_ Client        = (*apiClient)(nil)
+	_ API           = (*apiClient)(nil)
+)
+
+// apiClient decorates the CLI's client with retries and typed errors.
+type apiClient struct {
+	api   internal.Client
+	retry RetryPolicy
+}
+
+// New creates a Client. Without options it behaves like the CLI: the endpoint and timeout come
+// from AGENTBAY_CLI_ENDPOINT, AGENTBAY_ENV and AGENTBAY_CLI_TIMEOUT_MS, and credentials from the
+// AGENTBAY_ACCESS_KEY_* environment variables or the `agentbay login` session.
+func New(opts ...Option) (Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.endpoint == "" {
+		return nil, errors.New("agentbay: endpoint must not be empty")
+	}
+	if o.credentials == nil {
+		return nil, errors.New("agentbay: credentials provider must not be nil")
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: o.timeout}
+	}
+	return &apiClient{
+		api: internal.NewClientWithOptions(internal.ClientOptions{
+			APIConfig: &config.APIConfig{
+				Endpoint:  o.endpoint,
+				TimeoutMs: int(o.timeout / time.Millisecond),
+			},
+			Credentials: o.credentials,
+			HTTPClient:  httpClient,
+			UserAgent:   o.userAgent,
+		}),
+		retry: o.retry,
+	}, nil
+}
+
+// defaultCredentials resolves credentials the way the CLI does: access keys from the environment
+// first, then the login token, refreshed when it is about to expire.
+func defaultCredentials(ctx context.Context) (Credentials, error) {
+	if accessKeyID, accessKeySecret, securityToken, ok := config.AccessKeyFromEnv(); ok {
+		return Credentials{AccessKeyID: accessKeyID, AccessKeySecret: accessKeySecret, SecurityToken: securityToken}, nil
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return Credentials{}, err
+	}
+	if !cfg.IsAuthenticated() {
+		return Credentials{}, ErrNoCredentials
+	}
+	token, err := internal.FreshToken(cfg)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{BearerToken: token.AccessToken}, nil
+}
+
+func (c *apiClient) API() API {
+	return c
+}
+
+func (c *apiClient) ListImages(ctx context.Context, opts ListImagesOptions) (*ImageList, error) {
+	return listImages(ctx, c, opts)
+}
+
+func (c *apiClient) GetImage(ctx context.Context, imageId string) (*ImageInfo, error) {
+	return GetImageInfo(ctx, c, imageId)
+}
+
+func (c *apiClient) DeleteImage(ctx context.Context, imageId string) error {
+	_, err := c.DeleteMcpImage(ctx, &DeleteMcpImageRequest{ImageId: &imageId})
+	return err
+}
+
+func (c *apiClient) ActivateImage(ctx context.Context, imageId string, opts ActivateOptions) (*ImageInfo, error) {
+	return ActivateImage(ctx, c, imageId, opts)
+}
+
+func (c *apiClient) WaitForStatus(ctx context.Context, imageId string, want ImageStatus, opts WaitOptions) (*ImageInfo, error) {
+	return WaitForStatus(ctx, c, imageId, want, opts)
+}
+
+// invoke calls action through call, converting failures to *Error and retrying transient ones
+// as the retry policy allows.
+func invoke[Req, Resp any](ctx context.Context, c *apiClient, action string, request Req, call func(context.Context, Req) (Resp, error)) (Resp, error) {
+	var resp Resp
+	attempt := func() error {
+		var err error
+		resp, err = call(ctx, request)
+		if err != nil {
+			return newError(action, err)
+		}
+		return responseError(action, resp)
+	}
+
+	var err error
+	if c.retry.appliesTo(action) {
+		err = c.retry.do(ctx, attempt)
+	} else {
+		err = attempt()
+	}
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) GetDockerFileStoreCredential(ctx context.Context, request *GetDockerFileStoreCredentialRequest) (*GetDockerFileStoreCredentialResponse, error) {
+	return invoke(ctx, c, "GetDockerFileStoreCredential", request, c.api.GetDockerFileStoreCredential)
+}
+
+func (c *apiClient) CreateDockerImageTask(ctx context.Context, request *CreateDockerImageTaskRequest) (*CreateDockerImageTaskResponse, error) {
+	return invoke(ctx, c, "CreateDockerImageTask", request, c.api.CreateDockerImageTask)
+}
+
+func (c *apiClient) GetDockerImageTask(ctx context.Context, request *GetDockerImageTaskRequest) (*GetDockerImageTaskResponse, error) {
+	return invoke(ctx, c, "GetDockerImageTask", request, c.api.GetDockerImageTask)
+}
+
+func (c *apiClient) ListMcpImages(ctx context.Context, request *ListMcpImagesRequest) (*ListMcpImagesResponse, error) {
+	return invoke(ctx, c, "ListMcpImages", request, c.api.ListMcpImages)
+}
+
+func (c *apiClient) GetMcpImageInfo(ctx context.Context, request *GetMcpImageInfoRequest) (*GetMcpImageInfoResponse, error) {
+	return invoke(ctx, c, "GetMcpImageInfo", request, c.api.GetMcpImageInfo)
+}
+
+func (c *apiClient) CreateResourceGroup(ctx context.Context, request *CreateResourceGroupRequest) (*CreateResourceGroupResponse, error) {
+	return invoke(ctx, c, "CreateResourceGroup", request, c.api.CreateResourceGroup)
+}
+
+func (c *apiClient) DeleteResourceGroup(ctx context.Context, request *DeleteResourceGroupRequest) (*DeleteResourceGroupResponse, error) {
+	return invoke(ctx, c, "DeleteResourceGroup", request, c.api.DeleteResourceGroup)
+}
+
+func (c *apiClient) DeleteMcpImage(ctx context.Context, request *DeleteMcpImageRequest) (*DeleteMcpImageResponse, error) {
+	return invoke(ctx, c, "DeleteMcpImage", request, c.api.DeleteMcpImage)
+}
+
+func (c *apiClient) GetDockerfileTemplate(ctx context.Context, request *GetDockerfileTemplateRequest) (*GetDockerfileTemplateResponse, error) {
+	return invoke(ctx, c, "GetDockerfileTemplate", request, c.api.GetDockerfileTemplate)
+}
+
+func (c *apiClient) GetMarketSkillCredential(ctx context.Context, request *GetMarketSkillCredentialRequest) (*GetMarketSkillCredentialResponse, error) {
+	return invoke(ctx, c, "GetMarketSkillCredential", request, c.api.GetMarketSkillCredential)
+}
+
+func (c *apiClient) CreateMarketSkill(ctx context.Context, request *CreateMarketSkillRequest) (*CreateMarketSkillResponse, error) {
+	return invoke(ctx, c, "CreateMarketSkill", request, c.api.CreateMarketSkill)
+}
+
+func (c *apiClient) UpdateMarketSkill(ctx context.Context, request *UpdateMarketSkillRequest) (*UpdateMarketSkillResponse, error) {
+	return invoke(ctx, c, "UpdateMarketSkill", request, func(ctx context.Context, request *UpdateMarketSkillRequest) (*UpdateMarketSkillResponse, error) {
+		resp, err := c.api.UpdateMarketSkill(ctx, request)
+		if err != nil || resp == nil {
+			return nil, err
+		}
+		// The CLI's client returns the update under the create model; the bodies are the same
+		return &UpdateMarketSkillResponse{
+			Headers:    resp.Headers,
+			StatusCode: resp.StatusCode,
+			Body:       resp.Body,
+			RawBody:    resp.RawBody,
+		}, nil
+	})
+}
+
+func (c *apiClient) DescribeMarketSkillDetail(ctx context.Context, request *DescribeMarketSkillDetailRequest) (*DescribeMarketSkillDetailResponse, error) {
+	return invoke(ctx, c, "DescribeMarketSkillDetail", request, c.api.DescribeMarketSkillDetail)
+}
+
+func (c *apiClient) ListMarketSkillByPage(ctx context.Context, request *ListMarketSkillByPageRequest) (*ListMarketSkillByPageResponse, error) {
+	return invoke(ctx, c, "ListMarketSkillByPage", request, c.api.ListMarketSkillByPage)
+}
+
+func (c *apiClient) DeleteMarketSkill(ctx context.Context, request *DeleteMarketSkillRequest) (*DeleteMarketSkillResponse, error) {
+	return invoke(ctx, c, "DeleteMarketSkill", request, c.api.DeleteMarketSkill)
+}
+
+func (c *apiClient) ListTag(ctx context.Context, request *ListTagRequest) (*ListTagResponse, error) {
+	return invoke(ctx, c, "ListTag", request, func(ctx context.Context, _ *ListTagRequest) (*ListTagResponse, error) {
+		return c.api.ListTag(ctx)
+	})
+}
+
+func (c *apiClient) CreateTag(ctx context.Context, request *CreateTagRequest) (*CreateTagResponse, error) {
+	return invoke(ctx, c, "CreateTag", request, c.api.CreateTag)
+}
+
+func (c *apiClient) CreateApiKey(ctx context.Context, request *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	return invoke(ctx, c, "CreateApiKey", request, c.api.CreateApiKey)
+}
+
+func (c *apiClient) ModifyMcpApiKeyConfig(ctx context.Context, request *ModifyMcpApiKeyConfigRequest) (*ModifyMcpApiKeyConfigResponse, error) {
+	return invoke(ctx, c, "ModifyMcpApiKeyConfig", request, c.api.ModifyMcpApiKeyConfig)
+}
+
+func (c *apiClient) DescribeMcpApiKey(ctx context.Context, request *DescribeMcpApiKeyRequest) (*DescribeMcpApiKeyResponse, error) {
+	return invoke(ctx, c, "DescribeMcpApiKey", request, c.api.DescribeMcpApiKey)
+}
+
+func (c *apiClient) ModifyApiKeyStatus(ctx context.Context, request *ModifyApiKeyStatusRequest) (*ModifyApiKeyStatusResponse, error) {
+	return invoke(ctx, c, "ModifyApiKeyStatus", request, c.api.ModifyApiKeyStatus)
+}
+
+func (c *apiClient) DeleteApiKey(ctx context.Context, request *DeleteApiKeyRequest) (*DeleteApiKeyResponse, error) {
+	return invoke(ctx, c, "DeleteApiKey", request, c.api.DeleteApiKey)
+}
+
+func (c *apiClient) DescribeApiKeys(ctx context.Context, request *DescribeApiKeysRequest) (*DescribeApiKeysResponse, error) {
+	return invoke(ctx, c, "DescribeApiKeys", request, c.api.DescribeApiKeys)
+}
+
+func (c *apiClient) DescribeKeyContent(ctx context.Context, request *DescribeKeyContentRequest) (*DescribeKeyContentResponse, error) {
+	return invoke(ctx, c, "DescribeKeyContent", request, c.api.DescribeKeyContent)
+}
+
+func (c *apiClient) DescribeInstanceTypes(ctx context.Context, request *DescribeInstanceTypesRequest) (*DescribeInstanceTypesResponse, error) {
+	return invoke(ctx, c, "DescribeInstanceTypes", request, c.api.DescribeInstanceTypes)
+}
+
+func (c *apiClient) DescribeMcpPolicyData(ctx context.Context, request *DescribeMcpPolicyDataRequest) (*DescribeMcpPolicyDataResponse, error) {
+	return invoke(ctx, c, "DescribeMcpPolicyData", request, c.api.DescribeMcpPolicyData)
+}
+
+func (c *apiClient) SaveMcpPolicyData(ctx context.Context, request *SaveMcpPolicyDataRequest) (*SaveMcpPolicyDataResponse, error) {
+	return invoke(ctx, c, "SaveMcpPolicyData", request, c.api.SaveMcpPolicyData)
+}
+
+func (c *apiClient) DescribeOfficeSites(ctx context.Context, request *DescribeOfficeSitesRequest) (*DescribeOfficeSitesResponse, error) {
+	return invoke(ctx, c, "DescribeOfficeSites", request, c.api.DescribeOfficeSites)
+}
+
+func (c *apiClient) CreateSimpleOfficeSite(ctx context.Context, request *CreateSimpleOfficeSiteRequest) (*CreateSimpleOfficeSiteResponse, error) {
+	return invoke(ctx, c, "CreateSimpleOfficeSite", request, c.api.CreateSimpleOfficeSite)
+}
+
+func (c *apiClient) CreateMcpPolicyData(ctx context.Context, request *CreateModifyMcpPolicyDataRequest) (*CreateMcpPolicyDataResponse, error) {
+	return invoke(ctx, c, "CreateMcpPolicyData", request, c.api.CreateMcpPolicyData)
+}
+
+func (c *apiClient) ModifyMcpPolicyData(ctx context.Context, request *CreateModifyMcpPolicyDataRequest) (*ModifyMcpPolicyDataResponse, error) {
+	return invoke(ctx, c, "ModifyMcpPolicyData", request, c.api.ModifyMcpPolicyData)
+}
+
+func (c *apiClient) DescribeNetworkPackages(ctx context.Context, request *DescribeNetworkPackagesRequest) (*DescribeNetworkPackagesResponse, error) {
+	return invoke(ctx, c, "DescribeNetworkPackages", request, c.api.DescribeNetworkPackages)
+}
+
+func (c *apiClient) BatchCreateHideResourceGroupsWithMaxSession(ctx context.Context, request *BatchCreateHideResourceGroupsWithMaxSessionRequest) (*BatchCreateHideResourceGroupsWithMaxSessionResponse, error) {
+	return invoke(ctx, c, "BatchCreateHideResourceGroupsWithMaxSession", request, c.api.BatchCreateHideResourceGroupsWithMaxSession)
+}
+
+func (c *apiClient) UpdateImageReserveMinAmount(ctx context.Context, request *UpdateImageReserveMinAmountRequest) (*UpdateImageReserveMinAmountResponse, error) {
+	return invoke(ctx, c, "UpdateImageReserveMinAmount", request, c.api.UpdateImageReserveMinAmount)
+}
+
+func (c *apiClient) DescribeWarmUpStatusOpen(ctx context.Context, request *DescribeWarmUpStatusOpenRequest) (*DescribeWarmUpStatusOpenResponse, error) {
+	return invoke(ctx, c, "DescribeWarmUpStatusOpen", request, c.api.DescribeWarmUpStatusOpen)
+}
+
+func (c *apiClient) DescribeImageReserveMinAmount(ctx context.Context, request *DescribeImageReserveMinAmountRequest) (*DescribeImageReserveMinAmountResponse, error) {
+	return invoke(ctx, c, "DescribeImageReserveMinAmount", request, c.api.DescribeImageReserveMinAmount)
+}
+
+func (c *apiClient) ShareDockerRepo(ctx context.Context, request *ShareDockerRepoRequest) (*ShareDockerRepoResponse, error) {
+	return invoke(ctx, c, "ShareDockerRepo", request, c.api.ShareDockerRepo)
+}
+
+func (c *apiClient) UnshareDockerRepo(ctx context.Context, request *UnshareDockerRepoRequest) (*UnshareDockerRepoResponse, error) {
+	return invoke(ctx, c, "UnshareDockerRepo", request, c.api.UnshareDockerRepo)
+}
+
+func (c *apiClient) ListSharedDockerRepos(ctx context.Context, request *ListSharedDockerReposRequest) (*ListSharedDockerReposResponse, error) {
+	return invoke(ctx, c, "ListSharedDockerRepos", request, c.api.ListSharedDockerRepos)
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internal "github.com/agentbay/agentbay-cli/internal/agentbay"
+)
+
+// flakyAPI fails the first failures calls of DescribeApiKeys and CreateApiKey with err.
+type flakyAPI struct {
+	internal.Client
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakyAPI) DescribeApiKeys(ctx context.Context, req *DescribeApiKeysRequest) (*DescribeApiKeysResponse, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return &DescribeApiKeysResponse{Body: &DescribeApiKeysResponseBody{
+		Success:   dara.Bool(true),
+		RequestId: dara.String("req-ok"),
+	}}, nil
+}
+
+func (f *flakyAPI) CreateApiKey(ctx context.Context, req *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *flakyAPI) GetMcpImageInfo(ctx context.Context, req *GetMcpImageInfoRequest) (*GetMcpImageInfoResponse, error) {
+	f.calls++
+	return &GetMcpImageInfoResponse{
+		StatusCode: dara.Int32(200),
+		Body: &GetMcpImageInfoResponseBody{
+			Success:   dara.Bool(false),
+			Code:      dara.String("InvalidImageId.NotFound"),
+			Message:   dara.String("image not found"),
+			RequestId: dara.String("req-404"),
+		},
+	}, nil
+}
+
+var fastRetry = RetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 2}
+
+func TestClientReturnsBodyFailureAsError(t *testing.T) {
+	api := &flakyAPI{}
+	c := &apiClient{api: api, retry: fastRetry}
+
+	_, err := c.GetMcpImageInfo(context.Background(), &GetMcpImageInfoRequest{ImageId: dara.String("imgc-1")})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "GetMcpImageInfo", apiErr.Action)
+	assert.Equal(t, "InvalidImageId.NotFound", apiErr.Code)
+	assert.Equal(t, "image not found", apiErr.Message)
+	assert.Equal(t, "req-404", apiErr.RequestId)
+	assert.Equal(t, 200, apiErr.StatusCode)
+	assert.Equal(t, "GetMcpImageInfo: InvalidImageId.NotFound - image not found (Request ID: req-404)", err.Error())
+	assert.Equal(t, 1, api.calls, "failures reported in the body are not retried")
+}
+
+func TestClientRetriesTransientReads(t *testing.T) {
+	api := &flakyAPI{failures: 2, err: errors.New("read tcp: connection reset by peer")}
+	c := &apiClient{api: api, retry: fastRetry}
+
+	resp, err := c.DescribeApiKeys(context.Background(), &DescribeApiKeysRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "req-ok", dara.StringValue(resp.Body.RequestId))
+	assert.Equal(t, 3, api.calls)
+}
+
+func TestClientDoesNotRetryWritesByDefault(t *testing.T) {
+	api := &flakyAPI{err: errors.New("read tcp: connection reset by peer")}
+	c := &apiClient{api: api, retry: fastRetry}
+
+	_, err := c.CreateApiKey(context.Background(), &CreateApiKeyRequest{})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "CreateApiKey", apiErr.Action)
+	assert.Equal(t, 1, api.calls)
+
+	api.calls = 0
+	policy := fastRetry
+	policy.RetryWrites = true
+	c.retry = policy
+	_, err = c.CreateApiKey(context.Background(), &CreateApiKeyRequest{})
+	require.Error(t, err)
+	assert.Equal(t, 4, api.calls, "RetryWrites retries writes up to MaxRetries")
+}
+
+func TestClientDoesNotRetryPermanentErrors(t *testing.T) {
+	api := &flakyAPI{failures: 5, err: &Error{Code: "Forbidden", StatusCode: 403}}
+	c := &apiClient{api: api, retry: fastRetry}
+
+	_, err := c.DescribeApiKeys(context.Background(), &DescribeApiKeysRequest{})
+	require.Error(t, err)
+	assert.Equal(t, 1, api.calls)
+}
+
+func TestNewSendsRequestsThroughOptions(t *testing.T) {
+	var gotToken, gotUserAgent string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("X-Acs-Bearer-Token")
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"RequestId":"req-403","Code":"Forbidden.RAM","Message":"not allowed"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(
+		WithEndpoint(strings.TrimPrefix(server.URL, "https://")),
+		WithBearerToken("token-1"),
+		WithHTTPClient(server.Client()),
+		WithUserAgent("my-service/1.0"),
+		WithRetry(RetryPolicy{}),
+	)
+	require.NoError(t, err)
+
+	_, err = c.API().DescribeApiKeys(context.Background(), &DescribeApiKeysRequest{})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "DescribeApiKeys", apiErr.Action)
+	assert.Equal(t, "Forbidden.RAM", apiErr.Code)
+	assert.Equal(t, "req-403", apiErr.RequestId)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, "not allowed", apiErr.Message)
+	assert.Equal(t, "token-1", gotToken)
+	assert.Contains(t, gotUserAgent, "my-service/1.0")
+}
+
+func TestNewRejectsInvalidOptions(t *testing.T) {
+	_, err := New(WithEndpoint(""), WithBearerToken("t"))
+	assert.Error(t, err)
+
+	_, err = New(WithEndpoint("example.com"), WithCredentialsProvider(nil))
+	assert.Error(t, err)
+}
+
+// imagesAPI serves one ListMcpImages page and records the request.
+type imagesAPI struct {
+	internal.Client
+	req *ListMcpImagesRequest
+}
+
+func (f *imagesAPI) ListMcpImages(ctx context.Context, req *ListMcpImagesRequest) (*ListMcpImagesResponse, error) {
+	f.req = req
+	return &ListMcpImagesResponse{Body: &ListMcpImagesResponseBody{
+		Success:    dara.Bool(true),
+		RequestId:  dara.String("req-list"),
+		TotalCount: dara.Int32(7),
+		Data: []*ListMcpImagesResponseBodyData{{
+			ImageId:   dara.String("imgc-1"),
+			ImageName: dara.String("web"),
+			ImageInfo: &ListMcpImagesResponseBodyDataImageInfo{OsName: dara.String("Linux"), Status: dara.String("IMAGE_AVAILABLE")},
+		}},
+	}}, nil
+}
+
+func TestClientListImagesReturnsOwnedTypes(t *testing.T) {
+	api := &imagesAPI{}
+	c := &apiClient{api: api, retry: fastRetry}
+
+	list, err := c.ListImages(context.Background(), ListImagesOptions{Page: 2, PageSize: 5})
+	require.NoError(t, err)
+	assert.Equal(t, ImageTypeUser, dara.StringValue(api.req.ImageType))
+	assert.Equal(t, int32(2), dara.Int32Value(api.req.PageStart))
+	assert.Equal(t, int32(5), dara.Int32Value(api.req.PageSize))
+	assert.Equal(t, 7, list.TotalCount)
+	assert.Equal(t, "req-list", list.RequestId)
+	assert.Equal(t, []ImageSummary{{ImageId: "imgc-1", Name: "web", ImageType: ImageTypeUser, OsName: "Linux", Status: StatusImageAvailable}}, list.Images)
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package agentbay is a Go client for the AgentBay API, built on the same code as the agentbay
+// CLI: the same credential chain, endpoints, retries and response parsing.
+//
+// Create a Client with New and functional options:
+//
+//	c, err := agentbay.New(
+//		agentbay.WithAccessKey(os.Getenv("AK"), os.Getenv("SK")),
+//		agentbay.WithRetry(agentbay.DefaultRetryPolicy()),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	list, err := c.ListImages(ctx, agentbay.ListImagesOptions{ImageType: agentbay.ImageTypeUser})
+//
+// Without options, New behaves like the CLI: the endpoint comes from AGENTBAY_CLI_ENDPOINT or
+// AGENTBAY_ENV, and credentials from AGENTBAY_ACCESS_KEY_* or the `agentbay login` session.
+//
+// Client is the stable interface: its methods take and return types owned by this package, and
+// operations added later go on API instead. API, returned by Client.API, calls any action the CLI
+// calls with the generated request and response models, which follow the API definition.
+//
+// Every API failure is returned as an *Error carrying the action, error code and Request ID,
+// including responses that report a failure in their body:
+//
+//	var apiErr *agentbay.Error
+//	if errors.As(err, &apiErr) && apiErr.Code == "InvalidImageId.NotFound" {
+//		log.Printf("image not found (Request ID: %s)", apiErr.RequestId)
+//	}
+//
+// Beyond the per-action methods, the package provides the multi-step workflows of the CLI:
+// ActivateImage and StartActivation run `agentbay image activate`, WaitForStatus and
+// WaitForResourceGroup poll an image, and ListInstanceTypes and MatchInstanceType resolve CPU and
+// memory to an instance type. They take only the part of API they call, such as ImageAPI.
+//
+// A Client keeps no local state: unlike the CLI, its writes do not touch the response cache in
+// the CLI config directory.
+package agentbay
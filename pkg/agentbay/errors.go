@@ -0,0 +1,207 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/alibabacloud-go/tea/tea"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// ErrNoCredentials is returned when no credentials were configured and neither the
+// AGENTBAY_ACCESS_KEY_* environment variables nor an `agentbay login` session are available.
+var ErrNoCredentials = errors.New("no credentials: set AGENTBAY_ACCESS_KEY_ID and AGENTBAY_ACCESS_KEY_SECRET, run 'agentbay login', or pass WithAccessKey or WithBearerToken")
+
+// Error describes a failed API call: either the request did not complete, or the API answered
+// with an error. Client methods always return an *Error, so callers can use errors.As to read the
+// error code and the Request ID to quote to support.
+type Error struct {
+	// Action is the API action, e.g. "GetMcpImageInfo".
+	Action string
+	// Code is the API error code, e.g. "InvalidImageId.NotFound". Empty when no response was received.
+	Code string
+	// Message is the error message returned by the API.
+	Message string
+	// RequestId identifies the request in the backend logs.
+	RequestId string
+	// StatusCode is the HTTP status code; 0 when no response was received.
+	StatusCode int
+	// Err is the underlying transport or SDK error; nil when the API reported the failure in a
+	// successful HTTP response.
+	Err error
+}
+
+// Error returns "<Action>: <Code> - <Message> (Request ID: <RequestId>)", leaving out the parts
+// that are not known.
+func (e *Error) Error() string {
+	var b strings.Builder
+	if e.Action != "" {
+		b.WriteString(e.Action)
+		b.WriteString(": ")
+	}
+	switch {
+	case e.Code != "" && e.Message != "":
+		fmt.Fprintf(&b, "%s - %s", e.Code, e.Message)
+	case e.Code != "":
+		b.WriteString(e.Code)
+	case e.Message != "":
+		b.WriteString(e.Message)
+	case e.Err != nil:
+		b.WriteString(e.Err.Error())
+	default:
+		b.WriteString("request failed")
+	}
+	if e.RequestId != "" {
+		fmt.Fprintf(&b, " (Request ID: %s)", e.RequestId)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// The generated SDK errors expose their fields through getters with slightly different method
+// sets; these interfaces pick up whichever are present.
+type (
+	codeGetter       interface{ GetCode() *string }
+	messageGetter    interface{ GetMessage() *string }
+	requestIDGetter  interface{ GetRequestId() *string }
+	statusCodeGetter interface{ GetStatusCode() *int }
+)
+
+// newError converts an error returned by the generated client into an *Error for action.
+func newError(action string, err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	out := &Error{Action: action, Err: err}
+	var code codeGetter
+	if errors.As(err, &code) {
+		out.Code = dara.StringValue(code.GetCode())
+	}
+	var message messageGetter
+	if errors.As(err, &message) {
+		out.Message = dara.StringValue(message.GetMessage())
+	}
+	var requestID requestIDGetter
+	if errors.As(err, &requestID) {
+		out.RequestId = dara.StringValue(requestID.GetRequestId())
+	}
+	var statusCode statusCodeGetter
+	if errors.As(err, &statusCode) {
+		out.StatusCode = dara.IntValue(statusCode.GetStatusCode())
+	}
+
+	var sdkErr *dara.SDKError
+	if errors.As(err, &sdkErr) {
+		if out.Message == "" {
+			out.Message = dara.StringValue(sdkErr.Message)
+		}
+		if out.StatusCode == 0 {
+			out.StatusCode = dara.IntValue(sdkErr.StatusCode)
+		}
+	}
+	var teaErr *tea.SDKError
+	if errors.As(err, &teaErr) {
+		if out.Code == "" {
+			out.Code = tea.StringValue(teaErr.Code)
+		}
+		if out.Message == "" {
+			out.Message = tea.StringValue(teaErr.Message)
+		}
+		if out.StatusCode == 0 {
+			out.StatusCode = tea.IntValue(teaErr.StatusCode)
+		}
+		// Data holds the response body, whose Message is cleaner than the SDK's composed one
+		var body struct{ Message, RequestId string }
+		if json.Unmarshal([]byte(tea.StringValue(teaErr.Data)), &body) == nil {
+			if body.Message != "" {
+				out.Message = body.Message
+			}
+			if out.RequestId == "" {
+				out.RequestId = body.RequestId
+			}
+		}
+	}
+	var withID *client.ErrWithRequestID
+	if out.RequestId == "" && errors.As(err, &withID) {
+		out.RequestId = withID.RequestID
+	}
+	return out
+}
+
+// responseError returns an *Error when resp reports a failure in its body: Success is false, or,
+// for APIs without Success, Code is set to something other than "ok" or an HTTP 2xx status.
+func responseError(action string, resp any) error {
+	v := reflect.ValueOf(resp)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	body := v.Elem().FieldByName("Body")
+	if !body.IsValid() || body.Kind() != reflect.Ptr || body.IsNil() || body.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	fields := body.Elem()
+
+	code := stringField(fields, "Code")
+	if success, ok := boolField(fields, "Success"); ok {
+		if success {
+			return nil
+		}
+	} else if code == "" || isSuccessCode(code) {
+		return nil
+	}
+
+	apiErr := &Error{
+		Action:    action,
+		Code:      code,
+		Message:   stringField(fields, "Message"),
+		RequestId: stringField(fields, "RequestId"),
+	}
+	if status := v.Elem().FieldByName("StatusCode"); status.IsValid() && status.Kind() == reflect.Ptr && !status.IsNil() {
+		apiErr.StatusCode = int(status.Elem().Int())
+	}
+	return apiErr
+}
+
+// isSuccessCode reports whether a response Code means success: "ok" or an HTTP 2xx status, which
+// some APIs return instead.
+func isSuccessCode(code string) bool {
+	if strings.EqualFold(code, "ok") {
+		return true
+	}
+	return len(code) == 3 && code[0] == '2'
+}
+
+// stringField returns the string or *string field name of v, or "" when it is missing or nil.
+func stringField(v reflect.Value, name string) string {
+	f := v.FieldByName(name)
+	if f.IsValid() && f.Kind() == reflect.Ptr && !f.IsNil() {
+		f = f.Elem()
+	}
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+// boolField returns the *bool field name of v and whether it is set.
+func boolField(v reflect.Value, name string) (bool, bool) {
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.Ptr || f.IsNil() || f.Elem().Kind() != reflect.Bool {
+		return false, false
+	}
+	return f.Elem().Bool(), true
+}
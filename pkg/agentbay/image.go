@@ -0,0 +1,380 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+// ImageStatus is the resource status of an image, as returned in ImageResourceStatus.
+type ImageStatus string
+
+const (
+	StatusImageCreating       ImageStatus = "IMAGE_CREATING"
+	StatusImageCreateFailed   ImageStatus = "IMAGE_CREATE_FAILED"
+	StatusImageAvailable      ImageStatus = "IMAGE_AVAILABLE"
+	StatusResourceDeploying   ImageStatus = "RESOURCE_DEPLOYING"
+	StatusResourcePublished   ImageStatus = "RESOURCE_PUBLISHED"
+	StatusResourceDeleting    ImageStatus = "RESOURCE_DELETING"
+	StatusResourceFailed      ImageStatus = "RESOURCE_FAILED"
+	StatusResourceCeased      ImageStatus = "RESOURCE_CEASED"
+	StatusResourceMaintaining ImageStatus = "RESOURCE_MAINTAINING"
+)
+
+// Text returns the status in the words the CLI shows, e.g. "Activated" for RESOURCE_PUBLISHED.
+func (s ImageStatus) Text() string {
+	switch s {
+	case StatusImageCreating:
+		return "Creating"
+	case StatusImageCreateFailed:
+		return "Create Failed"
+	case StatusImageAvailable:
+		return "Available (Deactivated)"
+	case StatusResourceDeploying:
+		return "Activating"
+	case StatusResourcePublished:
+		return "Activated"
+	case StatusResourceDeleting:
+		return "Deactivating"
+	case StatusResourceFailed:
+		return "Activation Failed"
+	case StatusResourceCeased:
+		return "Ceased"
+	case StatusResourceMaintaining:
+		return "Maintaining"
+	default:
+		if s == "" {
+			return "Unknown"
+		}
+		return string(s)
+	}
+}
+
+// IsFailed reports whether building or activating the image failed.
+func (s ImageStatus) IsFailed() bool {
+	return s == StatusImageCreateFailed || s == StatusResourceFailed
+}
+
+// IsTerminal reports whether the status is final, i.e. no operation is in progress.
+func (s ImageStatus) IsTerminal() bool {
+	return s == StatusResourcePublished ||
+		s == StatusImageAvailable ||
+		s == StatusImageCreateFailed ||
+		s == StatusResourceFailed ||
+		s == StatusResourceCeased
+}
+
+// IsDeletable reports whether an image in this status can be deleted. Images that are being
+// built, activated or deactivated, are activated, or failed to activate cannot.
+func (s ImageStatus) IsDeletable() bool {
+	switch s {
+	case StatusImageCreating,
+		StatusResourceDeploying,
+		StatusResourceDeleting,
+		StatusResourcePublished,
+		StatusResourceFailed,
+		StatusResourceMaintaining:
+		return false
+	default:
+		return true
+	}
+}
+
+// Image types returned in ImageInfo.ImageType.
+const (
+	ImageTypeUser   = "User"
+	ImageTypeSystem = "System"
+)
+
+// ImageInfo is the status of an image as reported by GetMcpImageInfo.
+type ImageInfo struct {
+	ImageId            string
+	Status             ImageStatus
+	ImageType          string // ImageTypeUser or ImageTypeSystem
+	OsName             string // Linux, Android, Windows, etc.
+	RequestId          string // Request ID of the GetMcpImageInfo call
+	ResourceGroupReady bool   // Whether the resource group is ready after set-max-session
+}
+
+// ListImagesOptions select the images returned by Client.ListImages.
+type ListImagesOptions struct {
+	ImageType string // ImageTypeUser or ImageTypeSystem; empty means ImageTypeUser
+	OsType    string // e.g. "Linux"; empty lists every OS
+	Page      int    // 1-based page number; 0 means the first page
+	PageSize  int    // 0 means the API default
+}
+
+// ImageSummary is one image returned by Client.ListImages.
+type ImageSummary struct {
+	ImageId    string
+	Name       string
+	ImageType  string
+	OsName     string
+	OsVersion  string
+	Status     ImageStatus
+	UpdateTime string
+}
+
+// ImageList is one page of images returned by Client.ListImages.
+type ImageList struct {
+	Images     []ImageSummary
+	TotalCount int    // Images of the type across all pages; -1 when the API does not report it
+	RequestId  string // Request ID of the ListMcpImages call
+}
+
+// listImages calls ListMcpImages for one page and converts the result to an ImageList.
+func listImages(ctx context.Context, c API, opts ListImagesOptions) (*ImageList, error) {
+	imageType := opts.ImageType
+	if imageType == "" {
+		imageType = ImageTypeUser
+	}
+	request := &ListMcpImagesRequest{ImageType: dara.String(imageType)}
+	if opts.OsType != "" {
+		request.OsType = dara.String(opts.OsType)
+	}
+	if opts.Page > 0 {
+		request.PageStart = dara.Int32(int32(opts.Page))
+	}
+	if opts.PageSize > 0 {
+		request.PageSize = dara.Int32(int32(opts.PageSize))
+	}
+
+	resp, err := c.ListMcpImages(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil {
+		return nil, fmt.Errorf("invalid response from ListMcpImages")
+	}
+
+	list := &ImageList{TotalCount: -1, RequestId: dara.StringValue(resp.Body.RequestId)}
+	if resp.Body.TotalCount != nil {
+		list.TotalCount = int(*resp.Body.TotalCount)
+	}
+	for _, data := range resp.Body.Data {
+		if data == nil {
+			continue
+		}
+		image := ImageSummary{
+			ImageId:   dara.StringValue(data.ImageId),
+			Name:      dara.StringValue(data.ImageName),
+			ImageType: imageType,
+			Status:    ImageStatus(dara.StringValue(data.ImageResourceStatus)),
+		}
+		if info := data.ImageInfo; info != nil {
+			image.OsName = dara.StringValue(info.OsName)
+			image.OsVersion = dara.StringValue(info.OsVersion)
+			image.UpdateTime = dara.StringValue(info.UpdateTime)
+			if image.Status == "" {
+				image.Status = ImageStatus(dara.StringValue(info.Status))
+			}
+		}
+		list.Images = append(list.Images, image)
+	}
+	return list, nil
+}
+
+// GetImageInfo returns the status and type of an image.
+func GetImageInfo(ctx context.Context, c ImageAPI, imageId string) (*ImageInfo, error) {
+	request := &GetMcpImageInfoRequest{}
+	request.SetImageId(imageId)
+
+	resp, err := c.GetMcpImageInfo(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image info: %w", err)
+	}
+
+	if resp == nil || resp.Body == nil {
+		return nil, fmt.Errorf("invalid response from GetMcpImageInfo")
+	}
+
+	if !dara.BoolValue(resp.Body.Success) {
+		return nil, &Error{
+			Action:    "GetMcpImageInfo",
+			Code:      dara.StringValue(resp.Body.Code),
+			Message:   dara.StringValue(resp.Body.Message),
+			RequestId: dara.StringValue(resp.Body.RequestId),
+		}
+	}
+
+	if resp.Body.Data == nil {
+		return nil, fmt.Errorf("no data in GetMcpImageInfo response")
+	}
+
+	info := &ImageInfo{
+		ImageId:   imageId,
+		RequestId: dara.StringValue(resp.Body.RequestId),
+	}
+
+	// The XML parser of the generated client reports status and type in synthetic headers
+	if resp.Headers != nil {
+		if resourceStatus, ok := resp.Headers["X-Image-Resource-Status"]; ok && resourceStatus != nil {
+			info.Status = ImageStatus(dara.StringValue(resourceStatus))
+		}
+		if imageType, ok := resp.Headers["X-Image-Type"]; ok && imageType != nil {
+			info.ImageType = dara.StringValue(imageType)
+		}
+	}
+
+	// JSON body fields (SDK path does not populate X-* headers)
+	if info.Status == "" && resp.Body.Data.ImageResourceStatus != nil {
+		info.Status = ImageStatus(dara.StringValue(resp.Body.Data.ImageResourceStatus))
+	}
+
+	// Fallback to ImageInfo.Status if ImageResourceStatus not available
+	if info.Status == "" && resp.Body.Data.ImageInfo != nil && resp.Body.Data.ImageInfo.Status != nil {
+		info.Status = ImageStatus(dara.StringValue(resp.Body.Data.ImageInfo.Status))
+	}
+
+	if info.ImageType == "" && resp.Body.Data.ImageInfo != nil && resp.Body.Data.ImageInfo.ImageType != nil {
+		info.ImageType = dara.StringValue(resp.Body.Data.ImageInfo.ImageType)
+	}
+
+	if resp.Body.Data.ImageInfo != nil && resp.Body.Data.ImageInfo.OsName != nil {
+		info.OsName = dara.StringValue(resp.Body.Data.ImageInfo.OsName)
+	}
+
+	if resp.Body.Data.ResourceGroupReady != nil {
+		info.ResourceGroupReady = dara.BoolValue(resp.Body.Data.ResourceGroupReady)
+	}
+
+	// User images have imgc- IDs; the JSON path may omit ImageType
+	if info.ImageType == "" {
+		info.ImageType = ImageTypeFromID(imageId)
+	}
+
+	if info.Status == "" {
+		return nil, fmt.Errorf("no status information available in response")
+	}
+
+	return info, nil
+}
+
+// ImageTypeFromID infers the image type from the ID: user images have imgc- IDs.
+func ImageTypeFromID(imageId string) string {
+	if strings.HasPrefix(imageId, "imgc-") {
+		return ImageTypeUser
+	}
+	return ImageTypeSystem
+}
+
+// Errors returned by WaitForStatus and WaitForResourceGroup, wrapped with details.
+var (
+	// ErrWaitTimeout means the image did not reach the wanted state within WaitOptions.Timeout,
+	// or the context was done first.
+	ErrWaitTimeout = errors.New("timed out waiting for image")
+	// ErrWaitMaxAttempts means the image did not reach the wanted state within
+	// WaitOptions.MaxAttempts status checks.
+	ErrWaitMaxAttempts = errors.New("image status checked too many times")
+	// ErrImageFailed means the image reached a failed status while waiting.
+	ErrImageFailed = errors.New("image operation failed")
+)
+
+// WaitOptions control how WaitForStatus and WaitForResourceGroup poll. Zero fields use the
+// defaults of DefaultWaitOptions.
+type WaitOptions struct {
+	// Interval is the wait after the first status check; it grows by half after every check.
+	Interval time.Duration
+	// MaxInterval caps the wait between status checks.
+	MaxInterval time.Duration
+	// Timeout bounds the whole wait.
+	Timeout time.Duration
+	// MaxAttempts bounds the number of status checks; 0 means no limit besides Timeout.
+	MaxAttempts int
+	// OnPoll, when set, is called after every status check.
+	OnPoll func(PollEvent)
+}
+
+// PollEvent reports one status check while waiting for an image.
+type PollEvent struct {
+	Attempt int           // 1 for the first check
+	Elapsed time.Duration // Time since the wait started
+	Info    *ImageInfo    // Status read; nil when the check failed
+	Err     error         // Error of a failed check; failed checks are retried
+}
+
+// DefaultWaitOptions returns the options the CLI uses to wait for an activation: status checks
+// every 5s, growing to every 30s, for up to 30 minutes.
+func DefaultWaitOptions() WaitOptions {
+	return WaitOptions{
+		Interval:    5 * time.Second,
+		MaxInterval: 30 * time.Second,
+		Timeout:     30 * time.Minute,
+	}
+}
+
+// WaitForStatus polls the image until it reaches want and returns its final ImageInfo. It fails
+// with ErrImageFailed when the image reaches a failed status first, returning the ImageInfo that
+// reported it, and with ErrWaitTimeout or ErrWaitMaxAttempts when the wait runs out. Failed status
+// checks are retried until then.
+func WaitForStatus(ctx context.Context, c ImageAPI, imageId string, want ImageStatus, opts WaitOptions) (*ImageInfo, error) {
+	return waitForImage(ctx, c, imageId, opts, string(want), func(info *ImageInfo) bool {
+		return info.Status == want
+	})
+}
+
+// WaitForResourceGroup polls the image until its resource group is ready, e.g. after
+// BatchCreateHideResourceGroupsWithMaxSession. It fails like WaitForStatus.
+func WaitForResourceGroup(ctx context.Context, c ImageAPI, imageId string, opts WaitOptions) (*ImageInfo, error) {
+	return waitForImage(ctx, c, imageId, opts, "resource group ready", func(info *ImageInfo) bool {
+		return info.ResourceGroupReady
+	})
+}
+
+// waitForImage polls the image until done reports true, backing off 1.5x between checks.
+func waitForImage(ctx context.Context, c ImageAPI, imageId string, opts WaitOptions, want string, done func(*ImageInfo) bool) (*ImageInfo, error) {
+	defaults := DefaultWaitOptions()
+	if opts.Interval <= 0 {
+		opts.Interval = defaults.Interval
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = defaults.MaxInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaults.Timeout
+	}
+
+	start := time.Now()
+	interval := opts.Interval
+	timeoutCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		if opts.MaxAttempts > 0 && attempt > opts.MaxAttempts {
+			return nil, fmt.Errorf("%w: %s not %s after %d checks", ErrWaitMaxAttempts, imageId, want, opts.MaxAttempts)
+		}
+		if timeoutCtx.Err() != nil {
+			return nil, fmt.Errorf("%w: %s not %s after %v", ErrWaitTimeout, imageId, want, time.Since(start).Round(time.Second))
+		}
+
+		info, err := GetImageInfo(timeoutCtx, c, imageId)
+		if opts.OnPoll != nil {
+			opts.OnPoll(PollEvent{Attempt: attempt, Elapsed: time.Since(start), Info: info, Err: err})
+		}
+		if err == nil {
+			if done(info) {
+				return info, nil
+			}
+			if info.Status.IsFailed() {
+				return info, fmt.Errorf("%w: %s is %s", ErrImageFailed, imageId, info.Status.Text())
+			}
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			return nil, fmt.Errorf("%w: %s not %s after %v", ErrWaitTimeout, imageId, want, time.Since(start).Round(time.Second))
+		case <-time.After(interval):
+			interval = interval * 3 / 2
+			if interval > opts.MaxInterval {
+				interval = opts.MaxInterval
+			}
+		}
+	}
+}
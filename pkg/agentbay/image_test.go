@@ -0,0 +1,107 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusClient reports the statuses in order from GetMcpImageInfo, repeating the last one.
+type statusClient struct {
+	API
+	statuses []ImageStatus
+	calls    int
+}
+
+func (s *statusClient) GetMcpImageInfo(ctx context.Context, req *GetMcpImageInfoRequest) (*GetMcpImageInfoResponse, error) {
+	status := s.statuses[min(s.calls, len(s.statuses)-1)]
+	s.calls++
+	if status == "" {
+		return nil, errors.New("connection reset by peer")
+	}
+	return &GetMcpImageInfoResponse{Body: &GetMcpImageInfoResponseBody{
+		Success:   dara.Bool(true),
+		RequestId: dara.String("req-status"),
+		Data: &GetMcpImageInfoResponseBodyData{
+			ImageResourceStatus: dara.String(string(status)),
+			ResourceGroupReady:  dara.Bool(status == StatusResourcePublished),
+		},
+	}}, nil
+}
+
+var fastWait = WaitOptions{Interval: time.Millisecond, MaxInterval: time.Millisecond, Timeout: time.Second}
+
+func TestGetImageInfoInfersImageType(t *testing.T) {
+	c := &statusClient{statuses: []ImageStatus{StatusImageAvailable}}
+
+	info, err := GetImageInfo(context.Background(), c, "imgc-0a9mg1h2l5dwec9vs")
+	require.NoError(t, err)
+	assert.Equal(t, StatusImageAvailable, info.Status)
+	assert.Equal(t, ImageTypeUser, info.ImageType)
+	assert.Equal(t, "req-status", info.RequestId)
+	assert.Equal(t, "Available (Deactivated)", info.Status.Text())
+}
+
+func TestWaitForStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns once the status is reached, retrying failed checks", func(t *testing.T) {
+		c := &statusClient{statuses: []ImageStatus{StatusResourceDeploying, "", StatusResourcePublished}}
+		var events []PollEvent
+		opts := fastWait
+		opts.OnPoll = func(e PollEvent) { events = append(events, e) }
+
+		info, err := WaitForStatus(ctx, c, "imgc-1", StatusResourcePublished, opts)
+		require.NoError(t, err)
+		assert.Equal(t, StatusResourcePublished, info.Status)
+		require.Len(t, events, 3)
+		assert.Equal(t, 2, events[1].Attempt)
+		assert.Error(t, events[1].Err)
+		assert.Nil(t, events[1].Info)
+	})
+
+	t.Run("failed status", func(t *testing.T) {
+		c := &statusClient{statuses: []ImageStatus{StatusResourceDeploying, StatusResourceFailed}}
+
+		info, err := WaitForStatus(ctx, c, "imgc-1", StatusResourcePublished, fastWait)
+		assert.ErrorIs(t, err, ErrImageFailed)
+		require.NotNil(t, info)
+		assert.Equal(t, StatusResourceFailed, info.Status)
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		c := &statusClient{statuses: []ImageStatus{StatusResourceDeploying}}
+		opts := fastWait
+		opts.MaxAttempts = 3
+
+		_, err := WaitForStatus(ctx, c, "imgc-1", StatusResourcePublished, opts)
+		assert.ErrorIs(t, err, ErrWaitMaxAttempts)
+		assert.Equal(t, 3, c.calls)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := &statusClient{statuses: []ImageStatus{StatusResourceDeploying}}
+		opts := fastWait
+		opts.Timeout = 20 * time.Millisecond
+
+		_, err := WaitForStatus(ctx, c, "imgc-1", StatusResourcePublished, opts)
+		assert.ErrorIs(t, err, ErrWaitTimeout)
+	})
+}
+
+func TestWaitForResourceGroup(t *testing.T) {
+	c := &statusClient{statuses: []ImageStatus{StatusResourceDeploying, StatusResourcePublished}}
+
+	info, err := WaitForResourceGroup(context.Background(), c, "imgc-1", fastWait)
+	require.NoError(t, err)
+	assert.True(t, info.ResourceGroupReady)
+	assert.Equal(t, 2, c.calls)
+}
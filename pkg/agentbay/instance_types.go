@@ -0,0 +1,133 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+// ListInstanceTypes calls DescribeInstanceTypes and returns the usable entries (with CPU and
+// memory), sorted by CPU then memory, together with the Request ID. An empty imageId lists the
+// instance types of all images; when regionId is set, entries that declare their regions and do
+// not include regionId are dropped.
+func ListInstanceTypes(ctx context.Context, c InstanceTypeAPI, imageId, regionId string) ([]*DescribeInstanceTypesResponseBodyDataInstanceType, string, error) {
+	req := &DescribeInstanceTypesRequest{}
+	if imageId != "" {
+		req.SetImageId(imageId)
+	}
+	if regionId != "" {
+		req.SetBizRegionId(regionId)
+	}
+
+	resp, err := c.DescribeInstanceTypes(ctx, req)
+	if err != nil {
+		return nil, requestIDOf(err), err
+	}
+	if resp == nil || resp.Body == nil {
+		return nil, "", fmt.Errorf("invalid response: missing body")
+	}
+	requestId := dara.StringValue(resp.Body.GetRequestId())
+	if resp.Body.GetSuccess() != nil && !*resp.Body.GetSuccess() {
+		return nil, requestId, &Error{
+			Action:    "DescribeInstanceTypes",
+			Code:      dara.StringValue(resp.Body.GetCode()),
+			RequestId: requestId,
+		}
+	}
+
+	return filterInstanceTypes(resp.Body.GetData(), regionId), requestId, nil
+}
+
+// InstanceTypeError is returned by MatchInstanceType when no instance type has the requested
+// CPU and memory.
+type InstanceTypeError struct {
+	CPU       int
+	Memory    int
+	Available []string // Available "<cpu>c<memory>g" combinations, in ascending order
+}
+
+func (e *InstanceTypeError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("no matching instance type for %dc%dg (no instance types are available)", e.CPU, e.Memory)
+	}
+	return fmt.Sprintf("no matching instance type for %dc%dg. Available options: %s", e.CPU, e.Memory, strings.Join(e.Available, ", "))
+}
+
+// MatchInstanceType returns the instance type with the given CPU cores and memory in GB, or an
+// *InstanceTypeError listing every combination that is available.
+func MatchInstanceType(cpu, memory int, types []*DescribeInstanceTypesResponseBodyDataInstanceType) (*DescribeInstanceTypesResponseBodyDataInstanceType, error) {
+	for _, item := range types {
+		if item == nil || item.Cpu == nil || item.Memory == nil {
+			continue
+		}
+		if *item.Cpu == int32(cpu) && *item.Memory == int32(memory) {
+			return item, nil
+		}
+	}
+	return nil, &InstanceTypeError{CPU: cpu, Memory: memory, Available: InstanceTypeCombos(types)}
+}
+
+// InstanceTypeCombos returns the distinct "<cpu>c<memory>g" combinations in ascending order.
+func InstanceTypeCombos(types []*DescribeInstanceTypesResponseBodyDataInstanceType) []string {
+	seen := make(map[string]bool)
+	var combos []string
+	for _, item := range filterInstanceTypes(types, "") {
+		combo := fmt.Sprintf("%dc%dg", *item.Cpu, *item.Memory)
+		if seen[combo] {
+			continue
+		}
+		seen[combo] = true
+		combos = append(combos, combo)
+	}
+	return combos
+}
+
+// filterInstanceTypes drops entries without CPU/memory and entries not offered in regionId, then sorts the rest.
+func filterInstanceTypes(items []*DescribeInstanceTypesResponseBodyDataInstanceType, regionId string) []*DescribeInstanceTypesResponseBodyDataInstanceType {
+	var out []*DescribeInstanceTypesResponseBodyDataInstanceType
+	for _, item := range items {
+		if item == nil || item.GetCpu() == nil || item.GetMemory() == nil {
+			continue
+		}
+		if regionId != "" && len(item.GetRegionIds()) > 0 && !containsString(item.GetRegionIds(), regionId) {
+			continue
+		}
+		out = append(out, item)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if *out[i].Cpu != *out[j].Cpu {
+			return *out[i].Cpu < *out[j].Cpu
+		}
+		return *out[i].Memory < *out[j].Memory
+	})
+	return out
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// requestIDOf returns the Request ID carried by err, or "" when there is none.
+func requestIDOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.RequestId
+	}
+	return newError("", err).RequestId
+}
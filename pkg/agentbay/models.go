@@ -0,0 +1,174 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import "github.com/agentbay/agentbay-cli/internal/client"
+
+// Request and response models of the AgentBay API, used by API and the workflow helpers. They are
+// aliases of the types the CLI uses, so values can be passed between this package and the
+// generated client without conversion. Optional fields are pointers; use dara.String, dara.Int32
+// and friends from github.com/alibabacloud-go/tea/dara to set them, or the generated SetX methods.
+// Being generated, the models change with the API definition; Client does not use them.
+type (
+	BatchCreateHideResourceGroupsWithMaxSessionRequest      = client.BatchCreateHideResourceGroupsWithMaxSessionRequest
+	BatchCreateHideResourceGroupsWithMaxSessionResponse     = client.BatchCreateHideResourceGroupsWithMaxSessionResponse
+	BatchCreateHideResourceGroupsWithMaxSessionResponseBody = client.BatchCreateHideResourceGroupsWithMaxSessionResponseBody
+	CreateApiKeyRequest                                     = client.CreateApiKeyRequest
+	CreateApiKeyResponse                                    = client.CreateApiKeyResponse
+	CreateApiKeyResponseBody                                = client.CreateApiKeyResponseBody
+	CreateDockerImageTaskRequest                            = client.CreateDockerImageTaskRequest
+	CreateDockerImageTaskResponse                           = client.CreateDockerImageTaskResponse
+	CreateDockerImageTaskResponseBody                       = client.CreateDockerImageTaskResponseBody
+	CreateDockerImageTaskResponseBodyData                   = client.CreateDockerImageTaskResponseBodyData
+	CreateMarketSkillRequest                                = client.CreateMarketSkillRequest
+	CreateMarketSkillResponse                               = client.CreateMarketSkillResponse
+	CreateMarketSkillResponseBody                           = client.CreateMarketSkillResponseBody
+	CreateMarketSkillResponseBodyData                       = client.CreateMarketSkillResponseBodyData
+	CreateMcpPolicyDataResponse                             = client.CreateMcpPolicyDataResponse
+	CreateMcpPolicyDataResponseBody                         = client.CreateMcpPolicyDataResponseBody
+	CreateModifyMcpPolicyDataRequest                        = client.CreateModifyMcpPolicyDataRequest
+	CreateResourceGroupRequest                              = client.CreateResourceGroupRequest
+	CreateResourceGroupResponse                             = client.CreateResourceGroupResponse
+	CreateResourceGroupResponseBody                         = client.CreateResourceGroupResponseBody
+	CreateSimpleOfficeSiteRequest                           = client.CreateSimpleOfficeSiteRequest
+	CreateSimpleOfficeSiteResponse                          = client.CreateSimpleOfficeSiteResponse
+	CreateSimpleOfficeSiteResponseBody                      = client.CreateSimpleOfficeSiteResponseBody
+	CreateTagRequest                                        = client.CreateTagRequest
+	CreateTagResponse                                       = client.CreateTagResponse
+	CreateTagResponseBody                                   = client.CreateTagResponseBody
+	CreateTagResponseBodyDataItem                           = client.CreateTagResponseBodyDataItem
+	DeleteApiKeyRequest                                     = client.DeleteApiKeyRequest
+	DeleteApiKeyResponse                                    = client.DeleteApiKeyResponse
+	DeleteApiKeyResponseBody                                = client.DeleteApiKeyResponseBody
+	DeleteMarketSkillRequest                                = client.DeleteMarketSkillRequest
+	DeleteMarketSkillResponse                               = client.DeleteMarketSkillResponse
+	DeleteMarketSkillResponseBody                           = client.DeleteMarketSkillResponseBody
+	DeleteMcpImageRequest                                   = client.DeleteMcpImageRequest
+	DeleteMcpImageResponse                                  = client.DeleteMcpImageResponse
+	DeleteMcpImageResponseBody                              = client.DeleteMcpImageResponseBody
+	DeleteResourceGroupRequest                              = client.DeleteResourceGroupRequest
+	DeleteResourceGroupResponse                             = client.DeleteResourceGroupResponse
+	DeleteResourceGroupResponseBody                         = client.DeleteResourceGroupResponseBody
+	DescribeApiKeysRequest                                  = client.DescribeApiKeysRequest
+	DescribeApiKeysResponse                                 = client.DescribeApiKeysResponse
+	DescribeApiKeysResponseBody                             = client.DescribeApiKeysResponseBody
+	DescribeApiKeysResponseBodyData                         = client.DescribeApiKeysResponseBodyData
+	DescribeApiKeysResponseBodyDataApiKey                   = client.DescribeApiKeysResponseBodyDataApiKey
+	DescribeApiKeysResponseBodyDataApiKeyBoundPolicy        = client.DescribeApiKeysResponseBodyDataApiKeyBoundPolicy
+	DescribeApiKeysResponseBodyDataApiKeyBoundResource      = client.DescribeApiKeysResponseBodyDataApiKeyBoundResource
+	DescribeImageReserveMinAmountImage                      = client.DescribeImageReserveMinAmountImage
+	DescribeImageReserveMinAmountRequest                    = client.DescribeImageReserveMinAmountRequest
+	DescribeImageReserveMinAmountResourceGroup              = client.DescribeImageReserveMinAmountResourceGroup
+	DescribeImageReserveMinAmountResponse                   = client.DescribeImageReserveMinAmountResponse
+	DescribeImageReserveMinAmountResponseBody               = client.DescribeImageReserveMinAmountResponseBody
+	DescribeImageReserveMinAmountResponseBodyData           = client.DescribeImageReserveMinAmountResponseBodyData
+	DescribeInstanceTypesRequest                            = client.DescribeInstanceTypesRequest
+	DescribeInstanceTypesResponse                           = client.DescribeInstanceTypesResponse
+	DescribeInstanceTypesResponseBody                       = client.DescribeInstanceTypesResponseBody
+	DescribeInstanceTypesResponseBodyDataInstanceType       = client.DescribeInstanceTypesResponseBodyDataInstanceType
+	DescribeKeyContentRequest                               = client.DescribeKeyContentRequest
+	DescribeKeyContentResponse                              = client.DescribeKeyContentResponse
+	DescribeKeyContentResponseBody                          = client.DescribeKeyContentResponseBody
+	DescribeKeyContentResponseBodyData                      = client.DescribeKeyContentResponseBodyData
+	DescribeMarketSkillDetailRequest                        = client.DescribeMarketSkillDetailRequest
+	DescribeMarketSkillDetailResponse                       = client.DescribeMarketSkillDetailResponse
+	DescribeMarketSkillDetailResponseBody                   = client.DescribeMarketSkillDetailResponseBody
+	DescribeMarketSkillDetailResponseBodyData               = client.DescribeMarketSkillDetailResponseBodyData
+	DescribeMcpApiKeyRequest                                = client.DescribeMcpApiKeyRequest
+	DescribeMcpApiKeyResponse                               = client.DescribeMcpApiKeyResponse
+	DescribeMcpApiKeyResponseBody                           = client.DescribeMcpApiKeyResponseBody
+	DescribeMcpApiKeyResponseBodyData                       = client.DescribeMcpApiKeyResponseBodyData
+	DescribeMcpPolicyDataRequest                            = client.DescribeMcpPolicyDataRequest
+	DescribeMcpPolicyDataResponse                           = client.DescribeMcpPolicyDataResponse
+	DescribeMcpPolicyDataResponseBody                       = client.DescribeMcpPolicyDataResponseBody
+	DescribeMcpPolicyDataResponseBodyData                   = client.DescribeMcpPolicyDataResponseBodyData
+	DescribeNetworkPackagesRequest                          = client.DescribeNetworkPackagesRequest
+	DescribeNetworkPackagesResponse                         = client.DescribeNetworkPackagesResponse
+	DescribeNetworkPackagesResponseBody                     = client.DescribeNetworkPackagesResponseBody
+	DescribeNetworkPackagesResponseBodyData                 = client.DescribeNetworkPackagesResponseBodyData
+	DescribeNetworkPackagesResponseBodyDataItem             = client.DescribeNetworkPackagesResponseBodyDataItem
+	DescribeOfficeSitesRequest                              = client.DescribeOfficeSitesRequest
+	DescribeOfficeSitesResponse                             = client.DescribeOfficeSitesResponse
+	DescribeOfficeSitesResponseBody                         = client.DescribeOfficeSitesResponseBody
+	DescribeOfficeSitesResponseBodyData                     = client.DescribeOfficeSitesResponseBodyData
+	DescribeWarmUpStatusOpenRequest                         = client.DescribeWarmUpStatusOpenRequest
+	DescribeWarmUpStatusOpenResponse                        = client.DescribeWarmUpStatusOpenResponse
+	DescribeWarmUpStatusOpenResponseBody                    = client.DescribeWarmUpStatusOpenResponseBody
+	DescribeWarmUpStatusOpenResponseBodyData                = client.DescribeWarmUpStatusOpenResponseBodyData
+	DescribeWarmUpStatusOpenResponseBodyDataImage           = client.DescribeWarmUpStatusOpenResponseBodyDataImage
+	DisplayConfig                                           = client.DisplayConfig
+	GetDockerFileStoreCredentialRequest                     = client.GetDockerFileStoreCredentialRequest
+	GetDockerFileStoreCredentialResponse                    = client.GetDockerFileStoreCredentialResponse
+	GetDockerFileStoreCredentialResponseBody                = client.GetDockerFileStoreCredentialResponseBody
+	GetDockerFileStoreCredentialResponseBodyData            = client.GetDockerFileStoreCredentialResponseBodyData
+	GetDockerImageTaskRequest                               = client.GetDockerImageTaskRequest
+	GetDockerImageTaskResponse                              = client.GetDockerImageTaskResponse
+	GetDockerImageTaskResponseBody                          = client.GetDockerImageTaskResponseBody
+	GetDockerImageTaskResponseBodyData                      = client.GetDockerImageTaskResponseBodyData
+	GetDockerfileTemplateRequest                            = client.GetDockerfileTemplateRequest
+	GetDockerfileTemplateResponse                           = client.GetDockerfileTemplateResponse
+	GetDockerfileTemplateResponseBody                       = client.GetDockerfileTemplateResponseBody
+	GetDockerfileTemplateResponseBodyData                   = client.GetDockerfileTemplateResponseBodyData
+	GetMarketSkillCredentialRequest                         = client.GetMarketSkillCredentialRequest
+	GetMarketSkillCredentialResponse                        = client.GetMarketSkillCredentialResponse
+	GetMarketSkillCredentialResponseBody                    = client.GetMarketSkillCredentialResponseBody
+	GetMarketSkillCredentialResponseBodyData                = client.GetMarketSkillCredentialResponseBodyData
+	GetMcpImageInfoRequest                                  = client.GetMcpImageInfoRequest
+	GetMcpImageInfoResponse                                 = client.GetMcpImageInfoResponse
+	GetMcpImageInfoResponseBody                             = client.GetMcpImageInfoResponseBody
+	GetMcpImageInfoResponseBodyData                         = client.GetMcpImageInfoResponseBodyData
+	GetMcpImageInfoResponseBodyDataImageBuildInfo           = client.GetMcpImageInfoResponseBodyDataImageBuildInfo
+	GetMcpImageInfoResponseBodyDataImageInfo                = client.GetMcpImageInfoResponseBodyDataImageInfo
+	GroupSpec                                               = client.GroupSpec
+	ListMarketSkillByPageRequest                            = client.ListMarketSkillByPageRequest
+	ListMarketSkillByPageResponse                           = client.ListMarketSkillByPageResponse
+	ListMarketSkillByPageResponseBody                       = client.ListMarketSkillByPageResponseBody
+	ListMarketSkillByPageResponseBodyData                   = client.ListMarketSkillByPageResponseBodyData
+	ListMarketSkillByPageResponseBodyDataResult             = client.ListMarketSkillByPageResponseBodyDataResult
+	ListMcpImagesRequest                                    = client.ListMcpImagesRequest
+	ListMcpImagesResponse                                   = client.ListMcpImagesResponse
+	ListMcpImagesResponseBody                               = client.ListMcpImagesResponseBody
+	ListMcpImagesResponseBodyData                           = client.ListMcpImagesResponseBodyData
+	ListMcpImagesResponseBodyDataImageBuildInfo             = client.ListMcpImagesResponseBodyDataImageBuildInfo
+	ListMcpImagesResponseBodyDataImageInfo                  = client.ListMcpImagesResponseBodyDataImageInfo
+	ListMcpImagesResponseBodyDataImageResourceGroupInfo     = client.ListMcpImagesResponseBodyDataImageResourceGroupInfo
+	ListMcpImagesResponseBodyDataToolInfo                   = client.ListMcpImagesResponseBodyDataToolInfo
+	ListMcpImagesResponseBodyDataToolInfoToolList           = client.ListMcpImagesResponseBodyDataToolInfoToolList
+	ListSharedDockerReposRequest                            = client.ListSharedDockerReposRequest
+	ListSharedDockerReposResponse                           = client.ListSharedDockerReposResponse
+	ListSharedDockerReposResponseBody                       = client.ListSharedDockerReposResponseBody
+	ListSharedDockerReposResponseBodyDataItem               = client.ListSharedDockerReposResponseBodyDataItem
+	ListTagRequest                                          = client.ListTagRequest
+	ListTagResponse                                         = client.ListTagResponse
+	ListTagResponseBody                                     = client.ListTagResponseBody
+	ListTagResponseBodyDataItem                             = client.ListTagResponseBodyDataItem
+	ModifyApiKeyStatusRequest                               = client.ModifyApiKeyStatusRequest
+	ModifyApiKeyStatusResponse                              = client.ModifyApiKeyStatusResponse
+	ModifyApiKeyStatusResponseBody                          = client.ModifyApiKeyStatusResponseBody
+	ModifyMcpApiKeyConfigRequest                            = client.ModifyMcpApiKeyConfigRequest
+	ModifyMcpApiKeyConfigResponse                           = client.ModifyMcpApiKeyConfigResponse
+	ModifyMcpApiKeyConfigResponseBody                       = client.ModifyMcpApiKeyConfigResponseBody
+	ModifyMcpPolicyDataResponse                             = client.ModifyMcpPolicyDataResponse
+	ModifyMcpPolicyDataResponseBody                         = client.ModifyMcpPolicyDataResponseBody
+	NetworkConfig                                           = client.NetworkConfig
+	NetworkData                                             = client.NetworkData
+	SandboxLifeCycle                                        = client.SandboxLifeCycle
+	SaveMcpPolicyDataRequest                                = client.SaveMcpPolicyDataRequest
+	SaveMcpPolicyDataResponse                               = client.SaveMcpPolicyDataResponse
+	SaveMcpPolicyDataResponseBody                           = client.SaveMcpPolicyDataResponseBody
+	ScreenSettings                                          = client.ScreenSettings
+	ShareDockerRepoRequest                                  = client.ShareDockerRepoRequest
+	ShareDockerRepoResponse                                 = client.ShareDockerRepoResponse
+	ShareDockerRepoResponseBody                             = client.ShareDockerRepoResponseBody
+	ShareDockerRepoResponseBodyData                         = client.ShareDockerRepoResponseBodyData
+	UnshareDockerRepoRequest                                = client.UnshareDockerRepoRequest
+	UnshareDockerRepoResponse                               = client.UnshareDockerRepoResponse
+	UnshareDockerRepoResponseBody                           = client.UnshareDockerRepoResponseBody
+	UnshareDockerRepoResponseBodyData                       = client.UnshareDockerRepoResponseBodyData
+	UpdateImageReserveMinAmountRequest                      = client.UpdateImageReserveMinAmountRequest
+	UpdateImageReserveMinAmountResponse                     = client.UpdateImageReserveMinAmountResponse
+	UpdateImageReserveMinAmountResponseBody                 = client.UpdateImageReserveMinAmountResponseBody
+	UpdateMarketSkillRequest                                = client.UpdateMarketSkillRequest
+	UpdateMarketSkillResponse                               = client.UpdateMarketSkillResponse
+)
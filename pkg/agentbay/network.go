@@ -0,0 +1,114 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+// NetworkType is the network an activated image runs in.
+type NetworkType string
+
+const (
+	// NetworkDefault is the shared default network.
+	NetworkDefault NetworkType = "DEFAULT"
+	// NetworkAdvanced is a dedicated network with configurable session bandwidth and DNS.
+	NetworkAdvanced NetworkType = "ADVANCED"
+	// NetworkCustomized attaches sessions to a VPC and VSwitch of the account.
+	NetworkCustomized NetworkType = "CUSTOMIZED"
+)
+
+// NetworkCheckError is returned when the VPC and region of a CUSTOMIZED activation conflict with
+// an existing office site. Nothing has been changed when it is returned.
+type NetworkCheckError struct {
+	Problems []string
+}
+
+func (e *NetworkCheckError) Error() string {
+	return "network pre-flight validation failed: " + strings.Join(e.Problems, "; ")
+}
+
+// CheckCustomizedNetwork verifies, with read-only calls, that the VPC and region given for a
+// CUSTOMIZED activation are consistent with any office site that already exists for the VPC.
+// It returns every problem found; callers must run it before the first write call and skip it
+// when the region is unknown. VSwitch existence cannot be checked through the AgentBay network API
+// and is left to the backend.
+func CheckCustomizedNetwork(ctx context.Context, c NetworkAPI, vpcId, regionName string) []string {
+	resp, err := c.DescribeOfficeSites(ctx, &DescribeOfficeSitesRequest{
+		OfficeSiteType: dara.String(string(NetworkCustomized)),
+		RegionName:     dara.String(regionName),
+		VpcId:          dara.String(vpcId),
+	})
+	if err != nil {
+		msg := fmt.Sprintf("failed to look up office sites for VPC %s in region %s: %v", vpcId, regionName, err)
+		if reqID := requestIDOf(err); reqID != "" && !strings.Contains(msg, reqID) {
+			msg += fmt.Sprintf(" (Request ID: %s)", reqID)
+		}
+		return []string{msg}
+	}
+	if resp == nil || resp.Body == nil {
+		return []string{"invalid response from DescribeOfficeSites"}
+	}
+	code := dara.StringValue(resp.Body.GetCode())
+	if (resp.Body.GetSuccess() != nil && !*resp.Body.GetSuccess()) || (code != "" && !isSuccessCode(code)) {
+		return []string{fmt.Sprintf("DescribeOfficeSites rejected VPC %s in region %s: Code=%s, Message=%s",
+			vpcId, regionName, code, dara.StringValue(resp.Body.GetMessage()))}
+	}
+
+	var problems []string
+	for _, site := range resp.Body.GetOfficeSites() {
+		if site == nil {
+			continue
+		}
+		siteId := dara.StringValue(site.GetOfficeSiteId())
+		if siteVpc := dara.StringValue(site.GetVpcId()); siteVpc != "" && siteVpc != vpcId {
+			problems = append(problems, fmt.Sprintf("office site %s returned for VPC %s is bound to a different VPC (%s)", siteId, vpcId, siteVpc))
+		}
+		if siteRegion := dara.StringValue(site.GetRegionId()); siteRegion != "" && siteRegion != regionName {
+			problems = append(problems, fmt.Sprintf("VPC %s already has office site %s in region %s, which does not match region %s", vpcId, siteId, siteRegion, regionName))
+		}
+	}
+	return problems
+}
+
+// CreateCustomizedOfficeSite creates a customized office site bound to vpcId via CreateSimpleOfficeSite
+// and returns the new OfficeSiteId and the Request ID. An empty name defaults to "AgentBay-<timestamp>".
+func CreateCustomizedOfficeSite(ctx context.Context, c NetworkAPI, vpcId, regionId, name string) (string, string, error) {
+	if name == "" {
+		name = fmt.Sprintf("AgentBay-%s", time.Now().Format("20060102-15:04:05"))
+	}
+	req := &CreateSimpleOfficeSiteRequest{
+		VpcType:           dara.String("customized"),
+		OfficeSiteName:    dara.String(name),
+		VpcId:             dara.String(vpcId),
+		RegionId:          dara.String(regionId),
+		RegionName:        dara.String(regionId),
+		DesktopAccessType: dara.String("INTERNET"),
+	}
+	resp, err := c.CreateSimpleOfficeSite(ctx, req)
+	if err != nil {
+		return "", requestIDOf(err), err
+	}
+	if resp.Body == nil {
+		return "", "", fmt.Errorf("invalid response from CreateSimpleOfficeSite")
+	}
+	requestId := dara.StringValue(resp.Body.GetRequestId())
+
+	// Success determination: Code-based (new API, Success field may not be returned)
+	code := dara.StringValue(resp.Body.GetCode())
+	successPtr := resp.Body.Success
+	if (successPtr != nil && !*successPtr) || (code != "" && !strings.EqualFold(code, "ok")) {
+		return "", requestId, fmt.Errorf("Code=%s, Message=%s", code, dara.StringValue(resp.Body.GetMessage()))
+	}
+	officeSiteId := dara.StringValue(resp.Body.Data)
+	if officeSiteId == "" {
+		return "", requestId, fmt.Errorf("create simple office site succeeded but returned empty OfficeSiteId")
+	}
+	return officeSiteId, requestId, nil
+}
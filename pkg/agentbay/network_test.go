@@ -0,0 +1,77 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// mockOfficeSitesClient serves DescribeOfficeSites for CheckCustomizedNetwork tests.
+type mockOfficeSitesClient struct {
+	API
+	body *DescribeOfficeSitesResponseBody
+	err  error
+}
+
+func (m *mockOfficeSitesClient) DescribeOfficeSites(ctx context.Context, req *DescribeOfficeSitesRequest) (*DescribeOfficeSitesResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &DescribeOfficeSitesResponse{Body: m.body}, nil
+}
+
+func TestCheckCustomizedNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no existing office site passes", func(t *testing.T) {
+		m := &mockOfficeSitesClient{body: &DescribeOfficeSitesResponseBody{Code: dara.String("ok")}}
+		assert.Empty(t, CheckCustomizedNetwork(ctx, m, "vpc-aaaaaaaa", "cn-hangzhou"))
+	})
+
+	t.Run("matching office site passes", func(t *testing.T) {
+		site := &DescribeOfficeSitesResponseBodyData{
+			OfficeSiteId: dara.String("cn-hangzhou+dir-1"),
+			VpcId:        dara.String("vpc-aaaaaaaa"),
+			RegionId:     dara.String("cn-hangzhou"),
+		}
+		m := &mockOfficeSitesClient{body: &DescribeOfficeSitesResponseBody{OfficeSites: []*DescribeOfficeSitesResponseBodyData{site}}}
+		assert.Empty(t, CheckCustomizedNetwork(ctx, m, "vpc-aaaaaaaa", "cn-hangzhou"))
+	})
+
+	t.Run("VPC and region mismatches are reported together", func(t *testing.T) {
+		site := &DescribeOfficeSitesResponseBodyData{
+			OfficeSiteId: dara.String("cn-shanghai+dir-1"),
+			VpcId:        dara.String("vpc-bbbbbbbb"),
+			RegionId:     dara.String("cn-shanghai"),
+		}
+		m := &mockOfficeSitesClient{body: &DescribeOfficeSitesResponseBody{OfficeSites: []*DescribeOfficeSitesResponseBodyData{site}}}
+		problems := CheckCustomizedNetwork(ctx, m, "vpc-aaaaaaaa", "cn-hangzhou")
+		require.Len(t, problems, 2)
+		assert.Contains(t, problems[0], "different VPC (vpc-bbbbbbbb)")
+		assert.Contains(t, problems[1], "does not match region cn-hangzhou")
+	})
+
+	t.Run("API error is reported with request ID", func(t *testing.T) {
+		m := &mockOfficeSitesClient{err: &client.ErrWithRequestID{Err: fmt.Errorf("InvalidVpcId.NotFound"), RequestID: "REQ-1"}}
+		problems := CheckCustomizedNetwork(ctx, m, "vpc-aaaaaaaa", "cn-hangzhou")
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0], "InvalidVpcId.NotFound")
+		assert.Contains(t, problems[0], "REQ-1")
+	})
+
+	t.Run("failed Code is reported", func(t *testing.T) {
+		m := &mockOfficeSitesClient{body: &DescribeOfficeSitesResponseBody{Code: dara.String("InvalidRegionId"), Message: dara.String("bad region")}}
+		problems := CheckCustomizedNetwork(ctx, m, "vpc-aaaaaaaa", "cn-nowhere")
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0], "Code=InvalidRegionId")
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	internal "github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// Credentials authenticate API requests: an access key pair, with SecurityToken for STS
+// credentials, or an OAuth BearerToken. It is the type the CLI's client authenticates with.
+type Credentials = internal.Credentials
+
+// CredentialsProvider returns the credentials for a request. It is called before every request,
+// so it can hand out rotated STS credentials or refreshed tokens.
+type CredentialsProvider func(ctx context.Context) (Credentials, error)
+
+// Option configures a Client created with New.
+type Option func(*options)
+
+type options struct {
+	endpoint    string
+	timeout     time.Duration
+	credentials CredentialsProvider
+	httpClient  *http.Client
+	retry       RetryPolicy
+	userAgent   string
+}
+
+// defaultUserAgent is the CLI's User-Agent, marked as sent through this package.
+const defaultUserAgent = internal.DefaultUserAgent + " (go-sdk)"
+
+func defaultOptions() *options {
+	apiConfig := config.LoadAPIConfig(nil)
+	return &options{
+		endpoint:    apiConfig.Endpoint,
+		timeout:     time.Duration(apiConfig.TimeoutMs) * time.Millisecond,
+		credentials: defaultCredentials,
+		retry:       DefaultRetryPolicy(),
+		userAgent:   defaultUserAgent,
+	}
+}
+
+// WithEndpoint sets the API endpoint, e.g. "xiaoying.cn-shanghai.aliyuncs.com". The default is
+// AGENTBAY_CLI_ENDPOINT, or the endpoint of the environment selected with AGENTBAY_ENV.
+func WithEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithTimeout sets the timeout of a single request attempt. The default is AGENTBAY_CLI_TIMEOUT_MS,
+// or 60 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithAccessKey authenticates with an access key pair.
+func WithAccessKey(accessKeyID, accessKeySecret string) Option {
+	return WithCredentials(Credentials{AccessKeyID: accessKeyID, AccessKeySecret: accessKeySecret})
+}
+
+// WithSTSToken authenticates with temporary STS credentials.
+func WithSTSToken(accessKeyID, accessKeySecret, securityToken string) Option {
+	return WithCredentials(Credentials{AccessKeyID: accessKeyID, AccessKeySecret: accessKeySecret, SecurityToken: securityToken})
+}
+
+// WithBearerToken authenticates with an OAuth access token.
+func WithBearerToken(token string) Option {
+	return WithCredentials(Credentials{BearerToken: token})
+}
+
+// WithCredentials authenticates every request with creds.
+func WithCredentials(creds Credentials) Option {
+	return WithCredentialsProvider(func(context.Context) (Credentials, error) {
+		return creds, nil
+	})
+}
+
+// WithCredentialsProvider authenticates every request with the credentials returned by provider.
+//
+// Without a credentials option, a Client uses the CLI's chain: AGENTBAY_ACCESS_KEY_ID,
+// AGENTBAY_ACCESS_KEY_SECRET and AGENTBAY_ACCESS_KEY_SESSION_TOKEN from the environment, then the
+// token saved by `agentbay login`, refreshed when it is about to expire.
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(o *options) {
+		o.credentials = provider
+	}
+}
+
+// WithRetry sets the retry policy. WithRetry(RetryPolicy{}) disables retrying.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithHTTPClient sends requests with httpClient, e.g. to add tracing or a proxy. The request
+// context is attached to every request, so cancelling it aborts the request; WithTimeout does not
+// apply, set httpClient.Timeout instead.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithUserAgent replaces the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
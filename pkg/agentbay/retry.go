@@ -0,0 +1,107 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// RetryPolicy controls how a Client retries requests that failed with a transient error: a
+// network failure, a gateway error, throttling or an HTTP 408, 429 or 5xx status.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt; 0 disables retrying.
+	MaxRetries int
+	// InitialDelay is the wait before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay caps the wait between retries.
+	MaxDelay time.Duration
+	// BackoffFactor multiplies the wait after every retry.
+	BackoffFactor float64
+	// RetryWrites also retries actions that change state. A write that timed out may still have
+	// been applied, so only enable it when repeating a write is harmless.
+	RetryWrites bool
+}
+
+// DefaultRetryPolicy returns the policy the CLI uses: 3 retries of read-only actions with
+// exponential backoff from 1s to 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	cfg := client.DefaultRetryConfig()
+	return RetryPolicy{
+		MaxRetries:    cfg.MaxRetries,
+		InitialDelay:  cfg.InitialDelay,
+		MaxDelay:      cfg.MaxDelay,
+		BackoffFactor: cfg.BackoffFactor,
+	}
+}
+
+// appliesTo reports whether requests of action are retried.
+func (p RetryPolicy) appliesTo(action string) bool {
+	if p.MaxRetries <= 0 {
+		return false
+	}
+	return p.RetryWrites || isReadAction(action)
+}
+
+// do runs fn until it succeeds, fails with a permanent error or the retries are exhausted. The
+// wait between attempts ends early when ctx is done.
+func (p RetryPolicy) do(ctx context.Context, fn func() error) error {
+	delay := p.InitialDelay
+	var lastErr error
+	for attempt := 0; attempt <= p.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return lastErr
+			case <-time.After(delay):
+			}
+			next := time.Duration(float64(delay) * p.BackoffFactor)
+			if p.MaxDelay > 0 && next > p.MaxDelay {
+				next = p.MaxDelay
+			}
+			delay = next
+		}
+		lastErr = fn()
+		if lastErr == nil || ctx.Err() != nil || !isTransient(lastErr) {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// isReadAction reports whether action only reads state, going by the API naming convention.
+func isReadAction(action string) bool {
+	for _, prefix := range []string{"Get", "List", "Describe"} {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransient reports whether err is worth retrying.
+func isTransient(err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode != 0 && client.IsRetryableHTTPStatus(apiErr.StatusCode) {
+			return true
+		}
+		if strings.Contains(strings.ToLower(apiErr.Code), "throttl") {
+			return true
+		}
+		if apiErr.Err == nil {
+			// The API answered and reported the failure in the body; repeating will not help.
+			return false
+		}
+		if apiErr.StatusCode != 0 {
+			return false
+		}
+		err = apiErr.Err
+	}
+	return client.IsTransientGatewayError(err)
+}
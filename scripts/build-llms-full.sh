@@ -44,7 +44,10 @@ FILES=(
   "docs/en/apikey.md"
   "docs/en/docker.md"
   "docs/en/network.md"
+  "docs/en/instance-types.md"
   "docs/en/skills.md"
+  "docs/en/plugin.md"
+  "docs/en/sdk.md"
   "docs/en/ram-permissions.md"
   "docs/en/faq.md"
 )
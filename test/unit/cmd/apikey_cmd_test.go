@@ -401,3 +401,82 @@ func TestApiKeyDescribeKeyContentCmd(t *testing.T) {
 		assert.True(t, isRequired, "--api-key-id should be marked as required")
 	})
 }
+
+func TestApiKeyRotateCmd(t *testing.T) {
+	var rotateCmd *cobra.Command
+	for _, c := range cmd.ApiKeyCmd.Commands() {
+		if c.Use == "rotate" {
+			rotateCmd = c
+			break
+		}
+	}
+
+	t.Run("rotate command exists", func(t *testing.T) {
+		assert.NotNil(t, rotateCmd, "rotate command should be registered under apikey")
+	})
+
+	t.Run("rotate command flags", func(t *testing.T) {
+		assert.NotNil(t, rotateCmd)
+		for _, name := range []string{"api-key", "api-key-id", "name", "grace", "finalize", "pending", "abandon", "force", "yes"} {
+			assert.NotNil(t, rotateCmd.Flags().Lookup(name), "missing --%s", name)
+		}
+		assert.Equal(t, "0s", rotateCmd.Flags().Lookup("grace").DefValue)
+		assert.Equal(t, "y", rotateCmd.Flags().Lookup("yes").Shorthand)
+	})
+}
+
+func TestApiKeySecretDeliveryFlags(t *testing.T) {
+	for _, name := range []string{"create", "describe-key-content", "rotate"} {
+		var c *cobra.Command
+		for _, sub := range cmd.ApiKeyCmd.Commands() {
+			if sub.Name() == name {
+				c = sub
+				break
+			}
+		}
+		if !assert.NotNil(t, c, name) {
+			continue
+		}
+		for _, flag := range []string{"show-secret", "write-to", "format", "env-file", "env-var", "k8s-secret", "k8s-secret-name", "k8s-namespace", "vault-path", "vault-addr", "vault-kv-version"} {
+			assert.NotNil(t, c.Flags().Lookup(flag), "%s is missing --%s", name, flag)
+		}
+		assert.Equal(t, "raw", c.Flags().Lookup("format").DefValue)
+		assert.Equal(t, "AGENTBAY_API_KEY", c.Flags().Lookup("env-var").DefValue)
+	}
+}
+
+func TestApiKeyMetadataCmds(t *testing.T) {
+	find := func(name string) *cobra.Command {
+		for _, c := range cmd.ApiKeyCmd.Commands() {
+			if c.Name() == name {
+				return c
+			}
+		}
+		return nil
+	}
+
+	for _, name := range []string{"describe", "label", "expire", "audit"} {
+		c := find(name)
+		if !assert.NotNil(t, c, "%s command should be registered under apikey", name) {
+			continue
+		}
+		if name != "audit" {
+			assert.NotNil(t, c.Flags().Lookup("api-key"), name)
+			assert.NotNil(t, c.Flags().Lookup("api-key-id"), name)
+		}
+	}
+
+	audit := find("audit")
+	if assert.NotNil(t, audit) {
+		assert.Equal(t, "90d", audit.Flags().Lookup("max-age").DefValue)
+		assert.Equal(t, "30d", audit.Flags().Lookup("unused-for").DefValue)
+		assert.Equal(t, "7d", audit.Flags().Lookup("expiring-within").DefValue)
+		assert.NotNil(t, audit.Flags().Lookup("strict"))
+	}
+	expire := find("expire")
+	if assert.NotNil(t, expire) {
+		for _, flag := range []string{"at", "in", "max-age", "clear"} {
+			assert.NotNil(t, expire.Flags().Lookup(flag), flag)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestResourceIDArgsComplete(t *testing.T) {
+	cases := []struct {
+		root *cobra.Command
+		path []string
+	}{
+		{cmd.ImageCmd, []string{"activate"}},
+		{cmd.ImageCmd, []string{"deactivate"}},
+		{cmd.ImageCmd, []string{"status"}},
+		{cmd.ImageCmd, []string{"delete"}},
+		{cmd.SkillsCmd, []string{"show"}},
+		{cmd.SkillsCmd, []string{"delete"}},
+		{cmd.SkillsCmd, []string{"pull"}},
+		{cmd.SkillsCmd, []string{"diff"}},
+		{cmd.SkillsCmd, []string{"rollback"}},
+		{cmd.SkillsCmd, []string{"history"}},
+		{cmd.DockerCmd, []string{"unshare"}},
+	}
+	for _, tc := range cases {
+		c, _, err := tc.root.Find(tc.path)
+		require.NoError(t, err)
+		assert.NotNil(t, c.ValidArgsFunction, "%s completes its argument", c.CommandPath())
+	}
+}
+
+type flagCompletionCase struct {
+	root *cobra.Command
+	path []string
+	flag string
+}
+
+func TestResourceIDFlagsComplete(t *testing.T) {
+	cases := []flagCompletionCase{
+		{cmd.ImageCmd, []string{"set-max-session"}, "image-id"},
+		{cmd.ImageCmd, []string{"set-pre-open"}, "image-id"},
+		{cmd.ImageCmd, []string{"describe-pre-open"}, "image-id"},
+		{cmd.ImageCmd, []string{"capacity"}, "image-id"},
+		{cmd.InstanceTypesCmd, []string{"list"}, "image-id"},
+		{cmd.NetworkCmd, []string{"report"}, "image-id"},
+		{cmd.SkillsCmd, []string{"update"}, "skill-id"},
+		{cmd.SkillsCmd, []string{"delete"}, "skill-id"},
+		{cmd.DockerCmd, []string{"unshare"}, "target-uid"},
+		{cmd.ApiKeyCmd, []string{"describe-key-content"}, "api-key-id"},
+		{cmd.ApiKeyConcurrencyCmd, []string{"set"}, "api-key"},
+		{cmd.ApiKeyConcurrencyCmd, []string{"set"}, "api-key-id"},
+	}
+	for _, sub := range []string{"enable", "disable", "delete", "describe", "list", "rotate", "label", "expire"} {
+		cases = append(cases,
+			flagCompletionCase{cmd.ApiKeyCmd, []string{sub}, "api-key"},
+			flagCompletionCase{cmd.ApiKeyCmd, []string{sub}, "api-key-id"})
+	}
+	for _, tc := range cases {
+		c, _, err := tc.root.Find(tc.path)
+		require.NoError(t, err)
+		_, ok := c.GetFlagCompletionFunc(tc.flag)
+		assert.True(t, ok, "%s --%s completes", c.CommandPath(), tc.flag)
+	}
+}
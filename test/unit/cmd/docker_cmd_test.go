@@ -160,3 +160,101 @@ func findSubCmd(parent interface{ Commands() []*cobra.Command }, name string) *c
 	}
 	return nil
 }
+
+func TestDockerPushCmd(t *testing.T) {
+	var pushCmd = findSubCmd(cmd.DockerCmd, "push")
+
+	t.Run("push command has correct metadata", func(t *testing.T) {
+		assert.NotNil(t, pushCmd)
+		assert.Equal(t, "push <image|tag>", pushCmd.Use)
+		assert.NoError(t, pushCmd.Args(nil, []string{"v1"}))
+		assert.Error(t, pushCmd.Args(nil, []string{}))
+	})
+
+	t.Run("push command has daemonless flags", func(t *testing.T) {
+		assert.NotNil(t, pushCmd)
+		for _, name := range []string{"from", "source-ref", "mount-from"} {
+			assert.NotNil(t, pushCmd.Flags().Lookup(name), name)
+		}
+		assert.Equal(t, "stringArray", pushCmd.Flags().Lookup("mount-from").Value.Type())
+	})
+}
+
+func TestDockerImagesAndInspectCmd(t *testing.T) {
+	imagesCmd := findSubCmd(cmd.DockerCmd, "images")
+	inspectCmd := findSubCmd(cmd.DockerCmd, "inspect")
+
+	t.Run("images command has correct metadata", func(t *testing.T) {
+		assert.NotNil(t, imagesCmd)
+		assert.Equal(t, "images", imagesCmd.Use)
+		assert.NotNil(t, imagesCmd.Flags().ShorthandLookup("o"))
+		assert.Error(t, imagesCmd.Args(imagesCmd, []string{"x"}))
+	})
+
+	t.Run("inspect command takes one reference and --platform", func(t *testing.T) {
+		assert.NotNil(t, inspectCmd)
+		assert.Equal(t, "inspect <tag|digest|image>", inspectCmd.Use)
+		assert.NoError(t, inspectCmd.Args(nil, []string{"v1"}))
+		assert.Error(t, inspectCmd.Args(nil, []string{}))
+		assert.NotNil(t, inspectCmd.Flags().Lookup("platform"))
+	})
+}
+
+func TestDockerCredentialFlags(t *testing.T) {
+	loginCmd := findSubCmd(cmd.DockerCmd, "login")
+	assert.NotNil(t, loginCmd)
+	status := loginCmd.Flags().Lookup("status")
+	assert.NotNil(t, status)
+	assert.Equal(t, "false", status.DefValue)
+
+	for _, name := range []string{"tag", "push", "images", "inspect"} {
+		sub := findSubCmd(cmd.DockerCmd, name)
+		assert.NotNil(t, sub, name)
+		assert.NotNil(t, sub.Flags().Lookup("registry"), name)
+	}
+}
+
+func TestDockerCredentialHelperCmd(t *testing.T) {
+	helperCmd := findSubCmd(cmd.DockerCmd, "credential-helper")
+	assert.NotNil(t, helperCmd)
+	assert.Equal(t, []string{"get", "store", "erase", "list"}, helperCmd.ValidArgs)
+	assert.NoError(t, helperCmd.Args(helperCmd, []string{"get"}))
+	assert.Error(t, helperCmd.Args(helperCmd, []string{}))
+
+	installCmd := findSubCmd(helperCmd, "install")
+	assert.NotNil(t, installCmd)
+	assert.NotNil(t, installCmd.Flags().Lookup("dir"))
+	assert.NotNil(t, installCmd.Flags().Lookup("registry"))
+	assert.Error(t, installCmd.Args(installCmd, []string{"x"}))
+}
+
+func TestDockerBuildCmd(t *testing.T) {
+	buildCmd := findSubCmd(cmd.DockerCmd, "build")
+	assert.NotNil(t, buildCmd)
+	assert.Equal(t, "build [context]", buildCmd.Use)
+	assert.NoError(t, buildCmd.Args(buildCmd, []string{}))
+	assert.Error(t, buildCmd.Args(buildCmd, []string{"a", "b"}))
+	assert.NotNil(t, buildCmd.Flags().ShorthandLookup("f"))
+	assert.NotNil(t, buildCmd.Flags().ShorthandLookup("t"))
+	for _, name := range []string{"platform", "push", "build-arg", "builder", "create-image-name", "template-image-id"} {
+		assert.NotNil(t, buildCmd.Flags().Lookup(name), name)
+	}
+}
+
+func TestDockerShareExpiryAndBulkFlags(t *testing.T) {
+	shareCmd := findSubCmd(cmd.DockerCmd, "share")
+	assert.NotNil(t, shareCmd)
+	for _, name := range []string{"uid-file", "expires-in", "expires-at"} {
+		assert.NotNil(t, shareCmd.Flags().Lookup(name), name)
+	}
+	unshareCmd := findSubCmd(cmd.DockerCmd, "unshare")
+	assert.NotNil(t, unshareCmd)
+	assert.NotNil(t, unshareCmd.Flags().Lookup("uid-file"))
+
+	sharesCmd := findSubCmd(cmd.DockerCmd, "shares")
+	assert.NotNil(t, sharesCmd)
+	reconcileCmd := findSubCmd(sharesCmd, "reconcile")
+	assert.NotNil(t, reconcileCmd)
+	assert.NotNil(t, reconcileCmd.Flags().Lookup("dry-run"))
+	assert.Error(t, reconcileCmd.Args(reconcileCmd, []string{"x"}))
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestImageCapacityCmd(t *testing.T) {
+	capacityCmd := findSubcommand(cmd.ImageCmd, "capacity")
+	require.NotNil(t, capacityCmd, "image capacity subcommand not found")
+	assert.Contains(t, capacityCmd.Long, "DescribeWarmUpStatusOpen")
+	assert.Contains(t, capacityCmd.Long, "DescribeImageReserveMinAmount")
+
+	for _, name := range []string{"image-id", "watch", "interval", "output"} {
+		assert.NotNil(t, capacityCmd.Flags().Lookup(name), "flag %s should exist", name)
+	}
+	assert.Equal(t, "o", capacityCmd.Flags().Lookup("output").Shorthand)
+	assert.Equal(t, "10s", capacityCmd.Flags().Lookup("interval").DefValue)
+	assert.Equal(t, "false", capacityCmd.Flags().Lookup("watch").DefValue)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestValidateNetworkParams(t *testing.T) {
+	const (
+		validVpc     = "vpc-bp1aevy8sofi8mh1q0000"
+		validVSwitch = "vsw-bp1s5fnvk4gn2tws00000"
+	)
+
+	t.Run("valid CUSTOMIZED parameters", func(t *testing.T) {
+		problems := cmd.ValidateNetworkParams("CUSTOMIZED", validVpc, validVSwitch, []string{"100.100.2.136", "8.8.8.8"}, 0, "cn-hangzhou")
+		assert.Empty(t, problems)
+	})
+
+	t.Run("valid ADVANCED parameters", func(t *testing.T) {
+		assert.Empty(t, cmd.ValidateNetworkParams("ADVANCED", "", "", nil, 10, ""))
+		assert.Empty(t, cmd.ValidateNetworkParams("ADVANCED", "", "", nil, cmd.MinSessionBandwidth, ""))
+		assert.Empty(t, cmd.ValidateNetworkParams("ADVANCED", "", "", nil, cmd.MaxSessionBandwidth, ""))
+	})
+
+	t.Run("all problems are reported together", func(t *testing.T) {
+		problems := cmd.ValidateNetworkParams("CUSTOMIZED", "vpc_123", "vswitch-1", []string{"8.8.8", "1.1.1.1", "1.1.1.1"}, 0, "Hangzhou")
+		require.Len(t, problems, 5)
+		assert.Contains(t, problems[0], "--region-id")
+		assert.Contains(t, problems[1], "--vpc-id")
+		assert.Contains(t, problems[2], "--vswitch-id")
+		assert.Contains(t, problems[3], `"8.8.8" is not a valid IPv4 address`)
+		assert.Contains(t, problems[4], "more than once")
+	})
+
+	t.Run("IPv6 DNS addresses are rejected", func(t *testing.T) {
+		problems := cmd.ValidateNetworkParams("ADVANCED", "", "", []string{"2001:db8::1"}, 0, "")
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0], "IPv4")
+	})
+
+	t.Run("session bandwidth outside the recommended range only warns", func(t *testing.T) {
+		for _, bw := range []int{1, 201, 1000} {
+			assert.Empty(t, cmd.ValidateNetworkParams("ADVANCED", "", "", nil, bw, ""), "bandwidth %d", bw)
+			assert.Contains(t, cmd.SessionBandwidthWarning("ADVANCED", bw), "outside the recommended range", "bandwidth %d", bw)
+		}
+		for _, bw := range []int{0, cmd.MinSessionBandwidth, 100, cmd.MaxSessionBandwidth} {
+			assert.Empty(t, cmd.SessionBandwidthWarning("ADVANCED", bw), "bandwidth %d", bw)
+		}
+	})
+
+	t.Run("negative session bandwidth is rejected", func(t *testing.T) {
+		problems := cmd.ValidateNetworkParams("ADVANCED", "", "", nil, -5, "")
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0], "positive")
+	})
+}
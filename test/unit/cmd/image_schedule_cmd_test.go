@@ -0,0 +1,41 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestImageScheduleCmd(t *testing.T) {
+	scheduleCmd := findSubcommand(cmd.ImageCmd, "schedule")
+	require.NotNil(t, scheduleCmd, "image schedule subcommand not found")
+	assert.Contains(t, scheduleCmd.Long, "cron")
+
+	t.Run("apply flags", func(t *testing.T) {
+		applyCmd := findSubcommand(scheduleCmd, "apply")
+		require.NotNil(t, applyCmd, "image schedule apply subcommand not found")
+
+		for _, name := range []string{"file", "dry-run", "at", "force"} {
+			assert.NotNil(t, applyCmd.Flags().Lookup(name), "flag %s should exist", name)
+		}
+		fileFlag := applyCmd.Flags().Lookup("file")
+		assert.Equal(t, []string{"true"}, fileFlag.Annotations["cobra_annotation_bash_completion_one_required_flag"])
+		assert.Equal(t, "false", applyCmd.Flags().Lookup("dry-run").DefValue)
+	})
+
+	t.Run("run flags", func(t *testing.T) {
+		runCmd := findSubcommand(scheduleCmd, "run")
+		require.NotNil(t, runCmd, "image schedule run subcommand not found")
+
+		assert.NotNil(t, runCmd.Flags().Lookup("file"))
+		intervalFlag := runCmd.Flags().Lookup("interval")
+		require.NotNil(t, intervalFlag)
+		assert.Equal(t, "1m0s", intervalFlag.DefValue)
+	})
+}
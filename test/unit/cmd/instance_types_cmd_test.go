@@ -0,0 +1,93 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func findInstanceTypesSubcommand(name string) *cobra.Command {
+	for _, sub := range cmd.InstanceTypesCmd.Commands() {
+		if sub.Name() == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func TestInstanceTypesCmd(t *testing.T) {
+	t.Run("instance-types command has correct metadata", func(t *testing.T) {
+		assert.Equal(t, "instance-types", cmd.InstanceTypesCmd.Use)
+		assert.Equal(t, "management", cmd.InstanceTypesCmd.GroupID)
+		assert.Contains(t, cmd.InstanceTypesCmd.Long, "CPU/memory")
+	})
+
+	t.Run("instance-types has list subcommand", func(t *testing.T) {
+		require.NotNil(t, findInstanceTypesSubcommand("list"))
+	})
+}
+
+func TestInstanceTypesListCmd(t *testing.T) {
+	listCmd := findInstanceTypesSubcommand("list")
+	require.NotNil(t, listCmd)
+
+	t.Run("list command takes no arguments", func(t *testing.T) {
+		assert.NoError(t, listCmd.Args(listCmd, []string{}))
+		assert.Error(t, listCmd.Args(listCmd, []string{"extra"}))
+	})
+
+	t.Run("list command has optional filter and output flags", func(t *testing.T) {
+		for _, name := range []string{"region", "image-id", "output"} {
+			flag := listCmd.Flags().Lookup(name)
+			require.NotNil(t, flag, "flag %s not found", name)
+			assert.Equal(t, "", flag.DefValue)
+		}
+		assert.Equal(t, "o", listCmd.Flags().Lookup("output").Shorthand)
+	})
+}
+
+func instanceType(name string, cpu, memory int32) *client.DescribeInstanceTypesResponseBodyDataInstanceType {
+	return &client.DescribeInstanceTypesResponseBodyDataInstanceType{
+		AppInstanceType: dara.String(name),
+		Cpu:             dara.Int32(cpu),
+		Memory:          dara.Int32(memory),
+	}
+}
+
+func TestValidateInstanceTypeCombo(t *testing.T) {
+	types := []*client.DescribeInstanceTypesResponseBodyDataInstanceType{
+		instanceType("acp.large", 8, 16),
+		instanceType("acp.small", 2, 4),
+		nil,
+		{AppInstanceType: dara.String("broken")},
+		instanceType("acp.medium", 4, 8),
+	}
+
+	t.Run("matching combination returns the instance type", func(t *testing.T) {
+		matched, err := cmd.ValidateInstanceTypeCombo(4, 8, types)
+		require.NoError(t, err)
+		assert.Equal(t, "acp.medium", *matched.AppInstanceType)
+	})
+
+	t.Run("unknown combination lists available options in order", func(t *testing.T) {
+		_, err := cmd.ValidateInstanceTypeCombo(3, 6, types)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "3c6g")
+		assert.Contains(t, err.Error(), "Available options: 2c4g, 4c8g, 8c16g")
+	})
+
+	t.Run("empty instance types reports nothing available", func(t *testing.T) {
+		_, err := cmd.ValidateInstanceTypeCombo(2, 4, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no instance types are available")
+	})
+}
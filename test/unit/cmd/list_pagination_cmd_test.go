@@ -0,0 +1,46 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestListCommandsPagingFlags(t *testing.T) {
+	find := func(parent *cobra.Command, name string) *cobra.Command {
+		for _, c := range parent.Commands() {
+			if c.Name() == name {
+				return c
+			}
+		}
+		return nil
+	}
+	listCmds := map[string]*cobra.Command{
+		"apikey list":             find(cmd.ApiKeyCmd, "list"),
+		"image list":              find(cmd.ImageCmd, "list"),
+		"image describe-pre-open": find(cmd.ImageCmd, "describe-pre-open"),
+		"skills list":             find(cmd.SkillsCmd, "list"),
+		"docker list-shares":      find(cmd.DockerCmd, "list-shares"),
+	}
+	for name, c := range listCmds {
+		t.Run(name, func(t *testing.T) {
+			require.NotNil(t, c)
+			all := c.Flags().Lookup("all")
+			require.NotNil(t, all, "--all flag should exist")
+			assert.Equal(t, "false", all.DefValue)
+			limit := c.Flags().Lookup("limit")
+			require.NotNil(t, limit, "--limit flag should exist")
+			assert.Equal(t, "0", limit.DefValue)
+			output := c.Flags().Lookup("output")
+			require.NotNil(t, output)
+			assert.Contains(t, output.Usage, "ndjson")
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/agentbay/agentbay-cli/cmd"
 )
@@ -28,6 +29,8 @@ func TestNetworkCmd(t *testing.T) {
 			names[i] = c.Name()
 		}
 		assert.Contains(t, names, "package")
+		assert.Contains(t, names, "office-site")
+		assert.Contains(t, names, "report")
 	})
 }
 
@@ -45,6 +48,7 @@ func TestNetworkPackageCmd(t *testing.T) {
 			names[i] = c.Name()
 		}
 		assert.Contains(t, names, "list")
+		assert.Contains(t, names, "describe")
 	})
 }
 
@@ -95,3 +99,68 @@ func TestNetworkPackageListCmd(t *testing.T) {
 		assert.Nil(t, flag)
 	})
 }
+
+func findSubcommand(parent *cobra.Command, name string) *cobra.Command {
+	for _, c := range parent.Commands() {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestNetworkPackageDescribeCmd(t *testing.T) {
+	describeCmd := findSubcommand(cmd.NetworkPackageCmd, "describe")
+	require.NotNil(t, describeCmd)
+
+	t.Run("describe requires exactly one network package ID", func(t *testing.T) {
+		assert.Error(t, describeCmd.Args(describeCmd, []string{}))
+		assert.NoError(t, describeCmd.Args(describeCmd, []string{"np-xxx"}))
+		assert.Error(t, describeCmd.Args(describeCmd, []string{"np-1", "np-2"}))
+	})
+
+	t.Run("describe has biz-region-id and output flags", func(t *testing.T) {
+		flag := describeCmd.Flags().Lookup("biz-region-id")
+		require.NotNil(t, flag)
+		assert.Equal(t, "cn-hangzhou", flag.DefValue)
+		require.NotNil(t, describeCmd.Flags().Lookup("output"))
+	})
+}
+
+func TestNetworkOfficeSiteCmd(t *testing.T) {
+	t.Run("office-site has list, describe and create subcommands", func(t *testing.T) {
+		assert.Equal(t, "office-site", cmd.NetworkOfficeSiteCmd.Use)
+		for _, name := range []string{"list", "describe", "create"} {
+			assert.NotNil(t, findSubcommand(cmd.NetworkOfficeSiteCmd, name), "missing subcommand %s", name)
+		}
+	})
+
+	t.Run("list has region, type, vpc and output flags", func(t *testing.T) {
+		listCmd := findSubcommand(cmd.NetworkOfficeSiteCmd, "list")
+		require.NotNil(t, listCmd)
+		assert.Equal(t, "cn-hangzhou", listCmd.Flags().Lookup("region-id").DefValue)
+		for _, name := range []string{"type", "vpc-id", "output"} {
+			assert.NotNil(t, listCmd.Flags().Lookup(name), "missing flag %s", name)
+		}
+	})
+
+	t.Run("create requires vpc-id and region-id", func(t *testing.T) {
+		createCmd := findSubcommand(cmd.NetworkOfficeSiteCmd, "create")
+		require.NotNil(t, createCmd)
+		for _, name := range []string{"vpc-id", "region-id"} {
+			flag := createCmd.Flags().Lookup(name)
+			require.NotNil(t, flag)
+			assert.Equal(t, []string{"true"}, flag.Annotations[cobra.BashCompOneRequiredFlag])
+		}
+		assert.NotNil(t, createCmd.Flags().Lookup("name"))
+	})
+}
+
+func TestNetworkReportCmd(t *testing.T) {
+	reportCmd := findSubcommand(cmd.NetworkCmd, "report")
+	require.NotNil(t, reportCmd)
+	assert.NoError(t, reportCmd.Args(reportCmd, []string{}))
+	assert.Error(t, reportCmd.Args(reportCmd, []string{"extra"}))
+	assert.NotNil(t, reportCmd.Flags().Lookup("image-id"))
+	assert.NotNil(t, reportCmd.Flags().Lookup("output"))
+}
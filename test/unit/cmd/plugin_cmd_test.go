@@ -0,0 +1,42 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestPluginCommand(t *testing.T) {
+	assert.Equal(t, "plugin", cmd.PluginCmd.Use)
+	assert.Equal(t, "management", cmd.PluginCmd.GroupID)
+
+	names := []string{}
+	for _, c := range cmd.PluginCmd.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "install", "context"}, names)
+
+	install, _, err := cmd.PluginCmd.Find([]string{"install"})
+	require.NoError(t, err)
+	for _, name := range []string{"name", "sha256", "force"} {
+		assert.NotNil(t, install.Flags().Lookup(name), "plugin install --%s", name)
+	}
+	assert.Error(t, install.Args(install, nil), "install needs a path or URL")
+
+	list, _, err := cmd.PluginCmd.Find([]string{"list"})
+	require.NoError(t, err)
+	f := list.Flags().Lookup("output")
+	require.NotNil(t, f)
+	assert.Equal(t, "o", f.Shorthand)
+}
+
+func TestPluginExitError(t *testing.T) {
+	err := &cmd.PluginExitError{Name: "audit", Code: 3}
+	assert.Equal(t, "plugin audit exited with status 3", err.Error())
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestCachedCommandsHaveCacheFlags(t *testing.T) {
+	cases := []struct {
+		root *cobra.Command
+		path []string
+	}{
+		{cmd.ImageCmd, []string{"list"}},
+		{cmd.ImageCmd, []string{"status"}},
+		{cmd.SkillsCmd, []string{"list"}},
+		{cmd.SkillsCmd, []string{"show"}},
+		{cmd.ApiKeyCmd, []string{"list"}},
+		{cmd.ApiKeyCmd, []string{"describe"}},
+		{cmd.DockerCmd, []string{"list-shares"}},
+	}
+	for _, tc := range cases {
+		c, _, err := tc.root.Find(tc.path)
+		require.NoError(t, err)
+		for _, name := range []string{"refresh", "offline"} {
+			f := c.Flags().Lookup(name)
+			if assert.NotNil(t, f, "%s --%s", c.CommandPath(), name) {
+				assert.Equal(t, "false", f.DefValue)
+			}
+		}
+	}
+}
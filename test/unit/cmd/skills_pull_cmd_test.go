@@ -0,0 +1,44 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestSkillsPullDiffCmd(t *testing.T) {
+	find := func(name string) *cobra.Command {
+		for _, c := range cmd.SkillsCmd.Commands() {
+			if c.Name() == name {
+				return c
+			}
+		}
+		return nil
+	}
+
+	t.Run("skills pull requires a skill ID and has --dir and --force", func(t *testing.T) {
+		c := find("pull")
+		require.NotNil(t, c)
+		assert.Error(t, c.Args(c, []string{}))
+		assert.NoError(t, c.Args(c, []string{"sk-1"}))
+		assert.Equal(t, "", c.Flags().Lookup("dir").DefValue)
+		assert.Equal(t, "false", c.Flags().Lookup("force").DefValue)
+	})
+
+	t.Run("skills diff requires a skill ID and a directory", func(t *testing.T) {
+		c := find("diff")
+		require.NotNil(t, c)
+		assert.Error(t, c.Args(c, []string{"sk-1"}))
+		assert.NoError(t, c.Args(c, []string{"sk-1", "./dir"}))
+		for _, name := range []string{"name-only", "exit-code", "output"} {
+			assert.NotNil(t, c.Flags().Lookup(name), "--%s flag should exist", name)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestSkillsSyncCmd(t *testing.T) {
+	sync := func() *cobra.Command {
+		for _, c := range cmd.SkillsCmd.Commands() {
+			if c.Name() == "sync" {
+				return c
+			}
+		}
+		return nil
+	}()
+	require.NotNil(t, sync)
+
+	t.Run("skills sync requires a root directory", func(t *testing.T) {
+		assert.Error(t, sync.Args(sync, []string{}))
+		assert.NoError(t, sync.Args(sync, []string{"./skills"}))
+	})
+
+	t.Run("skills sync has plan and lock flags", func(t *testing.T) {
+		for _, name := range []string{"dry-run", "prune", "yes", "no-validate"} {
+			f := sync.Flags().Lookup(name)
+			require.NotNil(t, f, "--%s flag should exist", name)
+			assert.Equal(t, "false", f.DefValue)
+		}
+		assert.Equal(t, "y", sync.Flags().Lookup("yes").Shorthand)
+		assert.Equal(t, "", sync.Flags().Lookup("lock-file").DefValue)
+	})
+}
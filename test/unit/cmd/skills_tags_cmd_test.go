@@ -0,0 +1,57 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestSkillsTagsCmd(t *testing.T) {
+	find := func(parent *cobra.Command, name string) *cobra.Command {
+		for _, c := range parent.Commands() {
+			if c.Name() == name {
+				return c
+			}
+		}
+		return nil
+	}
+	tags := find(cmd.SkillsCmd, "tags")
+	require.NotNil(t, tags)
+
+	t.Run("skills tags has list, create and delete", func(t *testing.T) {
+		for _, name := range []string{"list", "create", "delete"} {
+			assert.NotNil(t, find(tags, name), "skills tags %s should exist", name)
+		}
+	})
+
+	t.Run("create and delete need at least one tag", func(t *testing.T) {
+		for _, name := range []string{"create", "delete"} {
+			c := find(tags, name)
+			assert.Error(t, c.Args(c, []string{}))
+			assert.NoError(t, c.Args(c, []string{"a", "b"}))
+		}
+		assert.Equal(t, "y", find(tags, "delete").Flags().Lookup("yes").Shorthand)
+	})
+
+	t.Run("list has --unused and -o", func(t *testing.T) {
+		list := find(tags, "list")
+		assert.NotNil(t, list.Flags().Lookup("unused"))
+		assert.Equal(t, "o", list.Flags().Lookup("output").Shorthand)
+	})
+
+	t.Run("update has incremental tag flags", func(t *testing.T) {
+		update := find(cmd.SkillsCmd, "update")
+		for _, name := range []string{"add-tag", "remove-tag"} {
+			f := update.Flags().Lookup(name)
+			require.NotNil(t, f, "--%s flag should exist", name)
+			assert.Equal(t, "[]", f.DefValue)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestSkillsInitValidateCmd(t *testing.T) {
+	find := func(name string) *cobra.Command {
+		for _, c := range cmd.SkillsCmd.Commands() {
+			if c.Name() == name {
+				return c
+			}
+		}
+		return nil
+	}
+
+	t.Run("skills init requires a name and has scaffold flags", func(t *testing.T) {
+		c := find("init")
+		require.NotNil(t, c)
+		assert.Error(t, c.Args(c, []string{}))
+		assert.NoError(t, c.Args(c, []string{"my-skill"}))
+		for _, name := range []string{"dir", "description", "author", "force"} {
+			assert.NotNil(t, c.Flags().Lookup(name), "--%s flag should exist", name)
+		}
+		assert.Equal(t, ".", c.Flags().Lookup("dir").DefValue)
+	})
+
+	t.Run("skills validate requires a directory and has --strict and --output", func(t *testing.T) {
+		c := find("validate")
+		require.NotNil(t, c)
+		assert.Error(t, c.Args(c, []string{}))
+		assert.NoError(t, c.Args(c, []string{"./my-skill"}))
+		assert.Equal(t, "false", c.Flags().Lookup("strict").DefValue)
+		assert.Equal(t, "o", c.Flags().Lookup("output").Shorthand)
+	})
+
+	t.Run("skills push and update have --no-validate", func(t *testing.T) {
+		for _, name := range []string{"push", "update"} {
+			c := find(name)
+			require.NotNil(t, c)
+			f := c.Flags().Lookup("no-validate")
+			require.NotNil(t, f, "%s --no-validate should exist", name)
+			assert.Equal(t, "false", f.DefValue)
+		}
+	})
+}
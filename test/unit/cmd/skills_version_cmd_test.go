@@ -0,0 +1,50 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestSkillsVersionCmds(t *testing.T) {
+	sub := func(name string) *cobra.Command {
+		for _, c := range cmd.SkillsCmd.Commands() {
+			if c.Name() == name {
+				return c
+			}
+		}
+		return nil
+	}
+	rollback, history, push, update := sub("rollback"), sub("history"), sub("push"), sub("update")
+	require.NotNil(t, rollback)
+	require.NotNil(t, history)
+
+	t.Run("skills rollback requires a skill ID and --to", func(t *testing.T) {
+		assert.Error(t, rollback.Args(rollback, []string{}))
+		assert.NoError(t, rollback.Args(rollback, []string{"sk-1"}))
+		to := rollback.Flags().Lookup("to")
+		require.NotNil(t, to)
+		assert.Equal(t, []string{"true"}, to.Annotations[cobra.BashCompOneRequiredFlag])
+		assert.Equal(t, "y", rollback.Flags().Lookup("yes").Shorthand)
+	})
+
+	t.Run("skills history takes a skill ID", func(t *testing.T) {
+		assert.Error(t, history.Args(history, []string{}))
+		assert.NotNil(t, history.Flags().Lookup("output"))
+	})
+
+	t.Run("push and update record a changelog", func(t *testing.T) {
+		assert.NotNil(t, push.Flags().Lookup("changelog"))
+		assert.NotNil(t, update.Flags().Lookup("changelog"))
+		f := update.Flags().Lookup("skip-version-check")
+		require.NotNil(t, f)
+		assert.Equal(t, "false", f.DefValue)
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestUICmd(t *testing.T) {
+	assert.Equal(t, "ui", cmd.UICmd.Use)
+	assert.Equal(t, "core", cmd.UICmd.GroupID)
+	assert.NotEmpty(t, cmd.UICmd.Short)
+	assert.Error(t, cmd.UICmd.Args(cmd.UICmd, []string{"extra"}), "ui takes no arguments")
+
+	tab := cmd.UICmd.Flags().Lookup("tab")
+	require.NotNil(t, tab)
+	assert.Equal(t, "images", tab.DefValue)
+
+	t.Run("invalid --tab is rejected before touching the terminal", func(t *testing.T) {
+		require.NoError(t, cmd.UICmd.Flags().Set("tab", "volumes"))
+		t.Cleanup(func() { _ = cmd.UICmd.Flags().Set("tab", "images") })
+		err := cmd.UICmd.RunE(cmd.UICmd, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "images, skills, apikeys, shares")
+	})
+
+	t.Run("refuses to run without a terminal", func(t *testing.T) {
+		err := cmd.UICmd.RunE(cmd.UICmd, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "interactive terminal")
+	})
+}
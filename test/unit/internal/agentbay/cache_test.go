@@ -0,0 +1,314 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+type countingClient struct {
+	agentbay.Client
+	calls int
+}
+
+func (m *countingClient) ListMcpImages(ctx context.Context, req *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
+	m.calls++
+	return &client.ListMcpImagesResponse{Body: &client.ListMcpImagesResponseBody{
+		RequestId: dara.String("req-1"),
+		Data:      []*client.ListMcpImagesResponseBodyData{{ImageId: dara.String(dara.StringValue(req.ImageType))}},
+	}}, nil
+}
+
+func setupCache(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", dir)
+	t.Setenv(config.EnvAccessKeyID, "")
+	t.Setenv(config.EnvAccessKeySecret, "")
+	return dir
+}
+
+func listRequest(imageType string) *client.ListMcpImagesRequest {
+	return &client.ListMcpImagesRequest{ImageType: dara.String(imageType)}
+}
+
+func TestCachedClientDefaultMode(t *testing.T) {
+	dir := setupCache(t)
+	inner := &countingClient{}
+	c := agentbay.NewCachedClient(inner, agentbay.CacheDefault, 0)
+	ctx := context.Background()
+
+	resp, err := c.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	assert.Equal(t, "User", dara.StringValue(resp.Body.Data[0].ImageId))
+	_, cached := c.CachedSince()
+	assert.False(t, cached, "the first call goes to the API")
+
+	info, err := os.Stat(filepath.Join(dir, "cache", "images.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	resp, err = c.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.calls, "a fresh entry is served from the cache")
+	assert.Equal(t, "req-1", dara.StringValue(resp.Body.RequestId))
+	since, cached := c.CachedSince()
+	assert.True(t, cached)
+	assert.WithinDuration(t, time.Now(), since, time.Minute)
+
+	_, err = c.ListMcpImages(ctx, listRequest("System"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls, "requests with other parameters are cached separately")
+
+	t.Setenv(config.EnvAccessKeyID, "LTAI-other")
+	t.Setenv(config.EnvAccessKeySecret, "secret")
+	_, err = c.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.calls, "another credential does not see the cached responses")
+}
+
+func TestCachedClientExpiry(t *testing.T) {
+	setupCache(t)
+	inner := &countingClient{}
+	ctx := context.Background()
+
+	_, err := agentbay.NewCachedClient(inner, agentbay.CacheDefault, time.Nanosecond).ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	c := agentbay.NewCachedClient(inner, agentbay.CacheDefault, time.Nanosecond)
+	_, err = c.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls, "an expired entry is fetched again")
+
+	offline := agentbay.NewCachedClient(inner, agentbay.CacheOffline, time.Nanosecond)
+	_, err = offline.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls, "offline serves entries of any age")
+	_, cached := offline.CachedSince()
+	assert.True(t, cached)
+}
+
+func TestCachedClientRefreshAndOffline(t *testing.T) {
+	setupCache(t)
+	inner := &countingClient{}
+	ctx := context.Background()
+
+	_, err := agentbay.NewCachedClient(inner, agentbay.CacheOffline, 0).ListMcpImages(ctx, listRequest("User"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, agentbay.ErrNotCached))
+	assert.Contains(t, err.Error(), "--offline")
+	assert.Equal(t, 0, inner.calls, "offline never calls the API")
+
+	refresh := agentbay.NewCachedClient(inner, agentbay.CacheRefresh, 0)
+	for i := 0; i < 2; i++ {
+		_, err = refresh.ListMcpImages(ctx, listRequest("User"))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, inner.calls, "refresh always calls the API")
+	_, cached := refresh.CachedSince()
+	assert.False(t, cached)
+
+	_, err = agentbay.NewCachedClient(inner, agentbay.CacheOffline, 0).ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err, "refresh stores what it fetched")
+}
+
+func TestCachedClientCacheable(t *testing.T) {
+	setupCache(t)
+	inner := &countingClient{}
+	c := agentbay.NewCachedClient(inner, agentbay.CacheDefault, 0)
+	c.Cacheable = func(any) bool { return false }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.ListMcpImages(ctx, listRequest("User"))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, inner.calls, "responses rejected by Cacheable are not stored")
+}
+
+// failingBodyClient answers every cached call with an HTTP 200 body that reports a failure.
+type failingBodyClient struct {
+	agentbay.Client
+	calls int
+}
+
+func (m *failingBodyClient) ListMcpImages(ctx context.Context, req *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
+	m.calls++
+	return &client.ListMcpImagesResponse{Body: &client.ListMcpImagesResponseBody{Success: dara.Bool(false), Code: dara.String("Throttling")}}, nil
+}
+
+func (m *failingBodyClient) DescribeApiKeys(ctx context.Context, req *client.DescribeApiKeysRequest) (*client.DescribeApiKeysResponse, error) {
+	m.calls++
+	return &client.DescribeApiKeysResponse{Body: &client.DescribeApiKeysResponseBody{Code: dara.String("Throttling.User")}}, nil
+}
+
+func (m *failingBodyClient) DescribeMcpApiKey(ctx context.Context, req *client.DescribeMcpApiKeyRequest) (*client.DescribeMcpApiKeyResponse, error) {
+	m.calls++
+	return &client.DescribeMcpApiKeyResponse{Body: &client.DescribeMcpApiKeyResponseBody{Success: dara.Bool(false)}}, nil
+}
+
+func (m *failingBodyClient) ListMarketSkillByPage(ctx context.Context, req *client.ListMarketSkillByPageRequest) (*client.ListMarketSkillByPageResponse, error) {
+	m.calls++
+	return &client.ListMarketSkillByPageResponse{Body: &client.ListMarketSkillByPageResponseBody{Success: dara.Bool(false)}}, nil
+}
+
+func (m *failingBodyClient) DescribeMarketSkillDetail(ctx context.Context, req *client.DescribeMarketSkillDetailRequest) (*client.DescribeMarketSkillDetailResponse, error) {
+	m.calls++
+	return &client.DescribeMarketSkillDetailResponse{Body: &client.DescribeMarketSkillDetailResponseBody{Code: dara.String("InternalError")}}, nil
+}
+
+func (m *failingBodyClient) ListSharedDockerRepos(ctx context.Context, req *client.ListSharedDockerReposRequest) (*client.ListSharedDockerReposResponse, error) {
+	m.calls++
+	return &client.ListSharedDockerReposResponse{Body: &client.ListSharedDockerReposResponseBody{Success: dara.Bool(false)}}, nil
+}
+
+func TestCachedClientSkipsFailedBodies(t *testing.T) {
+	dir := setupCache(t)
+	ctx := context.Background()
+	calls := map[string]func(c *agentbay.CachedClient) error{
+		"ListMcpImages": func(c *agentbay.CachedClient) error {
+			_, err := c.ListMcpImages(ctx, listRequest("User"))
+			return err
+		},
+		"DescribeApiKeys": func(c *agentbay.CachedClient) error {
+			_, err := c.DescribeApiKeys(ctx, &client.DescribeApiKeysRequest{})
+			return err
+		},
+		"DescribeMcpApiKey": func(c *agentbay.CachedClient) error {
+			_, err := c.DescribeMcpApiKey(ctx, &client.DescribeMcpApiKeyRequest{ApiKey: dara.String("akm-1")})
+			return err
+		},
+		"ListMarketSkillByPage": func(c *agentbay.CachedClient) error {
+			_, err := c.ListMarketSkillByPage(ctx, &client.ListMarketSkillByPageRequest{})
+			return err
+		},
+		"DescribeMarketSkillDetail": func(c *agentbay.CachedClient) error {
+			_, err := c.DescribeMarketSkillDetail(ctx, &client.DescribeMarketSkillDetailRequest{})
+			return err
+		},
+		"ListSharedDockerRepos": func(c *agentbay.CachedClient) error {
+			_, err := c.ListSharedDockerRepos(ctx, &client.ListSharedDockerReposRequest{})
+			return err
+		},
+	}
+	for action, call := range calls {
+		t.Run(action, func(t *testing.T) {
+			inner := &failingBodyClient{}
+			c := agentbay.NewCachedClient(inner, agentbay.CacheDefault, 0)
+			require.NoError(t, call(c), "the body is passed through to the command")
+			require.NoError(t, call(c))
+			assert.Equal(t, 2, inner.calls, "a failed body is not served from the cache")
+
+			err := call(agentbay.NewCachedClient(inner, agentbay.CacheOffline, 0))
+			assert.ErrorIs(t, err, agentbay.ErrNotCached)
+		})
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, "cache"))
+	assert.Empty(t, entries, "nothing was written to the cache")
+}
+
+type apiKeyClient struct {
+	agentbay.Client
+}
+
+func (apiKeyClient) DescribeApiKeys(ctx context.Context, req *client.DescribeApiKeysRequest) (*client.DescribeApiKeysResponse, error) {
+	return &client.DescribeApiKeysResponse{Body: &client.DescribeApiKeysResponseBody{
+		Success: dara.Bool(true),
+		Data: &client.DescribeApiKeysResponseBodyData{ApiKeys: []*client.DescribeApiKeysResponseBodyDataApiKey{
+			{KeyId: dara.String("ak-1"), ApiKey: dara.String("akm-0123456789abcdef")},
+		}},
+	}}, nil
+}
+
+func (apiKeyClient) DescribeMcpApiKey(ctx context.Context, req *client.DescribeMcpApiKeyRequest) (*client.DescribeMcpApiKeyResponse, error) {
+	return &client.DescribeMcpApiKeyResponse{Body: &client.DescribeMcpApiKeyResponseBody{
+		Success: dara.Bool(true),
+		Data:    &client.DescribeMcpApiKeyResponseBodyData{ApiKeyId: dara.String("ak-1")},
+	}}, nil
+}
+
+func TestCachedClientStoresNoPlaintextKeys(t *testing.T) {
+	dir := setupCache(t)
+	ctx := context.Background()
+	c := agentbay.NewCachedClient(apiKeyClient{}, agentbay.CacheDefault, 0)
+
+	resp, err := c.DescribeApiKeys(ctx, &client.DescribeApiKeysRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "akm-0123456789abcdef", resp.Body.Data.ApiKeys[0].GetApiKey(), "the caller gets the response as returned")
+	_, err = c.DescribeMcpApiKey(ctx, &client.DescribeMcpApiKeyRequest{ApiKey: dara.String("akm-0123456789abcdef")})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "cache", "apikeys.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "0123456789abcdef", "neither responses nor request keys hold the plaintext key")
+
+	cached, err := agentbay.NewCachedClient(apiKeyClient{}, agentbay.CacheOffline, 0).DescribeApiKeys(ctx, &client.DescribeApiKeysRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "akm-****cdef", cached.Body.Data.ApiKeys[0].GetApiKey())
+}
+
+func TestInvalidateCache(t *testing.T) {
+	setupCache(t)
+	var invalidated []string
+	agentbay.OnCacheInvalidate(func(kind string) { invalidated = append(invalidated, kind) })
+	inner := &countingClient{}
+	c := agentbay.NewCachedClient(inner, agentbay.CacheDefault, 0)
+	ctx := context.Background()
+
+	_, err := c.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+
+	agentbay.InvalidateCache(agentbay.CacheSkills)
+	_, err = c.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.calls, "other kinds are kept")
+
+	agentbay.InvalidateCache(agentbay.CacheImages)
+	_, err = c.ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls)
+	assert.Equal(t, []string{agentbay.CacheSkills, agentbay.CacheImages}, invalidated)
+
+	require.NoError(t, agentbay.ClearCache())
+	_, err = agentbay.NewCachedClient(inner, agentbay.CacheOffline, 0).ListMcpImages(ctx, listRequest("User"))
+	assert.True(t, errors.Is(err, agentbay.ErrNotCached))
+}
+
+func TestOnlyCLIClientWritesInvalidateCache(t *testing.T) {
+	setupCache(t)
+	ctx := context.Background()
+	inner := &countingClient{}
+	_, err := agentbay.NewCachedClient(inner, agentbay.CacheDefault, 0).ListMcpImages(ctx, listRequest("User"))
+	require.NoError(t, err)
+	cached := func() bool {
+		_, err := agentbay.NewCachedClient(inner, agentbay.CacheOffline, 0).ListMcpImages(ctx, listRequest("User"))
+		return err == nil
+	}
+
+	noCreds := errors.New("no credentials")
+	sdk := agentbay.NewClientWithOptions(agentbay.ClientOptions{
+		APIConfig:   &config.APIConfig{Endpoint: "127.0.0.1:1"},
+		Credentials: func(context.Context) (agentbay.Credentials, error) { return agentbay.Credentials{}, noCreds },
+	})
+	_, err = sdk.CreateDockerImageTask(ctx, &client.CreateDockerImageTaskRequest{})
+	require.ErrorIs(t, err, noCreds)
+	assert.True(t, cached(), "a write through an SDK client keeps the CLI cache")
+
+	cli := agentbay.NewClient(&config.APIConfig{Endpoint: "127.0.0.1:1"}, &config.Config{})
+	_, err = cli.CreateDockerImageTask(ctx, &client.CreateDockerImageTaskRequest{})
+	require.Error(t, err)
+	assert.False(t, cached(), "a write through the CLI client drops the cached images")
+}
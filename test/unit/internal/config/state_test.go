@@ -0,0 +1,101 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+type counterState struct {
+	Count int            `json:"count"`
+	Seen  map[string]int `json:"seen,omitempty"`
+}
+
+func TestStateFileSaveAndLoad(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", filepath.Join(t.TempDir(), "nested"))
+	f, err := config.NewStateFile("state.json")
+	require.NoError(t, err)
+
+	var missing counterState
+	require.NoError(t, f.Load(&missing), "a missing file is not an error")
+	assert.Zero(t, missing.Count)
+
+	require.NoError(t, f.Save(&counterState{Count: 3}))
+	info, err := os.Stat(f.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	var loaded counterState
+	require.NoError(t, f.Load(&loaded))
+	assert.Equal(t, 3, loaded.Count)
+
+	entries, err := os.ReadDir(filepath.Dir(f.Path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary or lock files are left behind")
+
+	require.NoError(t, os.WriteFile(f.Path, []byte(`{"count": 4`), 0600))
+	assert.ErrorContains(t, f.Load(&loaded), "failed to parse")
+}
+
+func TestStateFileConcurrentUpdates(t *testing.T) {
+	f := &config.StateFile{Path: filepath.Join(t.TempDir(), "state.json")}
+	const writers = 20
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var s counterState
+			assert.NoError(t, f.Update(&s, func() error {
+				s.Count++
+				return nil
+			}))
+		}()
+	}
+	wg.Wait()
+
+	var s counterState
+	require.NoError(t, f.Load(&s))
+	assert.Equal(t, writers, s.Count, "no update is lost")
+}
+
+func TestStateFileUpdateKeepsFileOnError(t *testing.T) {
+	f := &config.StateFile{Path: filepath.Join(t.TempDir(), "state.json")}
+	require.NoError(t, f.Save(&counterState{Count: 1}))
+
+	var s counterState
+	err := f.Update(&s, func() error {
+		s.Count = 99
+		return os.ErrInvalid
+	})
+	assert.ErrorIs(t, err, os.ErrInvalid)
+
+	var loaded counterState
+	require.NoError(t, f.Load(&loaded))
+	assert.Equal(t, 1, loaded.Count)
+}
+
+func TestStateFileTakesOverStaleLock(t *testing.T) {
+	f := &config.StateFile{Path: filepath.Join(t.TempDir(), "state.json")}
+	lock := f.Path + ".lock"
+	require.NoError(t, os.WriteFile(lock, nil, 0600))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(lock, old, old))
+
+	unlock, err := f.Lock()
+	require.NoError(t, err)
+	unlock()
+	_, err = os.Stat(lock)
+	assert.True(t, os.IsNotExist(err))
+}